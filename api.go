@@ -0,0 +1,805 @@
+package main
+
+// the /api/v1 handlers answer in JSON for programs, like a church management system, that read and write schedules without the pages. They make the same vsadb calls as the page
+// handlers, and a schedule sent to them is turned into the save-parameters form (see scheduleForm) so it is checked by parametersValidated and saved exactly like one from the page.
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"VolunteerSchedulerApp/vsasched"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const apiPrefix = "/api/v1"
+const apiRealm = "Volunteer Scheduler App" // the realm of the WWW-Authenticate header sent with a 401
+const apiMaxBodyBytes = 4 << 20
+
+// apiErrorStruct is the body of every /api/v1 error response.
+type apiErrorStruct struct {
+	Status int                 `json:"status"`           // the HTTP status code
+	Error  string              `json:"error"`            // what went wrong
+	Fields map[string][]string `json:"fields,omitempty"` // only for a 422: the problems with each field of the request body, keyed like scheduleName, timeSlots.Sunday or volunteerRoles.Tim
+}
+
+// apiVolunteerStruct is one volunteer of a schedule, gathered from the volunteer's entries in the maps of vsadb.SendReceiveDataStruct.
+type apiVolunteerStruct struct {
+	Name           string                  `json:"name"`
+	Email          string                  `json:"email"`
+	Phone          string                  `json:"phone"`
+	Roles          []string                `json:"roles"`
+	ServesWith     []string                `json:"servesWith"` // volunteers always scheduled into the same shift
+	NeverWith      []string                `json:"neverWith"`  // volunteers never scheduled on the same date
+	Unavailability apiUnavailabilityStruct `json:"unavailability"`
+	Preferences    []string                `json:"preferences"`
+	Assignments    []string                `json:"assignments"` // ShiftKey strings of the saved schedule. Read only: they are saved through the assignments endpoints
+}
+
+type apiUnavailabilityStruct struct {
+	Dates  []string          `json:"dates"`  // YYYY-MM-DD, within the schedule dates
+	Rules  []string          `json:"rules"`  // UnavailabilityRule strings, like 1st Sunday
+	Ranges []vsadb.DateRange `json:"ranges"` // time away
+}
+
+type apiAssignmentsStruct struct {
+	Assignments map[string][]string `json:"volunteerAssignments"` // volunteer name to ShiftKey strings
+}
+
+// apiGeneratedStruct is a generated schedule that has not been saved, along with the shifts that could not be filled.
+type apiGeneratedStruct struct {
+	vsasched.Schedule
+	Shortages []vsasched.Shortage `json:"shortages"`
+}
+
+// writeJSON answers the request with status and v as its JSON body.
+func writeJSON(w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err = w.Write(append(body, '\n')); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+	}
+}
+
+// respondWithAPIError is respondWithError for /api/v1: it logs err and answers with an apiErrorStruct. fieldErrors are listed in its Fields.
+func respondWithAPIError(w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, err error) {
+	log.Printf("Error in %s (%d %s): %v", handlerInfo.address, status, http.StatusText(status), err)
+	body := apiErrorStruct{Status: status, Error: fieldErrorMessage(err)}
+	var problems fieldErrors
+	if errors.As(err, &problems) {
+		body.Error = "the request body has problems, listed by field"
+		body.Fields = problems
+	}
+	encoded, _ := json.Marshal(body) // cannot fail for an apiErrorStruct
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err = w.Write(append(encoded, '\n')); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+	}
+}
+
+// decodeJSON reads the request body into v. Fields v does not have are refused, so a misspelled field is not quietly ignored.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("error in decodeJSON: the request body is not valid JSON for %s: %w", r.URL.Path, err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("error in decodeJSON: the request body has more than one JSON value")
+	}
+	return nil
+}
+
+// withAPIUser is withSession for /api/v1. Besides a session cookie or the trusted UserHeader, programs can sign in with HTTP basic authentication, and the workspace is the one named
+// by the workspace query parameter (the user's own by default) instead of a cookie. Requests from no one get a 401, a workspace the user is not a member of a 404, and a role in
+// it below needed a 403.
+func (env *Env) withAPIUser(needed string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handlerInfo := handlerInfoStruct{r.URL.Path, "withAPIUser", r.Method}
+		userName, err := env.apiUserName(r)
+		if errors.Is(err, http.ErrNoCookie) || errors.Is(err, vsadb.ErrNotFound) || errors.Is(err, vsadb.ErrInvalidCredentials) {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q, charset=\"UTF-8\"", apiRealm))
+			if !errors.Is(err, vsadb.ErrInvalidCredentials) {
+				err = errors.New("no one is signed in. Sign in with HTTP basic authentication or a session cookie")
+			}
+			respondWithAPIError(w, handlerInfo, http.StatusUnauthorized, err)
+			return
+		}
+		if errors.Is(err, vsadb.ErrUserExists) { // the proxy named an organization
+			respondWithAPIError(w, handlerInfo, http.StatusForbidden, err)
+			return
+		}
+		if err != nil {
+			respondWithAPIError(w, handlerInfo, http.StatusInternalServerError, err)
+			return
+		}
+		workspace := r.URL.Query().Get("workspace")
+		if workspace == "" {
+			workspace = userName
+		}
+		role, err := env.DBModel.RequestWorkspaceRole(userName, workspace)
+		if err != nil {
+			respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+			return
+		}
+		if !vsadb.RoleAllows(role, needed) {
+			respondWithAPIError(w, handlerInfo, http.StatusForbidden, fmt.Errorf("%s is a %s of %s, and only an %s or above can do that", userName, role, workspace, needed))
+			return
+		}
+		handler(w, r.WithContext(contextWithUser(r.Context(), requestUser{Name: userName, Workspace: workspace, WorkspaceRole: role})))
+	}
+}
+
+// apiUserName is requestUserName, except that a request with HTTP basic authentication is from the user it names, as long as the password is right.
+func (env *Env) apiUserName(r *http.Request) (string, error) {
+	if userName, password, ok := r.BasicAuth(); ok {
+		if err := env.DBModel.AuthenticateUser(userName, password); err != nil {
+			return "", err
+		}
+		return userName, nil
+	}
+	return env.requestUserName(r)
+}
+
+// scheduleForm is the save-parameters form that would save data over the schedule selected by selection. names holds the volunteer of each veX- index for apiFieldName, and
+// problems are those the form has no input for, like a weekday that is not one of the checkboxes.
+func scheduleForm(data vsadb.SendReceiveDataStruct, selection string) (form url.Values, names []string, problems fieldErrors) {
+	form = url.Values{"schedule-selection": {selection}, "schedule-name": {data.ScheduleName}, "min-date": {data.StartDate}, "max-date": {data.EndDate}, "shifts-off": {""}, "per-shift": {""},
+		"roles": {formatRoles(data.RolesForSchedule)}}
+	problems = fieldErrors{}
+	if data.ScheduleName == "" {
+		problems.add("scheduleName", "is required")
+	}
+	if data.StartDate == "" {
+		problems.add("startDate", "is required")
+	}
+	if data.EndDate == "" {
+		problems.add("endDate", "is required")
+	}
+	if data.ShiftsOff > -1 {
+		form.Set("shifts-off", strconv.Itoa(data.ShiftsOff))
+	}
+	if data.VolunteersPerShift > -1 {
+		form.Set("per-shift", strconv.Itoa(data.VolunteersPerShift))
+	}
+	abbreviation := func(weekdayName string) string { // the value of weekdayName's checkbox, or "" if it is not a weekday
+		for _, weekday := range []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"} {
+			if convertWeToWeekday([]string{weekday})[0] == weekdayName {
+				return weekday
+			}
+		}
+		return ""
+	}
+	for _, weekdayName := range data.WeekdaysForSchedule {
+		if weekday := abbreviation(weekdayName); weekday != "" {
+			form.Add("weekday", weekday)
+		} else {
+			problems.add("weekdays", fmt.Sprintf("\"%s\" is not a weekday (Sunday, Monday, ...)", weekdayName))
+		}
+	}
+	for weekdayName, timeSlots := range data.TimeSlotsForSchedule {
+		if weekday := abbreviation(weekdayName); weekday != "" {
+			form.Set(fmt.Sprintf("slots-%s", weekday), formatTimeSlots(map[string][]vsadb.TimeSlot{weekdayName: timeSlots})[weekday])
+		} else {
+			problems.add("timeSlots", fmt.Sprintf("\"%s\" is not a weekday (Sunday, Monday, ...)", weekdayName))
+		}
+	}
+	for _, volunteerPairing := range data.VolunteerPairingData {
+		if volunteerPairing.Pairing != vsadb.PairingTogether && volunteerPairing.Pairing != vsadb.PairingApart {
+			problems.add("volunteerPairings", fmt.Sprintf("\"%s\" is not a pairing (%s or %s)", volunteerPairing.Pairing, vsadb.PairingTogether, vsadb.PairingApart))
+		}
+	}
+	// every volunteer mentioned in one of the maps gets an entry, like the volunteer column of the page
+	for _, volunteerMap := range []map[string][]string{data.VolunteerUnavailabilityData, data.VolunteerRoleData, data.VolunteerUnavailabilityRuleData, data.VolunteerPreferenceData} {
+		names = append(names, getStringMapKeys(volunteerMap, false)...)
+	}
+	names = append(names, getStringMapKeys(data.VolunteerUnavailabilityRangeData, false)...)
+	names = append(names, getStringMapKeys(data.VolunteerContactData, false)...)
+	slices.Sort(names)
+	names = slices.Compact(names)
+	for i, name := range names {
+		if name == "" {
+			problems.add("volunteerUnavailability", "volunteer names cannot be blank")
+		}
+		prefix := fmt.Sprintf("ve%d-", i)
+		form.Set(veX_n(i), name)
+		form[veX_u(i)] = []string{""}
+		if dates := data.VolunteerUnavailabilityData[name]; len(dates) > 0 {
+			form[veX_u(i)] = slices.Clone(dates)
+		}
+		form.Set(prefix+"q", strings.Join(data.VolunteerRoleData[name], ", "))
+		form.Set(prefix+"t", formatPartners(data.VolunteerPairingData, name, vsadb.PairingTogether))
+		form.Set(prefix+"a", formatPartners(data.VolunteerPairingData, name, vsadb.PairingApart))
+		form.Set(prefix+"r", strings.Join(data.VolunteerUnavailabilityRuleData[name], ", "))
+		form.Set(prefix+"p", strings.Join(data.VolunteerPreferenceData[name], ", "))
+		form.Set(prefix+"m", data.VolunteerContactData[name].Email)
+		form.Set(prefix+"h", data.VolunteerContactData[name].Phone)
+		for _, dateRange := range data.VolunteerUnavailabilityRangeData[name] {
+			form.Add(prefix+"f", dateRange.Start)
+			form.Add(prefix+"e", dateRange.End)
+		}
+	}
+	return form, names, problems
+}
+
+// apiFieldName is the JSON field of vsadb.SendReceiveDataStruct that the save-parameters form input formKey was made from by scheduleForm, with the weekday or volunteer it is for,
+// like timeSlots.Sunday or volunteerRoles.Tim.
+func apiFieldName(formKey string, names []string) string {
+	switch formKey {
+	case "schedule-name":
+		return "scheduleName"
+	case "min-date":
+		return "startDate"
+	case "max-date":
+		return "endDate"
+	case "shifts-off":
+		return "shiftsOff"
+	case "per-shift":
+		return "volunteersPerShift"
+	case "roles":
+		return "roles"
+	}
+	if weekday, found := strings.CutPrefix(formKey, "slots-"); found {
+		if weekdayName := convertWeToWeekday([]string{weekday}); len(weekdayName) == 1 {
+			return fmt.Sprintf("timeSlots.%s", weekdayName[0])
+		}
+	}
+	volunteerFields := map[string]string{"n": "volunteerUnavailability", "u": "volunteerUnavailability", "q": "volunteerRoles", "t": "volunteerPairings", "a": "volunteerPairings",
+		"r": "volunteerUnavailabilityRules", "f": "volunteerUnavailabilityRanges", "e": "volunteerUnavailabilityRanges", "p": "volunteerPreferences",
+		"m": "volunteerContacts", "h": "volunteerContacts"}
+	if index, suffix, found := strings.Cut(strings.TrimPrefix(formKey, "ve"), "-"); found {
+		if i, err := strconv.Atoi(index); err == nil && i >= 0 && i < len(names) && volunteerFields[suffix] != "" {
+			return fmt.Sprintf("%s.%s", volunteerFields[suffix], names[i])
+		}
+	}
+	return formKey
+}
+
+// scheduleDataValidated is parametersValidated for a schedule that did not come from the page: data is turned into the save-parameters form that would save it over the schedule selected
+// by selection (see scheduleForm), and the form is checked the way handleSaveParameters checks it. Problems are fieldErrors keyed by form input, and names maps the veX- indexes of those
+// keys back to volunteers.
+func (env *Env) scheduleDataValidated(ctx context.Context, data vsadb.SendReceiveDataStruct, selection string) (form url.Values, names []string, err error) {
+	form, names, problems := scheduleForm(data, selection)
+	if err = env.parametersValidated(ctx, form, "schedule-selection"); err != nil {
+		return form, names, err
+	}
+	err = env.parametersValidated(ctx, form, "schedule-name", "veX-X", "min-date", "max-date", "weekday", "shifts-off", "per-shift", "slots-X", "roles", "pairings")
+	var formProblems fieldErrors
+	if errors.As(err, &formProblems) {
+		for formKey, messages := range formProblems {
+			for _, message := range messages {
+				problems.add(formKey, message)
+			}
+		}
+	} else if err != nil {
+		return form, names, err
+	}
+	if len(problems) > 0 {
+		return form, names, problems
+	}
+	return form, names, nil
+}
+
+// storeAPISchedule checks data the way a save-parameters form is checked, then saves it in the request's workspace, as a new schedule when bNewSchedule. Like the form, it replaces
+// the whole schedule but keeps its saved assignments. On failure it returns the status to respond with, which is 422 for fieldErrors keyed by apiFieldName.
+func (env *Env) storeAPISchedule(ctx context.Context, data vsadb.SendReceiveDataStruct, bNewSchedule bool) (int, error) {
+	selection := data.ScheduleName
+	if bNewSchedule {
+		selection = "new-schedule"
+	}
+	problems := fieldErrors{}
+	if bNewSchedule && data.ShiftsOff < 0 {
+		problems.add("shiftsOff", "is required for a new schedule")
+	}
+	if bNewSchedule && data.VolunteersPerShift < 1 {
+		problems.add("volunteersPerShift", "is required for a new schedule")
+	}
+	form, names, err := env.scheduleDataValidated(ctx, data, selection)
+	var formProblems fieldErrors
+	if errors.As(err, &formProblems) {
+		for formKey, messages := range formProblems {
+			for _, message := range messages {
+				problems.add(apiFieldName(formKey, names), message)
+			}
+		}
+	} else if err != nil {
+		return validationErrorStatus(err), err
+	}
+	if len(problems) > 0 {
+		return http.StatusUnprocessableEntity, problems
+	}
+	if err = env.DBModel.RecieveAndStoreData(userFromContext(ctx).Workspace, scheduleFromForm(form), bNewSchedule); err != nil {
+		return dbErrorStatus(err), err
+	}
+	return http.StatusOK, nil
+}
+
+// completeAPISchedule fills the nil maps and slices of data with empty ones, so clients get [] and {} instead of null.
+func completeAPISchedule(data vsadb.SendReceiveDataStruct) vsadb.SendReceiveDataStruct {
+	for _, stringMap := range []*map[string][]string{&data.VolunteerRoleData, &data.VolunteerUnavailabilityData, &data.VolunteerUnavailabilityRuleData, &data.VolunteerPreferenceData, &data.VolunteerScheduledData} {
+		if *stringMap == nil {
+			*stringMap = map[string][]string{}
+		}
+	}
+	if data.VolunteerUnavailabilityRangeData == nil {
+		data.VolunteerUnavailabilityRangeData = map[string][]vsadb.DateRange{}
+	}
+	if data.VolunteerContactData == nil {
+		data.VolunteerContactData = map[string]vsadb.VolunteerContact{}
+	}
+	if data.TimeSlotsForSchedule == nil {
+		data.TimeSlotsForSchedule = map[string][]vsadb.TimeSlot{}
+	}
+	if data.WeekdaysForSchedule == nil {
+		data.WeekdaysForSchedule = []string{}
+	}
+	if data.RolesForSchedule == nil {
+		data.RolesForSchedule = []vsadb.RoleRequirement{}
+	}
+	if data.VolunteerPairingData == nil {
+		data.VolunteerPairingData = []vsadb.VolunteerPairing{}
+	}
+	return data
+}
+
+// apiVolunteer gathers the volunteer called name from data. ok is false when data has no such volunteer.
+func apiVolunteer(data vsadb.SendReceiveDataStruct, name string) (volunteer apiVolunteerStruct, ok bool) {
+	dates, ok := data.VolunteerUnavailabilityData[name]
+	if !ok {
+		return apiVolunteerStruct{}, false
+	}
+	return apiVolunteerStruct{
+		Name:        name,
+		Email:       data.VolunteerContactData[name].Email,
+		Phone:       data.VolunteerContactData[name].Phone,
+		Roles:       append([]string{}, data.VolunteerRoleData[name]...),
+		ServesWith:  pairedVolunteers(data.VolunteerPairingData, name, vsadb.PairingTogether),
+		NeverWith:   pairedVolunteers(data.VolunteerPairingData, name, vsadb.PairingApart),
+		Preferences: append([]string{}, data.VolunteerPreferenceData[name]...),
+		Assignments: append([]string{}, data.VolunteerScheduledData[name]...),
+		Unavailability: apiUnavailabilityStruct{
+			Dates:  append([]string{}, dates...),
+			Rules:  append([]string{}, data.VolunteerUnavailabilityRuleData[name]...),
+			Ranges: append([]vsadb.DateRange{}, data.VolunteerUnavailabilityRangeData[name]...),
+		},
+	}, true
+}
+
+// removeVolunteer takes the volunteer called name, and their pairings, out of data.
+func removeVolunteer(data *vsadb.SendReceiveDataStruct, name string) {
+	for _, stringMap := range []map[string][]string{data.VolunteerRoleData, data.VolunteerUnavailabilityData, data.VolunteerUnavailabilityRuleData, data.VolunteerPreferenceData, data.VolunteerScheduledData} {
+		delete(stringMap, name)
+	}
+	delete(data.VolunteerUnavailabilityRangeData, name)
+	delete(data.VolunteerContactData, name)
+	data.VolunteerPairingData = slices.DeleteFunc(slices.Clone(data.VolunteerPairingData), func(volunteerPairing vsadb.VolunteerPairing) bool {
+		return volunteerPairing.Volunteer == name || volunteerPairing.PairedVolunteer == name
+	})
+}
+
+// setVolunteer puts volunteer in data in place of the volunteer with the same name, if there is one. Their pairings become exactly volunteer.ServesWith and volunteer.NeverWith,
+// whichever side of each pair listed them before.
+func setVolunteer(data *vsadb.SendReceiveDataStruct, volunteer apiVolunteerStruct) {
+	*data = completeAPISchedule(*data)
+	removeVolunteer(data, volunteer.Name)
+	data.VolunteerUnavailabilityData[volunteer.Name] = append([]string{}, volunteer.Unavailability.Dates...)
+	if len(volunteer.Roles) > 0 {
+		data.VolunteerRoleData[volunteer.Name] = volunteer.Roles
+	}
+	if len(volunteer.Unavailability.Rules) > 0 {
+		data.VolunteerUnavailabilityRuleData[volunteer.Name] = volunteer.Unavailability.Rules
+	}
+	if len(volunteer.Unavailability.Ranges) > 0 {
+		data.VolunteerUnavailabilityRangeData[volunteer.Name] = volunteer.Unavailability.Ranges
+	}
+	if len(volunteer.Preferences) > 0 {
+		data.VolunteerPreferenceData[volunteer.Name] = volunteer.Preferences
+	}
+	if contact := (vsadb.VolunteerContact{Email: volunteer.Email, Phone: volunteer.Phone}); contact != (vsadb.VolunteerContact{}) {
+		data.VolunteerContactData[volunteer.Name] = contact
+	}
+	for pairing, partners := range map[string][]string{vsadb.PairingTogether: volunteer.ServesWith, vsadb.PairingApart: volunteer.NeverWith} {
+		for _, partner := range partners {
+			data.VolunteerPairingData = append(data.VolunteerPairingData, vsadb.VolunteerPairing{Volunteer: volunteer.Name, PairedVolunteer: partner, Pairing: pairing})
+		}
+	}
+}
+
+// apiSchedule is the schedule named by the request's {schedule} path value in the request's workspace.
+func (env *Env) apiSchedule(r *http.Request) (vsadb.SendReceiveDataStruct, error) {
+	return env.DBModel.FetchAndSendScheduleData(userFromContext(r.Context()).Workspace, r.PathValue("schedule"))
+}
+
+// apiScheduleURL is where the schedule called scheduleName is served.
+func apiScheduleURL(scheduleName string) string {
+	return fmt.Sprintf("%s/schedules/%s", apiPrefix, url.PathEscape(scheduleName))
+}
+
+// handleAPINotFound answers requests under /api/ that no other /api/v1 handler takes, including known paths with a method they do not have, which would otherwise reach handleRoot.
+func handleAPINotFound(w http.ResponseWriter, r *http.Request) {
+	respondWithAPIError(w, handlerInfoStruct{r.URL.Path, "handleAPINotFound", r.Method}, http.StatusNotFound, fmt.Errorf("there is no %s %s in the API", r.Method, r.URL.Path))
+}
+
+func (env *Env) handleAPIListSchedules(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules", "handleAPIListSchedules", "GET"}
+	//---------------------------------------------------------------------------------
+	scheduleNames, err := env.DBModel.SendScheduleNames(userFromContext(r.Context()).Workspace, true)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, append([]string{}, scheduleNames...))
+}
+
+func (env *Env) handleAPIGetSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}", "handleAPIGetSchedule", "GET"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, completeAPISchedule(schedule))
+}
+
+func (env *Env) handleAPICreateSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules", "handleAPICreateSchedule", "POST"}
+	//---------------------------------------------------------------------------------
+	user := userFromContext(r.Context())
+	toBeReceived := vsadb.SendReceiveDataStruct{ShiftsOff: -1, VolunteersPerShift: -1} // left out of the body means left blank in the form
+	if err := decodeJSON(w, r, &toBeReceived); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	scheduleNames, err := env.DBModel.SendScheduleNames(user.Workspace, false)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if slices.Contains(scheduleNames, toBeReceived.ScheduleName) {
+		respondWithAPIError(w, handlerInfo, http.StatusConflict, fmt.Errorf("there is already a schedule called \"%s\"", toBeReceived.ScheduleName))
+		return
+	}
+	if status, err := env.storeAPISchedule(r.Context(), toBeReceived, true); err != nil {
+		respondWithAPIError(w, handlerInfo, status, err)
+		return
+	}
+	schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, toBeReceived.ScheduleName)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	log.Printf("%s created the schedule %s in %s through the API", user.Name, schedule.ScheduleName, user.Workspace)
+	w.Header().Set("Location", apiScheduleURL(schedule.ScheduleName))
+	writeJSON(w, handlerInfo, http.StatusCreated, completeAPISchedule(schedule))
+}
+
+func (env *Env) handleAPIUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}", "handleAPIUpdateSchedule", "PUT"}
+	//---------------------------------------------------------------------------------
+	toBeReceived := vsadb.SendReceiveDataStruct{ShiftsOff: -1, VolunteersPerShift: -1}
+	if err := decodeJSON(w, r, &toBeReceived); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if toBeReceived.ScheduleName == "" {
+		toBeReceived.ScheduleName = r.PathValue("schedule")
+	} else if toBeReceived.ScheduleName != r.PathValue("schedule") {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("scheduleName \"%s\" does not match the schedule in the URL. Schedules cannot be renamed", toBeReceived.ScheduleName))
+		return
+	}
+	if status, err := env.storeAPISchedule(r.Context(), toBeReceived, false); err != nil {
+		respondWithAPIError(w, handlerInfo, status, err)
+		return
+	}
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, completeAPISchedule(schedule))
+}
+
+func (env *Env) handleAPIDeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}", "handleAPIDeleteSchedule", "DELETE"}
+	//---------------------------------------------------------------------------------
+	user := userFromContext(r.Context())
+	err := env.DBModel.RecieveAndDeleteData(user.Workspace, vsadb.SendReceiveDataStruct{ScheduleName: r.PathValue("schedule")})
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	log.Printf("%s deleted the schedule %s in %s through the API", user.Name, r.PathValue("schedule"), user.Workspace)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (env *Env) handleAPIListVolunteers(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers", "handleAPIListVolunteers", "GET"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteers := []apiVolunteerStruct{}
+	for _, name := range getStringMapKeys(schedule.VolunteerUnavailabilityData, true) {
+		volunteer, _ := apiVolunteer(schedule, name)
+		volunteers = append(volunteers, volunteer)
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, volunteers)
+}
+
+func (env *Env) handleAPIGetVolunteer(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "handleAPIGetVolunteer", "GET"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteer, ok := apiVolunteer(schedule, r.PathValue("volunteer"))
+	if !ok {
+		respondWithAPIError(w, handlerInfo, http.StatusNotFound, fmt.Errorf("%w: \"%s\" is not a volunteer on schedule \"%s\"", vsadb.ErrNotFound, r.PathValue("volunteer"), schedule.ScheduleName))
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, volunteer)
+}
+
+// saveAPIVolunteer saves volunteer on the request's schedule and answers with them as saved, with status.
+func (env *Env) saveAPIVolunteer(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct, schedule vsadb.SendReceiveDataStruct, volunteer apiVolunteerStruct, status int) {
+	setVolunteer(&schedule, volunteer)
+	if errStatus, err := env.storeAPISchedule(r.Context(), schedule, false); err != nil {
+		respondWithAPIError(w, handlerInfo, errStatus, err)
+		return
+	}
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteer, _ = apiVolunteer(schedule, volunteer.Name)
+	writeJSON(w, handlerInfo, status, volunteer)
+}
+
+func (env *Env) handleAPICreateVolunteer(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers", "handleAPICreateVolunteer", "POST"}
+	//---------------------------------------------------------------------------------
+	var volunteer apiVolunteerStruct
+	if err := decodeJSON(w, r, &volunteer); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if _, ok := apiVolunteer(schedule, volunteer.Name); ok {
+		respondWithAPIError(w, handlerInfo, http.StatusConflict, fmt.Errorf("\"%s\" is already a volunteer on schedule \"%s\"", volunteer.Name, schedule.ScheduleName))
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/volunteers/%s", apiScheduleURL(schedule.ScheduleName), url.PathEscape(volunteer.Name)))
+	env.saveAPIVolunteer(w, r, handlerInfo, schedule, volunteer, http.StatusCreated)
+}
+
+func (env *Env) handleAPIUpdateVolunteer(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "handleAPIUpdateVolunteer", "PUT"}
+	//---------------------------------------------------------------------------------
+	var volunteer apiVolunteerStruct
+	if err := decodeJSON(w, r, &volunteer); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if volunteer.Name == "" {
+		volunteer.Name = r.PathValue("volunteer")
+	} else if volunteer.Name != r.PathValue("volunteer") {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("name \"%s\" does not match the volunteer in the URL. Volunteers cannot be renamed", volunteer.Name))
+		return
+	}
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if _, ok := apiVolunteer(schedule, volunteer.Name); !ok {
+		respondWithAPIError(w, handlerInfo, http.StatusNotFound, fmt.Errorf("%w: \"%s\" is not a volunteer on schedule \"%s\"", vsadb.ErrNotFound, volunteer.Name, schedule.ScheduleName))
+		return
+	}
+	env.saveAPIVolunteer(w, r, handlerInfo, schedule, volunteer, http.StatusOK)
+}
+
+func (env *Env) handleAPIDeleteVolunteer(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "handleAPIDeleteVolunteer", "DELETE"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if _, ok := apiVolunteer(schedule, r.PathValue("volunteer")); !ok {
+		respondWithAPIError(w, handlerInfo, http.StatusNotFound, fmt.Errorf("%w: \"%s\" is not a volunteer on schedule \"%s\"", vsadb.ErrNotFound, r.PathValue("volunteer"), schedule.ScheduleName))
+		return
+	}
+	schedule = completeAPISchedule(schedule)
+	removeVolunteer(&schedule, r.PathValue("volunteer"))
+	if status, err := env.storeAPISchedule(r.Context(), schedule, false); err != nil {
+		respondWithAPIError(w, handlerInfo, status, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (env *Env) handleAPIGetUnavailability(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability", "handleAPIGetUnavailability", "GET"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteer, ok := apiVolunteer(schedule, r.PathValue("volunteer"))
+	if !ok {
+		respondWithAPIError(w, handlerInfo, http.StatusNotFound, fmt.Errorf("%w: \"%s\" is not a volunteer on schedule \"%s\"", vsadb.ErrNotFound, r.PathValue("volunteer"), schedule.ScheduleName))
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, volunteer.Unavailability)
+}
+
+func (env *Env) handleAPIUpdateUnavailability(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability", "handleAPIUpdateUnavailability", "PUT"}
+	//---------------------------------------------------------------------------------
+	var unavailability apiUnavailabilityStruct
+	if err := decodeJSON(w, r, &unavailability); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteer, ok := apiVolunteer(schedule, r.PathValue("volunteer"))
+	if !ok {
+		respondWithAPIError(w, handlerInfo, http.StatusNotFound, fmt.Errorf("%w: \"%s\" is not a volunteer on schedule \"%s\"", vsadb.ErrNotFound, r.PathValue("volunteer"), schedule.ScheduleName))
+		return
+	}
+	volunteer.Unavailability = unavailability
+	setVolunteer(&schedule, volunteer)
+	if status, err := env.storeAPISchedule(r.Context(), schedule, false); err != nil {
+		respondWithAPIError(w, handlerInfo, status, err)
+		return
+	}
+	if schedule, err = env.apiSchedule(r); err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	volunteer, _ = apiVolunteer(schedule, volunteer.Name)
+	writeJSON(w, handlerInfo, http.StatusOK, volunteer.Unavailability)
+}
+
+func (env *Env) handleAPIGetAssignments(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/assignments", "handleAPIGetAssignments", "GET"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, apiAssignmentsStruct{completeAPISchedule(schedule).VolunteerScheduledData})
+}
+
+// storeAPIAssignments saves assignments as the request's schedule's completed schedule, checking them like /save-schedule does, and answers with them as saved.
+func (env *Env) storeAPIAssignments(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct, assignments map[string][]string, status int) {
+	user := userFromContext(r.Context())
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	form := url.Values{"schedule-selection": {schedule.ScheduleName}}
+	problems := fieldErrors{}
+	for _, name := range getStringMapKeys(assignments, true) {
+		if _, ok := schedule.VolunteerUnavailabilityData[name]; !ok {
+			problems.add(fmt.Sprintf("volunteerAssignments.%s", name), fmt.Sprintf("\"%s\" is not a volunteer on schedule \"%s\"", name, schedule.ScheduleName))
+		}
+		for _, shiftKey := range assignments[name] {
+			if formKey := fmt.Sprintf("sv-%s", shiftKey); svX_Regex.MatchString(formKey) {
+				form.Add(formKey, name)
+			} else {
+				problems.add(fmt.Sprintf("volunteerAssignments.%s", name), fmt.Sprintf("\"%s\" is not a shift (YYYY-MM-DD, YYYY-MM-DD|time slot, or YYYY-MM-DD|time slot|role)", shiftKey))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		respondWithAPIError(w, handlerInfo, http.StatusUnprocessableEntity, problems)
+		return
+	}
+	if err = env.parametersValidated(r.Context(), form, "schedule-selection", "svX"); err != nil {
+		respondWithAPIError(w, handlerInfo, validationErrorStatus(err), err)
+		return
+	}
+	schedule.VolunteerScheduledData = extractScheduledVolunteers(form)
+	if err = env.DBModel.RecieveAndStoreData(user.Workspace, schedule, false); err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if schedule, err = env.apiSchedule(r); err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, handlerInfo, status, apiAssignmentsStruct{completeAPISchedule(schedule).VolunteerScheduledData})
+}
+
+func (env *Env) handleAPIUpdateAssignments(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/assignments", "handleAPIUpdateAssignments", "PUT"}
+	//---------------------------------------------------------------------------------
+	var assignments apiAssignmentsStruct
+	if err := decodeJSON(w, r, &assignments); err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	env.storeAPIAssignments(w, r, handlerInfo, assignments.Assignments, http.StatusOK)
+}
+
+func (env *Env) handleAPIDeleteAssignments(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/assignments", "handleAPIDeleteAssignments", "DELETE"}
+	//---------------------------------------------------------------------------------
+	env.storeAPIAssignments(w, r, handlerInfo, map[string][]string{}, http.StatusNoContent)
+}
+
+func (env *Env) handleAPIGenerateAssignments(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/schedules/{schedule}/assignments/generate", "handleAPIGenerateAssignments", "POST"}
+	//---------------------------------------------------------------------------------
+	schedule, err := env.apiSchedule(r)
+	if err != nil {
+		respondWithAPIError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	generated, err := vsasched.GenerateSchedule(schedule)
+	result := apiGeneratedStruct{Schedule: generated, Shortages: []vsasched.Shortage{}}
+	var infeasible *vsasched.InfeasibleError
+	if errors.As(err, &infeasible) { // still a schedule, with the shifts that could not be filled listed alongside it
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+		result.Shortages = infeasible.Shortages
+	} else if err != nil {
+		respondWithAPIError(w, handlerInfo, http.StatusUnprocessableEntity, err)
+		return
+	}
+	result.Data = completeAPISchedule(result.Data)
+	if result.Imbalances == nil {
+		result.Imbalances = []vsasched.Imbalance{}
+	}
+	writeJSON(w, handlerInfo, http.StatusOK, result)
+}
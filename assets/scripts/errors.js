@@ -0,0 +1,17 @@
+// htmx leaves error responses (4xx and 5xx) out of the page by default. The handlers answer errors with an error_banner fragment and HX-Retarget it to #error-banner, or with the whole page
+// and its field errors when a saved form is rejected (422), so let htmx swap them in.
+document.addEventListener("htmx:beforeSwap", function (evt) {
+    if (evt.detail.xhr.status >= 400) {
+        evt.detail.shouldSwap = true;
+        evt.detail.isError = false;
+    }
+});
+
+// clear the last error whenever a new request starts
+document.addEventListener("htmx:beforeRequest", function () {
+    const banner = document.getElementById("error-banner");
+    if (banner) {
+        banner.replaceChildren();
+        banner.removeAttribute("role");
+    }
+});
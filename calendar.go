@@ -0,0 +1,342 @@
+package main
+
+// calendar feeds put the saved shifts of a schedule into the calendar apps volunteers already use. Each feed is an RFC 5545 calendar served at /calendar/<token>.ics, where the token from
+// vsadb.EnsureCalendarFeed is the only thing that gives access, so calendar apps can subscribe to it without signing in. There is a feed for the whole schedule and one for each volunteer.
+// Events are built from VolunteerScheduledData (the scheduledVolunteersOnDates table), and their UIDs depend only on the schedule, the feed, and the shift, so when a calendar app fetches a
+// feed again a moved shift replaces its old event rather than being added next to it.
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"VolunteerSchedulerApp/vsasched"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const calendarProductID = "-//VolunteerSchedulerApp//Volunteer Scheduler//EN"
+
+// calendarTimeLayouts are the time slot names that are read as the time the shift starts, after they are lowercased and their spaces removed. Other names (like "Morning") make all-day events.
+var calendarTimeLayouts = []string{"3pm", "3:04pm", "15:04"}
+
+type calendar_linksStruct struct {
+	Schedule_selection string                // First Volunteers 2024 Q1
+	Schedule_feed      string                // https://example.com/calendar/<token>.ics, or "" when no editor has made the feed yet
+	Volunteer_feeds    []calendar_linkStruct // one per volunteer of the schedule, by name
+	Can_make_feeds     bool                  // the user is an editor or above, who makes the feeds and can replace them
+}
+
+type calendar_linkStruct struct {
+	Name string // Tim
+	Url  string // https://example.com/calendar/<token>.ics, or "" when no editor has made the feed yet
+}
+
+// calendarEvent is one VEVENT of a feed.
+type calendarEvent struct {
+	uid         string
+	date        time.Time // the day of the shift, at the time the time slot names when it names one
+	timed       bool      // whether date has the time of the shift, or the event is all day
+	summary     string
+	description string
+	transparent bool // whether the event leaves the time free, for the whole schedule's events since the subscriber is not on every shift
+}
+
+// calendarUID is the UID of the event for shiftKey in the feed of volunteer ("" for the whole schedule) in the schedule called scheduleName of workspace.
+func calendarUID(workspace string, scheduleName string, volunteer string, shiftKey string) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{workspace, scheduleName, volunteer, shiftKey}, "\x00")))
+	return fmt.Sprintf("%x@volunteer-scheduler", hash[:16])
+}
+
+// calendarStart is when the shift of key starts. timed is false when the time slot does not name a time, and start is then midnight of the date.
+func calendarStart(key vsadb.ShiftKey) (start time.Time, timed bool, err error) {
+	date, err := time.Parse(time.DateOnly, key.Date)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("error in calendarStart: %w", err)
+	}
+	name := strings.ToLower(strings.ReplaceAll(key.TimeSlot, " ", ""))
+	for _, layout := range calendarTimeLayouts {
+		if clock, err := time.Parse(layout, name); err == nil {
+			return date.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute), true, nil
+		}
+	}
+	return date, false, nil
+}
+
+// calendarEvents are the events of feed: one per shift with volunteers for the whole schedule, listing them, or one per shift of the volunteer, listing who else is on it.
+func calendarEvents(feed vsadb.CalendarFeed, schedule vsadb.SendReceiveDataStruct) ([]calendarEvent, error) {
+	scheduledByShift := vsasched.ScheduledVolunteersByShift(schedule.VolunteerScheduledData)
+	keys := []vsadb.ShiftKey{}
+	for _, shiftKey := range getStringMapKeys(scheduledByShift, true) {
+		key, err := vsadb.ShiftKey{}.FromString(shiftKey)
+		if err != nil {
+			return nil, fmt.Errorf("error in calendarEvents: %w", err)
+		}
+		if feed.Volunteer != "" && slices.Contains(scheduledByShift[shiftKey], feed.Volunteer) {
+			keys = append(keys, key)
+		} else if feed.Volunteer == "" && !slices.Contains(keys, key.Shift()) {
+			keys = append(keys, key.Shift()) // a shift with volunteers in more than one role is one event
+		}
+	}
+	events := make([]calendarEvent, 0, len(keys))
+	for _, key := range keys {
+		start, timed, err := calendarStart(key)
+		if err != nil {
+			return nil, fmt.Errorf("error in calendarEvents: %w", err)
+		}
+		title := schedule.ScheduleName
+		if !timed && key.TimeSlot != "" {
+			title = fmt.Sprintf("%s %s", title, key.TimeSlot)
+		}
+		onShift := []string{}   // everyone on the shift but the volunteer of the feed
+		roleLines := []string{} // Usher: Ann, Tim
+		withoutRole := []string{}
+		for _, shiftKey := range getStringMapKeys(scheduledByShift, true) {
+			if other, _ := (vsadb.ShiftKey{}).FromString(shiftKey); other.Shift() == key.Shift() {
+				names := slices.DeleteFunc(slices.Clone(scheduledByShift[shiftKey]), func(name string) bool { return name == feed.Volunteer })
+				onShift = append(onShift, names...)
+				if other.Role != "" && len(names) > 0 {
+					roleLines = append(roleLines, fmt.Sprintf("%s: %s", other.Role, strings.Join(names, ", ")))
+				} else if other.Role == "" {
+					withoutRole = append(withoutRole, names...)
+				}
+			}
+		}
+		slices.Sort(onShift)
+		onShift = slices.Compact(onShift)
+		event := calendarEvent{uid: calendarUID(feed.Workspace, schedule.ScheduleName, feed.Volunteer, key.ToString()), date: start, timed: timed}
+		if feed.Volunteer == "" {
+			event.summary = fmt.Sprintf("%s: %s", title, strings.Join(onShift, ", "))
+			event.transparent = true
+			if len(roleLines) > 0 && len(withoutRole) > 0 {
+				roleLines = append(roleLines, fmt.Sprintf("Volunteers: %s", strings.Join(withoutRole, ", ")))
+			}
+			event.description = strings.Join(roleLines, "\n")
+		} else {
+			event.summary = title
+			if key.Role != "" {
+				event.summary = fmt.Sprintf("%s (%s)", title, key.Role)
+			}
+			if len(onShift) > 0 {
+				event.description = fmt.Sprintf("With %s", strings.Join(onShift, ", "))
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// calendarText is value escaped as an RFC 5545 TEXT value.
+var calendarText = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// foldCalendarLine is line ended with CRLF and folded so no line is longer than 75 octets, without splitting a UTF-8 character.
+func foldCalendarLine(line string) string {
+	var builder strings.Builder
+	width := 0
+	for _, r := range line {
+		if size := utf8.RuneLen(r); width+size > 75 {
+			builder.WriteString("\r\n ")
+			width = 1
+		}
+		builder.WriteRune(r)
+		width += utf8.RuneLen(r)
+	}
+	builder.WriteString("\r\n")
+	return builder.String()
+}
+
+// writeCalendar writes events to w as an RFC 5545 calendar called name. stamp is the DTSTAMP of every event, the time the feed was made.
+func writeCalendar(w io.Writer, name string, events []calendarEvent, stamp time.Time) error {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:" + calendarProductID, "CALSCALE:GREGORIAN", "METHOD:PUBLISH",
+		"X-WR-CALNAME:" + calendarText.Replace(name), "REFRESH-INTERVAL;VALUE=DURATION:PT6H", "X-PUBLISHED-TTL:PT6H"}
+	for _, event := range events {
+		lines = append(lines, "BEGIN:VEVENT", "UID:"+event.uid, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+		if event.timed {
+			// the schedule does not say how long a shift is, so a shift at a named time is shown as an hour long. Times are floating: the time of day wherever the volunteer is
+			lines = append(lines, "DTSTART:"+event.date.Format("20060102T150405"), "DURATION:PT1H")
+		} else {
+			lines = append(lines, "DTSTART;VALUE=DATE:"+event.date.Format("20060102"), "DTEND;VALUE=DATE:"+event.date.AddDate(0, 0, 1).Format("20060102"))
+		}
+		lines = append(lines, "SUMMARY:"+calendarText.Replace(event.summary))
+		if event.description != "" {
+			lines = append(lines, "DESCRIPTION:"+calendarText.Replace(event.description))
+		}
+		if event.transparent {
+			lines = append(lines, "TRANSP:TRANSPARENT")
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldCalendarLine(line)); err != nil {
+			return fmt.Errorf("error in writeCalendar: %w", err)
+		}
+	}
+	return nil
+}
+
+// calendarFeedURL is the address the feed with token is served at. The token is the feed's only key, so the address starts with the configured BaseURL whenever there is one. Without it,
+// the address is on the host r was sent to, and X-Forwarded-Proto and X-Forwarded-Host only count behind the proxy UserHeader is set for, since any client can send them.
+func (env *Env) calendarFeedURL(r *http.Request, token string) string {
+	if env.BaseURL != "" {
+		return fmt.Sprintf("%s/calendar/%s.ics", env.BaseURL, token)
+	}
+	scheme, host := "http", r.Host
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if env.UserHeader != "" { // a chain of proxies lists one value each, the first from the proxy nearest the client
+		if proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ","); strings.TrimSpace(proto) == "http" || strings.TrimSpace(proto) == "https" {
+			scheme = strings.TrimSpace(proto)
+		}
+		if forwardedHost, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Host"), ","); strings.TrimSpace(forwardedHost) != "" {
+			host = strings.TrimSpace(forwardedHost)
+		}
+	}
+	return fmt.Sprintf("%s://%s/calendar/%s.ics", scheme, host, token)
+}
+
+func handleCalendarFeedError(w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, err error) {
+	// calendar apps show neither the error banner nor the page, so the feed answers in plain text. The token is left out of the log since it is the only key to the feed
+	log.Printf("Error in %s (%d %s): %v", handlerInfo.address, status, http.StatusText(status), err)
+	http.Error(w, http.StatusText(status), status)
+}
+
+func (env *Env) handleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/calendar/{token}", "handleCalendarFeed", "GET"}
+	//---------------------------------------------------------------------------------
+	feed, err := env.DBModel.RequestCalendarFeed(strings.TrimSuffix(r.PathValue("token"), ".ics"))
+	if err != nil {
+		handleCalendarFeedError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	schedule, err := env.DBModel.FetchAndSendScheduleData(feed.Workspace, feed.ScheduleName)
+	if err != nil {
+		handleCalendarFeedError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	events, err := calendarEvents(feed, schedule)
+	if err != nil {
+		handleCalendarFeedError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	name := feed.ScheduleName
+	if feed.Volunteer != "" {
+		name = fmt.Sprintf("%s: %s", feed.ScheduleName, feed.Volunteer)
+	}
+	var body bytes.Buffer
+	if err = writeCalendar(&body, name, events, time.Now()); err != nil {
+		handleCalendarFeedError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": name + ".ics"}))
+	if _, err = body.WriteTo(w); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+	}
+}
+
+// renderCalendarLinks shows the feed addresses of the schedule selected by the request. For an editor or above it makes the feeds that do not exist yet, and for a viewer it leaves them
+// out (see handleCalendarLinks).
+func (env *Env) renderCalendarLinks(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct) {
+	user := userFromContext(r.Context())
+	selection := r.Form["schedule-selection"][0]
+	schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, selection)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	links := calendar_linksStruct{Schedule_selection: selection, Volunteer_feeds: []calendar_linkStruct{}, Can_make_feeds: vsadb.RoleAllows(user.WorkspaceRole, vsadb.RoleEditor)}
+	feedURL := func(volunteerName string) (string, error) { // "" for a feed the user cannot make that does not exist yet
+		if links.Can_make_feeds {
+			token, err := env.DBModel.EnsureCalendarFeed(user.Workspace, selection, volunteerName)
+			if err != nil {
+				return "", err
+			}
+			return env.calendarFeedURL(r, token), nil
+		}
+		token, err := env.DBModel.RequestCalendarFeedToken(user.Workspace, selection, volunteerName)
+		if errors.Is(err, vsadb.ErrNotFound) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return env.calendarFeedURL(r, token), nil
+	}
+	if links.Schedule_feed, err = feedURL(""); err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	for _, name := range getStringMapKeys(schedule.VolunteerUnavailabilityData, true) {
+		address, err := feedURL(name)
+		if err != nil {
+			respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+			return
+		}
+		links.Volunteer_feeds = append(links.Volunteer_feeds, calendar_linkStruct{name, address})
+	}
+	renderTemplate(w, handlerInfo, "calendar_links", links)
+}
+
+// calendarRequestValidated reads the form of a request about the calendar feeds of a schedule, and checks that the schedule is saved, since only saved shifts are in the feeds.
+func (env *Env) calendarRequestValidated(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct) bool {
+	if err := r.ParseForm(); err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return false
+	}
+	if err := env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return false
+	}
+	if r.Form["schedule-selection"][0] == "new-schedule" || r.Form["schedule-selection"][0] == "copy-current-schedule" {
+		respondWithError(w, handlerInfo, http.StatusConflict, errors.New("save the schedule before subscribing to it"))
+		return false
+	}
+	return true
+}
+
+// handleCalendarLinks is open to viewers, since a feed shows no more than the saved schedule they can already read and they are often the ones handing addresses out to volunteers.
+// They only see the feeds an editor has made, though: making a feed writes a token to CalendarFeeds that reads the schedule without signing in until an editor resets it, so like
+// /reset-calendar-links, only editors and above make them.
+func (env *Env) handleCalendarLinks(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/calendar-links", "handleCalendarLinks", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if !env.calendarRequestValidated(w, r, handlerInfo) {
+		return
+	}
+	env.renderCalendarLinks(w, r, handlerInfo)
+}
+
+func (env *Env) handleResetCalendarLinks(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/reset-calendar-links", "handleResetCalendarLinks", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if !env.calendarRequestValidated(w, r, handlerInfo) {
+		return
+	}
+	user := userFromContext(r.Context())
+	if err := env.DBModel.DeleteCalendarFeeds(user.Workspace, r.Form["schedule-selection"][0]); err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	log.Printf("%s reset the calendar feeds of %s in %s", user.Name, r.Form["schedule-selection"][0], user.Workspace)
+	env.renderCalendarLinks(w, r, handlerInfo)
+}
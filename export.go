@@ -0,0 +1,319 @@
+package main
+
+// the export hands the saved schedule to people who keep rosters in spreadsheets. Both formats have the rows and columns of schedule_table, built by createRightColumnStruct, with the date and
+// time slot in columns of their own. The .xlsx workbook splits the rows into a sheet per month and adds a Summary sheet of how many shifts each volunteer has. It is written by hand with
+// archive/zip (see writeXLSX), since a workbook with only values and a couple of styles is a few small XML files.
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"VolunteerSchedulerApp/vsasched"
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// exportFormats are the values of the format parameter of /export-schedule, to the Content-Type of each.
+var exportFormats = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportRecords is table as spreadsheet rows: the date, the time slot when any row has one, and the volunteer in each column of the table. The first record of every row is the date.
+func exportRecords(table right_columnStruct) (header []string, records [][]string) {
+	withTimeSlots := slices.ContainsFunc(table.Rows, func(row table_rowStruct) bool { return row.Time_slot != "" })
+	header = []string{"Date"}
+	if withTimeSlots {
+		header = append(header, "Time slot")
+	}
+	header = append(header, table.Column_headers...)
+	records = make([][]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		record := []string{row.Date}
+		if withTimeSlots {
+			record = append(record, row.Time_slot)
+		}
+		for _, cell := range row.Volunteers {
+			record = append(record, cell.Name)
+		}
+		records = append(records, record)
+	}
+	return header, records
+}
+
+// csvSafe keeps a spreadsheet program from reading value as a formula when the CSV file is opened, by starting such values with an apostrophe.
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// writeScheduleCSV writes the schedule table of schedule to w as CSV. The file starts with a byte order mark so Excel reads it as UTF-8.
+func writeScheduleCSV(w io.Writer, schedule vsadb.SendReceiveDataStruct) error {
+	header, records := exportRecords(createRightColumnStruct(schedule, nil))
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return fmt.Errorf("error in writeScheduleCSV: %w", err)
+	}
+	writer := csv.NewWriter(w)
+	for _, record := range append([][]string{header}, records...) {
+		for i := range record {
+			record[i] = csvSafe(record[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("error in writeScheduleCSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error in writeScheduleCSV: %w", err)
+	}
+	return nil
+}
+
+// xlsxSheet is one sheet of a workbook. The first row is the header, shown in bold. Cells are string, int, or time.Time (a date), and an empty string leaves the cell blank.
+type xlsxSheet struct {
+	name string
+	rows [][]any
+}
+
+// scheduleSheets is the workbook of schedule: a sheet per month from its start date to its end date, in date order, then the Summary sheet with each volunteer's shifts per month and
+// in all. A month without shifts still gets its sheet and Summary column, and a month outside the schedule dates gets them only when volunteers were saved in it.
+func scheduleSheets(schedule vsadb.SendReceiveDataStruct) ([]xlsxSheet, error) {
+	header, records := exportRecords(createRightColumnStruct(schedule, nil))
+	headerRow := make([]any, 0, len(header))
+	for _, heading := range header {
+		headerRow = append(headerRow, heading)
+	}
+	months := []string{} // 2024-05, in the order of the sheets
+	if schedule.StartDate != "" && schedule.EndDate != "" {
+		startDate, err := time.Parse(time.DateOnly, schedule.StartDate)
+		if err != nil {
+			return nil, fmt.Errorf("error in scheduleSheets: %w", err)
+		}
+		endDate, err := time.Parse(time.DateOnly, schedule.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("error in scheduleSheets: %w", err)
+		}
+		for month := startDate.AddDate(0, 0, 1-startDate.Day()); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+			months = append(months, month.Format("2006-01"))
+		}
+	}
+	rows := make([][]any, 0, len(records))
+	for _, record := range records {
+		date, err := time.Parse(time.DateOnly, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("error in scheduleSheets: %w", err)
+		}
+		if month := date.Format("2006-01"); !slices.Contains(months, month) {
+			months = append(months, month)
+		}
+		row := []any{date}
+		for _, value := range record[1:] {
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+	slices.Sort(months)
+	sheets := make([]xlsxSheet, 0, len(months)+1)
+	for _, month := range months {
+		date, _ := time.Parse("2006-01", month) // made by Format above
+		sheet := xlsxSheet{date.Format("January 2006"), [][]any{headerRow}}
+		for _, row := range rows {
+			if row[0].(time.Time).Format("2006-01") == month {
+				sheet.rows = append(sheet.rows, row)
+			}
+		}
+		sheets = append(sheets, sheet)
+	}
+	summary := xlsxSheet{"Summary", [][]any{{"Volunteer"}}}
+	for _, sheet := range sheets {
+		summary.rows[0] = append(summary.rows[0], sheet.name)
+	}
+	summary.rows[0] = append(summary.rows[0], "Total")
+	shiftCounts := vsasched.CountShifts(schedule)
+	for _, name := range getStringMapKeys(shiftCounts, true) {
+		row := []any{name}
+		for _, month := range months {
+			row = append(row, len(slices.DeleteFunc(slices.Clone(schedule.VolunteerScheduledData[name]), func(shiftKey string) bool { return !strings.HasPrefix(shiftKey, month+"-") })))
+		}
+		summary.rows = append(summary.rows, append(row, shiftCounts[name]))
+	}
+	return append(sheets, summary), nil
+}
+
+// xlsxColumn is the letters of the column at index, 0 for A.
+func xlsxColumn(index int) string {
+	letters := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		letters = string(rune('A'+(index-1)%26)) + letters
+	}
+	return letters
+}
+
+// xlsxEscape is value escaped for XML text and attributes.
+func xlsxEscape(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value)) // writing to a bytes.Buffer does not fail
+	return buffer.String()
+}
+
+const xlsxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+// xlsxStyles are the cell formats the sheets use, by their index in cellXfs: 0 is plain, 1 is bold for headers, and 2 shows a date as YYYY-MM-DD like the rest of the app.
+const xlsxStyles = xlsxHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`
+
+// xlsxEpoch is day 0 of the dates in a workbook. Dates are stored as the number of days since then.
+var xlsxEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxWorksheet is the XML of sheet.
+func xlsxWorksheet(sheet xlsxSheet) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(xlsxHeader + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	columns := 0
+	for _, row := range sheet.rows {
+		columns = max(columns, len(row))
+	}
+	if columns > 0 {
+		fmt.Fprintf(&builder, `<cols><col min="1" max="%d" width="16" customWidth="1"/></cols>`, columns)
+	}
+	builder.WriteString(`<sheetData>`)
+	for r, row := range sheet.rows {
+		fmt.Fprintf(&builder, `<row r="%d">`, r+1)
+		style := 0
+		if r == 0 {
+			style = 1
+		}
+		for c, value := range row {
+			reference := fmt.Sprintf("%s%d", xlsxColumn(c), r+1)
+			switch value := value.(type) {
+			case string:
+				if value != "" {
+					fmt.Fprintf(&builder, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, style, xlsxEscape(value))
+				}
+			case int:
+				fmt.Fprintf(&builder, `<c r="%s" s="%d"><v>%d</v></c>`, reference, style, value)
+			case time.Time:
+				fmt.Fprintf(&builder, `<c r="%s" s="2"><v>%d</v></c>`, reference, int(value.Sub(xlsxEpoch).Hours()/24))
+			default:
+				return "", fmt.Errorf("error in xlsxWorksheet: %s has a %T, which is not a cell value", reference, value)
+			}
+		}
+		builder.WriteString(`</row>`)
+	}
+	builder.WriteString(`</sheetData></worksheet>`)
+	return builder.String(), nil
+}
+
+// writeXLSX writes sheets to w as an .xlsx workbook, in order.
+func writeXLSX(w io.Writer, sheets []xlsxSheet) error {
+	contentTypes := xlsxHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`
+	workbook := xlsxHeader + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+	workbookRels := xlsxHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`
+	parts := map[string]string{}
+	for i, sheet := range sheets {
+		worksheet, err := xlsxWorksheet(sheet)
+		if err != nil {
+			return fmt.Errorf("error in writeXLSX: %w", err)
+		}
+		parts[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = worksheet
+		contentTypes += fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		workbook += fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), i+1, i+1)
+		workbookRels += fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	workbookRels += fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+	parts["[Content_Types].xml"] = contentTypes + `</Types>`
+	parts["_rels/.rels"] = xlsxHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	parts["xl/workbook.xml"] = workbook + `</sheets></workbook>`
+	parts["xl/_rels/workbook.xml.rels"] = workbookRels + `</Relationships>`
+	parts["xl/styles.xml"] = xlsxStyles
+	archive := zip.NewWriter(w)
+	for _, name := range getStringMapKeys(parts, true) {
+		file, err := archive.Create(name)
+		if err != nil {
+			return fmt.Errorf("error in writeXLSX: %w", err)
+		}
+		if _, err = io.WriteString(file, parts[name]); err != nil {
+			return fmt.Errorf("error in writeXLSX: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("error in writeXLSX: %w", err)
+	}
+	return nil
+}
+
+func (env *Env) handleExportSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/export-schedule", "handleExportSchedule", "GET"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if err := env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
+	}
+	selection := r.Form["schedule-selection"][0]
+	if selection == "new-schedule" || selection == "copy-current-schedule" {
+		respondWithError(w, handlerInfo, http.StatusConflict, errors.New("save the schedule before exporting it"))
+		return
+	}
+	if len(r.Form["format"]) != 1 || exportFormats[r.Form["format"][0]] == "" {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("error in handleExportSchedule: \"format\" is not one of %s", strings.Join(getStringMapKeys(exportFormats, true), ", ")))
+		return
+	}
+	format := r.Form["format"][0]
+	user := userFromContext(r.Context())
+	schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, selection)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	var body bytes.Buffer // written in full before the response so a failure can still be reported as an error
+	if format == "csv" {
+		err = writeScheduleCSV(&body, schedule)
+	} else {
+		var sheets []xlsxSheet
+		if sheets, err = scheduleSheets(schedule); err == nil {
+			err = writeXLSX(&body, sheets)
+		}
+	}
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", exportFormats[format])
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": selection + "." + format}))
+	if _, err = body.WriteTo(w); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+		return
+	}
+	log.Printf("%s exported %s from %s as %s", user.Name, selection, user.Workspace, format)
+}
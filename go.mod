@@ -2,4 +2,8 @@ module VolunteerSchedulerApp
 
 go 1.22.3
 
-require github.com/mattn/go-sqlite3 v1.14.22
+require (
+	github.com/lib/pq v1.12.3
+	github.com/mattn/go-sqlite3 v1.14.22
+	golang.org/x/crypto v0.31.0
+)
@@ -0,0 +1,340 @@
+package main
+
+// the CSV import brings a roster kept in a spreadsheet into the selected schedule instead of typing each volunteer into the volunteer column. The file is merged into the saved schedule
+// and the result is checked by scheduleDataValidated, so it follows the same rules as the save-parameters form. /preview-import shows what the merge would change without saving anything,
+// and /import-volunteers saves it with RecieveAndStoreData.
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const importMaxBytes = 1 << 20 // a roster of a few hundred volunteers is a few KB
+
+// importColumns are the columns of an imported file, in the order they are read when the file has no header row. A header row names them in any order, and other columns are ignored.
+var importColumns = []string{"name", "email", "phone", "unavailable"}
+
+// importRowStruct is one volunteer line of an imported file. A volunteer may have more than one line, for example one per date they are away.
+type importRowStruct struct {
+	Line   int // 1 for the first line of the file
+	Name   string
+	Email  string
+	Phone  string
+	Dates  []string          // YYYY-MM-DD, as typed
+	Ranges []vsadb.DateRange // from "YYYY-MM-DD to YYYY-MM-DD" entries
+}
+
+type import_previewStruct struct {
+	Schedule_selection string                // First Volunteers 2024 Q1
+	Csv_text           string                // the file, sent back by the merge button so it does not have to be chosen again
+	Changes            []import_changeStruct // one per volunteer in the file, in the order of the file
+	Kept               int                   // volunteers of the schedule that are not in the file, left as they are
+	Problems           []string              // Tim (line 4): "2024-13-01" is not a valid date (YYYY-MM-DD); empty unless the file cannot be merged
+}
+
+type import_changeStruct struct {
+	Name    string   // Tim
+	Status  string   // new, updated, or unchanged
+	Details []string // email tim@example.com, unavailable 2024-01-07, away 2024-02-01 to 2024-02-10
+}
+
+// parseVolunteerCSV reads the volunteer lines of text. Unavailable entries are separated by semicolons or commas. Problems with the file itself are keyed by line, like "line 3".
+func parseVolunteerCSV(text string) ([]importRowStruct, fieldErrors) {
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(text, "\ufeff"))) // spreadsheet programs often start UTF-8 files with a byte order mark
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows := []importRowStruct{}
+	problems := fieldErrors{}
+	columns := map[string]int{}
+	for i, column := range importColumns {
+		columns[column] = i
+	}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			problems.add(fmt.Sprintf("line %d", parseErr.Line), fmt.Sprintf("is not valid CSV: %v", parseErr.Err))
+			return rows, problems
+		} else if err != nil {
+			problems.add(fmt.Sprintf("line %d", line), fmt.Sprintf("could not be read: %v", err))
+			return rows, problems
+		}
+		line, _ = reader.FieldPos(0)
+		cell := func(column string) string {
+			if index, ok := columns[column]; ok && index < len(record) {
+				return strings.TrimSpace(record[index])
+			}
+			return ""
+		}
+		if len(rows) == 0 && len(problems) == 0 && slices.ContainsFunc(record, func(s string) bool { return strings.EqualFold(strings.TrimSpace(s), "name") }) {
+			columns = map[string]int{}
+			for index, heading := range record {
+				if heading = strings.ToLower(strings.TrimSpace(heading)); slices.Contains(importColumns, heading) {
+					columns[heading] = index
+				}
+			}
+			continue
+		}
+		if !slices.ContainsFunc(record, func(s string) bool { return strings.TrimSpace(s) != "" }) {
+			continue // blank lines between groups of volunteers
+		}
+		row := importRowStruct{Line: line, Name: cell("name"), Email: cell("email"), Phone: cell("phone"), Dates: []string{}, Ranges: []vsadb.DateRange{}}
+		if row.Name == "" {
+			problems.add(fmt.Sprintf("line %d", line), "has no name")
+			continue
+		}
+		for _, entry := range strings.FieldsFunc(cell("unavailable"), func(r rune) bool { return r == ';' || r == ',' }) {
+			if entry = strings.TrimSpace(entry); entry == "" {
+				continue
+			}
+			if start, end, found := strings.Cut(entry, " to "); found {
+				row.Ranges = append(row.Ranges, vsadb.DateRange{Start: strings.TrimSpace(start), End: strings.TrimSpace(end)})
+			} else {
+				row.Dates = append(row.Dates, entry)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 && len(problems) == 0 {
+		problems.add("file", "has no volunteers")
+	}
+	return rows, problems
+}
+
+// mergeVolunteerCSV is schedule with rows merged into it. Volunteers in rows but not in schedule are added. Volunteers in both keep everything they have, gain the dates and ranges of their
+// rows, and take the email and phone of their rows where those are filled in. changes describes what happened to each volunteer in rows, for the preview.
+func mergeVolunteerCSV(schedule vsadb.SendReceiveDataStruct, rows []importRowStruct) (merged vsadb.SendReceiveDataStruct, changes []import_changeStruct, problems fieldErrors) {
+	merged = completeAPISchedule(schedule)
+	merged.VolunteerUnavailabilityData = maps.Clone(merged.VolunteerUnavailabilityData)
+	merged.VolunteerUnavailabilityRangeData = maps.Clone(merged.VolunteerUnavailabilityRangeData)
+	merged.VolunteerContactData = maps.Clone(merged.VolunteerContactData)
+	problems = fieldErrors{}
+	contactLines := map[string]map[string]int{"email": {}, "phone": {}} // the line each volunteer's email and phone came from, to catch a file that gives two
+	for _, row := range rows {
+		index := slices.IndexFunc(changes, func(change import_changeStruct) bool { return change.Name == row.Name })
+		if index == -1 {
+			status := "unchanged"
+			if _, ok := merged.VolunteerUnavailabilityData[row.Name]; !ok {
+				status = "new"
+				merged.VolunteerUnavailabilityData[row.Name] = []string{}
+			}
+			changes = append(changes, import_changeStruct{Name: row.Name, Status: status, Details: []string{}})
+			index = len(changes) - 1
+		}
+		change := &changes[index]
+		contact := merged.VolunteerContactData[row.Name]
+		for _, column := range []struct {
+			name  string
+			value string
+			field *string
+		}{{"email", row.Email, &contact.Email}, {"phone", row.Phone, &contact.Phone}} {
+			name, value, field := column.name, column.value, column.field
+			if value == "" || value == *field {
+				continue
+			}
+			if line, ok := contactLines[name][row.Name]; ok {
+				problems.add(fmt.Sprintf("line %d", row.Line), fmt.Sprintf("gives %s the %s %s, but line %d gives %s", row.Name, name, value, line, *field))
+				continue
+			}
+			contactLines[name][row.Name] = row.Line
+			*field = value
+			change.Details = append(change.Details, fmt.Sprintf("%s %s", name, value))
+		}
+		if contact != (vsadb.VolunteerContact{}) {
+			merged.VolunteerContactData[row.Name] = contact
+		}
+		for _, date := range row.Dates {
+			if !slices.Contains(merged.VolunteerUnavailabilityData[row.Name], date) {
+				merged.VolunteerUnavailabilityData[row.Name] = append(slices.Clone(merged.VolunteerUnavailabilityData[row.Name]), date)
+				change.Details = append(change.Details, fmt.Sprintf("unavailable %s", date))
+			}
+		}
+		for _, dateRange := range row.Ranges {
+			if !slices.Contains(merged.VolunteerUnavailabilityRangeData[row.Name], dateRange) {
+				merged.VolunteerUnavailabilityRangeData[row.Name] = append(slices.Clone(merged.VolunteerUnavailabilityRangeData[row.Name]), dateRange)
+				change.Details = append(change.Details, fmt.Sprintf("away %s to %s", dateRange.Start, dateRange.End))
+			}
+		}
+		if change.Status == "unchanged" && len(change.Details) > 0 {
+			change.Status = "updated"
+		}
+	}
+	return merged, changes, problems
+}
+
+// importPreview merges text into the schedule selected by the request, and checks the result the way the save-parameters form is checked. The merged schedule is only worth saving when
+// preview.Problems is empty.
+func (env *Env) importPreview(r *http.Request, text string) (preview import_previewStruct, merged vsadb.SendReceiveDataStruct, err error) {
+	selection := r.Form["schedule-selection"][0]
+	preview = import_previewStruct{Schedule_selection: selection, Csv_text: text, Changes: []import_changeStruct{}, Problems: []string{}}
+	schedule, err := env.DBModel.FetchAndSendScheduleData(userFromContext(r.Context()).Workspace, selection)
+	if err != nil {
+		return preview, vsadb.SendReceiveDataStruct{}, fmt.Errorf("error in importPreview: %w", err)
+	}
+	rows, problems := parseVolunteerCSV(text)
+	merged, changes, mergeProblems := mergeVolunteerCSV(schedule, rows)
+	preview.Changes = append(preview.Changes, changes...)
+	for key, messages := range mergeProblems {
+		problems[key] = append(problems[key], messages...)
+	}
+	for name := range merged.VolunteerUnavailabilityData {
+		if !slices.ContainsFunc(preview.Changes, func(change import_changeStruct) bool { return change.Name == name }) {
+			preview.Kept++
+		}
+	}
+	// problems with the merged schedule are put on the volunteer they belong to, with the lines of the file that name the volunteer
+	_, names, err := env.scheduleDataValidated(r.Context(), merged, selection)
+	var formProblems fieldErrors
+	if errors.As(err, &formProblems) {
+		for formKey, messages := range formProblems {
+			key := formKey
+			if index, _, found := strings.Cut(strings.TrimPrefix(formKey, "ve"), "-"); found && strings.HasPrefix(formKey, "ve") {
+				if i, err := strconv.Atoi(index); err == nil && i >= 0 && i < len(names) {
+					key = importVolunteerKey(names[i], rows)
+				}
+			}
+			problems[key] = append(problems[key], messages...)
+		}
+	} else if err != nil {
+		return preview, merged, fmt.Errorf("error in importPreview: %w", err)
+	}
+	for _, key := range getStringMapKeys(problems, true) {
+		for _, message := range problems[key] {
+			preview.Problems = append(preview.Problems, fmt.Sprintf("%s: %s", key, message))
+		}
+	}
+	return preview, merged, nil
+}
+
+// importVolunteerKey names the volunteer called name in a preview problem, along with the lines of rows that name them, like "Tim (lines 4, 9)".
+func importVolunteerKey(name string, rows []importRowStruct) string {
+	lines := []string{}
+	for _, row := range rows {
+		if row.Name == name {
+			lines = append(lines, strconv.Itoa(row.Line))
+		}
+	}
+	switch len(lines) {
+	case 0:
+		return name
+	case 1:
+		return fmt.Sprintf("%s (line %s)", name, lines[0])
+	}
+	return fmt.Sprintf("%s (lines %s)", name, strings.Join(lines, ", "))
+}
+
+// importRequestValidated reads the form of an import request, and checks that it is for a saved schedule. The selected schedule must be saved first so there is something to merge into.
+func (env *Env) importRequestValidated(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, importMaxBytes)
+	if err := r.ParseMultipartForm(importMaxBytes); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return false
+	} else if errors.Is(err, http.ErrNotMultipart) {
+		if err = r.ParseForm(); err != nil {
+			respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+			return false
+		}
+	}
+	if err := env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return false
+	}
+	if r.Form["schedule-selection"][0] == "new-schedule" || r.Form["schedule-selection"][0] == "copy-current-schedule" {
+		respondWithError(w, handlerInfo, http.StatusConflict, errors.New("save the schedule parameters before importing volunteers into the schedule"))
+		return false
+	}
+	return true
+}
+
+func (env *Env) handlePreviewImport(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/preview-import", "handlePreviewImport", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if !env.importRequestValidated(w, r, handlerInfo) {
+		return
+	}
+	file, _, err := r.FormFile("csv-file")
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("choose a CSV file to import: %w", err))
+		return
+	}
+	defer file.Close()
+	text, err := io.ReadAll(file)
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	preview, _, err := env.importPreview(r, string(text))
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	log.Printf("Previewed importing %d volunteer(s) into %s with %d problem(s)", len(preview.Changes), preview.Schedule_selection, len(preview.Problems))
+	status := http.StatusOK
+	if len(preview.Problems) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	renderTemplateWithStatus(w, handlerInfo, status, "import_preview", preview)
+}
+
+func (env *Env) handleImportVolunteers(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/import-volunteers", "handleImportVolunteers", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	if !env.importRequestValidated(w, r, handlerInfo) {
+		return
+	}
+	if len(r.Form["csv-text"]) != 1 {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, errors.New("error in handleImportVolunteers: \"csv-text\" does not have length of 1"))
+		return
+	}
+	preview, merged, err := env.importPreview(r, r.Form["csv-text"][0])
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if len(preview.Problems) > 0 { // the schedule changed since the preview. Show the problems in place of the preview instead of the page
+		log.Printf("Error in %s (%d %s): %s", handlerInfo.address, http.StatusUnprocessableEntity, http.StatusText(http.StatusUnprocessableEntity), strings.Join(preview.Problems, "; "))
+		w.Header().Set("HX-Retarget", "#import-preview")
+		w.Header().Set("HX-Reswap", "innerHTML")
+		renderTemplateWithStatus(w, handlerInfo, http.StatusUnprocessableEntity, "import_preview", preview)
+		return
+	}
+	form, _, err := env.scheduleDataValidated(r.Context(), merged, preview.Schedule_selection)
+	if err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
+	}
+	if err = env.DBModel.RecieveAndStoreData(user.Workspace, scheduleFromForm(form), false); err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	log.Printf("%s imported %d volunteer(s) into %s in %s", user.Name, len(preview.Changes), preview.Schedule_selection, user.Workspace)
+	base_page_data, err := env.prepareTemplateStructs(r.Context(), preview.Schedule_selection, true)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	renderTemplate(w, handlerInfo, "base_page", base_page_data)
+}
@@ -2,12 +2,19 @@ package main
 
 import (
 	"VolunteerSchedulerApp/vsadb"
-	"database/sql"
+	"VolunteerSchedulerApp/vsasched"
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
-	"math"
 	"net/http"
+	"net/mail"
 	"net/url"
 	"os"
 	"reflect"
@@ -16,12 +23,15 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
 // gobal variables
 const serverAddress = ":3030"
+const databaseEnvVar = "VSA_DATABASE" // a postgres:// URL, or the path of a SQLite file to use instead of vsadb.DbName
+const sessionCookieName = "vsa_session"
+const workspaceCookieName = "vsa_workspace"
+const userHeaderEnvVar = "VSA_USER_HEADER" // the request header a reverse proxy that signs people in puts their user name in. Left unset, no header is trusted
+const baseURLEnvVar = "VSA_BASE_URL"       // the address people reach the server at, like https://schedules.example.org, which calendar feed links start with. Left unset, the host of each request
 
 var templates *template.Template
 
@@ -29,6 +39,33 @@ var veX_nRegex *regexp.Regexp
 
 var veX_uRegex *regexp.Regexp
 
+var svX_Regex *regexp.Regexp
+
+var veX_qRegex *regexp.Regexp
+
+var veX_tRegex *regexp.Regexp
+
+var veX_aRegex *regexp.Regexp
+
+var veX_rRegex *regexp.Regexp
+
+var veX_pRegex *regexp.Regexp
+
+var veX_fRegex *regexp.Regexp
+
+var veX_eRegex *regexp.Regexp
+
+var veX_mRegex *regexp.Regexp
+
+var veX_hRegex *regexp.Regexp
+
+var phoneRegex *regexp.Regexp
+
+var errorPrefixRegex *regexp.Regexp
+
+// errScheduleLookup is wrapped by parametersValidated when it cannot read the schedule names, which is a server error rather than a bad request (see validationErrorStatus)
+var errScheduleLookup = errors.New("could not look up schedules")
+
 // useful structs
 
 type weekdaysStruct struct {
@@ -41,6 +78,16 @@ type weekdaysStruct struct {
 	Saturday  bool
 }
 
+type error_bannerStruct struct {
+	Status      int    // 404
+	Status_text string // Not Found
+	Message     string // error in RequestSchedule: not found: ...
+}
+
+// fieldErrors are the problems parametersValidated found with a submitted form that a person can fix, keyed by the name of the input they belong to (max-date, per-shift, ve3-n, ...) so the
+// templates can show each one next to its input.
+type fieldErrors map[string][]string
+
 type handlerInfoStruct struct {
 	address  string
 	funcName string
@@ -52,18 +99,46 @@ type base_pageStruct struct {
 	Top_bar      top_barStruct
 	Left_column  left_columnStruct
 	Right_column right_columnStruct
+	Error_banner error_bannerStruct // empty unless the page is showing an error
 }
 
 type top_barStruct struct {
-	User                 string         // Seth
-	Saved_schedules      []string       // First Volunteers 2024 Q1, First Volunteers 2024 Q2, Second Volunteers 2024 Q1, or Second Volunteers 2024 Q2
-	Current_schedule     string         // First Volunteers 2024 Q1
-	Min_date             string         // 5/19/24
-	Max_date             string         // 6/9/24
-	Volunteer_days       weekdaysStruct // M T W R F S and/or S
-	Shifts_off           int            // x weeks between volunteering
-	Volunteers_per_shift int            // min = 1, max = # of volunteers
-	Allow_copy           bool           // bool on whether thee schedule select element should have the copy-current-schedule option
+	User                 string             // Seth
+	Saved_schedules      []string           // First Volunteers 2024 Q1, First Volunteers 2024 Q2, Second Volunteers 2024 Q1, or Second Volunteers 2024 Q2
+	Current_schedule     string             // First Volunteers 2024 Q1
+	Min_date             string             // 5/19/24
+	Max_date             string             // 6/9/24
+	Volunteer_days       weekdaysStruct     // M T W R F S and/or S
+	Shifts_off           int                // x weeks between volunteering
+	Volunteers_per_shift int                // min = 1, max = # of volunteers
+	Time_slots           map[string]string  // Su: 8am=2, 11am=3
+	Roles                string             // Greeter lead=1, Usher=2
+	Allow_copy           bool               // bool on whether thee schedule select element should have the copy-current-schedule option
+	Field_errors         fieldErrors        // max-date: is before the start date; empty unless the form was just rejected
+	Workspace            string             // First Church
+	Workspaces           []vsadb.Membership // Seth (owner), First Church (editor)
+}
+
+type login_pageStruct struct {
+	Error_banner        error_bannerStruct
+	User_name           string   // Seth, kept in the sign-in form after a failed attempt
+	New_user_name       string   // Seth, kept in the register form after a failed attempt
+	Sign_in_errors      []string // invalid user name or password
+	Register_errors     []string // the passwords do not match
+	Min_password_length int      // 8
+}
+
+type organizations_pageStruct struct {
+	Error_banner      error_bannerStruct
+	User              string             // Seth
+	Workspace         string             // First Church
+	Role              string             // owner
+	Is_organization   bool               // false for the user's own workspace, which has no members
+	Can_manage        bool               // whether the user can add, change, and remove members
+	Members           []vsadb.Membership // Seth (owner), Ann (editor)
+	Roles             []string           // viewer, editor, owner
+	Form_errors       []string           // there is no user named "Bob"
+	Organization_name string             // First Church, kept in the create form after a failed attempt
 }
 
 type left_columnStruct struct {
@@ -72,18 +147,82 @@ type left_columnStruct struct {
 }
 
 type right_columnStruct struct {
-	Num_weeks []string
+	Schedule_name  string              // First Volunteers 2024 Q1 for the export links, or "" for a schedule that is not saved
+	Column_headers []string            // Greeter lead, Usher, Usher, Volunteer 1
+	Rows           []table_rowStruct   // one row per shift (shift date and time slot)
+	Shift_counts   []shift_countStruct // one entry per volunteer on the schedule
+	Shortages      []shortageStruct    // shift dates the generator could not fill, empty unless the schedule was just generated
+}
+
+type table_rowStruct struct {
+	Key        string             // 2024-05-19|8am
+	Date       string             // 2024-05-19
+	Time_slot  string             // 8am
+	Volunteers []table_cellStruct // one cell per column header
+}
+
+type table_cellStruct struct {
+	Key  string // 2024-05-19|8am|Usher
+	Name string // Tim
+}
+
+type shift_countStruct struct {
+	Name         string // Tim
+	Shifts       int    // 3
+	Load         string // "", "over", or "under"
+	Reason       string // unavailable on 5 of 6 shift dates
+	Satisfaction string // "75%" of the volunteer's preferences met, or "" for a volunteer without preferences
+}
+
+type shortageStruct struct {
+	Date       string // 2024-05-19
+	Time_slot  string // 8am
+	Role       string // Usher
+	Needed     int    // 3
+	Available  int    // 1
+	Constraint string // unavailability
+	Detail     string // unavailable: Bill, Tim; resting: Jack
 }
 
 type volunteer_entryStruct struct {
-	IdIndex string
-	Name    string
-	Dates   []string
+	IdIndex      string
+	Name         string
+	Dates        []string
+	Roles        string // Usher, Sound tech
+	Together     string // Bill, Tim
+	Apart        string // Jack
+	Rules        string // 1st Sunday, July, every 2 weeks from 2024-01-07
+	Ranges       []vsadb.DateRange
+	Preferences  string      // Saturday, 2024-03-31 x3, at most 2 shifts
+	Email        string      // tim@example.com
+	Phone        string      // 555-0100
+	Field_errors fieldErrors // ve3-n: Bill is already entered above; the same map as the top bar's, looked up with this entry's IdIndex
 }
 
 type Env struct {
-	DBModel      vsadb.VSAModel
-	LoggedInUser string
+	DBModel    vsadb.Store
+	UserHeader string            // from userHeaderEnvVar, or "" to only use sessions
+	BaseURL    string            // from baseURLEnvVar without a trailing /, or "" to build links from the host of each request
+	ClaimCodes map[string]string // user name to the code printed to the log at startup for each user without a password, which registering as them needs. Only read once serving starts
+}
+
+// requestUser is who a request is from. withSession puts it in the request's context, so handlers share one Env across concurrent requests and read the user from there instead.
+type requestUser struct {
+	Name          string
+	Workspace     string // whose schedules the request works on: Name's own, or an organization they are a member of
+	WorkspaceRole string // Name's role in Workspace
+}
+
+type requestUserKey struct{}
+
+func contextWithUser(ctx context.Context, user requestUser) context.Context {
+	return context.WithValue(ctx, requestUserKey{}, user)
+}
+
+// userFromContext returns the requestUser withSession stored in ctx, or the zero requestUser outside of withSession.
+func userFromContext(ctx context.Context) requestUser {
+	user, _ := ctx.Value(requestUserKey{}).(requestUser)
+	return user
 }
 
 // helper functions
@@ -114,6 +253,117 @@ func veX_u(value any) string {
 	}
 }
 
+// respondWithError logs err and answers the request with status and an error_banner in place of the handler's usual response. HX-Retarget and HX-Reswap swap the banner into
+// #error-banner (see base_page.gohtml) so the rest of the page is left alone, and scripts/errors.js lets htmx swap error responses at all.
+func respondWithError(w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, err error) {
+	log.Printf("Error in %s (%d %s): %v", handlerInfo.address, status, http.StatusText(status), err)
+	w.Header().Set("HX-Retarget", "#error-banner")
+	w.Header().Set("HX-Reswap", "outerHTML")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err = templates.ExecuteTemplate(w, "error_banner", error_bannerStruct{status, http.StatusText(status), err.Error()}); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+	}
+}
+
+// validationErrorStatus is the status to respond with when parametersValidated fails: 404 when the selected schedule does not exist, 500 when the schedules could not be looked up,
+// and 400 for every other invalid parameter.
+func validationErrorStatus(err error) int {
+	if errors.Is(err, vsadb.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, errScheduleLookup) {
+		return http.StatusInternalServerError
+	}
+	return http.StatusBadRequest
+}
+
+// dbErrorStatus is the status to respond with when a vsadb call fails: 404 when the schedule does not exist and 500 otherwise.
+func dbErrorStatus(err error) int {
+	if errors.Is(err, vsadb.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// renderTemplate executes the named template into a buffer before writing it, so a template that fails part way through gets a 500 error_banner instead of half a page.
+func renderTemplate(w http.ResponseWriter, handlerInfo handlerInfoStruct, name string, data any) {
+	renderTemplateWithStatus(w, handlerInfo, http.StatusOK, name, data)
+}
+
+// renderTemplateWithStatus is renderTemplate for a response that is not a 200, like a form sent back with its fieldErrors.
+func renderTemplateWithStatus(w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, name string, data any) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+		respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := buf.WriteTo(w); err != nil {
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+	}
+}
+
+func (problems fieldErrors) add(field string, message string) {
+	problems[field] = append(problems[field], message)
+}
+
+func (problems fieldErrors) Error() string {
+	messages := make([]string, 0, len(problems))
+	for _, field := range getStringMapKeys(problems, true) {
+		for _, message := range problems[field] {
+			messages = append(messages, fmt.Sprintf("\"%s\": %s", field, message))
+		}
+	}
+	return fmt.Sprintf("error in parametersValidated: %s", strings.Join(messages, "; "))
+}
+
+// fieldErrorMessage is err.Error() without the leading "error in FuncName: " parts, which mean nothing to someone reading it next to an input.
+func fieldErrorMessage(err error) string {
+	return errorPrefixRegex.ReplaceAllString(err.Error(), "")
+}
+
+// volunteerNameKeys is the veX-n keys of form in the order their entries appear on the page, along with the index X of each. A key whose index is too large for an int has no
+// entry in indexes and comes after the others, in key order, so a forged form cannot crash the server and still gets its problem shown next to the input.
+func volunteerNameKeys(form url.Values) (nameKeys []string, indexes map[string]int) {
+	indexes = map[string]int{}
+	for _, formKey := range getStringMapKeys(form, true) {
+		if !veX_nRegex.MatchString(formKey) {
+			continue
+		}
+		nameKeys = append(nameKeys, formKey)
+		if index, err := strconv.Atoi(formKey[len("ve") : len(formKey)-len("-n")]); err == nil {
+			indexes[formKey] = index
+		}
+	}
+	slices.SortStableFunc(nameKeys, func(a, b string) int {
+		indexA, okA := indexes[a]
+		indexB, okB := indexes[b]
+		if okA && okB {
+			return cmp.Compare(indexA, indexB)
+		}
+		if okA != okB { // the keys without an index go last
+			if okA {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	return nameKeys, indexes
+}
+
+// volunteerFieldKey is the name of the veX-<suffix> input of the (first, by key) volunteer called name in form, or "" if no volunteer in form has that name.
+func volunteerFieldKey(form url.Values, name string, suffix string) string {
+	for _, formKey := range getStringMapKeys(form, true) {
+		if veX_nRegex.MatchString(formKey) && slices.Contains(form[formKey], name) {
+			return fmt.Sprintf("%s%s", formKey[:len(formKey)-1], suffix)
+		}
+	}
+	return ""
+}
+
 func getStringMapKeys[stringMapType ~map[string]V, V any](stringMap stringMapType, sorted bool) []string {
 	// code adapted from https://pkg.go.dev/golang.org/x/exp/maps#Keys
 	// see https://go.dev/blog/intro-generics for an explanation about the ~
@@ -181,48 +431,346 @@ func convertWeToWeekday(weekdaysSlice []string) (result []string) {
 	return
 }
 
-func (env Env) prepareTemplateStructs(scheduleName string, bIsExistingAndCopyable bool) base_pageStruct {
-	scheduleNames, err := env.DBModel.SendScheduleNames(env.LoggedInUser, true)
+func parseNameCounts(value string, kind string) (names []string, counts []int, err error) {
+	// value is a comma separated list in the form name=volunteers per shift, like "8am=2, 11am=3". kind (time slot, role) is only used in error messages.
+	names, counts = []string{}, []int{}
+	if strings.TrimSpace(value) == "" {
+		return names, counts, nil
+	}
+	for _, entry := range strings.Split(value, ",") {
+		name, count, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return []string{}, []int{}, fmt.Errorf("error in parseNameCounts: \"%s\" is not in the form name=volunteers per shift", strings.TrimSpace(entry))
+		}
+		if strings.Contains(name, vsadb.ShiftKeySeparator) {
+			return []string{}, []int{}, fmt.Errorf("error in parseNameCounts: %s \"%s\" contains illegal character (%s)", kind, name, vsadb.ShiftKeySeparator)
+		}
+		if slices.Contains(names, name) {
+			return []string{}, []int{}, fmt.Errorf("error in parseNameCounts: %s \"%s\" is listed more than once", kind, name)
+		}
+		volunteersPerShift, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil {
+			return []string{}, []int{}, fmt.Errorf("error in parseNameCounts: volunteers per shift of %s \"%s\" cannot be converted to an integer: %w", kind, name, err)
+		}
+		if volunteersPerShift < 1 {
+			return []string{}, []int{}, fmt.Errorf("error in parseNameCounts: volunteers per shift of %s \"%s\" is less than 1", kind, name)
+		}
+		names, counts = append(names, name), append(counts, volunteersPerShift)
+	}
+	return names, counts, nil
+}
+
+func parseTimeSlots(value string) ([]vsadb.TimeSlot, error) {
+	names, counts, err := parseNameCounts(value, "time slot")
+	if err != nil {
+		return []vsadb.TimeSlot{}, fmt.Errorf("error in parseTimeSlots: %w", err)
+	}
+	timeSlots := make([]vsadb.TimeSlot, 0, len(names))
+	for i, name := range names {
+		timeSlots = append(timeSlots, vsadb.TimeSlot{Name: name, VolunteersPerShift: counts[i]})
+	}
+	return timeSlots, nil
+}
+
+func parseRoles(value string) ([]vsadb.RoleRequirement, error) {
+	names, counts, err := parseNameCounts(value, "role")
+	if err != nil {
+		return []vsadb.RoleRequirement{}, fmt.Errorf("error in parseRoles: %w", err)
+	}
+	roles := make([]vsadb.RoleRequirement, 0, len(names))
+	for i, name := range names {
+		roles = append(roles, vsadb.RoleRequirement{Name: name, VolunteersPerShift: counts[i]})
+	}
+	return roles, nil
+}
+
+func formatRoles(rolesForSchedule []vsadb.RoleRequirement) string {
+	// the reverse of parseRoles
+	entries := make([]string, 0, len(rolesForSchedule))
+	for _, role := range rolesForSchedule {
+		entries = append(entries, fmt.Sprintf("%s=%d", role.Name, role.VolunteersPerShift))
+	}
+	return strings.Join(entries, ", ")
+}
+
+func parseQualifications(value string) []string {
+	// value is a comma separated list of role names, like "Usher, Sound tech". Blank and repeated names are dropped.
+	roles := []string{}
+	for _, entry := range strings.Split(value, ",") {
+		if role := strings.TrimSpace(entry); role != "" && !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func formatPartners(volunteerPairings []vsadb.VolunteerPairing, name string, pairing string) string {
+	// the names paired with name by pairing, from either side of each pair, sorted and comma separated like "Bill, Tim"
+	return strings.Join(pairedVolunteers(volunteerPairings, name, pairing), ", ")
+}
+
+func pairedVolunteers(volunteerPairings []vsadb.VolunteerPairing, name string, pairing string) []string {
+	// the names paired with name by pairing, from either side of each pair, sorted
+	partners := []string{}
+	for _, volunteerPairing := range volunteerPairings {
+		if volunteerPairing.Pairing != pairing {
+			continue
+		}
+		if volunteerPairing.Volunteer == name {
+			partners = append(partners, volunteerPairing.PairedVolunteer)
+		} else if volunteerPairing.PairedVolunteer == name {
+			partners = append(partners, volunteerPairing.Volunteer)
+		}
+	}
+	slices.Sort(partners)
+	return partners
+}
+
+func formatTimeSlots(timeSlotsForSchedule map[string][]vsadb.TimeSlot) map[string]string {
+	// the reverse of parseTimeSlots, keyed by the weekday values of the weekday checkboxes (Su, Mo, ...)
+	result := map[string]string{}
+	for _, weekday := range []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"} {
+		entries := []string{}
+		for _, timeSlot := range timeSlotsForSchedule[convertWeToWeekday([]string{weekday})[0]] {
+			entries = append(entries, fmt.Sprintf("%s=%d", timeSlot.Name, timeSlot.VolunteersPerShift))
+		}
+		result[weekday] = strings.Join(entries, ", ")
+	}
+	return result
+}
+
+func createRightColumnStruct(schedule vsadb.SendReceiveDataStruct, shortages []vsasched.Shortage) right_columnStruct {
+	// rows come from the shifts of the schedule plus any shifts that already have volunteers scheduled, so a saved schedule still shows up after its parameters change.
+	// each row is one date and time slot. the role columns come first (one column per volunteer the role needs) followed by the columns for volunteers without a role.
+	scheduledByShift := vsasched.ScheduledVolunteersByShift(schedule.VolunteerScheduledData)
+	roleNames := []string{}
+	roleColumns := map[string]int{}
+	for _, role := range schedule.RolesForSchedule {
+		roleNames = append(roleNames, role.Name)
+		roleColumns[role.Name] = role.VolunteersPerShift
+	}
+	generalColumns := 0
+	if len(roleNames) == 0 {
+		generalColumns = max(schedule.VolunteersPerShift, 1)
+	}
+	rowKeys := []vsadb.ShiftKey{}
+	if schedule.StartDate != "" && schedule.EndDate != "" {
+		shifts, err := vsasched.Shifts(schedule)
+		if err != nil {
+			log.Printf("error in createRightColumnStruct: %v", err)
+		}
+		for _, shift := range shifts {
+			if !slices.Contains(rowKeys, shift.Key.Shift()) {
+				rowKeys = append(rowKeys, shift.Key.Shift())
+			}
+			if shift.Key.Role == "" {
+				generalColumns = max(generalColumns, shift.VolunteersPerShift)
+			}
+		}
+	}
+	savedKeys := []vsadb.ShiftKey{}
+	savedRoleNames := []string{}
+	for _, shiftKey := range getStringMapKeys(scheduledByShift, true) {
+		key, err := vsadb.ShiftKey{}.FromString(shiftKey)
+		if err != nil {
+			log.Printf("error in createRightColumnStruct: %v", err)
+			continue
+		}
+		if !slices.Contains(rowKeys, key.Shift()) && !slices.Contains(savedKeys, key.Shift()) {
+			savedKeys = append(savedKeys, key.Shift())
+		}
+		if key.Role == "" {
+			generalColumns = max(generalColumns, len(scheduledByShift[shiftKey]))
+			continue
+		}
+		if !slices.Contains(roleNames, key.Role) && !slices.Contains(savedRoleNames, key.Role) {
+			savedRoleNames = append(savedRoleNames, key.Role) // a role that was dropped from the schedule after volunteers were saved in it
+		}
+		roleColumns[key.Role] = max(roleColumns[key.Role], len(scheduledByShift[shiftKey]))
+	}
+	rowKeys = append(rowKeys, savedKeys...)
+	roleNames = append(roleNames, savedRoleNames...)
+	columnRoles := []string{} // the role of each column, "" for volunteers without a role
+	for _, roleName := range roleNames {
+		for i := 0; i < roleColumns[roleName]; i++ {
+			columnRoles = append(columnRoles, roleName)
+		}
+	}
+	for i := 0; i < generalColumns; i++ {
+		columnRoles = append(columnRoles, "")
+	}
+	result := right_columnStruct{schedule.ScheduleName, make([]string, 0, len(columnRoles)), make([]table_rowStruct, 0, len(rowKeys)), []shift_countStruct{}, make([]shortageStruct, 0, len(shortages))}
+	for i, columnRole := range columnRoles {
+		if columnRole != "" {
+			result.Column_headers = append(result.Column_headers, columnRole)
+		} else {
+			result.Column_headers = append(result.Column_headers, fmt.Sprintf("Volunteer %d", i-len(columnRoles)+generalColumns+1))
+		}
+	}
+	for _, rowKey := range rowKeys {
+		row := table_rowStruct{rowKey.ToString(), rowKey.Date, rowKey.TimeSlot, make([]table_cellStruct, 0, len(columnRoles))}
+		for i, columnRole := range columnRoles {
+			cellKey := vsadb.ShiftKey{Date: rowKey.Date, TimeSlot: rowKey.TimeSlot, Role: columnRole}.ToString()
+			cell := table_cellStruct{Key: cellKey}
+			if seat := i - slices.Index(columnRoles, columnRole); seat < len(scheduledByShift[cellKey]) {
+				cell.Name = scheduledByShift[cellKey][seat]
+			}
+			row.Volunteers = append(row.Volunteers, cell)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	slices.SortStableFunc(result.Rows, func(a, b table_rowStruct) int { return strings.Compare(a.Date, b.Date) }) // stable so time slots keep their order within a date
+	shiftCounts := vsasched.CountShifts(schedule)
+	imbalances := []vsasched.Imbalance{}
+	if schedule.StartDate != "" && schedule.EndDate != "" {
+		var err error
+		imbalances, err = vsasched.FindImbalances(schedule)
+		if err != nil {
+			log.Printf("error in createRightColumnStruct: %v", err)
+		}
+	}
+	satisfaction := map[string]float64{}
+	if schedule.StartDate != "" && schedule.EndDate != "" {
+		var err error
+		satisfaction, err = vsasched.SatisfactionRates(schedule)
+		if err != nil {
+			log.Printf("error in createRightColumnStruct: %v", err)
+		}
+	}
+	for _, name := range getStringMapKeys(shiftCounts, true) {
+		shiftCount := shift_countStruct{Name: name, Shifts: shiftCounts[name]}
+		if rate, ok := satisfaction[name]; ok {
+			shiftCount.Satisfaction = fmt.Sprintf("%.0f%%", rate*100)
+		}
+		if index := slices.IndexFunc(imbalances, func(imbalance vsasched.Imbalance) bool { return imbalance.Volunteer == name }); index != -1 {
+			shiftCount.Load, shiftCount.Reason = imbalances[index].Load, imbalances[index].Reason
+		}
+		result.Shift_counts = append(result.Shift_counts, shiftCount)
+	}
+	for _, shortage := range shortages {
+		details := []string{}
+		if len(shortage.Unavailable) > 0 {
+			details = append(details, fmt.Sprintf("unavailable: %s", strings.Join(shortage.Unavailable, ", ")))
+		}
+		if len(shortage.Resting) > 0 {
+			details = append(details, fmt.Sprintf("resting: %s", strings.Join(shortage.Resting, ", ")))
+		}
+		if len(shortage.Paired) > 0 {
+			details = append(details, fmt.Sprintf("paired: %s", strings.Join(shortage.Paired, ", ")))
+		}
+		result.Shortages = append(result.Shortages, shortageStruct{shortage.Date, shortage.TimeSlot, shortage.Role, shortage.Needed, shortage.Available, shortage.Constraint, strings.Join(details, "; ")})
+	}
+	return result
+}
+
+func (env Env) prepareTemplateStructs(ctx context.Context, scheduleName string, bIsExistingAndCopyable bool) (base_pageStruct, error) {
+	user := userFromContext(ctx)
+	scheduleNames, err := env.DBModel.SendScheduleNames(user.Workspace, true)
+	if err != nil {
+		return base_pageStruct{}, fmt.Errorf("error in prepareTemplateStructs: %w", err)
+	}
+	workspaces, err := env.DBModel.RequestWorkspaces(user.Name)
 	if err != nil {
-		log.Fatalf("error in prepareTemplateStructs: %v", err)
+		return base_pageStruct{}, fmt.Errorf("error in prepareTemplateStructs: %w", err)
 	}
 	if !slices.Contains(scheduleNames, scheduleName) {
-		volunteer_entries_slice := []volunteer_entryStruct{{"0", "", []string{}}}
-		right_column_data := right_columnStruct{make([]string, 6)}
+		volunteer_entries_slice := []volunteer_entryStruct{{"0", "", []string{}, "", "", "", "", []vsadb.DateRange{}, "", "", "", nil}}
+		right_column_data := createRightColumnStruct(vsadb.SendReceiveDataStruct{}, nil)
 		left_column_data := left_columnStruct{volunteer_entries_slice, false}
-		top_bar_data := top_barStruct{env.LoggedInUser, scheduleNames, "", "", "", weekdaysStruct{}, -1, -1, bIsExistingAndCopyable}
-		return base_pageStruct{top_bar_data, left_column_data, right_column_data}
+		top_bar_data := top_barStruct{user.Name, scheduleNames, "", "", "", weekdaysStruct{}, -1, -1, formatTimeSlots(nil), "", bIsExistingAndCopyable, nil, user.Workspace, workspaces}
+		return base_pageStruct{top_bar_data, left_column_data, right_column_data, error_bannerStruct{}}, nil
 	} else {
-		schedule, err := env.DBModel.FetchAndSendScheduleData(env.LoggedInUser, scheduleName)
+		schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, scheduleName)
 		if err != nil {
-			log.Fatalf("error in prepareTemplateStructs: %v", err)
+			return base_pageStruct{}, fmt.Errorf("error in prepareTemplateStructs: %w", err)
 		}
 		volunteerNames := getStringMapKeys(schedule.VolunteerUnavailabilityData, true)
 		volunteer_entries_slice := make([]volunteer_entryStruct, 0, len(volunteerNames)+1)
 		i := 0
 		for index, volunteerName := range volunteerNames {
-			volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{fmt.Sprint(index), volunteerName, schedule.VolunteerUnavailabilityData[volunteerName]})
+			volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{fmt.Sprint(index), volunteerName, schedule.VolunteerUnavailabilityData[volunteerName], strings.Join(schedule.VolunteerRoleData[volunteerName], ", "),
+				formatPartners(schedule.VolunteerPairingData, volunteerName, vsadb.PairingTogether), formatPartners(schedule.VolunteerPairingData, volunteerName, vsadb.PairingApart),
+				strings.Join(schedule.VolunteerUnavailabilityRuleData[volunteerName], ", "), schedule.VolunteerUnavailabilityRangeData[volunteerName],
+				strings.Join(schedule.VolunteerPreferenceData[volunteerName], ", "), schedule.VolunteerContactData[volunteerName].Email, schedule.VolunteerContactData[volunteerName].Phone, nil})
 			i++
 		}
-		volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{fmt.Sprint(len(volunteerNames)), "", []string{}}) // need a blank volunteer entry
-		weeks := 0
-		if schedule.EndDate != "" && schedule.StartDate != "" {
-			max_date, err := time.Parse("2006-01-02", schedule.EndDate)
-			if err != nil {
-				log.Fatalf("error in prepareTemplateStructs: method failed to parse EndDate: %v. Value of EndDate is `%s`", err, schedule.EndDate)
-			}
-			min_date, err := time.Parse("2006-01-02", schedule.StartDate)
-			if err != nil {
-				log.Fatalf("error in prepareTemplateStructs: method failed to parse StartDate: %v. Value of StartDate is `%s`", err, schedule.StartDate)
-			}
-			weeks = int(math.Floor(max_date.Sub(min_date).Abs().Hours() / 24 / 7)) // (floor of the (((absolute value of (max date minus min date)) in hours) divided by 24hrs/dy divided by 7dy/wk)) converted to an int
-		}
+		volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{fmt.Sprint(len(volunteerNames)), "", []string{}, "", "", "", "", []vsadb.DateRange{}, "", "", "", nil}) // need a blank volunteer entry
 		selected_days := createWeekdaysStruct(schedule.WeekdaysForSchedule)
-		right_column_data := right_columnStruct{make([]string, weeks)}
+		right_column_data := createRightColumnStruct(schedule, nil)
 		left_column_data := left_columnStruct{volunteer_entries_slice, bIsExistingAndCopyable}
-		top_bar_data := top_barStruct{"Seth", scheduleNames, scheduleName, schedule.StartDate, schedule.EndDate, selected_days, schedule.ShiftsOff, schedule.VolunteersPerShift, bIsExistingAndCopyable}
-		return base_pageStruct{top_bar_data, left_column_data, right_column_data}
+		top_bar_data := top_barStruct{user.Name, scheduleNames, scheduleName, schedule.StartDate, schedule.EndDate, selected_days, schedule.ShiftsOff, schedule.VolunteersPerShift, formatTimeSlots(schedule.TimeSlotsForSchedule), formatRoles(schedule.RolesForSchedule), bIsExistingAndCopyable, nil, user.Workspace, workspaces}
+		return base_pageStruct{top_bar_data, left_column_data, right_column_data, error_bannerStruct{}}, nil
+	}
+}
+
+// prepareTemplateStructsFromForm is prepareTemplateStructs for a save-parameters form that parametersValidated sent back with problems: the saved schedules and the right column come from the
+// database as usual, but the top bar and volunteer column show what was submitted, with each problem next to its input, so nothing that was typed in is lost.
+// NOTE: this function does not check the lengths of the form values because this shouldn't be called without prior validation of form.
+func (env Env) prepareTemplateStructsFromForm(ctx context.Context, form url.Values, problems fieldErrors) (base_pageStruct, error) {
+	selectedSchedule := form["schedule-selection"][0]
+	base_page_data, err := env.prepareTemplateStructs(ctx, selectedSchedule, selectedSchedule != "new-schedule")
+	if err != nil {
+		return base_pageStruct{}, fmt.Errorf("error in prepareTemplateStructsFromForm: %w", err)
 	}
+	firstValue := func(key string) string {
+		if len(form[key]) == 0 {
+			return ""
+		}
+		return form[key][0]
+	}
+	top_bar_data := &base_page_data.Top_bar
+	top_bar_data.Current_schedule = form["schedule-name"][0]
+	top_bar_data.Min_date = form["min-date"][0]
+	top_bar_data.Max_date = form["max-date"][0]
+	top_bar_data.Volunteer_days = createWeekdaysStruct(convertWeToWeekday(form["weekday"]))
+	top_bar_data.Shifts_off, top_bar_data.Volunteers_per_shift = -1, -1 // values that are not whole numbers are shown blank
+	if value, err := strconv.Atoi(form["shifts-off"][0]); err == nil {
+		top_bar_data.Shifts_off = value
+	}
+	if value, err := strconv.Atoi(form["per-shift"][0]); err == nil {
+		top_bar_data.Volunteers_per_shift = value
+	}
+	top_bar_data.Time_slots = map[string]string{}
+	for _, weekday := range []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"} {
+		top_bar_data.Time_slots[weekday] = firstValue(fmt.Sprintf("slots-%s", weekday))
+	}
+	top_bar_data.Roles = firstValue("roles")
+	top_bar_data.Field_errors = problems
+	// rebuild the volunteer entries in the order they appear in the form and keep their IdIndex values so the veX- keys in problems still line up
+	nameKeys, indexes := volunteerNameKeys(form)
+	volunteer_entries_slice := make([]volunteer_entryStruct, 0, len(nameKeys)+1)
+	next_index, has_blank := 0, false
+	for _, nameKey := range nameKeys {
+		prefix := nameKey[:len(nameKey)-1]
+		id_index := nameKey[len("ve") : len(nameKey)-len("-n")]
+		ranges := []vsadb.DateRange{}
+		ends := form[fmt.Sprintf("%se", prefix)]
+		for i, start := range form[fmt.Sprintf("%sf", prefix)] {
+			if dateRange := (vsadb.DateRange{Start: start, End: ends[i]}); dateRange != (vsadb.DateRange{}) {
+				ranges = append(ranges, dateRange)
+			}
+		}
+		volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{id_index, form[nameKey][0],
+			slices.DeleteFunc(slices.Clone(form[fmt.Sprintf("%su", prefix)]), func(s string) bool { return s == "" }), firstValue(fmt.Sprintf("%sq", prefix)),
+			firstValue(fmt.Sprintf("%st", prefix)), firstValue(fmt.Sprintf("%sa", prefix)), firstValue(fmt.Sprintf("%sr", prefix)), ranges, firstValue(fmt.Sprintf("%sp", prefix)),
+			firstValue(fmt.Sprintf("%sm", prefix)), firstValue(fmt.Sprintf("%sh", prefix)), problems})
+		if index, ok := indexes[nameKey]; ok {
+			next_index = max(next_index, index+1)
+		}
+		has_blank = has_blank || form[nameKey][0] == ""
+	}
+	if !has_blank {
+		volunteer_entries_slice = append(volunteer_entries_slice, volunteer_entryStruct{fmt.Sprint(next_index), "", []string{}, "", "", "", "", []vsadb.DateRange{}, "", "", "", nil}) // need a blank volunteer entry
+	}
+	base_page_data.Left_column.Volunteer_column = volunteer_entries_slice
+	problemCount := 0
+	for _, messages := range problems {
+		problemCount += len(messages)
+	}
+	base_page_data.Error_banner = error_bannerStruct{http.StatusUnprocessableEntity, http.StatusText(http.StatusUnprocessableEntity),
+		fmt.Sprintf("the schedule parameters were not saved. Fix the %d problem(s) marked below and save again.", problemCount)}
+	return base_page_data, nil
 }
 
 func requestIsValid(w http.ResponseWriter, r *http.Request, intended_url string, intended_method string) bool {
@@ -233,7 +781,7 @@ func requestIsValid(w http.ResponseWriter, r *http.Request, intended_url string,
 		validRequest = false
 		log.Printf("Provided URL does not match the URL this function is intended to serve: %s (intended), %s (provided)", intended_url, r.URL.Path)
 	} else if r.Method != intended_method {
-		http.Error(w, "Method is not supported.", http.StatusNotFound)
+		http.Error(w, "Method is not supported.", http.StatusMethodNotAllowed)
 		validRequest = false
 		log.Printf("Method not supported: %s", r.Method)
 	}
@@ -255,9 +803,189 @@ func extractVolunteers(form url.Values) map[string][]string {
 	return volunteers
 }
 
-func (env Env) parametersValidated(form url.Values, keys_to_check ...string) error {
-	// possbile keys_to_check: "schedule-selection", "schedule-name", "IdIndex" "veX-X", "min-date", "max-date", "weekday", "shifts-off", "per-shift"
+func extractVolunteerRoles(form url.Values) map[string][]string {
+	// same as extractVolunteers, but the value is the roles listed in the corresponding veX-q. volunteers without roles are left out.
+	var volunteerRoles = map[string][]string{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if veX_nRegex.MatchString(v) && slices.Contains(keys, fmt.Sprintf("%sq", v[:len(v)-1])) && form[v][0] != "" {
+			if roles := parseQualifications(form[fmt.Sprintf("%sq", v[:len(v)-1])][0]); len(roles) > 0 {
+				volunteerRoles[form[v][0]] = roles
+			}
+		}
+	}
+	return volunteerRoles
+}
+
+func extractVolunteerRules(form url.Values) map[string][]string {
+	// same as extractVolunteerRoles, but the value is the unavailability rules listed in the corresponding veX-r. volunteers without rules are left out.
+	var volunteerRules = map[string][]string{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if veX_nRegex.MatchString(v) && slices.Contains(keys, fmt.Sprintf("%sr", v[:len(v)-1])) && form[v][0] != "" {
+			if rules := parseQualifications(form[fmt.Sprintf("%sr", v[:len(v)-1])][0]); len(rules) > 0 {
+				volunteerRules[form[v][0]] = rules
+			}
+		}
+	}
+	return volunteerRules
+}
+
+func extractVolunteerPreferences(form url.Values) map[string][]string {
+	// same as extractVolunteerRoles, but the value is the preferences listed in the corresponding veX-p. volunteers without preferences are left out.
+	var volunteerPreferences = map[string][]string{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if veX_nRegex.MatchString(v) && slices.Contains(keys, fmt.Sprintf("%sp", v[:len(v)-1])) && form[v][0] != "" {
+			if preferences := parseQualifications(form[fmt.Sprintf("%sp", v[:len(v)-1])][0]); len(preferences) > 0 {
+				volunteerPreferences[form[v][0]] = preferences
+			}
+		}
+	}
+	return volunteerPreferences
+}
+
+func extractVolunteerContacts(form url.Values) map[string]vsadb.VolunteerContact {
+	// same as extractVolunteerRoles, but the value is the email address in the corresponding veX-m and the phone number in the corresponding veX-h. volunteers with neither are left out.
+	var volunteerContacts = map[string]vsadb.VolunteerContact{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if veX_nRegex.MatchString(v) && form[v][0] != "" {
+			contact := vsadb.VolunteerContact{}
+			if value := form[fmt.Sprintf("%sm", v[:len(v)-1])]; len(value) == 1 {
+				contact.Email = strings.TrimSpace(value[0])
+			}
+			if value := form[fmt.Sprintf("%sh", v[:len(v)-1])]; len(value) == 1 {
+				contact.Phone = strings.TrimSpace(value[0])
+			}
+			if contact != (vsadb.VolunteerContact{}) {
+				volunteerContacts[form[v][0]] = contact
+			}
+		}
+	}
+	return volunteerContacts
+}
+
+func extractVolunteerRanges(form url.Values) map[string][]vsadb.DateRange {
+	// same as extractVolunteerRoles, but the value is the date ranges made by pairing up the corresponding veX-f (from) and veX-e (to) values. blank pairs and volunteers without date ranges are left out.
+	// NOTE: this function does not check that veX-f and veX-e have the same length because this shouldn't be called without prior validation of form.
+	var volunteerRanges = map[string][]vsadb.DateRange{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if veX_nRegex.MatchString(v) && slices.Contains(keys, fmt.Sprintf("%sf", v[:len(v)-1])) && form[v][0] != "" {
+			ends := form[fmt.Sprintf("%se", v[:len(v)-1])]
+			for i, start := range form[fmt.Sprintf("%sf", v[:len(v)-1])] {
+				if dateRange := (vsadb.DateRange{Start: start, End: ends[i]}); dateRange != (vsadb.DateRange{}) && !slices.Contains(volunteerRanges[form[v][0]], dateRange) {
+					volunteerRanges[form[v][0]] = append(volunteerRanges[form[v][0]], dateRange)
+				}
+			}
+		}
+	}
+	return volunteerRanges
+}
+
+func extractVolunteerPairings(form url.Values) []vsadb.VolunteerPairing {
+	// read the names listed in each veX-t (serves with) and veX-a (never with) of a named volunteer. names that are not another volunteer in the form are dropped so a deleted volunteer takes their
+	// pairings with them, and a pair listed from both sides is only returned once.
+	// NOTE: this function does not check for a pair that is listed as both together and apart because this shouldn't be called without prior validation of form.
+	var volunteerPairings = []vsadb.VolunteerPairing{}
+	volunteers := extractVolunteers(form)
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if !veX_nRegex.MatchString(v) || form[v][0] == "" {
+			continue
+		}
+		for suffix, pairing := range map[string]string{"t": vsadb.PairingTogether, "a": vsadb.PairingApart} {
+			if !slices.Contains(keys, fmt.Sprintf("%s%s", v[:len(v)-1], suffix)) {
+				continue
+			}
+			for _, partner := range parseQualifications(form[fmt.Sprintf("%s%s", v[:len(v)-1], suffix)][0]) {
+				if _, ok := volunteers[partner]; !ok || partner == form[v][0] {
+					continue
+				}
+				if !slices.ContainsFunc(volunteerPairings, func(volunteerPairing vsadb.VolunteerPairing) bool {
+					return volunteerPairing.Pairing == pairing && (volunteerPairing.Volunteer == partner && volunteerPairing.PairedVolunteer == form[v][0] || volunteerPairing.Volunteer == form[v][0] && volunteerPairing.PairedVolunteer == partner)
+				}) {
+					volunteerPairings = append(volunteerPairings, vsadb.VolunteerPairing{Volunteer: form[v][0], PairedVolunteer: partner, Pairing: pairing})
+				}
+			}
+		}
+	}
+	return volunteerPairings
+}
+
+// scheduleFromForm is the schedule a save-parameters form describes.
+// NOTE: this function does not check form because this shouldn't be called without prior validation of form.
+func scheduleFromForm(form url.Values) vsadb.SendReceiveDataStruct {
+	toBeReceived := vsadb.SendReceiveDataStruct{}
+	toBeReceived.ScheduleName = form["schedule-name"][0]
+	toBeReceived.VolunteerUnavailabilityData = extractVolunteers(form)
+	toBeReceived.StartDate = form["min-date"][0]
+	toBeReceived.EndDate = form["max-date"][0]
+	toBeReceived.WeekdaysForSchedule = convertWeToWeekday(form["weekday"])
+	if form["shifts-off"][0] != "" {
+		toBeReceived.ShiftsOff = mustAtoI(form["shifts-off"][0])
+	} else {
+		toBeReceived.ShiftsOff = -1
+	}
+	if form["per-shift"][0] != "" {
+		toBeReceived.VolunteersPerShift = mustAtoI(form["per-shift"][0])
+	} else {
+		toBeReceived.VolunteersPerShift = -1
+	}
+	toBeReceived.TimeSlotsForSchedule = map[string][]vsadb.TimeSlot{} // non-nil so time slots cleared in the form are deleted
+	for _, weekday := range form["weekday"] {
+		if formValue := form[fmt.Sprintf("slots-%s", weekday)]; len(formValue) == 1 {
+			timeSlots, _ := parseTimeSlots(formValue[0]) // already validated
+			if len(timeSlots) > 0 {
+				toBeReceived.TimeSlotsForSchedule[convertWeToWeekday([]string{weekday})[0]] = timeSlots
+			}
+		}
+	}
+	toBeReceived.RolesForSchedule = []vsadb.RoleRequirement{} // non-nil so roles cleared in the form are deleted
+	if len(form["roles"]) == 1 {
+		toBeReceived.RolesForSchedule, _ = parseRoles(form["roles"][0]) // already validated
+	}
+	toBeReceived.VolunteerRoleData = extractVolunteerRoles(form)
+	toBeReceived.VolunteerPairingData = extractVolunteerPairings(form) // non-nil so pairings cleared in the form are deleted
+	toBeReceived.VolunteerUnavailabilityRuleData = extractVolunteerRules(form)
+	toBeReceived.VolunteerUnavailabilityRangeData = extractVolunteerRanges(form)
+	toBeReceived.VolunteerPreferenceData = extractVolunteerPreferences(form)
+	toBeReceived.VolunteerContactData = extractVolunteerContacts(form) // non-nil so contacts cleared in the form are deleted
+	// VolunteerScheduledData is left nil so a completed schedule saved through /save-schedule is kept
+	return toBeReceived
+}
+
+func extractScheduledVolunteers(form url.Values) map[string][]string {
+	// loop over the keys on r.Form and if the key is sv- followed by a ShiftKey string (sv-YYYY-MM-DD, sv-YYYY-MM-DD|time slot, sv-YYYY-MM-DD|time slot|role), then add the shift to each volunteer named in form[sv-...] (ignoring "" values).
+	// NOTE: this function does not check the shifts because this shouldn't be called without prior validation of form.
+	var scheduledVolunteers = map[string][]string{}
+	keys := getStringMapKeys(form, true)
+	for _, v := range keys {
+		if svX_Regex.MatchString(v) {
+			for _, name := range form[v] {
+				if name != "" && !slices.Contains(scheduledVolunteers[name], v[len("sv-"):]) {
+					scheduledVolunteers[name] = append(scheduledVolunteers[name], v[len("sv-"):])
+				}
+			}
+		}
+	}
+	return scheduledVolunteers
+}
+
+func (env Env) parametersValidated(ctx context.Context, form url.Values, keys_to_check ...string) error {
+	// possbile keys_to_check: "schedule-selection", "schedule-name", "IdIndex" "veX-X", "svX", "min-date", "max-date", "weekday", "shifts-off", "per-shift", "slots-X", "roles", "pairings"
+	// a malformed request (wrong number of values, unknown schedule, non weekday values, ...) is returned straight away as an error. problems a person can fix by editing the form are collected
+	// instead and returned together as fieldErrors once every key has been checked, so they can all be shown next to their inputs.
 	mustBeLen1 := []string{"schedule-selection", "schedule-name", "IdIndex", "min-date", "max-date", "shifts-off", "per-shift"} // veX-n must also be len 1, but that is handled later
+	problems := fieldErrors{}
+	scheduleDate := func(key string) (time.Time, bool) { // the parsed min-date or max-date, if it was filled in correctly
+		if len(form[key]) != 1 {
+			return time.Time{}, false
+		}
+		value, err := time.Parse("2006-01-02", form[key][0])
+		return value, err == nil
+	}
 	for _, keyToCheck := range keys_to_check {
 		if slices.Contains(mustBeLen1, keyToCheck) {
 			if len(form[keyToCheck]) != 1 {
@@ -265,17 +993,17 @@ func (env Env) parametersValidated(form url.Values, keys_to_check ...string) err
 			}
 		}
 		if keyToCheck == "schedule-selection" {
-			scheduleKeys, err := env.DBModel.SendScheduleNames(env.LoggedInUser, false)
+			scheduleKeys, err := env.DBModel.SendScheduleNames(userFromContext(ctx).Workspace, false)
 			if err != nil {
-				return fmt.Errorf("error in parametersValidated: %w", err)
+				return fmt.Errorf("error in parametersValidated: %w: %w", errScheduleLookup, err)
 			}
 			allowedValues := make([]string, 0, len(scheduleKeys)+2)
 			allowedValues = append(allowedValues, scheduleKeys...)
 			allowedValues = append(allowedValues, "new-schedule", "copy-current-schedule")
 			if !slices.Contains(allowedValues, form[keyToCheck][0]) {
-				return fmt.Errorf("error in parametersValidated: Value of \"%s\" for \"%s\" was not a known response", form[keyToCheck][0], keyToCheck)
+				return fmt.Errorf("error in parametersValidated: %w: Value of \"%s\" for \"%s\" was not a known response", vsadb.ErrNotFound, form[keyToCheck][0], keyToCheck)
 			}
-		} else if keyToCheck == "IdIndex" || keyToCheck == "shifts-off" {
+		} else if keyToCheck == "IdIndex" {
 			if form[keyToCheck][0] != "" {
 				value, err := strconv.Atoi(form[keyToCheck][0])
 				if err != nil {
@@ -285,34 +1013,148 @@ func (env Env) parametersValidated(form url.Values, keys_to_check ...string) err
 					return fmt.Errorf("error in parametersValidated: \"%s\" is less than 0", keyToCheck)
 				}
 			}
+		} else if keyToCheck == "shifts-off" {
+			if form[keyToCheck][0] != "" {
+				value, err := strconv.Atoi(form[keyToCheck][0])
+				if err != nil {
+					problems.add(keyToCheck, "must be a whole number")
+				} else if value < 0 {
+					problems.add(keyToCheck, "cannot be less than 0")
+				}
+			}
 		} else if keyToCheck == "schedule-name" {
 			if strings.ContainsAny(form[keyToCheck][0], "\\/:*?\"<>|") {
-				return fmt.Errorf("error in parametersValidated: \"%s\" contains illegal characters (\\/:*?\"<>|)", keyToCheck)
+				problems.add(keyToCheck, "cannot contain any of \\ / : * ? \" < > |")
 			}
 		} else if keyToCheck == "veX-X" {
+			// the same name entered for two volunteers is flagged on every entry after the first, in the order the entries appear in the form
+			nameKeys, indexes := volunteerNameKeys(form)
+			seenNames := []string{}
+			for _, nameKey := range nameKeys {
+				if len(form[nameKey]) != 1 {
+					return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", nameKey)
+				}
+				if _, ok := indexes[nameKey]; !ok {
+					problems.add(nameKey, "is not a volunteer entry of this form (the number in its name is too large)")
+				}
+				if name := form[nameKey][0]; name != "" {
+					if slices.Contains(seenNames, name) {
+						problems.add(nameKey, fmt.Sprintf("%s is already entered above", name))
+					}
+					seenNames = append(seenNames, name)
+				}
+			}
+			minDate, hasMinDate := scheduleDate("min-date")
+			maxDate, hasMaxDate := scheduleDate("max-date")
 			for formKey, formValue := range form {
-				if veX_nRegex.MatchString(formKey) {
+				if veX_qRegex.MatchString(formKey) || veX_tRegex.MatchString(formKey) || veX_aRegex.MatchString(formKey) {
 					if len(formValue) != 1 {
-						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", keyToCheck)
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", formKey)
 					}
-				} else if veX_uRegex.MatchString(formKey) {
-					for _, stringElement := range formValue {
-						if stringElement != "" {
-							_, err := time.Parse("2006-01-02", stringElement)
+					if strings.Contains(formValue[0], vsadb.ShiftKeySeparator) {
+						problems.add(formKey, fmt.Sprintf("cannot contain %s", vsadb.ShiftKeySeparator))
+					}
+				} else if veX_rRegex.MatchString(formKey) {
+					if len(formValue) != 1 {
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", formKey)
+					}
+					for _, ruleString := range parseQualifications(formValue[0]) {
+						if _, err := (vsadb.UnavailabilityRule{}).FromString(ruleString); err != nil {
+							problems.add(formKey, fieldErrorMessage(err))
+						}
+					}
+				} else if veX_pRegex.MatchString(formKey) {
+					if len(formValue) != 1 {
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", formKey)
+					}
+					for _, preferenceString := range parseQualifications(formValue[0]) {
+						if _, err := (vsadb.VolunteerPreference{}).FromString(preferenceString); err != nil {
+							problems.add(formKey, fieldErrorMessage(err))
+						}
+					}
+				} else if veX_fRegex.MatchString(formKey) || veX_eRegex.MatchString(formKey) {
+					// check each from (veX-f) and to (veX-e) pair once, from the veX-f side. problems with a pair are all reported on veX-f
+					starts, ends := form[fmt.Sprintf("%sf", formKey[:len(formKey)-1])], form[fmt.Sprintf("%se", formKey[:len(formKey)-1])]
+					if len(starts) != len(ends) {
+						return fmt.Errorf("error in parametersValidated: \"%sf\" and \"%se\" do not have the same length", formKey[:len(formKey)-1], formKey[:len(formKey)-1])
+					}
+					if veX_eRegex.MatchString(formKey) {
+						continue
+					}
+					for i := range starts {
+						if starts[i] == "" && ends[i] == "" {
+							continue
+						}
+						start, startErr := time.Parse("2006-01-02", starts[i])
+						if startErr != nil {
+							problems.add(formKey, fmt.Sprintf("\"%s\" is not a valid date (YYYY-MM-DD)", starts[i]))
+						}
+						end, endErr := time.Parse("2006-01-02", ends[i])
+						if endErr != nil {
+							problems.add(formKey, fmt.Sprintf("\"%s\" is not a valid date (YYYY-MM-DD)", ends[i]))
+						}
+						if startErr == nil && endErr == nil && end.Before(start) {
+							problems.add(formKey, fmt.Sprintf("the time away from %s to %s ends before it starts", starts[i], ends[i]))
+						}
+					}
+				} else if veX_mRegex.MatchString(formKey) {
+					if len(formValue) != 1 {
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", formKey)
+					}
+					if value := strings.TrimSpace(formValue[0]); value != "" {
+						if address, err := mail.ParseAddress(value); err != nil || address.Name != "" || address.Address != value {
+							problems.add(formKey, fmt.Sprintf("\"%s\" is not an email address", value))
+						}
+					}
+				} else if veX_hRegex.MatchString(formKey) {
+					if len(formValue) != 1 {
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not have length of 1", formKey)
+					}
+					// digits with the usual separators, 7 to 15 of them (the most E.164 allows)
+					if value := strings.TrimSpace(formValue[0]); value != "" {
+						digits := len(strings.Map(func(r rune) rune {
+							if r >= '0' && r <= '9' {
+								return r
+							}
+							return -1
+						}, value))
+						if !phoneRegex.MatchString(value) || digits < 7 || digits > 15 {
+							problems.add(formKey, fmt.Sprintf("\"%s\" is not a phone number", value))
+						}
+					}
+				} else if veX_uRegex.MatchString(formKey) {
+					for _, stringElement := range formValue {
+						if stringElement != "" {
+							unavailableDate, err := time.Parse("2006-01-02", stringElement)
 							if err != nil {
-								return fmt.Errorf("error in parametersValidated: \"%s\" value \"%s\" is not in a valid date format (YYYY-MM-DD): %w", formKey, stringElement, err)
+								problems.add(formKey, fmt.Sprintf("\"%s\" is not a valid date (YYYY-MM-DD)", stringElement))
+							} else if hasMinDate && unavailableDate.Before(minDate) || hasMaxDate && unavailableDate.After(maxDate) {
+								problems.add(formKey, fmt.Sprintf("%s is outside the schedule dates", stringElement))
 							}
 						}
 					}
-
 				}
 			}
-
+		} else if keyToCheck == "svX" {
+			for formKey := range form {
+				if svX_Regex.MatchString(formKey) {
+					_, err := vsadb.ShiftKey{}.FromString(formKey[len("sv-"):])
+					if err != nil {
+						return fmt.Errorf("error in parametersValidated: \"%s\" does not end in a valid shift (YYYY-MM-DD, YYYY-MM-DD|time slot, or YYYY-MM-DD|time slot|role): %w", formKey, err)
+					}
+				}
+			}
 		} else if keyToCheck == "min-date" || keyToCheck == "max-date" {
 			if form[keyToCheck][0] != "" {
-				_, err := time.Parse("2006-01-02", form[keyToCheck][0])
-				if err != nil {
-					return fmt.Errorf("error in parametersValidated: \"%s\" is not in a valid date format (YYYY-MM-DD): %w", keyToCheck, err)
+				if _, err := time.Parse("2006-01-02", form[keyToCheck][0]); err != nil {
+					problems.add(keyToCheck, "is not a valid date (YYYY-MM-DD)")
+				}
+			}
+			if keyToCheck == "max-date" {
+				minDate, hasMinDate := scheduleDate("min-date")
+				maxDate, hasMaxDate := scheduleDate("max-date")
+				if hasMinDate && hasMaxDate && maxDate.Before(minDate) {
+					problems.add(keyToCheck, "is before the start date")
 				}
 			}
 		} else if keyToCheck == "weekday" {
@@ -323,16 +1165,56 @@ func (env Env) parametersValidated(form url.Values, keys_to_check ...string) err
 			if form[keyToCheck][0] != "" {
 				value, err := strconv.Atoi(form[keyToCheck][0])
 				if err != nil {
-					return fmt.Errorf("error in parametersValidated: \"%s\" cannot be converted to an integer: %w", keyToCheck, err)
+					problems.add(keyToCheck, "must be a whole number")
+				} else if value < 1 {
+					problems.add(keyToCheck, "cannot be less than 1")
+				} else if volunteerCount := len(extractVolunteers(form)); value > volunteerCount {
+					problems.add(keyToCheck, fmt.Sprintf("is more than the %d volunteer(s) entered", volunteerCount))
+				}
+			}
+		} else if keyToCheck == "slots-X" {
+			for _, weekday := range []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"} {
+				formKey := fmt.Sprintf("slots-%s", weekday)
+				if len(form[formKey]) > 1 {
+					return fmt.Errorf("error in parametersValidated: \"%s\" has length greater than 1", formKey)
 				}
-				if value < 1 {
-					return fmt.Errorf("error in parametersValidated: \"%s\" is less than 1", keyToCheck)
+				if len(form[formKey]) == 1 {
+					if _, err := parseTimeSlots(form[formKey][0]); err != nil {
+						problems.add(formKey, fieldErrorMessage(err))
+					}
+				}
+			}
+		} else if keyToCheck == "roles" {
+			if len(form[keyToCheck]) > 1 {
+				return fmt.Errorf("error in parametersValidated: \"%s\" has length greater than 1", keyToCheck)
+			}
+			if len(form[keyToCheck]) == 1 {
+				if _, err := parseRoles(form[keyToCheck][0]); err != nil {
+					problems.add(keyToCheck, fieldErrorMessage(err))
+				}
+			}
+		} else if keyToCheck == "pairings" {
+			// veX-X covers the format of veX-t and veX-a; here a pair of volunteers cannot be listed as both serving together and never together. the problem is reported on the veX-a of the volunteer
+			// that lists it
+			together := map[string][]string{}
+			for _, volunteerPairing := range extractVolunteerPairings(form) {
+				if volunteerPairing.Pairing == vsadb.PairingTogether {
+					together[volunteerPairing.Volunteer] = append(together[volunteerPairing.Volunteer], volunteerPairing.PairedVolunteer)
+					together[volunteerPairing.PairedVolunteer] = append(together[volunteerPairing.PairedVolunteer], volunteerPairing.Volunteer)
+				}
+			}
+			for _, volunteerPairing := range extractVolunteerPairings(form) {
+				if volunteerPairing.Pairing == vsadb.PairingApart && slices.Contains(together[volunteerPairing.Volunteer], volunteerPairing.PairedVolunteer) {
+					problems.add(volunteerFieldKey(form, volunteerPairing.Volunteer, "a"), fmt.Sprintf("%s is also listed as serving with %s", volunteerPairing.PairedVolunteer, volunteerPairing.Volunteer))
 				}
 			}
 		} else {
 			return fmt.Errorf("error in parametersValidated: \"%s\" is present but unchecked", keyToCheck)
 		}
 	}
+	if len(problems) > 0 {
+		return problems
+	}
 	return nil
 }
 
@@ -346,11 +1228,12 @@ func (env *Env) handleRoot(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
 		return
 	}
-	base_page_data := env.prepareTemplateStructs("", false)
-	err := templates.ExecuteTemplate(w, "base_page", base_page_data)
+	base_page_data, err := env.prepareTemplateStructs(r.Context(), "", false)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
 	}
+	renderTemplate(w, handlerInfo, "base_page", base_page_data)
 }
 
 func (env *Env) handleSelectSchedule(w http.ResponseWriter, r *http.Request) {
@@ -363,25 +1246,28 @@ func (env *Env) handleSelectSchedule(w http.ResponseWriter, r *http.Request) {
 	}
 	err := r.ParseForm()
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
 	}
-	if err = env.parametersValidated(r.Form, "schedule-selection", "schedule-name"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-selection", "schedule-name"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
 	}
 	log.Printf("Evaluating %s from get: %v", handlerInfo.address, r.Form)
 	var base_page_data base_pageStruct
 	if r.Form["schedule-selection"][0] == "new-schedule" { // case where no schedule is selected (the blank entry in the select element)
-		base_page_data = env.prepareTemplateStructs("", false)
+		base_page_data, err = env.prepareTemplateStructs(r.Context(), "", false)
 	} else if r.Form["schedule-selection"][0] == "copy-current-schedule" { // case where copying schedule
-		base_page_data = env.prepareTemplateStructs(r.Form["schedule-name"][0], false)
+		base_page_data, err = env.prepareTemplateStructs(r.Context(), r.Form["schedule-name"][0], false)
 		base_page_data.Top_bar.Current_schedule = fmt.Sprintf("Copy of %s", base_page_data.Top_bar.Current_schedule)
 	} else { // case where selection is not new or copy
-		base_page_data = env.prepareTemplateStructs(r.Form["schedule-selection"][0], true)
+		base_page_data, err = env.prepareTemplateStructs(r.Context(), r.Form["schedule-selection"][0], true)
 	}
-	err = templates.ExecuteTemplate(w, "base_page", base_page_data)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
 	}
+	renderTemplate(w, handlerInfo, "base_page", base_page_data)
 }
 
 func (env *Env) handleAddVolunteerUnavailability(w http.ResponseWriter, r *http.Request) {
@@ -394,10 +1280,12 @@ func (env *Env) handleAddVolunteerUnavailability(w http.ResponseWriter, r *http.
 	}
 	err := r.ParseForm()
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
 	}
-	if err = env.parametersValidated(r.Form, "IdIndex"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
+	if err = env.parametersValidated(r.Context(), r.Form, "IdIndex"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
 	}
 	log.Printf("Evaluating %s from get: %v", handlerInfo.address, r.Form)
 	id_index := r.Form["IdIndex"][0]
@@ -405,10 +1293,7 @@ func (env *Env) handleAddVolunteerUnavailability(w http.ResponseWriter, r *http.
 		log.Print("Not adding new blank volunteer unavailability since one blank volunteer is already present.")
 		return
 	}
-	err = templates.ExecuteTemplate(w, "ve_unavailable_single_blank", volunteer_entryStruct{id_index, "", []string{}})
-	if err != nil {
-		log.Fatal(err)
-	}
+	renderTemplate(w, handlerInfo, "ve_unavailable_single_blank", volunteer_entryStruct{id_index, "", []string{}, "", "", "", "", []vsadb.DateRange{}, "", "", "", nil})
 }
 
 func (env *Env) handleModVolunteers(w http.ResponseWriter, r *http.Request) {
@@ -421,10 +1306,12 @@ func (env *Env) handleModVolunteers(w http.ResponseWriter, r *http.Request) {
 	}
 	err := r.ParseForm()
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
 	}
-	if err = env.parametersValidated(r.Form, "IdIndex"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
+	if err = env.parametersValidated(r.Context(), r.Form, "IdIndex"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
 	}
 	log.Printf("Evaluating %s from get: %v", handlerInfo.address, r.Form)
 	id_index := r.Form["IdIndex"][0]
@@ -444,10 +1331,7 @@ func (env *Env) handleModVolunteers(w http.ResponseWriter, r *http.Request) {
 	}
 	//log.Printf("Blanks: %d; IdIndex: %s", count_blanks, id_index)
 	if count_blanks == 0 || (slices.Contains(r.Form[veX_n(id_index)], "") && count_blanks <= 1) {
-		err = templates.ExecuteTemplate(w, "volunteer_entry", volunteer_entryStruct{fmt.Sprint(next_index), "", []string{}})
-		if err != nil {
-			log.Fatal(err)
-		}
+		renderTemplate(w, handlerInfo, "volunteer_entry", volunteer_entryStruct{fmt.Sprint(next_index), "", []string{}, "", "", "", "", []vsadb.DateRange{}, "", "", "", nil})
 	}
 }
 
@@ -459,48 +1343,47 @@ func (env *Env) handleSaveParameters(w http.ResponseWriter, r *http.Request) { /
 		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
 		return
 	}
+	user := userFromContext(r.Context())
 	err := r.ParseForm()
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
 	}
 	// Perform basic validations
-	if err = env.parametersValidated(r.Form, "schedule-selection", "schedule-name"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
 	}
 	log.Printf("Evaluating %s from post: %v", handlerInfo.address, r.Form)
-	if err = env.parametersValidated(r.Form, "veX-X", "min-date", "max-date", "weekday", "shifts-off", "per-shift"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
-	}
-	selected_schedule_entry := r.Form["schedule-selection"][0]
-	volunteer_entries := extractVolunteers(r.Form)
-	bNewSchedule := selected_schedule_entry == "new-schedule"
-	toBeReceived := vsadb.SendReceiveDataStruct{}
-	toBeReceived.ScheduleName = r.Form["schedule-name"][0]
-	toBeReceived.VolunteerUnavailabilityData = volunteer_entries
-	toBeReceived.StartDate = r.Form["min-date"][0]
-	toBeReceived.EndDate = r.Form["max-date"][0]
-	toBeReceived.WeekdaysForSchedule = convertWeToWeekday(r.Form["weekday"])
-	if r.Form["shifts-off"][0] != "" {
-		toBeReceived.ShiftsOff = mustAtoI(r.Form["shifts-off"][0])
-	} else {
-		toBeReceived.ShiftsOff = -1
-	}
-	if r.Form["per-shift"][0] != "" {
-		toBeReceived.VolunteersPerShift = mustAtoI(r.Form["per-shift"][0])
-	} else {
-		toBeReceived.VolunteersPerShift = -1
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-name", "veX-X", "min-date", "max-date", "weekday", "shifts-off", "per-shift", "slots-X", "roles", "pairings"); err != nil {
+		var problems fieldErrors
+		if !errors.As(err, &problems) {
+			respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+			return
+		}
+		// send the form back with the problems next to their inputs instead of saving it
+		log.Printf("Error in %s (%d %s): %v", handlerInfo.address, http.StatusUnprocessableEntity, http.StatusText(http.StatusUnprocessableEntity), err)
+		base_page_data, err := env.prepareTemplateStructsFromForm(r.Context(), r.Form, problems)
+		if err != nil {
+			respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+			return
+		}
+		renderTemplateWithStatus(w, handlerInfo, http.StatusUnprocessableEntity, "base_page", base_page_data)
+		return
 	}
-	// Add saving completed schedule stuff here once it's implemented in the web app TODO
-	//log.Printf("%#v", toBeReceived)
-	err = env.DBModel.RecieveAndStoreData(env.LoggedInUser, toBeReceived, bNewSchedule)
+	bNewSchedule := r.Form["schedule-selection"][0] == "new-schedule"
+	toBeReceived := scheduleFromForm(r.Form)
+	err = env.DBModel.RecieveAndStoreData(user.Workspace, toBeReceived, bNewSchedule)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
 	}
-	base_page_data := env.prepareTemplateStructs(r.Form["schedule-name"][0], true)
-	err = templates.ExecuteTemplate(w, "base_page", base_page_data)
+	base_page_data, err := env.prepareTemplateStructs(r.Context(), r.Form["schedule-name"][0], true)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
 	}
+	renderTemplate(w, handlerInfo, "base_page", base_page_data)
 }
 
 func (env *Env) handleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
@@ -511,26 +1394,643 @@ func (env *Env) handleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
 		return
 	}
+	user := userFromContext(r.Context())
 	err := r.ParseForm()
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
 	}
-	if err = env.parametersValidated(r.Form, "schedule-selection"); err != nil {
-		log.Fatalf("Fatal error in %s: %v", handlerInfo.address, err)
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
 	}
 	log.Printf("Evaluating %s from get: %v", handlerInfo.address, r.Form)
 	if r.Form["schedule-selection"][0] == "new-schedule" {
+		log.Print("Not deleting a schedule since no schedule is selected.")
 		w.Header().Set("HX-Retarget", "none") // overrides hx-target="body" from `<button id="schedule-delete-btn"...` in top_bar_div.gohtml
+		return
 	}
 	data := vsadb.SendReceiveDataStruct{ScheduleName: r.Form["schedule-selection"][0]}
-	err = env.DBModel.RecieveAndDeleteData(env.LoggedInUser, data)
+	err = env.DBModel.RecieveAndDeleteData(user.Workspace, data)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
 	}
-	base_page_data := env.prepareTemplateStructs("", false)
-	err = templates.ExecuteTemplate(w, "base_page", base_page_data)
+	base_page_data, err := env.prepareTemplateStructs(r.Context(), "", false)
 	if err != nil {
-		log.Fatal(err)
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	renderTemplate(w, handlerInfo, "base_page", base_page_data)
+}
+
+func (env *Env) handleGenerateSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/generate-schedule", "handleGenerateSchedule", "GET"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-selection"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
+	}
+	log.Printf("Evaluating %s from get: %v", handlerInfo.address, r.Form)
+	if r.Form["schedule-selection"][0] == "new-schedule" || r.Form["schedule-selection"][0] == "copy-current-schedule" {
+		log.Print("Not generating a schedule since the schedule parameters have not been saved yet.")
+		w.Header().Set("HX-Retarget", "none") // overrides hx-target="#schedule-output" from `<button id="gen-schedule-btn"...` in right_column_div.gohtml
+		return
+	}
+	schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, r.Form["schedule-selection"][0])
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	generated, err := vsasched.GenerateSchedule(schedule)
+	var infeasible *vsasched.InfeasibleError
+	if errors.As(err, &infeasible) { // show the partially filled schedule along with the dates that could not be filled
+		log.Printf("Error in %s: %v", handlerInfo.address, err)
+		renderTemplate(w, handlerInfo, "schedule_output", createRightColumnStruct(generated.Data, infeasible.Shortages))
+		return
+	} else if err != nil {
+		respondWithError(w, handlerInfo, http.StatusUnprocessableEntity, err)
+		return
+	}
+	renderTemplate(w, handlerInfo, "schedule_output", createRightColumnStruct(generated.Data, nil))
+}
+
+func (env *Env) handleSaveSchedule(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/save-schedule", "handleSaveSchedule", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if err = env.parametersValidated(r.Context(), r.Form, "schedule-selection", "svX"); err != nil {
+		respondWithError(w, handlerInfo, validationErrorStatus(err), err)
+		return
+	}
+	log.Printf("Evaluating %s from post: %v", handlerInfo.address, r.Form)
+	if r.Form["schedule-selection"][0] == "new-schedule" || r.Form["schedule-selection"][0] == "copy-current-schedule" {
+		log.Print("Not saving the schedule since the schedule parameters have not been saved yet.")
+		w.Header().Set("HX-Retarget", "none") // overrides hx-target="#schedule-output" from `<button id="save-schedule-btn"...` in right_column_div.gohtml
+		return
+	}
+	toBeReceived, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, r.Form["schedule-selection"][0])
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	toBeReceived.VolunteerScheduledData = extractScheduledVolunteers(r.Form)
+	for name := range toBeReceived.VolunteerScheduledData {
+		if _, ok := toBeReceived.VolunteerUnavailabilityData[name]; !ok {
+			respondWithError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("\"%s\" is not a volunteer on schedule \"%s\"", name, toBeReceived.ScheduleName))
+			return
+		}
+	}
+	err = env.DBModel.RecieveAndStoreData(user.Workspace, toBeReceived, false)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	schedule, err := env.DBModel.FetchAndSendScheduleData(user.Workspace, toBeReceived.ScheduleName)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	renderTemplate(w, handlerInfo, "schedule_output", createRightColumnStruct(schedule, nil))
+}
+
+// withSession runs handler with the requestUser for the person signed in with the request's session cookie, or named by the trusted UserHeader, in the request's context. Their
+// Workspace is the one they picked, so every vsadb call the handler makes is for that workspace. Requests from no one are sent to /login, and users whose role in the workspace is
+// below needed get a 403.
+func (env *Env) withSession(needed string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handlerInfo := handlerInfoStruct{r.URL.Path, "withSession", r.Method}
+		userName, err := env.requestUserName(r)
+		if errors.Is(err, http.ErrNoCookie) || errors.Is(err, vsadb.ErrNotFound) {
+			redirect(w, r, "/login")
+			return
+		}
+		if errors.Is(err, vsadb.ErrUserExists) { // the proxy named an organization
+			respondWithError(w, handlerInfo, http.StatusForbidden, err)
+			return
+		}
+		if err != nil {
+			respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+			return
+		}
+		workspace, role, err := env.workspaceFor(r, userName)
+		if err != nil {
+			respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+			return
+		}
+		if !vsadb.RoleAllows(role, needed) {
+			respondWithError(w, handlerInfo, http.StatusForbidden, fmt.Errorf("%s is a %s of %s, and only an %s or above can do that", userName, role, workspace, needed))
+			return
+		}
+		handler(w, r.WithContext(contextWithUser(r.Context(), requestUser{Name: userName, Workspace: workspace, WorkspaceRole: role})))
+	}
+}
+
+// requestUserName returns who the request is from: the user named in UserHeader when it is set and the request has it, otherwise the user signed in with the session cookie.
+// Only set UserHeader behind a proxy that signs people in and strips the header from what clients send, since anyone who can reach the server directly could otherwise send it.
+func (env *Env) requestUserName(r *http.Request) (string, error) {
+	if env.UserHeader != "" {
+		if userName := strings.TrimSpace(r.Header.Get(env.UserHeader)); userName != "" {
+			if err := env.DBModel.EnsureUser(userName); err != nil {
+				return "", err
+			}
+			return userName, nil
+		}
+	}
+	return env.sessionUser(r)
+}
+
+// workspaceFor returns the workspace picked by the request's workspace cookie and userName's role in it. Without a cookie, or once userName is no longer a member of the workspace,
+// it is their own.
+func (env *Env) workspaceFor(r *http.Request, userName string) (string, string, error) {
+	cookie, err := r.Cookie(workspaceCookieName)
+	if err != nil {
+		return userName, vsadb.RoleOwner, nil
+	}
+	workspace, err := url.QueryUnescape(cookie.Value)
+	if err != nil {
+		return userName, vsadb.RoleOwner, nil
+	}
+	role, err := env.DBModel.RequestWorkspaceRole(userName, workspace)
+	if errors.Is(err, vsadb.ErrNotFound) {
+		return userName, vsadb.RoleOwner, nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return workspace, role, nil
+}
+
+// setWorkspaceCookie remembers workspace for this browser, or forgets it when workspace is "".
+func setWorkspaceCookie(w http.ResponseWriter, r *http.Request, workspace string) {
+	cookie := &http.Cookie{Name: workspaceCookieName, Value: url.QueryEscape(workspace), Path: "/", MaxAge: int(vsadb.SessionLifetime.Seconds()), HttpOnly: true, Secure: r.TLS != nil, SameSite: http.SameSiteStrictMode}
+	if workspace == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
+
+// sessionUser returns the user signed in with the request's session cookie.
+func (env *Env) sessionUser(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return "", err
+	}
+	return env.DBModel.RequestSessionUser(cookie.Value)
+}
+
+// setSessionCookie hands token to the browser, or clears the cookie when token is "". SameSite=Strict keeps other sites from using the session, which matters because /delete-schedule
+// is a GET.
+func setSessionCookie(w http.ResponseWriter, r *http.Request, token string) {
+	cookie := &http.Cookie{Name: sessionCookieName, Value: token, Path: "/", MaxAge: int(vsadb.SessionLifetime.Seconds()), HttpOnly: true, Secure: r.TLS != nil, SameSite: http.SameSiteStrictMode}
+	if token == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+}
+
+// redirect sends the browser to url. htmx requests get HX-Redirect instead of a 303, which htmx would follow itself and swap into the page.
+func redirect(w http.ResponseWriter, r *http.Request, url string) {
+	if r.Header.Get("HX-Request") != "" {
+		w.Header().Set("HX-Redirect", url)
+		return
+	}
+	http.Redirect(w, r, url, http.StatusSeeOther)
+}
+
+// newClaimCodes makes a claim code for each user without a password, like the Seth that owns the schedules saved before sign-in existed, and prints them to the log. Only someone
+// the operator passes a code on to can register as one of those users and take over their schedules.
+func newClaimCodes(dbModel vsadb.Store) (map[string]string, error) {
+	userNames, err := dbModel.RequestUnclaimedUsers()
+	if err != nil {
+		return nil, fmt.Errorf("error in newClaimCodes: %w", err)
+	}
+	claimCodes := map[string]string{}
+	for _, userName := range userNames {
+		codeBytes := make([]byte, 16)
+		if _, err = rand.Read(codeBytes); err != nil {
+			return nil, fmt.Errorf("error in newClaimCodes: rand.Read error: %w", err)
+		}
+		claimCodes[userName] = hex.EncodeToString(codeBytes)
+		log.Printf("%s has no password. To sign in as %s, register with that user name and the claim code %s", userName, userName, claimCodes[userName])
+	}
+	return claimCodes, nil
+}
+
+// startSession signs userName in on this browser and sends it on to the schedules.
+func (env *Env) startSession(w http.ResponseWriter, r *http.Request, handlerInfo handlerInfoStruct, userName string) {
+	token, err := env.DBModel.CreateSession(userName)
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	log.Printf("%s signed in", userName)
+	setSessionCookie(w, r, token)
+	redirect(w, r, "/")
+}
+
+// parseBaseURL checks value, the setting of baseURLEnvVar, and returns it without a trailing /. It must be an http or https URL with a host, and may have a path for a server reached
+// under one, but nothing after it.
+func parseBaseURL(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	baseURL, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("error in parseBaseURL: %s is not a URL: %w", baseURLEnvVar, err)
+	}
+	if baseURL.Scheme != "http" && baseURL.Scheme != "https" || baseURL.Host == "" || baseURL.User != nil || baseURL.RawQuery != "" || baseURL.Fragment != "" {
+		return "", fmt.Errorf("error in parseBaseURL: %s must be like https://schedules.example.org, not \"%s\"", baseURLEnvVar, value)
+	}
+	return strings.TrimSuffix(value, "/"), nil
+}
+
+// refusedBehindProxy answers with a 403 and returns true while UserHeader is trusted, for the pages that sign people in or register them. The proxy signs people in then, and
+// the users it names have no password, so anyone who reached the server directly could otherwise register or sign in as one of them and take over their schedules.
+func (env *Env) refusedBehindProxy(w http.ResponseWriter, handlerInfo handlerInfoStruct) bool {
+	if env.UserHeader == "" {
+		return false
+	}
+	respondWithError(w, handlerInfo, http.StatusForbidden, fmt.Errorf("this server signs people in through the proxy in front of it, which sets the %s header, so sign in there instead", env.UserHeader))
+	return true
+}
+
+func (env *Env) handleLogin(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/login", "handleLogin", "GET"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if _, err := env.requestUserName(r); err == nil { // already signed in
+		redirect(w, r, "/")
+		return
+	}
+	if env.refusedBehindProxy(w, handlerInfo) {
+		return
+	}
+	renderTemplate(w, handlerInfo, "login_page", login_pageStruct{Min_password_length: vsadb.MinPasswordLength})
+}
+
+func (env *Env) handleSignIn(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/sign-in", "handleSignIn", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if env.refusedBehindProxy(w, handlerInfo) {
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	userName := strings.TrimSpace(r.PostForm.Get("user-name"))
+	err = env.DBModel.AuthenticateUser(userName, r.PostForm.Get("password"))
+	if errors.Is(err, vsadb.ErrInvalidCredentials) {
+		log.Printf("Failed sign-in for %q", userName)
+		renderTemplateWithStatus(w, handlerInfo, http.StatusUnauthorized, "login_page", login_pageStruct{User_name: userName, Sign_in_errors: []string{"Invalid user name or password."}, Min_password_length: vsadb.MinPasswordLength})
+		return
+	}
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	env.startSession(w, r, handlerInfo, userName)
+}
+
+func (env *Env) handleRegister(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/register", "handleRegister", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if env.refusedBehindProxy(w, handlerInfo) {
+		return
+	}
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	userName := strings.TrimSpace(r.PostForm.Get("user-name"))
+	password := r.PostForm.Get("password")
+	var problems []string
+	if userName == "" {
+		problems = append(problems, "Enter a user name.")
+	}
+	if len(password) < vsadb.MinPasswordLength || len(password) > vsadb.MaxPasswordLength {
+		problems = append(problems, fmt.Sprintf("The password must be between %d and %d characters long.", vsadb.MinPasswordLength, vsadb.MaxPasswordLength))
+	} else if password != r.PostForm.Get("confirm-password") {
+		problems = append(problems, "The passwords do not match.")
+	}
+	status := http.StatusBadRequest
+	claimCode, bClaimable := env.ClaimCodes[userName]
+	if len(problems) == 0 {
+		if enteredCode := strings.TrimSpace(r.PostForm.Get("claim-code")); enteredCode == "" {
+			err = env.DBModel.CreateUser(userName, password)
+		} else if bClaimable && subtle.ConstantTimeCompare([]byte(enteredCode), []byte(claimCode)) == 1 {
+			err = env.DBModel.ClaimUser(userName, password)
+		} else {
+			problems = append(problems, fmt.Sprintf("That is not the claim code for %s.", userName))
+			status = http.StatusForbidden
+		}
+		if errors.Is(err, vsadb.ErrUserExists) && bClaimable {
+			problems = append(problems, fmt.Sprintf("The user name %s is taken. If you were given its claim code, enter it to register as %s.", userName, userName))
+			status = http.StatusConflict
+		} else if errors.Is(err, vsadb.ErrUserExists) {
+			problems = append(problems, fmt.Sprintf("The user name %s is taken.", userName))
+			status = http.StatusConflict
+		} else if err != nil {
+			respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	if len(problems) > 0 {
+		log.Printf("Error in %s (%d %s): %v", handlerInfo.address, status, http.StatusText(status), problems)
+		renderTemplateWithStatus(w, handlerInfo, status, "login_page", login_pageStruct{New_user_name: userName, Register_errors: problems, Min_password_length: vsadb.MinPasswordLength})
+		return
+	}
+	log.Printf("Registered %s", userName)
+	env.startSession(w, r, handlerInfo, userName)
+}
+
+func (env *Env) handleSignOut(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/sign-out", "handleSignOut", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if err = env.DBModel.DeleteSession(cookie.Value); err != nil {
+			respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	setSessionCookie(w, r, "")
+	setWorkspaceCookie(w, r, "")
+	redirect(w, r, "/login")
+}
+
+func (env *Env) handleSelectWorkspace(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/select-workspace", "handleSelectWorkspace", "GET"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	workspace := r.Form.Get("workspace")
+	if _, err = env.DBModel.RequestWorkspaceRole(user.Name, workspace); err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	setWorkspaceCookie(w, r, workspace)
+	redirect(w, r, "/")
+}
+
+// prepareOrganizationsPage fills in the organizations page for env's workspace, with formErrors shown under the forms.
+func (env *Env) prepareOrganizationsPage(ctx context.Context, formErrors []string) (organizations_pageStruct, error) {
+	user := userFromContext(ctx)
+	page_data := organizations_pageStruct{User: user.Name, Workspace: user.Workspace, Role: user.WorkspaceRole, Is_organization: user.Workspace != user.Name,
+		Can_manage: vsadb.RoleAllows(user.WorkspaceRole, vsadb.RoleOwner), Members: []vsadb.Membership{}, Roles: vsadb.Roles, Form_errors: formErrors}
+	if page_data.Is_organization {
+		members, err := env.DBModel.RequestMembers(user.Workspace)
+		if err != nil {
+			return organizations_pageStruct{}, fmt.Errorf("error in prepareOrganizationsPage: %w", err)
+		}
+		page_data.Members = members
+	}
+	return page_data, nil
+}
+
+// renderOrganizationsPage answers with the organizations page, showing formErrors under the forms when status is not a 200.
+func (env *Env) renderOrganizationsPage(ctx context.Context, w http.ResponseWriter, handlerInfo handlerInfoStruct, status int, formErrors []string) {
+	page_data, err := env.prepareOrganizationsPage(ctx, formErrors)
+	if err != nil {
+		respondWithError(w, handlerInfo, dbErrorStatus(err), err)
+		return
+	}
+	if status != http.StatusOK {
+		log.Printf("Error in %s (%d %s): %v", handlerInfo.address, status, http.StatusText(status), formErrors)
+	}
+	renderTemplateWithStatus(w, handlerInfo, status, "organizations_page", page_data)
+}
+
+func (env *Env) handleOrganizations(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/organizations", "handleOrganizations", "GET"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	env.renderOrganizationsPage(r.Context(), w, handlerInfo, http.StatusOK, nil)
+}
+
+func (env *Env) handleCreateOrganization(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/create-organization", "handleCreateOrganization", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	organizationName := strings.TrimSpace(r.PostForm.Get("organization-name"))
+	if organizationName == "" {
+		env.renderOrganizationsPage(r.Context(), w, handlerInfo, http.StatusBadRequest, []string{"Enter a name for the organization."})
+		return
+	}
+	err = env.DBModel.CreateOrganization(user.Name, organizationName)
+	if errors.Is(err, vsadb.ErrUserExists) {
+		env.renderOrganizationsPage(r.Context(), w, handlerInfo, http.StatusConflict, []string{fmt.Sprintf("The name %s is taken.", organizationName)})
+		return
+	}
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusInternalServerError, err)
+		return
+	}
+	log.Printf("%s created the organization %s", user.Name, organizationName)
+	setWorkspaceCookie(w, r, organizationName)
+	redirect(w, r, "/organizations")
+}
+
+// membershipError is the status and message to show on the organizations page when SaveMembership or DeleteMembership fails because of what was asked for, and ok is false for
+// any other error.
+func membershipError(err error) (status int, message string, ok bool) {
+	switch {
+	case errors.Is(err, vsadb.ErrNotFound):
+		return http.StatusNotFound, "There is no user with that name. They need to register before they can be added.", true
+	case errors.Is(err, vsadb.ErrLastOwner):
+		return http.StatusConflict, "An organization needs at least one owner. Make someone else an owner first.", true
+	}
+	return http.StatusInternalServerError, "", false
+}
+
+func (env *Env) handleSaveMember(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/save-member", "handleSaveMember", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	if user.Workspace == user.Name {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("your own workspace has no members. Select an organization first"))
+		return
+	}
+	member, role := strings.TrimSpace(r.PostForm.Get("user-name")), r.PostForm.Get("role")
+	if member == "" || !slices.Contains(vsadb.Roles, role) {
+		env.renderOrganizationsPage(r.Context(), w, handlerInfo, http.StatusBadRequest, []string{fmt.Sprintf("Enter a user name and pick one of the roles %s.", strings.Join(vsadb.Roles, ", "))})
+		return
+	}
+	err = env.DBModel.SaveMembership(user.Workspace, member, role)
+	if status, message, ok := membershipError(err); err != nil && ok {
+		env.renderOrganizationsPage(r.Context(), w, handlerInfo, status, []string{message})
+		return
+	} else if err != nil {
+		respondWithError(w, handlerInfo, status, err)
+		return
+	}
+	log.Printf("%s made %s a %s of %s", user.Name, member, role, user.Workspace)
+	redirect(w, r, "/organizations")
+}
+
+func (env *Env) handleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{"/remove-member", "handleRemoveMember", "POST"}
+	//---------------------------------------------------------------------------------
+	if !requestIsValid(w, r, handlerInfo.address, handlerInfo.method) {
+		log.Printf("Request to %s is invalid!", handlerInfo.funcName)
+		return
+	}
+	user := userFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, err)
+		return
+	}
+	member := r.PostForm.Get("user-name")
+	if member != user.Name && !vsadb.RoleAllows(user.WorkspaceRole, vsadb.RoleOwner) { // anyone can leave, but only owners can remove others
+		respondWithError(w, handlerInfo, http.StatusForbidden, fmt.Errorf("%s is a %s of %s, and only an owner can remove other members", user.Name, user.WorkspaceRole, user.Workspace))
+		return
+	}
+	if user.Workspace == user.Name {
+		respondWithError(w, handlerInfo, http.StatusBadRequest, fmt.Errorf("your own workspace has no members. Select an organization first"))
+		return
+	}
+	err = env.DBModel.DeleteMembership(user.Workspace, member)
+	if status, message, ok := membershipError(err); err != nil && ok {
+		env.renderOrganizationsPage(r.Context(), w, handlerInfo, status, []string{message})
+		return
+	} else if err != nil {
+		respondWithError(w, handlerInfo, status, err)
+		return
+	}
+	log.Printf("%s removed %s from %s", user.Name, member, user.Workspace)
+	if member == user.Name {
+		setWorkspaceCookie(w, r, "")
+		redirect(w, r, "/")
+		return
+	}
+	redirect(w, r, "/organizations")
+}
+
+// handleFuncMap is every route of the app, keyed by the pattern mux serves it at. Everything but signing in and out needs a session, and a role in the workspace that allows what
+// the handler does.
+func (env *Env) handleFuncMap() map[string]func(http.ResponseWriter, *http.Request) {
+	return map[string]func(http.ResponseWriter, *http.Request){
+		"/":                    env.withSession(vsadb.RoleViewer, env.handleRoot),
+		"/select-schedule":     env.withSession(vsadb.RoleViewer, env.handleSelectSchedule),
+		"/add-unavailability":  env.withSession(vsadb.RoleViewer, env.handleAddVolunteerUnavailability),
+		"/mod-volunteers":      env.withSession(vsadb.RoleViewer, env.handleModVolunteers),
+		"/save-parameters":     env.withSession(vsadb.RoleEditor, env.handleSaveParameters),
+		"/delete-schedule":     env.withSession(vsadb.RoleEditor, env.handleDeleteSchedule),
+		"/generate-schedule":   env.withSession(vsadb.RoleViewer, env.handleGenerateSchedule),
+		"/save-schedule":       env.withSession(vsadb.RoleEditor, env.handleSaveSchedule),
+		"/export-schedule":     env.withSession(vsadb.RoleViewer, env.handleExportSchedule),
+		"/preview-import":      env.withSession(vsadb.RoleEditor, env.handlePreviewImport),
+		"/import-volunteers":   env.withSession(vsadb.RoleEditor, env.handleImportVolunteers),
+		"/select-workspace":    env.withSession(vsadb.RoleViewer, env.handleSelectWorkspace),
+		"/organizations":       env.withSession(vsadb.RoleViewer, env.handleOrganizations),
+		"/create-organization": env.withSession(vsadb.RoleViewer, env.handleCreateOrganization),
+		"/save-member":         env.withSession(vsadb.RoleOwner, env.handleSaveMember),
+		"/remove-member":       env.withSession(vsadb.RoleViewer, env.handleRemoveMember), // members can remove themselves, so handleRemoveMember checks the rest
+		"/login":               env.handleLogin,
+		"/sign-in":             env.handleSignIn,
+		"/register":            env.handleRegister,
+		"/sign-out":            env.handleSignOut,
+
+		// calendar feeds (see calendar.go). The token in a feed's address is its only key, so calendar apps can subscribe without a session
+		"/calendar-links":       env.withSession(vsadb.RoleViewer, env.handleCalendarLinks),
+		"/reset-calendar-links": env.withSession(vsadb.RoleEditor, env.handleResetCalendarLinks),
+		"GET /calendar/{token}": env.handleCalendarFeed,
+		// the JSON API (see api.go)
+		"/api/":                                                                            handleAPINotFound,
+		"GET " + apiPrefix + "/openapi.json":                                               handleAPISpec,
+		"GET " + apiPrefix + "/schedules":                                                  env.withAPIUser(vsadb.RoleViewer, env.handleAPIListSchedules),
+		"POST " + apiPrefix + "/schedules":                                                 env.withAPIUser(vsadb.RoleEditor, env.handleAPICreateSchedule),
+		"GET " + apiPrefix + "/schedules/{schedule}":                                       env.withAPIUser(vsadb.RoleViewer, env.handleAPIGetSchedule),
+		"PUT " + apiPrefix + "/schedules/{schedule}":                                       env.withAPIUser(vsadb.RoleEditor, env.handleAPIUpdateSchedule),
+		"DELETE " + apiPrefix + "/schedules/{schedule}":                                    env.withAPIUser(vsadb.RoleEditor, env.handleAPIDeleteSchedule),
+		"GET " + apiPrefix + "/schedules/{schedule}/volunteers":                            env.withAPIUser(vsadb.RoleViewer, env.handleAPIListVolunteers),
+		"POST " + apiPrefix + "/schedules/{schedule}/volunteers":                           env.withAPIUser(vsadb.RoleEditor, env.handleAPICreateVolunteer),
+		"GET " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}":                env.withAPIUser(vsadb.RoleViewer, env.handleAPIGetVolunteer),
+		"PUT " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}":                env.withAPIUser(vsadb.RoleEditor, env.handleAPIUpdateVolunteer),
+		"DELETE " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}":             env.withAPIUser(vsadb.RoleEditor, env.handleAPIDeleteVolunteer),
+		"GET " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability": env.withAPIUser(vsadb.RoleViewer, env.handleAPIGetUnavailability),
+		"PUT " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability": env.withAPIUser(vsadb.RoleEditor, env.handleAPIUpdateUnavailability),
+		"GET " + apiPrefix + "/schedules/{schedule}/assignments":                           env.withAPIUser(vsadb.RoleViewer, env.handleAPIGetAssignments),
+		"PUT " + apiPrefix + "/schedules/{schedule}/assignments":                           env.withAPIUser(vsadb.RoleEditor, env.handleAPIUpdateAssignments),
+		"DELETE " + apiPrefix + "/schedules/{schedule}/assignments":                        env.withAPIUser(vsadb.RoleEditor, env.handleAPIDeleteAssignments),
+		"POST " + apiPrefix + "/schedules/{schedule}/assignments/generate":                 env.withAPIUser(vsadb.RoleViewer, env.handleAPIGenerateAssignments),
 	}
 }
 
@@ -541,30 +2041,66 @@ func init() { // this runs once before main(). I'm using it to parse templates o
 	template.Must(templates.ParseFiles("./assets/templates/left_column_div.gohtml"))
 	template.Must(templates.ParseFiles("./assets/templates/right_column_div.gohtml"))
 	template.Must(templates.ParseFiles("./assets/templates/volunteer_column_form.gohtml"))
+	template.Must(templates.ParseFiles("./assets/templates/login_page.gohtml"))
+	template.Must(templates.ParseFiles("./assets/templates/organizations_page.gohtml"))
+	template.Must(templates.ParseFiles("./assets/templates/import_preview.gohtml"))
+	template.Must(templates.ParseFiles("./assets/templates/calendar_links.gohtml"))
 	veX_nRegex = regexp.MustCompile("^ve[0-9]+-n$")
 	veX_uRegex = regexp.MustCompile("^ve[0-9]+-u$")
+	veX_qRegex = regexp.MustCompile("^ve[0-9]+-q$")
+	veX_tRegex = regexp.MustCompile("^ve[0-9]+-t$")
+	veX_aRegex = regexp.MustCompile("^ve[0-9]+-a$")
+	veX_rRegex = regexp.MustCompile("^ve[0-9]+-r$")
+	veX_pRegex = regexp.MustCompile("^ve[0-9]+-p$")
+	veX_fRegex = regexp.MustCompile("^ve[0-9]+-f$")
+	veX_eRegex = regexp.MustCompile("^ve[0-9]+-e$")
+	veX_mRegex = regexp.MustCompile("^ve[0-9]+-m$")
+	veX_hRegex = regexp.MustCompile("^ve[0-9]+-h$")
+	phoneRegex = regexp.MustCompile(`^\+?[0-9][0-9 ().-]*$`)
+	svX_Regex = regexp.MustCompile(`^sv-[0-9]{4}-[0-9]{2}-[0-9]{2}(\|.+)?$`)
+	errorPrefixRegex = regexp.MustCompile(`^(error in \w+: )+`)
 }
 
 func main() {
-	dbExists := false
-	if _, err := os.Stat(vsadb.DbName); err == nil {
-		dbExists = true
+	dsn := os.Getenv(databaseEnvVar)
+	if dsn == "" {
+		dsn = vsadb.DbName
 	}
-	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_foreign_keys=on", vsadb.DbName))
+	dbModel, err := vsadb.Open(dsn)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatalf("Crashed in main() with error: %v", err)
+	}
+	baseURL, err := parseBaseURL(os.Getenv(baseURLEnvVar))
+	if err != nil {
+		log.Fatalf("Crashed in main() with error: %v", err)
 	}
 	env := &Env{
-		DBModel:      vsadb.VSAModel{DB: db},
-		LoggedInUser: "Seth",
+		DBModel:    dbModel,
+		UserHeader: os.Getenv(userHeaderEnvVar),
+		BaseURL:    baseURL,
+	}
+	defer env.DBModel.Close()
+	if env.UserHeader != "" {
+		log.Printf("Trusting the %s request header for who is signed in", env.UserHeader)
 	}
-	defer env.DBModel.DB.Close()
-	if !dbExists {
-		if err = env.DBModel.CreateDatabase(); err != nil {
+	if env.BaseURL == "" {
+		log.Printf("%s is not set, so calendar feed links are built from the host each request was sent to", baseURLEnvVar)
+	}
+	// bring the database up to the current schema, creating it if it is new
+	fromVersion, toVersion, err := env.DBModel.MigrateDatabase()
+	if err != nil {
+		log.Fatalf("Crashed in main() with error: %v", err)
+	}
+	if fromVersion != toVersion {
+		log.Printf("Migrated the database from schema version %d to %d", fromVersion, toVersion)
+	}
+	if env.UserHeader == "" { // behind the proxy no one registers, and the user it names Seth gets the schedules saved before sign-in
+		env.ClaimCodes, err = newClaimCodes(env.DBModel)
+		if err != nil {
 			log.Fatalf("Crashed in main() with error: %v", err)
 		}
-		//vsadb.FillInSampleDB(env.LoggedInUser, env.DBModel) // FOR TESTING ONLY!!
 	}
+	//vsadb.FillInSampleDB("Seth", dbModel) // FOR TESTING ONLY!!
 	// initialize multiplexer
 	mux := http.NewServeMux()
 	// handle static content
@@ -577,15 +2113,7 @@ func main() {
 		mux.Handle(key, http.StripPrefix(key, http.FileServer(http.Dir(value))))
 	}
 	// handle dynamic content
-	var handleFuncMap = map[string]func(http.ResponseWriter, *http.Request){
-		"/":                   env.handleRoot,
-		"/select-schedule":    env.handleSelectSchedule,
-		"/add-unavailability": env.handleAddVolunteerUnavailability,
-		"/mod-volunteers":     env.handleModVolunteers,
-		"/save-parameters":    env.handleSaveParameters,
-		"/delete-schedule":    env.handleDeleteSchedule,
-	}
-	for key, value := range handleFuncMap {
+	for key, value := range env.handleFuncMap() {
 		mux.HandleFunc(key, value)
 	}
 	// start server
@@ -0,0 +1,1318 @@
+package main
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"VolunteerSchedulerApp/vsasched"
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// specJSON is the document served at /api/v1/openapi.json, decoded without knowing its shape, the way an integrator's tools see it.
+func specJSON(t *testing.T) map[string]any {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handleAPISpec(recorder, httptest.NewRequest(http.MethodGet, apiPrefix+"/openapi.json", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("handleAPISpec() status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	var spec map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("handleAPISpec() body is not JSON: %v", err)
+	}
+	return spec
+}
+
+// TestOpenAPISpec checks that the document lists exactly the /api/v1 routes registered in handleFuncMap, with a parameter for each wildcard in their paths.
+func TestOpenAPISpec(t *testing.T) {
+	registered := []string{}
+	for route := range (&Env{}).handleFuncMap() {
+		if method, path, found := strings.Cut(route, " "); found && strings.HasPrefix(path, apiPrefix+"/") {
+			registered = append(registered, method+" "+path)
+		}
+	}
+	slices.Sort(registered)
+	documented := []string{}
+	for path, item := range specJSON(t)["paths"].(map[string]any) {
+		for method, operation := range item.(map[string]any) {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+			wildcards := []string{}
+			for _, match := range regexp.MustCompile(`{([^}]+)}`).FindAllStringSubmatch(path, -1) {
+				wildcards = append(wildcards, match[1])
+			}
+			parameters := []string{}
+			list, _ := operation.(map[string]any)["parameters"].([]any)
+			for _, parameter := range list {
+				if name, ok := parameter.(map[string]any)["name"].(string); ok && parameter.(map[string]any)["in"] == "path" {
+					parameters = append(parameters, name)
+				}
+			}
+			if !slices.Equal(parameters, wildcards) {
+				t.Errorf("%s %s has path parameters %v, want %v", method, path, parameters, wildcards)
+			}
+		}
+	}
+	slices.Sort(documented)
+	if !slices.Equal(documented, registered) {
+		t.Errorf("the OpenAPI document has the routes\n%v\nwant the routes of handleFuncMap\n%v", documented, registered)
+	}
+}
+
+// TestOpenAPISpecSchemas checks that the schemas have the fields encoding/json gives the types the API sends and receives, and that every $ref in the document leads somewhere.
+func TestOpenAPISpecSchemas(t *testing.T) {
+	spec := specJSON(t)
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	tests := []struct {
+		name   string
+		schema string
+		input  any
+	}{
+		{name: "Schedule mirrors SendReceiveDataStruct", schema: "Schedule", input: vsadb.SendReceiveDataStruct{}},
+		{name: "Volunteer", schema: "Volunteer", input: apiVolunteerStruct{}},
+		{name: "Unavailability", schema: "Unavailability", input: apiUnavailabilityStruct{}},
+		{name: "Assignments", schema: "Assignments", input: apiAssignmentsStruct{}},
+		{name: "GeneratedSchedule with the embedded vsasched.Schedule", schema: "GeneratedSchedule", input: apiGeneratedStruct{}},
+		{name: "Error with the fields left out when empty", schema: "Error", input: apiErrorStruct{Fields: map[string][]string{"scheduleName": {"is required"}}}},
+		{name: "TimeSlot", schema: "TimeSlot", input: vsadb.TimeSlot{}},
+		{name: "RoleRequirement", schema: "RoleRequirement", input: vsadb.RoleRequirement{}},
+		{name: "VolunteerPairing", schema: "VolunteerPairing", input: vsadb.VolunteerPairing{}},
+		{name: "DateRange", schema: "DateRange", input: vsadb.DateRange{}},
+		{name: "VolunteerContact", schema: "VolunteerContact", input: vsadb.VolunteerContact{}},
+		{name: "Shortage", schema: "Shortage", input: vsasched.Shortage{}},
+		{name: "Imbalance", schema: "Imbalance", input: vsasched.Imbalance{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, ok := schemas[tt.schema].(map[string]any)
+			if !ok {
+				t.Fatalf("there is no %s schema", tt.schema)
+			}
+			body, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]any
+			if err = json.Unmarshal(body, &fields); err != nil {
+				t.Fatal(err)
+			}
+			want := []string{}
+			for field := range fields {
+				want = append(want, field)
+			}
+			slices.Sort(want)
+			got := []string{}
+			for property := range schema["properties"].(map[string]any) {
+				got = append(got, property)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s schema has the properties %v, want %v", tt.schema, got, want)
+			}
+		})
+	}
+	var checkRefs func(value any)
+	checkRefs = func(value any) {
+		switch value := value.(type) {
+		case map[string]any:
+			if ref, ok := value["$ref"].(string); ok {
+				parts := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+				var target any = spec
+				for _, part := range parts {
+					parent, _ := target.(map[string]any)
+					target = parent[part]
+				}
+				if target == nil {
+					t.Errorf("%s leads nowhere", ref)
+				}
+			}
+			for _, child := range value {
+				checkRefs(child)
+			}
+		case []any:
+			for _, child := range value {
+				checkRefs(child)
+			}
+		}
+	}
+	checkRefs(spec)
+}
+
+func TestParseVolunteerCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     []importRowStruct
+		problems []string
+	}{
+		{
+			name: "Columns in order without a header",
+			text: "Tim,tim@example.com,555-123-4567,2024-01-07; 2024-02-01 to 2024-02-10\nAnn\n",
+			want: []importRowStruct{
+				{Line: 1, Name: "Tim", Email: "tim@example.com", Phone: "555-123-4567", Dates: []string{"2024-01-07"}, Ranges: []vsadb.DateRange{{Start: "2024-02-01", End: "2024-02-10"}}},
+				{Line: 2, Name: "Ann", Dates: []string{}, Ranges: []vsadb.DateRange{}},
+			},
+			problems: []string{},
+		},
+		{
+			name: "Header in another order with a byte order mark, blank lines, and columns that are not imported",
+			text: "\ufeffNotes,Unavailable,NAME\nusher,\"2024-01-07, 2024-01-14\",Tim\n,,\n\nlead,,Ann\n",
+			want: []importRowStruct{
+				{Line: 2, Name: "Tim", Dates: []string{"2024-01-07", "2024-01-14"}, Ranges: []vsadb.DateRange{}},
+				{Line: 5, Name: "Ann", Dates: []string{}, Ranges: []vsadb.DateRange{}},
+			},
+			problems: []string{},
+		},
+		{
+			name:     "Line without a name",
+			text:     "Tim\n,tim@example.com\n",
+			want:     []importRowStruct{{Line: 1, Name: "Tim", Dates: []string{}, Ranges: []vsadb.DateRange{}}},
+			problems: []string{"line 2"},
+		},
+		{
+			name:     "Unclosed quote",
+			text:     "Tim\n\"Ann,\n",
+			want:     []importRowStruct{{Line: 1, Name: "Tim", Dates: []string{}, Ranges: []vsadb.DateRange{}}},
+			problems: []string{"line 2"},
+		},
+		{
+			name:     "Header only",
+			text:     "name,email\n",
+			want:     []importRowStruct{},
+			problems: []string{"file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, problems := parseVolunteerCSV(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseVolunteerCSV() = %+v, want %+v", got, tt.want)
+			}
+			if keys := getStringMapKeys(problems, true); !slices.Equal(keys, tt.problems) {
+				t.Errorf("parseVolunteerCSV() problems = %v, want problems for %v", problems, tt.problems)
+			}
+		})
+	}
+}
+
+func TestMergeVolunteerCSV(t *testing.T) {
+	schedule := vsadb.SendReceiveDataStruct{
+		ScheduleName:                     "Q1",
+		VolunteerUnavailabilityData:      map[string][]string{"Tim": {"2024-01-07"}, "Ann": {}},
+		VolunteerUnavailabilityRangeData: map[string][]vsadb.DateRange{},
+		VolunteerContactData:             map[string]vsadb.VolunteerContact{"Tim": {Email: "tim@example.com"}},
+	}
+	tests := []struct {
+		name         string
+		rows         []importRowStruct
+		wantDates    map[string][]string
+		wantRanges   map[string][]vsadb.DateRange
+		wantContacts map[string]vsadb.VolunteerContact
+		wantChanges  []import_changeStruct
+		problems     []string
+	}{
+		{
+			name: "New, updated, and unchanged volunteers",
+			rows: []importRowStruct{
+				{Line: 1, Name: "Tim", Email: "tim@example.com", Dates: []string{"2024-01-07", "2024-01-14"}},
+				{Line: 2, Name: "Bob", Phone: "555-123-4567", Ranges: []vsadb.DateRange{{Start: "2024-02-01", End: "2024-02-10"}}},
+				{Line: 3, Name: "Ann"},
+			},
+			wantDates:    map[string][]string{"Tim": {"2024-01-07", "2024-01-14"}, "Ann": {}, "Bob": {}},
+			wantRanges:   map[string][]vsadb.DateRange{"Bob": {{Start: "2024-02-01", End: "2024-02-10"}}},
+			wantContacts: map[string]vsadb.VolunteerContact{"Tim": {Email: "tim@example.com"}, "Bob": {Phone: "555-123-4567"}},
+			wantChanges: []import_changeStruct{
+				{Name: "Tim", Status: "updated", Details: []string{"unavailable 2024-01-14"}},
+				{Name: "Bob", Status: "new", Details: []string{"phone 555-123-4567", "away 2024-02-01 to 2024-02-10"}},
+				{Name: "Ann", Status: "unchanged", Details: []string{}},
+			},
+			problems: []string{},
+		},
+		{
+			name: "A volunteer on two lines with different emails",
+			rows: []importRowStruct{
+				{Line: 1, Name: "Ann", Email: "ann@example.com", Dates: []string{"2024-01-07"}},
+				{Line: 2, Name: "Ann", Email: "ann@example.org", Dates: []string{"2024-01-14"}},
+			},
+			wantDates:    map[string][]string{"Tim": {"2024-01-07"}, "Ann": {"2024-01-07", "2024-01-14"}},
+			wantRanges:   map[string][]vsadb.DateRange{},
+			wantContacts: map[string]vsadb.VolunteerContact{"Tim": {Email: "tim@example.com"}, "Ann": {Email: "ann@example.com"}},
+			wantChanges:  []import_changeStruct{{Name: "Ann", Status: "updated", Details: []string{"email ann@example.com", "unavailable 2024-01-07", "unavailable 2024-01-14"}}},
+			problems:     []string{"line 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, changes, problems := mergeVolunteerCSV(schedule, tt.rows)
+			if !reflect.DeepEqual(merged.VolunteerUnavailabilityData, tt.wantDates) {
+				t.Errorf("mergeVolunteerCSV() dates = %v, want %v", merged.VolunteerUnavailabilityData, tt.wantDates)
+			}
+			if !reflect.DeepEqual(merged.VolunteerUnavailabilityRangeData, tt.wantRanges) {
+				t.Errorf("mergeVolunteerCSV() ranges = %v, want %v", merged.VolunteerUnavailabilityRangeData, tt.wantRanges)
+			}
+			if !reflect.DeepEqual(merged.VolunteerContactData, tt.wantContacts) {
+				t.Errorf("mergeVolunteerCSV() contacts = %v, want %v", merged.VolunteerContactData, tt.wantContacts)
+			}
+			if !reflect.DeepEqual(changes, tt.wantChanges) {
+				t.Errorf("mergeVolunteerCSV() changes = %+v, want %+v", changes, tt.wantChanges)
+			}
+			if keys := getStringMapKeys(problems, true); !slices.Equal(keys, tt.problems) {
+				t.Errorf("mergeVolunteerCSV() problems = %v, want problems for %v", problems, tt.problems)
+			}
+		})
+	}
+	if !reflect.DeepEqual(schedule.VolunteerUnavailabilityData, map[string][]string{"Tim": {"2024-01-07"}, "Ann": {}}) || len(schedule.VolunteerContactData) != 1 {
+		t.Errorf("mergeVolunteerCSV() changed the schedule it was given: %+v", schedule)
+	}
+}
+
+// exportSchedule is a saved schedule with two time slots a week, across two months, for the export tests.
+var exportSchedule = vsadb.SendReceiveDataStruct{
+	ScheduleName:                "Q1",
+	VolunteersPerShift:          1,
+	StartDate:                   "2024-01-28",
+	EndDate:                     "2024-02-04",
+	WeekdaysForSchedule:         []string{"Sunday"},
+	TimeSlotsForSchedule:        map[string][]vsadb.TimeSlot{"Sunday": {{Name: "8am", VolunteersPerShift: 1}, {Name: "11am", VolunteersPerShift: 2}}},
+	VolunteerUnavailabilityData: map[string][]string{"Tim": {}, "Ann": {}, "=Bob": {}},
+	VolunteerScheduledData:      map[string][]string{"Tim": {"2024-01-28|8am", "2024-02-04|11am"}, "Ann": {"2024-01-28|11am"}, "=Bob": {"2024-01-28|11am"}},
+}
+
+func TestWriteScheduleCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule vsadb.SendReceiveDataStruct
+		want     [][]string
+	}{
+		{
+			name:     "Time slots, with a name that would be a formula",
+			schedule: exportSchedule,
+			want: [][]string{
+				{"Date", "Time slot", "Volunteer 1", "Volunteer 2"},
+				{"2024-01-28", "8am", "Tim", ""},
+				{"2024-01-28", "11am", "'=Bob", "Ann"},
+				{"2024-02-04", "8am", "", ""},
+				{"2024-02-04", "11am", "Tim", ""},
+			},
+		},
+		{
+			name: "Roles without time slots",
+			schedule: vsadb.SendReceiveDataStruct{ScheduleName: "Q2", VolunteersPerShift: 2, StartDate: "2024-04-07", EndDate: "2024-04-07", WeekdaysForSchedule: []string{"Sunday"},
+				RolesForSchedule:            []vsadb.RoleRequirement{{Name: "Usher", VolunteersPerShift: 2}},
+				VolunteerUnavailabilityData: map[string][]string{"Tim": {}, "Ann": {}},
+				VolunteerScheduledData:      map[string][]string{"Tim": {"2024-04-07||Usher"}, "Ann": {"2024-04-07||Usher"}}},
+			want: [][]string{{"Date", "Usher", "Usher"}, {"2024-04-07", "Ann", "Tim"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			if err := writeScheduleCSV(&body, tt.schedule); err != nil {
+				t.Fatal(err)
+			}
+			text, found := strings.CutPrefix(body.String(), "\ufeff")
+			if !found {
+				t.Errorf("writeScheduleCSV() does not start with a byte order mark")
+			}
+			got, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("writeScheduleCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// xlsxCells reads the workbook in body back as sheet name to rows of cell text, with dates and numbers as their stored values.
+func xlsxCells(t *testing.T, body []byte) (names []string, cells map[string][][]string) {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("the workbook is not a zip file: %v", err)
+	}
+	part := func(name string, into any) {
+		file, err := archive.Open(name)
+		if err != nil {
+			t.Fatalf("the workbook has no %s: %v", name, err)
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = xml.Unmarshal(content, into); err != nil {
+			t.Fatalf("%s is not XML: %v", name, err)
+		}
+	}
+	var types struct{}
+	part("[Content_Types].xml", &types)
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	part("xl/workbook.xml", &workbook)
+	type relationship struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	}
+	var rels struct {
+		Relationships []relationship `xml:"Relationship"`
+	}
+	part("xl/_rels/workbook.xml.rels", &rels)
+	cells = map[string][][]string{}
+	for _, sheet := range workbook.Sheets {
+		names = append(names, sheet.Name)
+		index := slices.IndexFunc(rels.Relationships, func(rel relationship) bool { return rel.ID == sheet.ID })
+		if index == -1 {
+			t.Fatalf("sheet %s has no relationship %s", sheet.Name, sheet.ID)
+		}
+		var worksheet struct {
+			Rows []struct {
+				Cells []struct {
+					Value  string `xml:"v"`
+					Inline string `xml:"is>t"`
+				} `xml:"c"`
+			} `xml:"sheetData>row"`
+		}
+		part("xl/"+rels.Relationships[index].Target, &worksheet)
+		for _, row := range worksheet.Rows {
+			values := []string{}
+			for _, cell := range row.Cells {
+				values = append(values, cell.Value+cell.Inline)
+			}
+			cells[sheet.Name] = append(cells[sheet.Name], values)
+		}
+	}
+	return names, cells
+}
+
+func TestWriteXLSX(t *testing.T) {
+	tests := []struct {
+		name      string
+		schedule  vsadb.SendReceiveDataStruct
+		wantNames []string
+		wantCells map[string][][]string
+	}{
+		{
+			name:      "A sheet per month and the summary",
+			schedule:  exportSchedule,
+			wantNames: []string{"January 2024", "February 2024", "Summary"},
+			wantCells: map[string][][]string{
+				"January 2024":  {{"Date", "Time slot", "Volunteer 1", "Volunteer 2"}, {"45319", "8am", "Tim"}, {"45319", "11am", "=Bob", "Ann"}},
+				"February 2024": {{"Date", "Time slot", "Volunteer 1", "Volunteer 2"}, {"45326", "8am"}, {"45326", "11am", "Tim"}},
+				"Summary":       {{"Volunteer", "January 2024", "February 2024", "Total"}, {"=Bob", "1", "0", "1"}, {"Ann", "1", "0", "1"}, {"Tim", "1", "1", "2"}},
+			},
+		},
+		{
+			name: "A month without shifts still gets a sheet and a summary column",
+			schedule: vsadb.SendReceiveDataStruct{ScheduleName: "Q2", VolunteersPerShift: 1, StartDate: "2024-01-15", EndDate: "2024-03-02", WeekdaysForSchedule: []string{"Sunday"},
+				VolunteerUnavailabilityData: map[string][]string{"Tim": {}}, VolunteerScheduledData: map[string][]string{"Tim": {"2024-01-21"}}},
+			wantNames: []string{"January 2024", "February 2024", "March 2024", "Summary"},
+			wantCells: map[string][][]string{
+				"January 2024":  {{"Date", "Volunteer 1"}, {"45312", "Tim"}, {"45319"}},
+				"February 2024": {{"Date", "Volunteer 1"}, {"45326"}, {"45333"}, {"45340"}, {"45347"}},
+				"March 2024":    {{"Date", "Volunteer 1"}},
+				"Summary":       {{"Volunteer", "January 2024", "February 2024", "March 2024", "Total"}, {"Tim", "1", "0", "0", "1"}},
+			},
+		},
+		{
+			name: "Months without any shifts and a month of shifts saved outside the schedule dates",
+			schedule: vsadb.SendReceiveDataStruct{ScheduleName: "Q3", VolunteersPerShift: 1, StartDate: "2024-03-30", EndDate: "2024-05-01",
+				VolunteerUnavailabilityData: map[string][]string{"Tim": {}}, VolunteerScheduledData: map[string][]string{"Tim": {"2024-01-07"}}},
+			wantNames: []string{"January 2024", "March 2024", "April 2024", "May 2024", "Summary"},
+			wantCells: map[string][][]string{
+				"January 2024": {{"Date", "Volunteer 1"}, {"45298", "Tim"}},
+				"March 2024":   {{"Date", "Volunteer 1"}},
+				"April 2024":   {{"Date", "Volunteer 1"}},
+				"May 2024":     {{"Date", "Volunteer 1"}},
+				"Summary":      {{"Volunteer", "January 2024", "March 2024", "April 2024", "May 2024", "Total"}, {"Tim", "1", "0", "0", "0", "1"}},
+			},
+		},
+		{
+			name:      "A schedule without dates is only the summary",
+			schedule:  vsadb.SendReceiveDataStruct{ScheduleName: "Q2", VolunteerUnavailabilityData: map[string][]string{"Tim": {}}},
+			wantNames: []string{"Summary"},
+			wantCells: map[string][][]string{"Summary": {{"Volunteer", "Total"}, {"Tim", "0"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheets, err := scheduleSheets(tt.schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var body bytes.Buffer
+			if err = writeXLSX(&body, sheets); err != nil {
+				t.Fatal(err)
+			}
+			names, cells := xlsxCells(t, body.Bytes())
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("writeXLSX() sheets = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(cells, tt.wantCells) {
+				t.Errorf("writeXLSX() cells = %v, want %v", cells, tt.wantCells)
+			}
+		})
+	}
+}
+
+func TestCalendarEvents(t *testing.T) {
+	schedule := vsadb.SendReceiveDataStruct{
+		ScheduleName: "Q1",
+		VolunteerScheduledData: map[string][]string{
+			"Tim":  {"2024-01-07|8am|Usher"},
+			"Ann":  {"2024-01-07|8am", "2024-01-14|Evening|Usher"},
+			"Bill": {"2024-01-07|8am", "2024-01-21"},
+		},
+	}
+	eightAM := time.Date(2024, time.January, 7, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		feed vsadb.CalendarFeed
+		want []calendarEvent
+	}{
+		{
+			name: "The whole schedule, one event per shift",
+			feed: vsadb.CalendarFeed{Workspace: "Seth", ScheduleName: "Q1"},
+			want: []calendarEvent{
+				{uid: calendarUID("Seth", "Q1", "", "2024-01-07|8am"), date: eightAM, timed: true, summary: "Q1: Ann, Bill, Tim", description: "Usher: Tim\nVolunteers: Ann, Bill", transparent: true},
+				{uid: calendarUID("Seth", "Q1", "", "2024-01-14|Evening"), date: time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC), summary: "Q1 Evening: Ann", description: "Usher: Ann", transparent: true},
+				{uid: calendarUID("Seth", "Q1", "", "2024-01-21"), date: time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC), summary: "Q1: Bill", transparent: true},
+			},
+		},
+		{
+			name: "One volunteer, with who else is on each shift",
+			feed: vsadb.CalendarFeed{Workspace: "Seth", ScheduleName: "Q1", Volunteer: "Ann"},
+			want: []calendarEvent{
+				{uid: calendarUID("Seth", "Q1", "Ann", "2024-01-07|8am"), date: eightAM, timed: true, summary: "Q1", description: "With Bill, Tim"},
+				{uid: calendarUID("Seth", "Q1", "Ann", "2024-01-14|Evening|Usher"), date: time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC), summary: "Q1 Evening (Usher)"},
+			},
+		},
+		{
+			name: "A volunteer without shifts",
+			feed: vsadb.CalendarFeed{Workspace: "Seth", ScheduleName: "Q1", Volunteer: "Jack"},
+			want: []calendarEvent{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calendarEvents(tt.feed, schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("calendarEvents() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+	if calendarUID("Seth", "Q1", "Ann", "2024-01-07|8am") == calendarUID("Seth", "Q1", "", "2024-01-07|8am") {
+		t.Errorf("calendarUID() is the same for a volunteer's feed and the schedule's feed")
+	}
+}
+
+func TestCalendarStart(t *testing.T) {
+	tests := []struct {
+		timeSlot  string
+		wantHour  int
+		wantMin   int
+		wantTimed bool
+	}{
+		{timeSlot: "8am", wantHour: 8, wantTimed: true},
+		{timeSlot: "11:30 AM", wantHour: 11, wantMin: 30, wantTimed: true},
+		{timeSlot: "18:45", wantHour: 18, wantMin: 45, wantTimed: true},
+		{timeSlot: "Morning"},
+		{timeSlot: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timeSlot, func(t *testing.T) {
+			start, timed, err := calendarStart(vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: tt.timeSlot})
+			if err != nil || timed != tt.wantTimed || start.Hour() != tt.wantHour || start.Minute() != tt.wantMin || start.Day() != 7 {
+				t.Errorf("calendarStart(%q) = %v, %t, %v, want %02d:%02d, %t", tt.timeSlot, start, timed, err, tt.wantHour, tt.wantMin, tt.wantTimed)
+			}
+		})
+	}
+}
+
+func TestParseBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Unset", value: "", want: ""},
+		{name: "A host", value: "https://schedules.example.org", want: "https://schedules.example.org"},
+		{name: "A host with a trailing slash", value: "https://schedules.example.org/", want: "https://schedules.example.org"},
+		{name: "A path on a shared host", value: "http://example.org:8080/schedules/", want: "http://example.org:8080/schedules"},
+		{name: "No scheme", value: "schedules.example.org", wantErr: true},
+		{name: "Another scheme", value: "ftp://schedules.example.org", wantErr: true},
+		{name: "A query", value: "https://schedules.example.org/?a=b", wantErr: true},
+		{name: "A user", value: "https://admin@schedules.example.org", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBaseURL(tt.value)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("parseBaseURL(%q) = %q, %v, want %q and error %t", tt.value, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalendarFeedURL(t *testing.T) {
+	forged := map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example.net"}
+	tests := []struct {
+		name       string
+		env        Env
+		bTLS       bool
+		headers    map[string]string
+		wantPrefix string // the address before /calendar/<token>.ics
+	}{
+		{name: "The configured base URL", env: Env{BaseURL: "https://schedules.example.org"}, headers: forged, wantPrefix: "https://schedules.example.org"},
+		{name: "The configured base URL behind the proxy", env: Env{BaseURL: "https://example.org/schedules", UserHeader: "X-Remote-User"}, headers: forged,
+			wantPrefix: "https://example.org/schedules"},
+		{name: "The request's host", wantPrefix: "http://example.com"},
+		{name: "The request's host over TLS", bTLS: true, wantPrefix: "https://example.com"},
+		{name: "Ignore forwarded headers without the proxy", headers: forged, wantPrefix: "http://example.com"},
+		{name: "Use forwarded headers behind the proxy", env: Env{UserHeader: "X-Remote-User"}, headers: map[string]string{"X-Forwarded-Proto": "https, http", "X-Forwarded-Host": "schedules.example.org, proxy.internal"},
+			wantPrefix: "https://schedules.example.org"},
+		{name: "Ignore a forwarded scheme that is not http or https", env: Env{UserHeader: "X-Remote-User"}, headers: map[string]string{"X-Forwarded-Proto": "javascript"}, wantPrefix: "http://example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "http://example.com/calendar-links", nil)
+			if tt.bTLS {
+				request = httptest.NewRequest(http.MethodPost, "https://example.com/calendar-links", nil)
+			}
+			for name, value := range tt.headers {
+				request.Header.Set(name, value)
+			}
+			if got, want := tt.env.calendarFeedURL(request, "abc123"), tt.wantPrefix+"/calendar/abc123.ics"; got != want {
+				t.Errorf("calendarFeedURL() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	stamp := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		events []calendarEvent
+		want   []string // the lines of the events, unfolded
+	}{
+		{
+			name:   "Timed and all-day events",
+			events: []calendarEvent{{uid: "a@x", date: time.Date(2024, time.January, 7, 8, 0, 0, 0, time.UTC), timed: true, summary: "Q1"}, {uid: "b@x", date: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), summary: "Q1 Evening", transparent: true}},
+			want: []string{
+				"BEGIN:VEVENT", "UID:a@x", "DTSTAMP:20240101T120000Z", "DTSTART:20240107T080000", "DURATION:PT1H", "SUMMARY:Q1", "END:VEVENT",
+				"BEGIN:VEVENT", "UID:b@x", "DTSTAMP:20240101T120000Z", "DTSTART;VALUE=DATE:20240131", "DTEND;VALUE=DATE:20240201", "SUMMARY:Q1 Evening", "TRANSP:TRANSPARENT", "END:VEVENT",
+			},
+		},
+		{
+			name:   "Escaped and folded text",
+			events: []calendarEvent{{uid: "c@x", date: time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC), summary: "Q1: Zoë, Jr.; Tim\\Ann", description: "Usher: Tim\n" + strings.Repeat("é", 60)}},
+			want: []string{
+				"BEGIN:VEVENT", "UID:c@x", "DTSTAMP:20240101T120000Z", "DTSTART;VALUE=DATE:20240107", "DTEND;VALUE=DATE:20240108", `SUMMARY:Q1: Zoë\, Jr.\; Tim\\Ann`,
+				`DESCRIPTION:Usher: Tim\n` + strings.Repeat("é", 60), "END:VEVENT",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body bytes.Buffer
+			if err := writeCalendar(&body, "Q1: Tim", tt.events, stamp); err != nil {
+				t.Fatal(err)
+			}
+			text := body.String()
+			if !strings.HasSuffix(text, "\r\n") || strings.Contains(strings.ReplaceAll(text, "\r\n", ""), "\n") {
+				t.Errorf("writeCalendar() lines do not all end in CRLF")
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+				if len(line) > 75 || !utf8.ValidString(line) {
+					t.Errorf("writeCalendar() line %q is %d octets or splits a character, want at most 75", line, len(line))
+				}
+			}
+			lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(text, "\r\n ", ""), "\r\n"), "\r\n")
+			if want := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:" + calendarProductID}; !slices.Equal(lines[:3], want) || lines[len(lines)-1] != "END:VCALENDAR" {
+				t.Errorf("writeCalendar() starts with %v and ends with %q, want %v and END:VCALENDAR", lines[:3], lines[len(lines)-1], want)
+			}
+			if !slices.Contains(lines, `X-WR-CALNAME:Q1: Tim`) {
+				t.Errorf("writeCalendar() does not name the calendar Q1: Tim: %v", lines)
+			}
+			start := slices.Index(lines, "BEGIN:VEVENT")
+			if got := lines[start : len(lines)-1]; !slices.Equal(got, tt.want) {
+				t.Errorf("writeCalendar() events are\n%v\nwant\n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestEnv is an Env on a new SQLite database in a temporary directory, and a mux serving every route of handleFuncMap the way main does.
+func newTestEnv(t *testing.T) (*Env, *http.ServeMux) {
+	t.Helper()
+	dbModel, err := vsadb.Open(filepath.Join(t.TempDir(), "vsa.db"))
+	if err != nil {
+		t.Fatalf("Error setting up test (vsadb.Open failed): %v", err)
+	}
+	t.Cleanup(func() { dbModel.Close() })
+	if _, _, err = dbModel.MigrateDatabase(); err != nil {
+		t.Fatalf("Error setting up test (MigrateDatabase failed): %v", err)
+	}
+	env := &Env{DBModel: dbModel, ClaimCodes: map[string]string{}}
+	mux := http.NewServeMux()
+	for pattern, handler := range env.handleFuncMap() {
+		mux.HandleFunc(pattern, handler)
+	}
+	return env, mux
+}
+
+// serve sends a request for method and target to mux, with form as its urlencoded body when it is not nil, along with cookies.
+func serve(mux *http.ServeMux, method string, target string, form url.Values, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	request := httptest.NewRequest(method, target, body)
+	if form != nil {
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// signIn registers userName with a password unless they are already registered, and returns a session cookie for them.
+func signIn(t *testing.T, env *Env, userName string) *http.Cookie {
+	t.Helper()
+	if err := env.DBModel.CreateUser(userName, "correct horse"); err != nil && !errors.Is(err, vsadb.ErrUserExists) {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	token, err := env.DBModel.CreateSession(userName)
+	if err != nil {
+		t.Fatalf("Error setting up test (CreateSession failed): %v", err)
+	}
+	return &http.Cookie{Name: sessionCookieName, Value: token}
+}
+
+func TestHandleRegister(t *testing.T) {
+	env, mux := newTestEnv(t)
+	env.ClaimCodes = map[string]string{"Seth": "0123456789abcdef"}
+	tests := []struct {
+		name       string
+		form       url.Values
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "Register a new user", form: url.Values{"user-name": {"Ann"}, "password": {"correct horse"}, "confirm-password": {"correct horse"}}, wantStatus: http.StatusSeeOther},
+		{name: "Fail to register a user name that is taken", form: url.Values{"user-name": {"Ann"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}}, wantStatus: http.StatusConflict, wantBody: "The user name Ann is taken."},
+		{name: "Fail to take over the user from before sign-in without its claim code", form: url.Values{"user-name": {"Seth"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}}, wantStatus: http.StatusConflict, wantBody: "enter it to register as Seth"},
+		{name: "Fail with the wrong claim code", form: url.Values{"user-name": {"Seth"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}, "claim-code": {"fedcba9876543210"}}, wantStatus: http.StatusForbidden, wantBody: "not the claim code for Seth"},
+		{name: "Fail with a claim code for a user who is not waiting to be claimed", form: url.Values{"user-name": {"Ann"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}, "claim-code": {"0123456789abcdef"}}, wantStatus: http.StatusForbidden, wantBody: "not the claim code for Ann"},
+		{name: "Take over the user from before sign-in with its claim code", form: url.Values{"user-name": {"Seth"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}, "claim-code": {"0123456789abcdef"}}, wantStatus: http.StatusSeeOther},
+		{name: "Fail to claim a user twice", form: url.Values{"user-name": {"Seth"}, "password": {"another password"}, "confirm-password": {"another password"}, "claim-code": {"0123456789abcdef"}}, wantStatus: http.StatusConflict, wantBody: "The user name Seth is taken."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(mux, http.MethodPost, "/register", tt.form)
+			if recorder.Code != tt.wantStatus || !strings.Contains(recorder.Body.String(), tt.wantBody) {
+				t.Errorf("/register status = %d, body %q, want %d and a body containing %q", recorder.Code, recorder.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+			if bSignedIn := slices.ContainsFunc(recorder.Result().Cookies(), func(cookie *http.Cookie) bool { return cookie.Name == sessionCookieName && cookie.Value != "" }); bSignedIn != (tt.wantStatus == http.StatusSeeOther) {
+				t.Errorf("/register signed in: %t, want %t", bSignedIn, tt.wantStatus == http.StatusSeeOther)
+			}
+		})
+	}
+	if err := env.DBModel.AuthenticateUser("Seth", "battery staple"); err != nil {
+		t.Errorf("AuthenticateUser() error = %v for the claimed user", err)
+	}
+}
+
+// TestWithSessionRoles checks the role handleFuncMap asks withSession for on every page route, by sending each one a method no handler accepts: a role that is too low gets
+// a 403 from withSession, and one that is high enough gets through to the handler's 405.
+func TestWithSessionRoles(t *testing.T) {
+	env, mux := newTestEnv(t)
+	wantRoles := map[string]string{
+		"/":                     vsadb.RoleViewer,
+		"/select-schedule":      vsadb.RoleViewer,
+		"/add-unavailability":   vsadb.RoleViewer,
+		"/mod-volunteers":       vsadb.RoleViewer,
+		"/save-parameters":      vsadb.RoleEditor,
+		"/delete-schedule":      vsadb.RoleEditor,
+		"/generate-schedule":    vsadb.RoleViewer,
+		"/save-schedule":        vsadb.RoleEditor,
+		"/export-schedule":      vsadb.RoleViewer,
+		"/preview-import":       vsadb.RoleEditor,
+		"/import-volunteers":    vsadb.RoleEditor,
+		"/select-workspace":     vsadb.RoleViewer,
+		"/organizations":        vsadb.RoleViewer,
+		"/create-organization":  vsadb.RoleViewer,
+		"/save-member":          vsadb.RoleOwner,
+		"/remove-member":        vsadb.RoleViewer,
+		"/calendar-links":       vsadb.RoleViewer,
+		"/reset-calendar-links": vsadb.RoleEditor,
+	}
+	for pattern := range env.handleFuncMap() {
+		if strings.Contains(pattern, " ") || strings.HasPrefix(pattern, "/api/") {
+			continue // method patterns and the JSON API do not use withSession
+		}
+		_, bListed := wantRoles[pattern]
+		if bSession := serve(mux, http.MethodPatch, pattern, nil).Code == http.StatusSeeOther; bSession != bListed {
+			t.Errorf("%s needs a session: %t, but is listed in wantRoles: %t", pattern, bSession, bListed)
+		}
+	}
+	owner := signIn(t, env, "Ann")
+	if err := env.DBModel.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	workspace := &http.Cookie{Name: workspaceCookieName, Value: url.QueryEscape("First Church")}
+	members := map[string]*http.Cookie{vsadb.RoleOwner: owner}
+	for _, role := range []string{vsadb.RoleViewer, vsadb.RoleEditor} {
+		members[role] = signIn(t, env, "Member "+role)
+		if err := env.DBModel.SaveMembership("First Church", "Member "+role, role); err != nil {
+			t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+		}
+	}
+	for pattern, needed := range wantRoles {
+		for _, role := range vsadb.Roles {
+			t.Run(fmt.Sprintf("%s as %s", pattern, role), func(t *testing.T) {
+				wantStatus := http.StatusMethodNotAllowed
+				if !vsadb.RoleAllows(role, needed) {
+					wantStatus = http.StatusForbidden
+				}
+				if got := serve(mux, http.MethodPatch, pattern, nil, members[role], workspace).Code; got != wantStatus {
+					t.Errorf("status = %d, want %d", got, wantStatus)
+				}
+			})
+		}
+	}
+}
+
+func TestWithSessionWorkspace(t *testing.T) {
+	env, mux := newTestEnv(t)
+	ann := signIn(t, env, "Ann")
+	viewer := signIn(t, env, "Vic")
+	outsider := signIn(t, env, "Zed")
+	if err := env.DBModel.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	if err := env.DBModel.SaveMembership("First Church", "Vic", vsadb.RoleViewer); err != nil {
+		t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+	}
+	churchSchedule := vsadb.SendReceiveDataStruct{ScheduleName: "Church Q1", ShiftsOff: 0, VolunteersPerShift: 1, StartDate: "2024-01-01", EndDate: "2024-01-31", WeekdaysForSchedule: []string{"Sunday"}, VolunteerUnavailabilityData: map[string][]string{"Tim": {}}}
+	if err := env.DBModel.RecieveAndStoreData("First Church", churchSchedule, true); err != nil {
+		t.Fatalf("Error setting up test (RecieveAndStoreData failed): %v", err)
+	}
+	env.UserHeader = "X-Remote-User"
+	workspace := &http.Cookie{Name: workspaceCookieName, Value: url.QueryEscape("First Church")}
+	tests := []struct {
+		name         string
+		cookies      []*http.Cookie
+		header       string // X-Remote-User
+		pattern      string
+		wantStatus   int
+		wantSchedule bool // the page lists Church Q1
+	}{
+		{name: "Send someone who is not signed in to /login", pattern: "/", wantStatus: http.StatusSeeOther},
+		{name: "Send someone with an unknown session to /login", cookies: []*http.Cookie{{Name: sessionCookieName, Value: "forged"}}, pattern: "/", wantStatus: http.StatusSeeOther},
+		{name: "Show an owner their organization", cookies: []*http.Cookie{ann, workspace}, pattern: "/", wantStatus: http.StatusOK, wantSchedule: true},
+		{name: "Show a viewer the organization", cookies: []*http.Cookie{viewer, workspace}, pattern: "/", wantStatus: http.StatusOK, wantSchedule: true},
+		{name: "Show the viewer their own schedules without the cookie", cookies: []*http.Cookie{viewer}, pattern: "/", wantStatus: http.StatusOK},
+		{name: "Refuse the viewer a save in the organization", cookies: []*http.Cookie{viewer, workspace}, pattern: "/save-parameters", wantStatus: http.StatusForbidden},
+		{name: "Keep someone whose cookie names an organization they are not in to their own schedules", cookies: []*http.Cookie{outsider, workspace}, pattern: "/", wantStatus: http.StatusOK},
+		{name: "Let someone whose cookie names an organization they are not in save their own schedules", cookies: []*http.Cookie{outsider, workspace}, pattern: "/save-parameters", wantStatus: http.StatusBadRequest},
+		{name: "Sign in the user the proxy names", header: "Pat", pattern: "/", wantStatus: http.StatusOK},
+		{name: "Put the proxy's user in the organization they are a member of", header: "Vic", cookies: []*http.Cookie{workspace}, pattern: "/", wantStatus: http.StatusOK, wantSchedule: true},
+		{name: "Refuse a proxy header naming an organization", header: "First Church", pattern: "/", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, tt.pattern, nil)
+			if tt.pattern != "/" {
+				request = httptest.NewRequest(http.MethodPost, tt.pattern, strings.NewReader(""))
+				request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tt.header != "" {
+				request.Header.Set(env.UserHeader, tt.header)
+			}
+			for _, cookie := range tt.cookies {
+				request.AddCookie(cookie)
+			}
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, request)
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if bSchedule := strings.Contains(recorder.Body.String(), "Church Q1"); recorder.Code == http.StatusOK && bSchedule != tt.wantSchedule {
+				t.Errorf("page lists Church Q1: %t, want %t", bSchedule, tt.wantSchedule)
+			}
+		})
+	}
+}
+
+// TestSignInBehindProxy checks that while the proxy header is trusted, someone who reaches the server directly cannot register or sign in as a user the proxy created.
+func TestSignInBehindProxy(t *testing.T) {
+	env, mux := newTestEnv(t)
+	env.UserHeader = "X-Remote-User"
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(env.UserHeader, "Pat")
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Error setting up test (the proxy's first request for Pat got status %d)", recorder.Code)
+	}
+	tests := []struct {
+		name    string
+		method  string
+		pattern string
+		form    url.Values
+	}{
+		{name: "Refuse the sign-in page", method: http.MethodGet, pattern: "/login"},
+		{name: "Refuse to register as the proxy's user", method: http.MethodPost, pattern: "/register", form: url.Values{"user-name": {"Pat"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}}},
+		{name: "Refuse to register a new user", method: http.MethodPost, pattern: "/register", form: url.Values{"user-name": {"Mallory"}, "password": {"battery staple"}, "confirm-password": {"battery staple"}}},
+		{name: "Refuse to sign in as the proxy's user", method: http.MethodPost, pattern: "/sign-in", form: url.Values{"user-name": {"Pat"}, "password": {""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(mux, tt.method, tt.pattern, tt.form)
+			if recorder.Code != http.StatusForbidden || len(recorder.Result().Cookies()) > 0 {
+				t.Errorf("status = %d, cookies %v, want %d and none", recorder.Code, recorder.Result().Cookies(), http.StatusForbidden)
+			}
+		})
+	}
+	if err := env.DBModel.AuthenticateUser("Pat", "battery staple"); !errors.Is(err, vsadb.ErrInvalidCredentials) {
+		t.Errorf("AuthenticateUser() error = %v for the proxy's user, want ErrInvalidCredentials", err)
+	}
+	if err := env.DBModel.CreateUser("Mallory", "battery staple"); err != nil {
+		t.Errorf("CreateUser() error = %v, want Mallory left unregistered", err)
+	}
+}
+
+// serveAPI sends a request for method and target to mux with body encoded as JSON, signed in with HTTP basic authentication as userName and password, unless userName is blank.
+func serveAPI(mux *http.ServeMux, method string, target string, body any, userName string, password string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != nil {
+		encoded, _ := json.Marshal(body)
+		reader = bytes.NewReader(encoded)
+	}
+	request := httptest.NewRequest(method, target, reader)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if userName != "" {
+		request.SetBasicAuth(userName, password)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAPIErrors(t *testing.T) {
+	env, mux := newTestEnv(t)
+	for _, userName := range []string{"Ann", "Vic", "Zed"} {
+		signIn(t, env, userName)
+	}
+	if err := env.DBModel.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	if err := env.DBModel.SaveMembership("First Church", "Vic", vsadb.RoleViewer); err != nil {
+		t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+	}
+	q1 := map[string]any{"scheduleName": "Q1", "shiftsOff": 0, "volunteersPerShift": 1, "startDate": "2024-01-01", "endDate": "2024-01-31", "weekdays": []string{"Sunday"},
+		"volunteerUnavailability": map[string][]string{"Tim": {}, "Bill": {}}}
+	for _, workspace := range []string{"", "?workspace=First%20Church"} {
+		if recorder := serveAPI(mux, http.MethodPost, apiPrefix+"/schedules"+workspace, q1, "Ann", "correct horse"); recorder.Code != http.StatusCreated {
+			t.Fatalf("Error setting up test (POST /schedules%s got status %d: %s)", workspace, recorder.Code, recorder.Body.String())
+		}
+	}
+	renamed := map[string]any{}
+	badRoles := map[string]any{}
+	for key, value := range q1 {
+		renamed[key], badRoles[key] = value, value
+	}
+	renamed["scheduleName"] = "Q2"
+	badRoles["scheduleName"] = "Q3"
+	badRoles["volunteerRoles"] = map[string][]string{"Tim": {"usher|lead"}}
+	badRoles["startDate"] = "2024-02-01"
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       any
+		userName   string
+		password   string
+		wantStatus int
+		wantFields []string // the keys of the apiErrorStruct's Fields
+	}{
+		{name: "Ask someone who is not signed in to authenticate", method: http.MethodGet, target: "/schedules", wantStatus: http.StatusUnauthorized},
+		{name: "Ask someone with the wrong password to authenticate", method: http.MethodGet, target: "/schedules", userName: "Ann", password: "wrong", wantStatus: http.StatusUnauthorized},
+		{name: "Ask someone who is not registered to authenticate", method: http.MethodGet, target: "/schedules", userName: "Mallory", password: "correct horse", wantStatus: http.StatusUnauthorized},
+		{name: "Show a viewer the organization's schedule", method: http.MethodGet, target: "/schedules/Q1?workspace=First%20Church", userName: "Vic", password: "correct horse", wantStatus: http.StatusOK},
+		{name: "Refuse a viewer a new schedule in the organization", method: http.MethodPost, target: "/schedules?workspace=First%20Church", body: renamed, userName: "Vic", password: "correct horse", wantStatus: http.StatusForbidden},
+		{name: "Refuse a viewer a change to the organization's schedule", method: http.MethodPut, target: "/schedules/Q1?workspace=First%20Church", body: q1, userName: "Vic", password: "correct horse", wantStatus: http.StatusForbidden},
+		{name: "Refuse a viewer the deletion of the organization's assignments", method: http.MethodDelete, target: "/schedules/Q1/assignments?workspace=First%20Church", userName: "Vic", password: "correct horse", wantStatus: http.StatusForbidden},
+		{name: "Hide an organization from someone who is not a member", method: http.MethodGet, target: "/schedules/Q1?workspace=First%20Church", userName: "Zed", password: "correct horse", wantStatus: http.StatusNotFound},
+		{name: "Hide a workspace that does not exist", method: http.MethodGet, target: "/schedules?workspace=Nowhere", userName: "Ann", password: "correct horse", wantStatus: http.StatusNotFound},
+		{name: "Answer an unknown schedule with a 404", method: http.MethodGet, target: "/schedules/Q9", userName: "Ann", password: "correct horse", wantStatus: http.StatusNotFound},
+		{name: "Refuse a schedule with a name that is taken", method: http.MethodPost, target: "/schedules", body: q1, userName: "Ann", password: "correct horse", wantStatus: http.StatusConflict},
+		{name: "List every problem of a new schedule by field", method: http.MethodPost, target: "/schedules", body: badRoles, userName: "Ann", password: "correct horse", wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"endDate", "volunteerRoles.Tim"}},
+		{name: "Require shiftsOff and volunteersPerShift for a new schedule", method: http.MethodPost, target: "/schedules", body: map[string]any{"scheduleName": "Q4", "startDate": "2024-01-01", "endDate": "2024-01-31"},
+			userName: "Ann", password: "correct horse", wantStatus: http.StatusUnprocessableEntity, wantFields: []string{"shiftsOff", "volunteersPerShift"}},
+		{name: "Refuse to rename a schedule", method: http.MethodPut, target: "/schedules/Q1", body: renamed, userName: "Ann", password: "correct horse", wantStatus: http.StatusBadRequest},
+		{name: "Refuse a field the schedule does not have", method: http.MethodPut, target: "/schedules/Q1", body: map[string]any{"schedule": "Q1"}, userName: "Ann", password: "correct horse", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveAPI(mux, tt.method, apiPrefix+tt.target, tt.body, tt.userName, tt.password)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if authenticate := recorder.Header().Get("WWW-Authenticate"); (tt.wantStatus == http.StatusUnauthorized) != strings.HasPrefix(authenticate, "Basic realm=") {
+				t.Errorf("WWW-Authenticate = %q with status %d", authenticate, recorder.Code)
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+			var body apiErrorStruct
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not an apiErrorStruct: %v", recorder.Body.String(), err)
+			}
+			if body.Status != tt.wantStatus || body.Error == "" {
+				t.Errorf("body = %+v, want status %d and an error", body, tt.wantStatus)
+			}
+			if gotFields := getStringMapKeys(body.Fields, true); !slices.Equal(gotFields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", body.Fields, tt.wantFields)
+			}
+		})
+	}
+	for _, scheduleName := range []string{"Q2", "Q3", "Q4"} {
+		if _, err := env.DBModel.FetchAndSendScheduleData("Ann", scheduleName); !errors.Is(err, vsadb.ErrNotFound) {
+			t.Errorf("FetchAndSendScheduleData(%s) error = %v, want the refused schedule left unsaved", scheduleName, err)
+		}
+	}
+}
+
+// TestAPIUpdateSchedule checks that a schedule replaced through the API keeps the assignments saved for it, like one saved from the page.
+func TestAPIUpdateSchedule(t *testing.T) {
+	env, mux := newTestEnv(t)
+	signIn(t, env, "Ann")
+	schedule := map[string]any{"scheduleName": "Q1", "shiftsOff": 0, "volunteersPerShift": 1, "startDate": "2024-01-01", "endDate": "2024-01-31", "weekdays": []string{"Sunday"},
+		"volunteerUnavailability": map[string][]string{"Tim": {}, "Bill": {}}}
+	if recorder := serveAPI(mux, http.MethodPost, apiPrefix+"/schedules", schedule, "Ann", "correct horse"); recorder.Code != http.StatusCreated {
+		t.Fatalf("Error setting up test (POST /schedules got status %d: %s)", recorder.Code, recorder.Body.String())
+	}
+	assignments := map[string][]string{"Tim": {"2024-01-07", "2024-01-21"}, "Bill": {"2024-01-14", "2024-01-28"}}
+	recorder := serveAPI(mux, http.MethodPut, apiPrefix+"/schedules/Q1/assignments", apiAssignmentsStruct{assignments}, "Ann", "correct horse")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Error setting up test (PUT /schedules/Q1/assignments got status %d: %s)", recorder.Code, recorder.Body.String())
+	}
+	schedule["volunteerUnavailability"] = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}}
+	schedule["volunteerContacts"] = map[string]vsadb.VolunteerContact{"Tim": {Email: "tim@example.com"}}
+	delete(schedule, "scheduleName") // taken from the path
+	recorder = serveAPI(mux, http.MethodPut, apiPrefix+"/schedules/Q1", schedule, "Ann", "correct horse")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("PUT /schedules/Q1 status = %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+	}
+	var updated vsadb.SendReceiveDataStruct
+	if err := json.Unmarshal(recorder.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("body %q is not a schedule: %v", recorder.Body.String(), err)
+	}
+	if !reflect.DeepEqual(updated.VolunteerScheduledData, assignments) {
+		t.Errorf("PUT /schedules/Q1 volunteerAssignments = %v, want %v", updated.VolunteerScheduledData, assignments)
+	}
+	if updated.ScheduleName != "Q1" || !slices.Equal(updated.VolunteerUnavailabilityData["Tim"], []string{"2024-01-14"}) || updated.VolunteerContactData["Tim"].Email != "tim@example.com" {
+		t.Errorf("PUT /schedules/Q1 = %+v, want Q1 with Tim's new unavailability and email", updated)
+	}
+	recorder = serveAPI(mux, http.MethodGet, apiPrefix+"/schedules/Q1/assignments", nil, "Ann", "correct horse")
+	var saved apiAssignmentsStruct
+	if err := json.Unmarshal(recorder.Body.Bytes(), &saved); err != nil || !reflect.DeepEqual(saved.Assignments, assignments) {
+		t.Errorf("GET /schedules/Q1/assignments = %s (%v), want %v", recorder.Body.String(), err, assignments)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantValidation int // validationErrorStatus
+		wantDB         int // dbErrorStatus
+	}{
+		{name: "Unknown schedule", err: fmt.Errorf("error in RequestSchedule: %w: Q9", vsadb.ErrNotFound), wantValidation: http.StatusNotFound, wantDB: http.StatusNotFound},
+		{name: "Unknown schedule wrapped twice", err: fmt.Errorf("error in prepareTemplateStructs: %w", fmt.Errorf("error in RequestSchedule: %w", vsadb.ErrNotFound)), wantValidation: http.StatusNotFound,
+			wantDB: http.StatusNotFound},
+		{name: "Schedule names could not be read", err: fmt.Errorf("error in parametersValidated: %w: %w", errScheduleLookup, errors.New("database is locked")), wantValidation: http.StatusInternalServerError,
+			wantDB: http.StatusInternalServerError},
+		{name: "Malformed parameter", err: errors.New("error in parametersValidated: \"schedule-name\" does not have length of 1"), wantValidation: http.StatusBadRequest, wantDB: http.StatusInternalServerError},
+		{name: "Problems with inputs", err: fieldErrors{"max-date": {"is before the start date"}}, wantValidation: http.StatusBadRequest, wantDB: http.StatusInternalServerError},
+		{name: "User name taken", err: fmt.Errorf("error in CreateUser: %w", vsadb.ErrUserExists), wantValidation: http.StatusBadRequest, wantDB: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validationErrorStatus(tt.err); got != tt.wantValidation {
+				t.Errorf("validationErrorStatus() = %d, want %d", got, tt.wantValidation)
+			}
+			if got := dbErrorStatus(tt.err); got != tt.wantDB {
+				t.Errorf("dbErrorStatus() = %d, want %d", got, tt.wantDB)
+			}
+		})
+	}
+}
+
+// failingStore is a vsadb.Store whose method named failing returns err, as if the database had failed part way through a request.
+type failingStore struct {
+	vsadb.Store
+	failing string
+	err     error
+}
+
+func (store failingStore) SendScheduleNames(currentUser string, sorted bool) ([]string, error) {
+	if store.failing == "SendScheduleNames" {
+		return nil, store.err
+	}
+	return store.Store.SendScheduleNames(currentUser, sorted)
+}
+
+func (store failingStore) FetchAndSendScheduleData(currentUser string, selectedSchedule string) (vsadb.SendReceiveDataStruct, error) {
+	if store.failing == "FetchAndSendScheduleData" {
+		return vsadb.SendReceiveDataStruct{}, store.err
+	}
+	return store.Store.FetchAndSendScheduleData(currentUser, selectedSchedule)
+}
+
+func (store failingStore) RecieveAndStoreData(currentUser string, data vsadb.SendReceiveDataStruct, bNewSchedule bool) error {
+	if store.failing == "RecieveAndStoreData" {
+		return store.err
+	}
+	return store.Store.RecieveAndStoreData(currentUser, data, bNewSchedule)
+}
+
+// TestHandlerErrorStatus checks that page handlers answer each class of error with its status: an unknown schedule with a 404, a malformed request with a 400, a form with
+// problems with a 422 page and a database failure with a 500.
+func TestHandlerErrorStatus(t *testing.T) {
+	env, mux := newTestEnv(t)
+	ann := signIn(t, env, "Ann")
+	q1 := vsadb.SendReceiveDataStruct{ScheduleName: "Q1", ShiftsOff: 0, VolunteersPerShift: 1, StartDate: "2024-01-01", EndDate: "2024-01-31", WeekdaysForSchedule: []string{"Sunday"}, VolunteerUnavailabilityData: map[string][]string{"Tim": {}}}
+	if err := env.DBModel.RecieveAndStoreData("Ann", q1, true); err != nil {
+		t.Fatalf("Error setting up test (RecieveAndStoreData failed): %v", err)
+	}
+	form := func(maxDate string) url.Values {
+		return url.Values{"schedule-selection": {"new-schedule"}, "schedule-name": {"Q2"}, "min-date": {"2024-02-01"}, "max-date": {maxDate}, "weekday": {"Su"}, "shifts-off": {"0"},
+			"per-shift": {"1"}, "ve0-n": {"Tim"}, "ve0-u": {""}}
+	}
+	store := env.DBModel
+	tests := []struct {
+		name       string
+		failing    string // the Store method that fails
+		method     string
+		target     string
+		form       url.Values
+		wantStatus int
+		wantBanner bool // the response is an error_banner swapped into #error-banner, not a page
+	}{
+		{name: "Answer an unknown schedule with a 404", method: http.MethodGet, target: "/select-schedule?schedule-selection=Q9&schedule-name=", wantStatus: http.StatusNotFound, wantBanner: true},
+		{name: "Answer a malformed request with a 400", method: http.MethodGet, target: "/select-schedule?schedule-selection=Q1", wantStatus: http.StatusBadRequest, wantBanner: true},
+		{name: "Send back a form with problems with a 422", method: http.MethodPost, target: "/save-parameters", form: form("2024-01-01"), wantStatus: http.StatusUnprocessableEntity},
+		{name: "Answer a failed schedule lookup with a 500", failing: "SendScheduleNames", method: http.MethodGet, target: "/select-schedule?schedule-selection=Q1&schedule-name=",
+			wantStatus: http.StatusInternalServerError, wantBanner: true},
+		{name: "Answer a failed read with a 500", failing: "FetchAndSendScheduleData", method: http.MethodGet, target: "/select-schedule?schedule-selection=Q1&schedule-name=",
+			wantStatus: http.StatusInternalServerError, wantBanner: true},
+		{name: "Answer a failed save with a 500", failing: "RecieveAndStoreData", method: http.MethodPost, target: "/save-parameters", form: form("2024-02-29"),
+			wantStatus: http.StatusInternalServerError, wantBanner: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env.DBModel = failingStore{Store: store, failing: tt.failing, err: errors.New("database is locked")}
+			defer func() { env.DBModel = store }()
+			recorder := serve(mux, tt.method, tt.target, tt.form, ann)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if bBanner := recorder.Header().Get("HX-Retarget") == "#error-banner"; bBanner != tt.wantBanner {
+				t.Errorf("response is an error_banner: %t, want %t", bBanner, tt.wantBanner)
+			}
+			if !strings.Contains(recorder.Body.String(), http.StatusText(tt.wantStatus)) {
+				t.Errorf("body does not show %q: %s", http.StatusText(tt.wantStatus), recorder.Body.String())
+			}
+		})
+	}
+}
+
+// TestSaveParametersProblems checks that a form with several problems gets all of them back at once, each keyed by its input and shown next to that input on the 422 page.
+func TestSaveParametersProblems(t *testing.T) {
+	env, mux := newTestEnv(t)
+	ann := signIn(t, env, "Ann")
+	form := url.Values{"schedule-selection": {"new-schedule"}, "schedule-name": {"Q1"}, "min-date": {"2024-02-01"}, "max-date": {"2024-01-01"}, "weekday": {"Su"}, "shifts-off": {"-1"},
+		"per-shift": {"two"}, "slots-Su": {"9am=many"}, "ve0-n": {"Tim"}, "ve0-u": {""}, "ve0-m": {"not-an-email"}, "ve1-n": {"Tim"}, "ve1-u": {""}, "ve1-h": {"12"}}
+	wantProblems := fieldErrors{
+		"max-date":   {"is before the start date"},
+		"shifts-off": {"cannot be less than 0"},
+		"per-shift":  {"must be a whole number"},
+		"ve0-m":      {"\"not-an-email\" is not an email address"},
+		"ve1-n":      {"Tim is already entered above"},
+		"ve1-h":      {"\"12\" is not a phone number"},
+	}
+	ctx := contextWithUser(context.Background(), requestUser{Name: "Ann", Workspace: "Ann", WorkspaceRole: vsadb.RoleOwner})
+	err := env.parametersValidated(ctx, form, "schedule-name", "veX-X", "min-date", "max-date", "weekday", "shifts-off", "per-shift", "slots-X", "roles", "pairings")
+	var problems fieldErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("parametersValidated() error = %v, want fieldErrors", err)
+	}
+	if _, ok := problems["slots-Su"]; !ok {
+		t.Errorf("parametersValidated() = %v, want a problem with slots-Su", problems)
+	}
+	wantProblems["slots-Su"] = problems["slots-Su"] // its message comes from parseTimeSlots
+	if !reflect.DeepEqual(problems, wantProblems) {
+		t.Errorf("parametersValidated() = %v, want %v", problems, wantProblems)
+	}
+	recorder := serve(mux, http.MethodPost, "/save-parameters", form, ann)
+	if recorder.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusUnprocessableEntity, recorder.Body.String())
+	}
+	page := recorder.Body.String()
+	for formKey, messages := range wantProblems {
+		// the input's own markup and what follows it, up to the next input
+		start := strings.Index(page, fmt.Sprintf("name=\"%s\"", formKey))
+		if start < 0 {
+			t.Errorf("the page has no %s input", formKey)
+			continue
+		}
+		next := strings.Index(page[start+1:], " name=\"")
+		if next < 0 {
+			next = len(page) - start - 1
+		}
+		for _, message := range messages {
+			if span := fmt.Sprintf("<span class=\"field-error\">%s</span>", template.HTMLEscapeString(message)); !strings.Contains(page[start:start+1+next], span) {
+				t.Errorf("%s is not shown after the %s input: %s", span, formKey, page[start:start+1+next])
+			}
+		}
+	}
+	if want := "Fix the 7 problem(s)"; !strings.Contains(page, want) {
+		t.Errorf("the error banner does not say %q", want)
+	}
+	if _, err := env.DBModel.FetchAndSendScheduleData("Ann", "Q1"); !errors.Is(err, vsadb.ErrNotFound) {
+		t.Errorf("FetchAndSendScheduleData() error = %v, want the form left unsaved", err)
+	}
+}
+
+// TestSaveParametersVolunteerIndex checks that a forged volunteer entry whose number does not fit in an int is sent back as a problem with that entry instead of stopping the server.
+func TestSaveParametersVolunteerIndex(t *testing.T) {
+	env, mux := newTestEnv(t)
+	ann := signIn(t, env, "Ann")
+	tooLarge := "ve99999999999999999999-n"
+	tests := []struct {
+		name string
+		form url.Values
+	}{
+		{name: "Only an entry with a number that is too large", form: url.Values{tooLarge: {"Tim"}}},
+		{name: "An entry with a number that is too large after others", form: url.Values{"ve0-n": {"Bill"}, "ve0-u": {""}, "ve1-n": {"Ann"}, "ve1-u": {""}, tooLarge: {"Tim"}}},
+		{name: "An entry with a number that is too large and the same name as another", form: url.Values{"ve0-n": {"Tim"}, "ve0-u": {""}, tooLarge: {"Tim"}}},
+		{name: "The largest int as an entry number", form: url.Values{"ve0-n": {"Bill"}, "ve0-u": {""}, "ve9223372036854775807-n": {"Tim"}, "ve9223372036854775807-q": {"Usher|Lead"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"schedule-selection": {"new-schedule"}, "schedule-name": {"Q1"}, "min-date": {"2024-01-01"}, "max-date": {"2024-01-31"}, "weekday": {"Su"},
+				"shifts-off": {"0"}, "per-shift": {"1"}}
+			for key, values := range tt.form {
+				form[key] = values
+			}
+			recorder := serve(mux, http.MethodPost, "/save-parameters", form, ann)
+			if recorder.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusUnprocessableEntity, recorder.Body.String())
+			}
+			if _, bTooLarge := tt.form[tooLarge]; bTooLarge && !strings.Contains(recorder.Body.String(), "the number in its name is too large") {
+				t.Errorf("the page does not show the problem with %s: %s", tooLarge, recorder.Body.String())
+			}
+		})
+	}
+}
+
+// TestCalendarLinksRoles checks that a viewer sees only the calendar feeds an editor has made, and cannot make or replace any.
+func TestCalendarLinksRoles(t *testing.T) {
+	env, mux := newTestEnv(t)
+	env.BaseURL = "https://schedules.example.org"
+	signIn(t, env, "Ann")
+	if err := env.DBModel.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	members := map[string]*http.Cookie{}
+	for _, role := range []string{vsadb.RoleViewer, vsadb.RoleEditor} {
+		members[role] = signIn(t, env, "Member "+role)
+		if err := env.DBModel.SaveMembership("First Church", "Member "+role, role); err != nil {
+			t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+		}
+	}
+	churchSchedule := vsadb.SendReceiveDataStruct{ScheduleName: "Church Q1", ShiftsOff: 0, VolunteersPerShift: 1, StartDate: "2024-01-01", EndDate: "2024-01-31", WeekdaysForSchedule: []string{"Sunday"},
+		VolunteerUnavailabilityData: map[string][]string{"Tim": {}, "Bill": {}}}
+	if err := env.DBModel.RecieveAndStoreData("First Church", churchSchedule, true); err != nil {
+		t.Fatalf("Error setting up test (RecieveAndStoreData failed): %v", err)
+	}
+	workspace := &http.Cookie{Name: workspaceCookieName, Value: url.QueryEscape("First Church")}
+	form := url.Values{"schedule-selection": {"Church Q1"}}
+	feedRegex := regexp.MustCompile(`https://schedules\.example\.org/calendar/[^"]+\.ics`)
+	tests := []struct {
+		name       string
+		role       string
+		pattern    string
+		wantStatus int
+		wantFeeds  int  // addresses on the page
+		wantButton bool // Make New Addresses
+		wantMade   int  // feeds of Church Q1 that exist afterwards, of 3
+	}{
+		{name: "Show a viewer no feeds before an editor makes them", role: vsadb.RoleViewer, pattern: "/calendar-links", wantStatus: http.StatusOK},
+		{name: "Refuse a viewer new addresses", role: vsadb.RoleViewer, pattern: "/reset-calendar-links", wantStatus: http.StatusForbidden},
+		{name: "Make the feeds for an editor", role: vsadb.RoleEditor, pattern: "/calendar-links", wantStatus: http.StatusOK, wantFeeds: 3, wantButton: true, wantMade: 3},
+		{name: "Show a viewer the feeds the editor made", role: vsadb.RoleViewer, pattern: "/calendar-links", wantStatus: http.StatusOK, wantFeeds: 3, wantMade: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serve(mux, http.MethodPost, tt.pattern, form, members[tt.role], workspace)
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.wantStatus, recorder.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				if feeds := feedRegex.FindAllString(recorder.Body.String(), -1); len(feeds) != tt.wantFeeds {
+					t.Errorf("page shows %d feed addresses, want %d: %s", len(feeds), tt.wantFeeds, recorder.Body.String())
+				}
+				if bButton := strings.Contains(recorder.Body.String(), "Make New Addresses"); bButton != tt.wantButton {
+					t.Errorf("page shows Make New Addresses: %t, want %t", bButton, tt.wantButton)
+				}
+			}
+			made := 0
+			for _, volunteerName := range []string{"", "Tim", "Bill"} {
+				if _, err := env.DBModel.RequestCalendarFeedToken("First Church", "Church Q1", volunteerName); err == nil {
+					made++
+				} else if !errors.Is(err, vsadb.ErrNotFound) {
+					t.Fatalf("RequestCalendarFeedToken() error = %v", err)
+				}
+			}
+			if made != tt.wantMade {
+				t.Errorf("%d feeds exist, want %d", made, tt.wantMade)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+// the OpenAPI 3 document at /api/v1/openapi.json is what integrators generate clients from. The paths come from apiOperations, which TestOpenAPISpec keeps in step with
+// handleFuncMap, and the schemas are read off the JSON fields of the structs the handlers send and receive, so they follow vsadb.SendReceiveDataStruct as it changes.
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const openAPIVersion = "3.0.3"
+const apiVersion = "1.0.0" // the version of the API described by the document. Bump it when the contract changes
+
+// apiOperation describes one /api/v1 route of handleFuncMap for openAPISpec.
+type apiOperation struct {
+	route       string // the handleFuncMap key, like "GET /api/v1/schedules/{schedule}"
+	operationID string
+	summary     string
+	request     any   // a value of the type of the request body, or nil for none
+	status      int   // the status of a successful response
+	response    any   // a value of the type of the response body, or nil for none
+	errors      []int // the error statuses, other than the 401, 403, and 404 every signed-in route can answer with for its workspace
+	public      bool  // true when the route needs no one signed in
+}
+
+var apiOperations = []apiOperation{
+	{"GET " + apiPrefix + "/openapi.json", "getOpenAPISpec", "This document", nil, http.StatusOK, map[string]any{}, nil, true},
+	{"GET " + apiPrefix + "/schedules", "listSchedules", "List the names of the schedules in the workspace", nil, http.StatusOK, []string{}, nil, false},
+	{"POST " + apiPrefix + "/schedules", "createSchedule", "Create a schedule", vsadb.SendReceiveDataStruct{}, http.StatusCreated, vsadb.SendReceiveDataStruct{},
+		[]int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}, false},
+	{"GET " + apiPrefix + "/schedules/{schedule}", "getSchedule", "Get a schedule", nil, http.StatusOK, vsadb.SendReceiveDataStruct{}, nil, false},
+	{"PUT " + apiPrefix + "/schedules/{schedule}", "updateSchedule", "Replace the parameters, volunteers, and unavailability of a schedule", vsadb.SendReceiveDataStruct{}, http.StatusOK,
+		vsadb.SendReceiveDataStruct{}, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, false},
+	{"DELETE " + apiPrefix + "/schedules/{schedule}", "deleteSchedule", "Delete a schedule", nil, http.StatusNoContent, nil, nil, false},
+	{"GET " + apiPrefix + "/schedules/{schedule}/volunteers", "listVolunteers", "List the volunteers of a schedule", nil, http.StatusOK, []apiVolunteerStruct{},
+		nil, false},
+	{"POST " + apiPrefix + "/schedules/{schedule}/volunteers", "createVolunteer", "Add a volunteer to a schedule", apiVolunteerStruct{}, http.StatusCreated, apiVolunteerStruct{},
+		[]int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity}, false},
+	{"GET " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "getVolunteer", "Get a volunteer of a schedule", nil, http.StatusOK, apiVolunteerStruct{},
+		nil, false},
+	{"PUT " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "updateVolunteer", "Replace a volunteer of a schedule", apiVolunteerStruct{}, http.StatusOK, apiVolunteerStruct{},
+		[]int{http.StatusBadRequest, http.StatusUnprocessableEntity}, false},
+	{"DELETE " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}", "deleteVolunteer", "Remove a volunteer from a schedule", nil, http.StatusNoContent, nil,
+		[]int{http.StatusUnprocessableEntity}, false},
+	{"GET " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability", "getUnavailability", "Get when a volunteer is unavailable", nil, http.StatusOK,
+		apiUnavailabilityStruct{}, nil, false},
+	{"PUT " + apiPrefix + "/schedules/{schedule}/volunteers/{volunteer}/unavailability", "updateUnavailability", "Replace when a volunteer is unavailable", apiUnavailabilityStruct{},
+		http.StatusOK, apiUnavailabilityStruct{}, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, false},
+	{"GET " + apiPrefix + "/schedules/{schedule}/assignments", "getAssignments", "Get the saved assignments of a schedule", nil, http.StatusOK, apiAssignmentsStruct{},
+		nil, false},
+	{"PUT " + apiPrefix + "/schedules/{schedule}/assignments", "updateAssignments", "Replace the saved assignments of a schedule", apiAssignmentsStruct{}, http.StatusOK,
+		apiAssignmentsStruct{}, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, false},
+	{"DELETE " + apiPrefix + "/schedules/{schedule}/assignments", "deleteAssignments", "Clear the saved assignments of a schedule", nil, http.StatusNoContent, nil,
+		nil, false},
+	{"POST " + apiPrefix + "/schedules/{schedule}/assignments/generate", "generateAssignments", "Generate assignments for a schedule without saving them", nil, http.StatusOK,
+		apiGeneratedStruct{}, []int{http.StatusUnprocessableEntity}, false},
+}
+
+// openAPISchemaNames names the schemas of the types whose Go names would mean nothing to an integrator. Other structs are named after their type.
+var openAPISchemaNames = map[reflect.Type]string{
+	reflect.TypeOf(vsadb.SendReceiveDataStruct{}): "Schedule",
+	reflect.TypeOf(apiVolunteerStruct{}):          "Volunteer",
+	reflect.TypeOf(apiUnavailabilityStruct{}):     "Unavailability",
+	reflect.TypeOf(apiAssignmentsStruct{}):        "Assignments",
+	reflect.TypeOf(apiGeneratedStruct{}):          "GeneratedSchedule",
+	reflect.TypeOf(apiErrorStruct{}):              "Error",
+}
+
+var pathParameterRegex = regexp.MustCompile(`{([^}]+)}`)
+
+// openAPISpec returns the OpenAPI document of the /api/v1 routes in apiOperations, ready to be marshaled to JSON.
+func openAPISpec() map[string]any {
+	schemas := map[string]any{}
+	errorSchema := openAPISchema(reflect.TypeOf(apiErrorStruct{}), schemas)
+	responses := map[string]any{}
+	paths := map[string]any{}
+	for _, operation := range apiOperations {
+		method, path, _ := strings.Cut(operation.route, " ")
+		parameters := []any{}
+		for _, match := range pathParameterRegex.FindAllStringSubmatch(path, -1) {
+			parameters = append(parameters, map[string]any{"name": match[1], "in": "path", "required": true, "schema": map[string]any{"type": "string"}})
+		}
+		success := map[string]any{"description": http.StatusText(operation.status)}
+		if operation.response != nil {
+			success["content"] = map[string]any{"application/json": map[string]any{"schema": openAPISchema(reflect.TypeOf(operation.response), schemas)}}
+		}
+		if operation.status == http.StatusCreated {
+			success["headers"] = map[string]any{"Location": map[string]any{"description": "the URL of what was created", "schema": map[string]any{"type": "string"}}}
+		}
+		operationResponses := map[string]any{strconv.Itoa(operation.status): success}
+		errorStatuses := operation.errors
+		if !operation.public {
+			errorStatuses = append([]int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}, errorStatuses...)
+		}
+		for _, status := range errorStatuses {
+			name := strings.ReplaceAll(http.StatusText(status), " ", "")
+			responses[name] = map[string]any{"description": http.StatusText(status), "content": map[string]any{"application/json": map[string]any{"schema": errorSchema}}}
+			operationResponses[strconv.Itoa(status)] = map[string]any{"$ref": "#/components/responses/" + name}
+		}
+		details := map[string]any{"operationId": operation.operationID, "summary": operation.summary, "responses": operationResponses}
+		if operation.public {
+			details["security"] = []any{}
+		} else {
+			parameters = append(parameters, map[string]any{"$ref": "#/components/parameters/workspace"})
+		}
+		if len(parameters) > 0 {
+			details["parameters"] = parameters
+		}
+		if operation.request != nil {
+			details["requestBody"] = map[string]any{"required": true,
+				"content": map[string]any{"application/json": map[string]any{"schema": openAPISchema(reflect.TypeOf(operation.request), schemas)}}}
+		}
+		if _, ok := paths[path]; !ok {
+			paths[path] = map[string]any{}
+		}
+		paths[path].(map[string]any)[strings.ToLower(method)] = details
+	}
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{"title": apiRealm + " API", "version": apiVersion,
+			"description": "Schedules, their volunteers, and their assignments. A schedule sent to the API is checked and saved exactly like one saved from the page."},
+		"paths":    paths,
+		"security": []any{map[string]any{"basicAuth": []any{}}, map[string]any{"sessionCookie": []any{}}},
+		"components": map[string]any{
+			"schemas":   schemas,
+			"responses": responses,
+			"parameters": map[string]any{"workspace": map[string]any{"name": "workspace", "in": "query", "required": false, "schema": map[string]any{"type": "string"},
+				"description": "the user or organization whose schedules to use. The signed-in user's own by default"}},
+			"securitySchemes": map[string]any{
+				"basicAuth":     map[string]any{"type": "http", "scheme": "basic"},
+				"sessionCookie": map[string]any{"type": "apiKey", "in": "cookie", "name": sessionCookieName},
+			},
+		},
+	}
+}
+
+// openAPISchema returns the schema of values of t. Structs are added to schemas, described by their JSON fields, and referred to by name.
+func openAPISchema(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": openAPISchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": openAPISchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		name, ok := openAPISchemaNames[t]
+		if !ok {
+			name = t.Name()
+		}
+		if _, ok := schemas[name]; !ok {
+			schemas[name] = nil // claimed before the fields are read, so a struct that holds itself refers back here
+			properties := map[string]any{}
+			openAPIProperties(t, properties, schemas)
+			schemas[name] = map[string]any{"type": "object", "properties": properties}
+			if t == reflect.TypeOf(apiErrorStruct{}) { // the only schema with optional fields, as the API leaves nothing else out of its responses
+				schemas[name].(map[string]any)["required"] = []string{"status", "error"}
+			}
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{} // any value
+}
+
+// openAPIProperties adds the JSON fields of struct type t to properties the way encoding/json marshals them: embedded structs without a tag lend their fields, and "-" fields are left out.
+func openAPIProperties(t reflect.Type, properties map[string]any, schemas map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			openAPIProperties(field.Type, properties, schemas)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = openAPISchema(field.Type, schemas)
+	}
+}
+
+func handleAPISpec(w http.ResponseWriter, r *http.Request) {
+	//------------------------ UPDATE THIS WHEN COPYING, DUMMY ------------------------
+	handlerInfo := handlerInfoStruct{apiPrefix + "/openapi.json", "handleAPISpec", "GET"}
+	//---------------------------------------------------------------------------------
+	writeJSON(w, handlerInfo, http.StatusOK, openAPISpec())
+}
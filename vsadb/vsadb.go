@@ -2,29 +2,382 @@ package vsadb
 
 import (
 	"bufio"
+	"cmp"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const DbName = "./vsa.db"
 
+// ErrNotFound is wrapped by the error RequestSchedule (and so FetchAndSendScheduleData) returns when no schedule matches, so callers can tell a missing schedule from a failed query.
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalidCredentials is returned by AuthenticateUser for an unknown user name or a wrong password alike, so a failed sign-in does not reveal which user names exist.
+var ErrInvalidCredentials = errors.New("invalid user name or password")
+
+// ErrUserExists is wrapped by the error CreateUser returns when the user name is already taken.
+var ErrUserExists = errors.New("user already exists")
+
+// MinPasswordLength and MaxPasswordLength bound the passwords CreateUser accepts. bcrypt only uses the first 72 bytes of a password, so longer ones are refused rather than silently cut short.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// SessionLifetime is how long a session from CreateSession stays signed in.
+const SessionLifetime = 14 * 24 * time.Hour
+
+// ErrLastOwner is wrapped by the error SaveMembership and DeleteMembership return when the change would leave an organization without an owner.
+var ErrLastOwner = errors.New("an organization needs at least one owner")
+
+// the roles a member can have in an organization, from least to most allowed. Viewers can look at schedules, editors can also change them, and owners can also manage the members.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+// Roles lists every role, from least to most allowed.
+var Roles = []string{RoleViewer, RoleEditor, RoleOwner}
+
+// RoleAllows reports whether a member with role can do what needs at least the needed role.
+func RoleAllows(role string, needed string) bool {
+	roleRank := slices.Index(Roles, role)
+	return roleRank >= 0 && roleRank >= slices.Index(Roles, needed)
+}
+
 type SampleEnv struct { //define in main module
 	Sample       VSAModel //would need to reference submodule with ".", i.e. models.SampleModel.
 	LoggedInUser string
 }
 
+// Store is everything the app needs from the database, so main does not depend on which one it runs on. VSAModel implements it on SQLite and on PostgreSQL. The CRUD methods
+// for single tables work on vsadb's own row types and are left out, since callers outside vsadb go through RecieveAndStoreData and FetchAndSendScheduleData instead.
+type Store interface {
+	MigrateDatabase() (from int, to int, err error)
+	Close() error
+	CreateUser(userName string, password string) error
+	ClaimUser(userName string, password string) error
+	RequestUnclaimedUsers() ([]string, error)
+	AuthenticateUser(userName string, password string) error
+	EnsureUser(userName string) error
+	CreateSession(userName string) (string, error)
+	RequestSessionUser(token string) (string, error)
+	DeleteSession(token string) error
+	EnsureCalendarFeed(currentUser string, scheduleName string, volunteerName string) (string, error)
+	RequestCalendarFeedToken(currentUser string, scheduleName string, volunteerName string) (string, error)
+	RequestCalendarFeed(token string) (CalendarFeed, error)
+	DeleteCalendarFeeds(currentUser string, scheduleName string) error
+	CreateOrganization(currentUser string, organizationName string) error
+	RequestWorkspaces(currentUser string) ([]Membership, error)
+	RequestWorkspaceRole(currentUser string, workspace string) (string, error)
+	RequestMembers(organization string) ([]Membership, error)
+	SaveMembership(organization string, member string, role string) error
+	DeleteMembership(organization string, member string) error
+	SendScheduleNames(currentUser string, sorted bool) ([]string, error)
+	FetchAndSendScheduleData(currentUser string, selectedSchedule string) (SendReceiveDataStruct, error)
+	RecieveAndStoreData(currentUser string, data SendReceiveDataStruct, bNewSchedule bool) error
+	RecieveAndDeleteData(currentUser string, data SendReceiveDataStruct) error
+}
+
+var _ Store = VSAModel{}
+
 type VSAModel struct { //define in submodule for db model
-	DB *sql.DB
+	DB      *sql.DB
+	dialect dialect // nil means SQLite, so a VSAModel{DB: db} over a SQLite database keeps working
+	tx      *sql.Tx // set on the copy inTransaction hands to its callback so every CRUD method joins the one transaction
+}
+
+// Open connects to the database named by dsn. A postgres:// or postgresql:// URL selects PostgreSQL, anything else is taken as the path of a SQLite file, which is created if it does not exist.
+// A SQLite path can carry driver options after a ?, like vsa.db?_busy_timeout=5000, and foreign keys are turned on whatever they say.
+func Open(dsn string) (VSAModel, error) {
+	var d dialect = sqliteDialect{}
+	dataSourceName := dsn
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		d = postgresDialect{}
+	} else {
+		// only the part after the first ? is parsed, since a file path is not a URL and may contain characters a URL cannot
+		path, query, _ := strings.Cut(dsn, "?")
+		options, err := url.ParseQuery(query)
+		if err != nil {
+			return VSAModel{}, fmt.Errorf("error in Open: the options after ? in the SQLite path are not a query string: %w", err)
+		}
+		options.Set("_foreign_keys", "on")
+		options.Del("_fk") // the driver's other name for _foreign_keys
+		dataSourceName = fmt.Sprintf("%s?%s", path, options.Encode())
+	}
+	db, err := sql.Open(d.driverName(), dataSourceName)
+	if err != nil {
+		return VSAModel{}, fmt.Errorf("error in Open: sql.Open error: %w", err)
+	}
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return VSAModel{}, fmt.Errorf("error in Open: sql.DB.Ping error: %w", err)
+	}
+	return VSAModel{DB: db, dialect: d}, nil
+}
+
+func (vsam VSAModel) Close() error {
+	return vsam.DB.Close()
+}
+
+func (vsam VSAModel) sqlDialect() dialect {
+	if vsam.dialect == nil {
+		return sqliteDialect{}
+	}
+	return vsam.dialect
+}
+
+// dialect is what differs between the databases VSAModel runs on. The queries and schema in this file are written for SQLite, and rebind rewrites them for the database in use.
+type dialect interface {
+	driverName() string
+	rebind(query string) string
+	tableExistsQuery() string
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) driverName() string { return "sqlite3" }
+
+func (sqliteDialect) rebind(query string) string { return query }
+
+func (sqliteDialect) tableExistsQuery() string {
+	return `select count(*) from sqlite_master where type = 'table' and name = ?`
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) driverName() string { return "postgres" }
+
+// postgresSchemaReplacer swaps the SQLite-only parts of the schema for their PostgreSQL equivalents.
+var postgresSchemaReplacer = strings.NewReplacer(
+	"integer primary key autoincrement", "integer primary key generated by default as identity",
+	") without rowid", ")",
+	"blob(64)", "bytea",
+)
+
+// rebind numbers the ? placeholders ($1, $2, ...), turns SQLite's double-quoted string literals into single-quoted ones, and quotes the User column since user is a reserved word in PostgreSQL.
+func (postgresDialect) rebind(query string) string {
+	query = postgresSchemaReplacer.Replace(query)
+	var b strings.Builder
+	placeholder := 0
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := i + 1
+			for end < len(query) {
+				if query[end] == c {
+					if end+1 < len(query) && query[end+1] == c { // a doubled quote inside the literal
+						end += 2
+						continue
+					}
+					end++
+					break
+				}
+				end++
+			}
+			if c == '"' && end-i >= 2 && query[end-1] == '"' {
+				literal := strings.ReplaceAll(query[i+1:end-1], `""`, `"`)
+				b.WriteString("'" + strings.ReplaceAll(literal, "'", "''") + "'")
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		case c == '?':
+			placeholder++
+			fmt.Fprintf(&b, "$%d", placeholder)
+			i++
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			end := i
+			for end < len(query) && (query[end] == '_' || 'a' <= query[end] && query[end] <= 'z' || 'A' <= query[end] && query[end] <= 'Z' || '0' <= query[end] && query[end] <= '9') {
+				end++
+			}
+			if query[i:end] == "User" {
+				b.WriteString(`"User"`)
+			} else {
+				b.WriteString(query[i:end])
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+func (postgresDialect) tableExistsQuery() string {
+	return `select count(*) from information_schema.tables where table_schema = current_schema() and table_name = lower(?)`
+}
+
+// querier is the subset of *sql.DB and *sql.Tx the CRUD methods read through.
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// dialectQuerier rebinds every statement for the database before handing it on.
+type dialectQuerier struct {
+	q querier
+	d dialect
+}
+
+func (dq dialectQuerier) Exec(query string, args ...any) (sql.Result, error) {
+	return dq.q.Exec(dq.d.rebind(query), args...)
+}
+
+func (dq dialectQuerier) Prepare(query string) (*sql.Stmt, error) {
+	return dq.q.Prepare(dq.d.rebind(query))
+}
+
+func (dq dialectQuerier) Query(query string, args ...any) (*sql.Rows, error) {
+	return dq.q.Query(dq.d.rebind(query), args...)
+}
+
+func (dq dialectQuerier) QueryRow(query string, args ...any) *sql.Row {
+	return dq.q.QueryRow(dq.d.rebind(query), args...)
+}
+
+// querier returns the open transaction when vsam is bound to one, and the database otherwise.
+func (vsam VSAModel) querier() querier {
+	if vsam.tx != nil {
+		return dialectQuerier{q: vsam.tx, d: vsam.sqlDialect()}
+	}
+	return dialectQuerier{q: vsam.DB, d: vsam.sqlDialect()}
+}
+
+// modelTx is the transaction a CRUD method writes through. When the method runs inside inTransaction it is the outer transaction, and Commit and Rollback are left to inTransaction.
+type modelTx struct {
+	*sql.Tx
+	dialectQuerier
+	nested bool
+}
+
+// these resolve the ambiguity between the embedded *sql.Tx and dialectQuerier in favor of the rebinding ones
+func (mtx modelTx) Exec(query string, args ...any) (sql.Result, error) {
+	return mtx.dialectQuerier.Exec(query, args...)
+}
+
+func (mtx modelTx) Prepare(query string) (*sql.Stmt, error) {
+	return mtx.dialectQuerier.Prepare(query)
+}
+
+func (mtx modelTx) Query(query string, args ...any) (*sql.Rows, error) {
+	return mtx.dialectQuerier.Query(query, args...)
+}
+
+func (mtx modelTx) QueryRow(query string, args ...any) *sql.Row {
+	return mtx.dialectQuerier.QueryRow(query, args...)
+}
+
+func (mtx modelTx) Commit() error {
+	if mtx.nested {
+		return nil
+	}
+	return mtx.Tx.Commit()
+}
+
+func (mtx modelTx) Rollback() error {
+	if mtx.nested {
+		return nil
+	}
+	return mtx.Tx.Rollback()
+}
+
+// begin starts a transaction for a single CRUD method, or joins the one vsam is bound to.
+func (vsam VSAModel) begin() (modelTx, error) {
+	if vsam.tx != nil {
+		return modelTx{Tx: vsam.tx, dialectQuerier: dialectQuerier{q: vsam.tx, d: vsam.sqlDialect()}, nested: true}, nil
+	}
+	tx, err := vsam.DB.Begin()
+	if err != nil {
+		return modelTx{}, err
+	}
+	return modelTx{Tx: tx, dialectQuerier: dialectQuerier{q: tx, d: vsam.sqlDialect()}}, nil
+}
+
+// inTransaction runs fn with a copy of vsam bound to one transaction, committing it when fn returns nil and rolling everything fn wrote back otherwise. If vsam is already bound to a transaction fn simply joins it.
+func (vsam VSAModel) inTransaction(fn func(txModel VSAModel) error) error {
+	if vsam.tx != nil {
+		return fn(vsam)
+	}
+	tx, err := vsam.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("error in inTransaction: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	err = fn(VSAModel{DB: vsam.DB, dialect: vsam.dialect, tx: tx})
+	if err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in inTransaction: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// inTransactionWithoutForeignKeys is inTransaction for a migration that rebuilds tables. SQLite only lets a referenced table be dropped and recreated with foreign keys off, and that cannot be changed inside a transaction, so it holds one connection, turns them off around the transaction, and checks nothing was left dangling before committing.
+func (vsam VSAModel) inTransactionWithoutForeignKeys(fn func(txModel VSAModel) error) error {
+	if _, ok := vsam.sqlDialect().(sqliteDialect); !ok || vsam.tx != nil {
+		return vsam.inTransaction(fn)
+	}
+	ctx := context.Background()
+	conn, err := vsam.DB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: sql.DB.Conn error: %w", err)
+	}
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, `pragma foreign_keys = off`)
+	if err != nil {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: sql.Conn.ExecContext error: %w", err)
+	}
+	defer conn.ExecContext(ctx, `pragma foreign_keys = on`)
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: sql.Conn.BeginTx error: %w", err)
+	}
+	defer tx.Rollback()
+	err = fn(VSAModel{DB: vsam.DB, dialect: vsam.dialect, tx: tx})
+	if err != nil {
+		return err
+	}
+	rows, err := tx.Query(`pragma foreign_key_check`)
+	if err != nil {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: sql.Tx.Query error: %w", err)
+	}
+	bViolation := rows.Next()
+	rows.Close()
+	if bViolation {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: method failed because the rebuilt tables break a foreign key")
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in inTransactionWithoutForeignKeys: sql.Tx.Commit error: %w", err)
+	}
+	return nil
 }
 
 type weekday struct {
@@ -38,7 +391,6 @@ type month struct {
 }
 
 type date struct {
-	DateID  int
 	Month   int
 	Day     int
 	Year    int
@@ -54,6 +406,8 @@ type volunteer struct {
 	VolunteerID   int
 	VolunteerName string
 	User          string
+	Email         string // "" when not known
+	Phone         string // "" when not known
 }
 
 type schedule struct {
@@ -62,8 +416,8 @@ type schedule struct {
 	ShiftsOff          int
 	VolunteersPerShift int
 	User               string
-	StartDate          int
-	EndDate            int
+	StartDate          string // dates are stored as ISO 8601 text (YYYY-MM-DD), which sorts in date order
+	EndDate            string
 }
 
 type weekdayForSchedule struct {
@@ -84,30 +438,185 @@ type unavailabilityForSchedule struct {
 	UFSID                int
 	User                 string
 	VolunteerForSchedule int
-	Date                 int
+	Date                 string
+}
+
+type timeSlotForSchedule struct {
+	TSFSID             int
+	User               string
+	Schedule           int
+	Weekday            string
+	SlotName           string
+	SlotOrder          int
+	VolunteersPerShift int
+}
+
+type role struct {
+	RoleID   int
+	RoleName string
+	User     string
+}
+
+type volunteerRole struct {
+	VRID      int
+	User      string
+	Volunteer int
+	Role      int
+}
+
+type roleForSchedule struct {
+	RFSID              int
+	User               string
+	Schedule           int
+	Role               int
+	RoleOrder          int
+	VolunteersPerShift int
+}
+
+type pairingForSchedule struct {
+	PFSID                      int
+	User                       string
+	VolunteerForSchedule       int
+	PairedVolunteerForSchedule int
+	Pairing                    string
+}
+
+type unavailabilityRuleForSchedule struct {
+	URFSID               int
+	User                 string
+	VolunteerForSchedule int
+	Rule                 string
+}
+
+type preferenceForSchedule struct {
+	PrFSID               int
+	User                 string
+	VolunteerForSchedule int
+	Preference           string
+}
+
+type dateRangeForSchedule struct {
+	DRFSID               int
+	User                 string
+	VolunteerForSchedule int
+	StartDate            string
+	EndDate              string
 }
 
 type scheduledVolunteerOnDate struct {
 	SVODID               int
 	User                 string
 	VolunteerForSchedule int
-	Date                 int
+	Date                 string
+	TimeSlot             string
+	Role                 string
+}
+
+// TimeSlot is one named shift on a weekday, e.g. the 8am service on Sundays.
+type TimeSlot struct {
+	Name               string `json:"name"`
+	VolunteersPerShift int    `json:"volunteersPerShift"`
+}
+
+// RoleRequirement is the number of volunteers qualified for a role that every shift of a schedule needs, e.g. two ushers.
+type RoleRequirement struct {
+	Name               string `json:"name"`
+	VolunteersPerShift int    `json:"volunteersPerShift"`
+}
+
+// Pairing values used in VolunteerPairing
+const (
+	PairingTogether = "together"
+	PairingApart    = "apart"
+)
+
+// VolunteerPairing is a hard constraint between two volunteers on a schedule. PairingTogether volunteers always serve the same shifts and PairingApart volunteers never serve on the same date.
+type VolunteerPairing struct {
+	Volunteer       string `json:"volunteer"`
+	PairedVolunteer string `json:"pairedVolunteer"`
+	Pairing         string `json:"pairing"` // PairingTogether or PairingApart
+}
+
+// VolunteerContact is how to reach a volunteer. It belongs to the volunteer rather than to a schedule, so every schedule of the workspace with the volunteer shares it.
+type VolunteerContact struct {
+	Email string `json:"email"` // "" when not known
+	Phone string `json:"phone"` // "" when not known
+}
+
+// DateRange is the days from Start through End, e.g. a volunteer's vacation.
+type DateRange struct {
+	Start string `json:"start"` // YYYY-MM-DD
+	End   string `json:"end"`   // YYYY-MM-DD, not before Start
+}
+
+// Kind values used in UnavailabilityRule
+const (
+	RuleWeekdayOfMonth = "weekday of month"
+	RuleMonth          = "month"
+	RuleDateRange      = "date range"
+	RuleEveryNWeeks    = "every n weeks"
+)
+
+// UnavailabilityRule is a recurring pattern of dates a volunteer cannot serve. VolunteerUnavailabilityRuleData stores UnavailabilityRules as strings made by ToString:
+// "1st Sunday" (or "last Sunday") of every month, every day in "July", every day from "2024-07-01 to 2024-07-14", or the week of 2024-01-07 and every second week after it ("every 2 weeks from 2024-01-07").
+type UnavailabilityRule struct {
+	Kind    string // RuleWeekdayOfMonth, RuleMonth, RuleDateRange, or RuleEveryNWeeks
+	Weekday string // full weekday name, for RuleWeekdayOfMonth
+	Ordinal int    // 1 through 5 for the first through fifth Weekday of the month, or -1 for the last one, for RuleWeekdayOfMonth
+	Month   string // full month name, for RuleMonth
+	Start   string // YYYY-MM-DD, for RuleDateRange and RuleEveryNWeeks
+	End     string // YYYY-MM-DD, for RuleDateRange
+	Weeks   int    // at least 1, for RuleEveryNWeeks
+}
+
+// Kind values used in VolunteerPreference
+const (
+	PreferenceWeekday   = "weekday"
+	PreferenceDate      = "date"
+	PreferenceMaxShifts = "max shifts"
+)
+
+// VolunteerPreference is a wish about when or how often a volunteer serves. Unlike unavailability it may be broken when the schedule cannot be filled otherwise. VolunteerPreferenceData stores
+// VolunteerPreferences as strings made by ToString: serving on a weekday ("Saturday"), serving on a date ("2024-03-31"), or serving "at most 2 shifts" on the schedule. A preference that matters
+// more than the volunteer's others ends with its Weight ("Saturday x3").
+type VolunteerPreference struct {
+	Kind      string // PreferenceWeekday, PreferenceDate, or PreferenceMaxShifts
+	Weekday   string // full weekday name, for PreferenceWeekday
+	Date      string // YYYY-MM-DD, for PreferenceDate
+	MaxShifts int    // at least 0, for PreferenceMaxShifts
+	Weight    int    // at least 1
+}
+
+// ShiftKey identifies a single shift: a date plus, when the date's weekday has time slots, the name of the slot. VolunteerScheduledData stores ShiftKeys as strings made by ToString,
+// with Role set to the role the volunteer fills on that shift (empty for a volunteer who is not filling a role).
+type ShiftKey struct {
+	Date     string // YYYY-MM-DD
+	TimeSlot string // empty when the weekday has a single unnamed shift
+	Role     string // empty when the volunteer is not filling a role
 }
 
 type SendReceiveDataStruct struct {
-	ScheduleName                string
-	ShiftsOff                   int
-	VolunteersPerShift          int
-	User                        string
-	StartDate                   string
-	EndDate                     string
-	WeekdaysForSchedule         []string
-	VolunteerUnavailabilityData map[string][]string
-	VolunteerScheduledData      map[string][]string
+	ScheduleName                     string                      `json:"scheduleName"`
+	ShiftsOff                        int                         `json:"shiftsOff"`
+	VolunteersPerShift               int                         `json:"volunteersPerShift"`
+	User                             string                      `json:"user"`
+	StartDate                        string                      `json:"startDate"`
+	EndDate                          string                      `json:"endDate"`
+	WeekdaysForSchedule              []string                    `json:"weekdays"`
+	TimeSlotsForSchedule             map[string][]TimeSlot       `json:"timeSlots"`                     // full weekday name to the time slots on that weekday in order. Weekdays without time slots have one shift of VolunteersPerShift volunteers
+	RolesForSchedule                 []RoleRequirement           `json:"roles"`                         // roles every shift needs, in order. A shift needing more volunteers than its roles add up to fills the rest with any volunteer
+	VolunteerRoleData                map[string][]string         `json:"volunteerRoles"`                // volunteer name to the names of the roles the volunteer is qualified for
+	VolunteerPairingData             []VolunteerPairing          `json:"volunteerPairings"`             // pairings between the volunteers of the schedule, each pair listed once
+	VolunteerUnavailabilityData      map[string][]string         `json:"volunteerUnavailability"`       // volunteer name to the dates the volunteer is unavailable. Every volunteer of the schedule is a key, even without dates
+	VolunteerUnavailabilityRuleData  map[string][]string         `json:"volunteerUnavailabilityRules"`  // volunteer name to UnavailabilityRule strings, for dates the volunteer is unavailable on top of those in VolunteerUnavailabilityData
+	VolunteerUnavailabilityRangeData map[string][]DateRange      `json:"volunteerUnavailabilityRanges"` // volunteer name to the date ranges the volunteer is away for (sorted by Start), on top of the dates in VolunteerUnavailabilityData
+	VolunteerPreferenceData          map[string][]string         `json:"volunteerPreferences"`          // volunteer name to VolunteerPreference strings
+	VolunteerScheduledData           map[string][]string         `json:"volunteerAssignments"`          // volunteer name to ShiftKey strings
+	VolunteerContactData             map[string]VolunteerContact `json:"volunteerContacts"`             // volunteer name to the volunteer's email address and phone number. Volunteers without either are left out
 }
 
 func (d date) ToString() string {
-	return fmt.Sprintf("%d-%02d-%02d", d.Year, d.Month, d.Day)
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
 }
 
 func (d date) FromString(str string) (date, error) {
@@ -121,6 +630,203 @@ func (d date) FromString(str string) (date, error) {
 	return d, nil
 }
 
+// checkDates returns an error for the first of dates that is set but is not an ISO 8601 date (YYYY-MM-DD). The database compares and sorts dates as text, which only keeps them in date order in that format.
+func checkDates(dates ...string) error {
+	for _, dateString := range dates {
+		if dateString == "" {
+			continue
+		}
+		if _, err := (date{}).FromString(dateString); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShiftKeySeparator separates the date, time slot, and role in a ShiftKey string, so it cannot be used in time slot or role names.
+const ShiftKeySeparator = "|"
+
+func (k ShiftKey) ToString() string {
+	if k.Role != "" {
+		return strings.Join([]string{k.Date, k.TimeSlot, k.Role}, ShiftKeySeparator)
+	}
+	if k.TimeSlot == "" {
+		return k.Date
+	}
+	return fmt.Sprintf("%s%s%s", k.Date, ShiftKeySeparator, k.TimeSlot)
+}
+
+func (k ShiftKey) FromString(str string) (ShiftKey, error) {
+	parts := strings.SplitN(str, ShiftKeySeparator, 3)
+	if _, err := time.Parse("2006-01-02", parts[0]); err != nil {
+		return ShiftKey{}, fmt.Errorf("error in FromString: \"%s\" does not start with a valid date (YYYY-MM-DD): %w", str, err)
+	}
+	k = ShiftKey{Date: parts[0]}
+	if len(parts) > 1 {
+		k.TimeSlot = parts[1]
+	}
+	if len(parts) > 2 {
+		if parts[2] == "" || strings.Contains(parts[2], ShiftKeySeparator) {
+			return ShiftKey{}, fmt.Errorf("error in FromString: \"%s\" has more than two %s or an empty role", str, ShiftKeySeparator)
+		}
+		k.Role = parts[2]
+	}
+	return k, nil
+}
+
+// Shift returns k without its Role, which identifies the shift itself rather than one volunteer's place on it.
+func (k ShiftKey) Shift() ShiftKey {
+	k.Role = ""
+	return k
+}
+
+var ruleOrdinals = map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 5: "5th", -1: "last"}
+
+func (r UnavailabilityRule) ToString() string {
+	switch r.Kind {
+	case RuleWeekdayOfMonth:
+		return fmt.Sprintf("%s %s", ruleOrdinals[r.Ordinal], r.Weekday)
+	case RuleMonth:
+		return r.Month
+	case RuleDateRange:
+		return fmt.Sprintf("%s to %s", r.Start, r.End)
+	case RuleEveryNWeeks:
+		return fmt.Sprintf("every %d weeks from %s", r.Weeks, r.Start)
+	}
+	return ""
+}
+
+// FromString parses the strings made by ToString. Weekday and month names and the words of the rule are not case sensitive, the ordinals can also be spelled out ("first Sunday"),
+// and "every other week from 2024-01-07" is the same as "every 2 weeks from 2024-01-07".
+func (r UnavailabilityRule) FromString(str string) (UnavailabilityRule, error) {
+	fields := strings.Fields(strings.ToLower(str))
+	titleCase := func(word string) string { return strings.ToUpper(word[:1]) + word[1:] }
+	switch {
+	case len(fields) == 1:
+		if _, err := time.Parse("January", titleCase(fields[0])); err == nil {
+			return UnavailabilityRule{Kind: RuleMonth, Month: titleCase(fields[0])}, nil
+		}
+	case len(fields) == 2:
+		ordinal := slices.Index([]string{"", "1st", "2nd", "3rd", "4th", "5th"}, fields[0])
+		if ordinal == -1 {
+			ordinal = slices.Index([]string{"", "first", "second", "third", "fourth", "fifth"}, fields[0])
+		}
+		if fields[0] == "last" {
+			ordinal = -1
+		} else if ordinal < 1 {
+			break
+		}
+		if _, err := time.Parse("Monday", titleCase(fields[1])); err == nil {
+			return UnavailabilityRule{Kind: RuleWeekdayOfMonth, Weekday: titleCase(fields[1]), Ordinal: ordinal}, nil
+		}
+	case len(fields) == 3 && fields[1] == "to":
+		start, err := time.Parse("2006-01-02", fields[0])
+		if err != nil {
+			return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" does not start with a valid date (YYYY-MM-DD): %w", str, err)
+		}
+		end, err := time.Parse("2006-01-02", fields[2])
+		if err != nil {
+			return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" does not end with a valid date (YYYY-MM-DD): %w", str, err)
+		}
+		if end.Before(start) {
+			return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" ends before it starts", str)
+		}
+		return UnavailabilityRule{Kind: RuleDateRange, Start: fields[0], End: fields[2]}, nil
+	case len(fields) == 5 && fields[0] == "every" && (fields[2] == "weeks" || fields[2] == "week") && fields[3] == "from":
+		weeks, err := strconv.Atoi(fields[1])
+		if fields[1] == "other" {
+			weeks, err = 2, nil
+		}
+		if err != nil || weeks < 1 {
+			return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" does not have a whole number of weeks of at least 1", str)
+		}
+		if _, err := time.Parse("2006-01-02", fields[4]); err != nil {
+			return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" does not end with a valid date (YYYY-MM-DD): %w", str, err)
+		}
+		return UnavailabilityRule{Kind: RuleEveryNWeeks, Start: fields[4], Weeks: weeks}, nil
+	}
+	return UnavailabilityRule{}, fmt.Errorf("error in FromString: \"%s\" is not an unavailability rule (like \"1st Sunday\", \"last Sunday\", \"July\", \"2024-07-01 to 2024-07-14\", or \"every 2 weeks from 2024-01-07\")", str)
+}
+
+// Matches reports whether the volunteer is unavailable on day according to r. r must be valid, as returned by FromString.
+func (r UnavailabilityRule) Matches(day time.Time) bool {
+	switch r.Kind {
+	case RuleWeekdayOfMonth:
+		if day.Weekday().String() != r.Weekday {
+			return false
+		}
+		if r.Ordinal == -1 {
+			return day.AddDate(0, 0, 7).Month() != day.Month()
+		}
+		return (day.Day()-1)/7+1 == r.Ordinal
+	case RuleMonth:
+		return day.Month().String() == r.Month
+	case RuleDateRange:
+		return day.Format("2006-01-02") >= r.Start && day.Format("2006-01-02") <= r.End
+	case RuleEveryNWeeks:
+		start, err := time.Parse("2006-01-02", r.Start)
+		if err != nil || day.Before(start) {
+			return false
+		}
+		return int(day.Sub(start).Hours()/24)/7%r.Weeks == 0
+	}
+	return false
+}
+
+func (p VolunteerPreference) ToString() string {
+	var result string
+	switch p.Kind {
+	case PreferenceWeekday:
+		result = p.Weekday
+	case PreferenceDate:
+		result = p.Date
+	case PreferenceMaxShifts:
+		result = fmt.Sprintf("at most %d shifts", p.MaxShifts)
+		if p.MaxShifts == 1 {
+			result = "at most 1 shift"
+		}
+	default:
+		return ""
+	}
+	if p.Weight > 1 {
+		result = fmt.Sprintf("%s x%d", result, p.Weight)
+	}
+	return result
+}
+
+// FromString parses the strings made by ToString. Weekday names and the words of the preference are not case sensitive, "at most 2 shift" is the same as "at most 2 shifts",
+// and a preference without a Weight gets a Weight of 1.
+func (p VolunteerPreference) FromString(str string) (VolunteerPreference, error) {
+	fields := strings.Fields(strings.ToLower(str))
+	p = VolunteerPreference{Weight: 1}
+	if len(fields) > 1 && strings.HasPrefix(fields[len(fields)-1], "x") {
+		weight, err := strconv.Atoi(fields[len(fields)-1][1:])
+		if err != nil || weight < 1 {
+			return VolunteerPreference{}, fmt.Errorf("error in FromString: \"%s\" does not end with a whole number weight of at least 1 (like \"x2\")", str)
+		}
+		fields, p.Weight = fields[:len(fields)-1], weight
+	}
+	switch {
+	case len(fields) == 1:
+		if _, err := time.Parse("Monday", strings.ToUpper(fields[0][:1])+fields[0][1:]); err == nil {
+			p.Kind, p.Weekday = PreferenceWeekday, strings.ToUpper(fields[0][:1])+fields[0][1:]
+			return p, nil
+		}
+		if _, err := time.Parse("2006-01-02", fields[0]); err == nil {
+			p.Kind, p.Date = PreferenceDate, fields[0]
+			return p, nil
+		}
+	case len(fields) == 4 && fields[0] == "at" && fields[1] == "most" && (fields[3] == "shifts" || fields[3] == "shift"):
+		maxShifts, err := strconv.Atoi(fields[2])
+		if err != nil || maxShifts < 0 {
+			return VolunteerPreference{}, fmt.Errorf("error in FromString: \"%s\" does not have a whole number of shifts of at least 0", str)
+		}
+		p.Kind, p.MaxShifts = PreferenceMaxShifts, maxShifts
+		return p, nil
+	}
+	return VolunteerPreference{}, fmt.Errorf("error in FromString: \"%s\" is not a preference (like \"Saturday\", \"2024-03-31\", or \"at most 2 shifts\", optionally followed by a weight like \"x3\")", str)
+}
+
 func CsvSlice(stringSlice []string, trimQuotes bool) string {
 	jsonEncodedSlice, err := json.Marshal(stringSlice)
 	if err != nil {
@@ -151,6 +857,65 @@ func testEmpty[T comparable](sliceOfT []T, emptyT T) (bool, T) {
 	return false, emptyT
 }
 
+// sqlQuery is a SQL statement written with ? placeholders along with the arguments that fill them in. Names, dates, and every other value only ever travel in args, so a volunteer named
+// O"Brien or a schedule name full of SQL cannot break the statement or change what it does.
+type sqlQuery struct {
+	text string
+	args []any
+}
+
+// sqlMatch is the "Column = ?" conditions a row has to meet to match one of the structs passed to a Request method. Only the struct values that were provided get a condition.
+type sqlMatch struct {
+	conditions []string
+	args       []any
+}
+
+func newSQLQuery(text string, args ...any) sqlQuery {
+	return sqlQuery{text, args}
+}
+
+// add appends text, which brings its own placeholders, and the args that go with them
+func (q *sqlQuery) add(text string, args ...any) {
+	q.text = fmt.Sprintf("%s%s", q.text, text)
+	q.args = append(q.args, args...)
+}
+
+// addMatchAny appends connector and then a condition that holds for rows that match at least one of matches, like ` and ((VolunteerID = ?) or (VolunteerName = ? and User = ?))`.
+// Nothing is appended when matches is empty so the query is left matching everything.
+func (q *sqlQuery) addMatchAny(connector string, matches []sqlMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	groups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		groups = append(groups, fmt.Sprintf("(%s)", strings.Join(match.conditions, " and ")))
+		q.args = append(q.args, match.args...)
+	}
+	q.text = fmt.Sprintf("%s%s(%s)", q.text, connector, strings.Join(groups, " or "))
+}
+
+// addIn appends connector and then `column in (?, ?, ...)` with one placeholder per value. An empty values matches no rows.
+func (q *sqlQuery) addIn(connector string, column string, values []int) {
+	if len(values) == 0 {
+		q.text = fmt.Sprintf("%s%sfalse", q.text, connector)
+		return
+	}
+	q.text = fmt.Sprintf("%s%s%s in (%s)", q.text, connector, column, strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+	for _, value := range values {
+		q.args = append(q.args, value)
+	}
+}
+
+// String is the statement followed by its arguments, for error messages
+func (q sqlQuery) String() string {
+	return fmt.Sprintf("%s %v", q.text, q.args)
+}
+
+func (m *sqlMatch) equals(column string, value any) {
+	m.conditions = append(m.conditions, fmt.Sprintf("%s = ?", column))
+	m.args = append(m.args, value)
+}
+
 func Must[T any](value T, err error) T { // only to be used in main function testing code. Actual implementations need to handle the errors without crashing the program (unless the final step is to crash).
 	if err != nil {
 		log.Fatalf("Error from Must: %v", err)
@@ -158,2067 +923,5102 @@ func Must[T any](value T, err error) T { // only to be used in main function tes
 	return value
 }
 
-func (vsam VSAModel) CreateDatabase() error {
-	initialTxQuery := `
-	create table Weekdays (
-		WeekdayID integer primary key autoincrement,
-		WeekdayName text not null unique
-	);
-	create table Months (
-		MonthID integer primary key autoincrement,
-		MonthName text not null unique
-	);
-	create table Dates (
-		DateID integer primary key autoincrement,
-		Month integer not null check (Month > 0),
-		Day integer not null check (Day > 0),
-		Year integer not null check (Year > 0),
-		Weekday text not null,
-		foreign key (Month) references Months(MonthID),
-		foreign key (Weekday) references Weekdays(WeekdayName)
-	);
-	create table Users (
-		UserName text primary key,
-		Password blob(64)
-	) without rowid;
-	create table Volunteers (
-		VolunteerID integer primary key autoincrement,
-		VolunteerName text not null,
-		User text,
-		foreign key (User) references Users(UserName)
-	);
-	create table Schedules (
-		ScheduleID integer primary key autoincrement,
-		ScheduleName text not null,
-		ShiftsOff integer not null check (ShiftsOff > -1),
-		VolunteersPerShift integer not null check (VolunteersPerShift > 0),
-		User text,
-		StartDate integer check (StartDate > 0),
-		EndDate integer check (EndDate > 0),
-		foreign key (User) references Users(UserName),
-		foreign key (StartDate) references Dates(DateID),
-		foreign key (EndDate) references Dates(DateID)
-	);
-	create table WeekdaysForSchedule (
-		WFSID integer primary key autoincrement,
-		User text,
-		Weekday text,
-		Schedule integer,
-		foreign key (User) references Users(UserName),
-		foreign key (Weekday) references Weekdays(WeekdayName),
-		foreign key (Schedule) references Schedules(ScheduleID)
-	);
-	create table VolunteersForSchedule (
-		VFSID integer primary key autoincrement,
-		User text,
-		Schedule integer,
-		Volunteer integer,
-		foreign key (User) references Users(UserName),
-		foreign key (Schedule) references Schedules(ScheduleID),
-		foreign key (Volunteer) references Volunteers(VolunteerID)
-	);
-	create table UnavailabilitiesForSchedule (
-		UFSID integer primary key autoincrement,
-		User text,
-		VolunteerForSchedule integer,
-		Date integer,
-		foreign key (User) references Users(UserName),
-		foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
-		foreign key (Date) references Dates(DateID)
-	);
-	create table scheduledVolunteersOnDates (
-		SVODID integer primary key autoincrement,
-		User text,
-		VolunteerForSchedule integer,
-		Date integer,
-		foreign key (User) references Users(UserName),
-		foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
-		foreign key (Date) references Dates(DateID)
-	);
-	`
-	fillWeekdaysTxQuery := `insert into Weekdays (WeekdayName) values ("Sunday"), ("Monday"), ("Tuesday"), ("Wednesday"), ("Thursday"), ("Friday"), ("Saturday");`
-	fillMonthsTxQuery := `insert into Months (MonthName) values ("January"), ("February"), ("March"), ("April"), ("May"), ("June"), ("July"), ("August"), ("September"), ("October"), ("November"), ("December");`
-	tx, err := vsam.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.DB.Begin error: %w", err)
-	}
-	defer tx.Rollback() // this will still be executed if tx.Commit() is called, but it will return sql.ErrTxDone, which can be ignored
-	_, err = tx.Exec(initialTxQuery)
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Exec error: %w. Value of initialTxQuery is `%s`", err, initialTxQuery)
-	}
-	_, err = tx.Exec(fillWeekdaysTxQuery)
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Exec error: %w. Value of fillWeekdaysTxQuery is `%s`", err, fillWeekdaysTxQuery)
-	}
-	_, err = tx.Exec(fillMonthsTxQuery)
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Exec error: %w. Value of fillMonthsTxQuery is `%s`", err, fillMonthsTxQuery)
-	}
-	_, err = tx.Exec(`insert into Users (UserName) values ("Seth")`) // for testing only
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Exec error: %w. This one is to create a user for testing, and should not appear in production", err)
-	}
-	fillDatesTableString := `insert into Dates (Month, Day, Year, Weekday) values (?, ?, ?, ?)`
-	fillDatesTableStmt, err := tx.Prepare(fillDatesTableString)
-	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Prepare error: %w. Value of fillDatesTableString is `%s`", err, fillDatesTableString)
-	}
-	defer fillDatesTableStmt.Close()
-	initDate := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
-	for i := 0; i < 365.25*40; i++ {
-		workingDate := initDate.AddDate(0, 0, i)
-		dateStruct := date{Month: int(workingDate.Month()), Day: workingDate.Day(), Year: workingDate.Year(), Weekday: fmt.Sprint(workingDate.Weekday())}
-		_, err = fillDatesTableStmt.Exec(dateStruct.Month, dateStruct.Day, dateStruct.Year, dateStruct.Weekday)
+// schemaMigration is one step in building the vsa.db schema. Each one runs in its own transaction together with the update to SchemaVersion, so a database is only ever left at a version it fully reached.
+type schemaMigration struct {
+	Version     int
+	Description string
+	Table       string // a table this migration creates, used to work out the version of a database made before SchemaVersion existed. Left empty by migrations added after that
+	Rebuild     bool   // the migration drops and recreates tables other tables reference, which SQLite only allows with foreign keys off
+	Up          func(q querier) error
+	UpPostgres  func(q querier) error // run in place of Up on PostgreSQL, for the few migrations that cannot be written for both databases. Left nil by the rest
+}
+
+// schemaMigrations must stay in Version order starting at 1. Never edit a migration that has shipped, append a new one instead.
+var schemaMigrations = []schemaMigration{
+	{Version: 1, Description: "weekdays, months, dates, users, volunteers, schedules, and their unavailabilities", Table: "Schedules", Up: func(q querier) error {
+		initialTxQuery := `
+		create table Weekdays (
+			WeekdayID integer primary key autoincrement,
+			WeekdayName text not null unique
+		);
+		create table Months (
+			MonthID integer primary key autoincrement,
+			MonthName text not null unique
+		);
+		create table Dates (
+			DateID integer primary key autoincrement,
+			Month integer not null check (Month > 0),
+			Day integer not null check (Day > 0),
+			Year integer not null check (Year > 0),
+			Weekday text not null,
+			foreign key (Month) references Months(MonthID),
+			foreign key (Weekday) references Weekdays(WeekdayName)
+		);
+		create table Users (
+			UserName text primary key,
+			Password blob(64)
+		) without rowid;
+		create table Volunteers (
+			VolunteerID integer primary key autoincrement,
+			VolunteerName text not null,
+			User text,
+			foreign key (User) references Users(UserName)
+		);
+		create table Schedules (
+			ScheduleID integer primary key autoincrement,
+			ScheduleName text not null,
+			ShiftsOff integer not null check (ShiftsOff > -1),
+			VolunteersPerShift integer not null check (VolunteersPerShift > 0),
+			User text,
+			StartDate integer check (StartDate > 0),
+			EndDate integer check (EndDate > 0),
+			foreign key (User) references Users(UserName),
+			foreign key (StartDate) references Dates(DateID),
+			foreign key (EndDate) references Dates(DateID)
+		);
+		create table WeekdaysForSchedule (
+			WFSID integer primary key autoincrement,
+			User text,
+			Weekday text,
+			Schedule integer,
+			foreign key (User) references Users(UserName),
+			foreign key (Weekday) references Weekdays(WeekdayName),
+			foreign key (Schedule) references Schedules(ScheduleID)
+		);
+		create table VolunteersForSchedule (
+			VFSID integer primary key autoincrement,
+			User text,
+			Schedule integer,
+			Volunteer integer,
+			foreign key (User) references Users(UserName),
+			foreign key (Schedule) references Schedules(ScheduleID),
+			foreign key (Volunteer) references Volunteers(VolunteerID)
+		);
+		create table UnavailabilitiesForSchedule (
+			UFSID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			Date integer,
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
+			foreign key (Date) references Dates(DateID)
+		);
+		create table scheduledVolunteersOnDates (
+			SVODID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			Date integer,
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
+			foreign key (Date) references Dates(DateID)
+		);
+		`
+		fillWeekdaysTxQuery := `insert into Weekdays (WeekdayName) values ("Sunday"), ("Monday"), ("Tuesday"), ("Wednesday"), ("Thursday"), ("Friday"), ("Saturday");`
+		fillMonthsTxQuery := `insert into Months (MonthName) values ("January"), ("February"), ("March"), ("April"), ("May"), ("June"), ("July"), ("August"), ("September"), ("October"), ("November"), ("December");`
+		_, err := q.Exec(initialTxQuery)
 		if err != nil {
-			return fmt.Errorf("error in CreateDatabase: sql.Stmt.Exec error: %w. Value of dateStruct is `%+v`", err, dateStruct)
+			return fmt.Errorf("sql.Tx.Exec error: %w. Value of initialTxQuery is `%s`", err, initialTxQuery)
 		}
-	}
-	err = tx.Commit()
+		_, err = q.Exec(fillWeekdaysTxQuery)
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Exec error: %w. Value of fillWeekdaysTxQuery is `%s`", err, fillWeekdaysTxQuery)
+		}
+		_, err = q.Exec(fillMonthsTxQuery)
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Exec error: %w. Value of fillMonthsTxQuery is `%s`", err, fillMonthsTxQuery)
+		}
+		_, err = q.Exec(`insert into Users (UserName) values ("Seth")`) // for testing only
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Exec error: %w. This one is to create a user for testing, and should not appear in production", err)
+		}
+		fillDatesTableString := `insert into Dates (Month, Day, Year, Weekday) values (?, ?, ?, ?)`
+		fillDatesTableStmt, err := q.Prepare(fillDatesTableString)
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Prepare error: %w. Value of fillDatesTableString is `%s`", err, fillDatesTableString)
+		}
+		defer fillDatesTableStmt.Close()
+		initDate := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 365.25*40; i++ {
+			workingDate := initDate.AddDate(0, 0, i)
+			dateStruct := date{Month: int(workingDate.Month()), Day: workingDate.Day(), Year: workingDate.Year(), Weekday: fmt.Sprint(workingDate.Weekday())}
+			_, err = fillDatesTableStmt.Exec(dateStruct.Month, dateStruct.Day, dateStruct.Year, dateStruct.Weekday)
+			if err != nil {
+				return fmt.Errorf("sql.Stmt.Exec error: %w. Value of dateStruct is `%+v`", err, dateStruct)
+			}
+		}
+		return nil
+	}},
+	{Version: 2, Description: "named time slots", Table: "TimeSlotsForSchedule", Up: execMigration(`
+		create table TimeSlotsForSchedule (
+			TSFSID integer primary key autoincrement,
+			User text,
+			Schedule integer,
+			Weekday text,
+			SlotName text not null,
+			SlotOrder integer not null check (SlotOrder > 0),
+			VolunteersPerShift integer not null check (VolunteersPerShift > 0),
+			foreign key (User) references Users(UserName),
+			foreign key (Schedule) references Schedules(ScheduleID),
+			foreign key (Weekday) references Weekdays(WeekdayName)
+		);
+		alter table scheduledVolunteersOnDates add column TimeSlot text not null default "";
+		`)},
+	{Version: 3, Description: "volunteer roles", Table: "Roles", Up: execMigration(`
+		create table Roles (
+			RoleID integer primary key autoincrement,
+			RoleName text not null,
+			User text,
+			foreign key (User) references Users(UserName)
+		);
+		create table VolunteerRoles (
+			VRID integer primary key autoincrement,
+			User text,
+			Volunteer integer,
+			Role integer,
+			foreign key (User) references Users(UserName),
+			foreign key (Volunteer) references Volunteers(VolunteerID),
+			foreign key (Role) references Roles(RoleID)
+		);
+		create table RolesForSchedule (
+			RFSID integer primary key autoincrement,
+			User text,
+			Schedule integer,
+			Role integer,
+			RoleOrder integer not null check (RoleOrder > 0),
+			VolunteersPerShift integer not null check (VolunteersPerShift > 0),
+			foreign key (User) references Users(UserName),
+			foreign key (Schedule) references Schedules(ScheduleID),
+			foreign key (Role) references Roles(RoleID)
+		);
+		alter table scheduledVolunteersOnDates add column Role text not null default "";
+		`)},
+	{Version: 4, Description: "volunteer pairings", Table: "PairingsForSchedule", Up: execMigration(`
+		create table PairingsForSchedule (
+			PFSID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			PairedVolunteerForSchedule integer,
+			Pairing text not null check (Pairing in ("together", "apart")),
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
+			foreign key (PairedVolunteerForSchedule) references VolunteersForSchedule(VFSID)
+		);
+		`)},
+	{Version: 5, Description: "recurring unavailability rules", Table: "UnavailabilityRulesForSchedule", Up: execMigration(`
+		create table UnavailabilityRulesForSchedule (
+			URFSID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			Rule text not null,
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID)
+		);
+		`)},
+	{Version: 6, Description: "vacation date ranges", Table: "DateRangesForSchedule", Up: execMigration(`
+		create table DateRangesForSchedule (
+			DRFSID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			StartDate integer,
+			EndDate integer,
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID),
+			foreign key (StartDate) references Dates(DateID),
+			foreign key (EndDate) references Dates(DateID)
+		);
+		`)},
+	{Version: 7, Description: "soft volunteer preferences", Table: "PreferencesForSchedule", Up: execMigration(`
+		create table PreferencesForSchedule (
+			PrFSID integer primary key autoincrement,
+			User text,
+			VolunteerForSchedule integer,
+			Preference text not null,
+			foreign key (User) references Users(UserName),
+			foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID)
+		);
+		`)},
+	{Version: 8, Description: "ISO 8601 date columns in place of the Dates table", Rebuild: true, Up: execMigration(rebuildDatesSQLite), UpPostgres: migrateDatesInPlace},
+	{Version: 9, Description: "sign-in sessions", Up: execMigration(`
+		create table Sessions (
+			TokenHash text primary key,
+			User text not null,
+			Expires integer not null,
+			foreign key (User) references Users(UserName)
+		) without rowid;
+		`)},
+	{Version: 10, Description: "organizations and their members", Up: execMigration(`
+		create table Organizations (
+			OrganizationName text primary key,
+			foreign key (OrganizationName) references Users(UserName)
+		) without rowid;
+		create table Memberships (
+			MembershipID integer primary key autoincrement,
+			Organization text not null,
+			Member text not null,
+			Role text not null check (Role in ("viewer", "editor", "owner")),
+			foreign key (Organization) references Organizations(OrganizationName),
+			foreign key (Member) references Users(UserName),
+			unique (Organization, Member)
+		);
+		`)},
+	{Version: 11, Description: "volunteer email addresses and phone numbers", Up: execMigration(`
+		alter table Volunteers add column Email text not null default '';
+		alter table Volunteers add column Phone text not null default '';
+		`)},
+	{Version: 12, Description: "calendar feed tokens", Up: execMigration(`
+		create table CalendarFeeds (
+			Token text primary key,
+			User text not null,
+			Schedule integer not null,
+			Volunteer text not null default '',
+			foreign key (User) references Users(UserName),
+			foreign key (Schedule) references Schedules(ScheduleID),
+			unique (Schedule, Volunteer)
+		) without rowid;
+		`)},
+}
+
+// isoDateColumns are the columns migration 8 turns from DateIDs into ISO 8601 text.
+var isoDateColumns = []struct {
+	Table   string
+	Columns []string
+}{
+	{Table: "Schedules", Columns: []string{"StartDate", "EndDate"}},
+	{Table: "UnavailabilitiesForSchedule", Columns: []string{"Date"}},
+	{Table: "scheduledVolunteersOnDates", Columns: []string{"Date"}},
+	{Table: "DateRangesForSchedule", Columns: []string{"StartDate", "EndDate"}},
+}
+
+// migrateDatesInPlace is migration 8 for PostgreSQL, which can drop a column that has a foreign key, so each one is replaced by a text copy of itself. The copy goes to the end of the table, which is why RequestSVOD names its columns.
+func migrateDatesInPlace(q querier) error {
+	for _, table := range isoDateColumns {
+		for _, column := range table.Columns {
+			statements := fmt.Sprintf(`
+			alter table %[1]s add column %[2]sISO text;
+			update %[1]s set %[2]sISO = (select to_char(make_date(Year, Month, Day), 'YYYY-MM-DD') from Dates where DateID = %[2]s);
+			alter table %[1]s drop column %[2]s;
+			alter table %[1]s rename column %[2]sISO to %[2]s;
+			`, table.Table, column)
+			_, err := q.Exec(statements)
+			if err != nil {
+				return fmt.Errorf("error in migrateDatesInPlace: sql.Tx.Exec error: %w. Value of statements is `%s`", err, statements)
+			}
+		}
+	}
+	_, err := q.Exec(`drop table Dates`)
 	if err != nil {
-		return fmt.Errorf("error in CreateDatabase: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in migrateDatesInPlace: sql.Tx.Exec error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) SendScheduleNames(currentUser string, sorted bool) (result []string, err error) {
-	scheduleStructs, err := vsam.RequestSchedules(currentUser, []schedule{})
-	if err != nil {
-		return []string{}, fmt.Errorf("error in SendScheduleNames: %w", err)
-	}
-	for i := 0; i < len(scheduleStructs); i++ {
-		result = append(result, scheduleStructs[i].ScheduleName)
+// rebuildDatesSQLite is migration 8 for SQLite, which cannot drop a column that has a foreign key, so each table is copied into a new one with text date columns and swapped in.
+const rebuildDatesSQLite = `
+create table Schedules_new (
+	ScheduleID integer primary key autoincrement,
+	ScheduleName text not null,
+	ShiftsOff integer not null check (ShiftsOff > -1),
+	VolunteersPerShift integer not null check (VolunteersPerShift > 0),
+	User text,
+	StartDate text,
+	EndDate text,
+	foreign key (User) references Users(UserName)
+);
+insert into Schedules_new select ScheduleID, ScheduleName, ShiftsOff, VolunteersPerShift, User,
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = StartDate),
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = EndDate)
+	from Schedules;
+drop table Schedules;
+alter table Schedules_new rename to Schedules;
+create table UnavailabilitiesForSchedule_new (
+	UFSID integer primary key autoincrement,
+	User text,
+	VolunteerForSchedule integer,
+	Date text,
+	foreign key (User) references Users(UserName),
+	foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID)
+);
+insert into UnavailabilitiesForSchedule_new select UFSID, User, VolunteerForSchedule,
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = Date)
+	from UnavailabilitiesForSchedule;
+drop table UnavailabilitiesForSchedule;
+alter table UnavailabilitiesForSchedule_new rename to UnavailabilitiesForSchedule;
+create table scheduledVolunteersOnDates_new (
+	SVODID integer primary key autoincrement,
+	User text,
+	VolunteerForSchedule integer,
+	Date text,
+	TimeSlot text not null default "",
+	Role text not null default "",
+	foreign key (User) references Users(UserName),
+	foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID)
+);
+insert into scheduledVolunteersOnDates_new select SVODID, User, VolunteerForSchedule,
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = Date),
+	TimeSlot, Role
+	from scheduledVolunteersOnDates;
+drop table scheduledVolunteersOnDates;
+alter table scheduledVolunteersOnDates_new rename to scheduledVolunteersOnDates;
+create table DateRangesForSchedule_new (
+	DRFSID integer primary key autoincrement,
+	User text,
+	VolunteerForSchedule integer,
+	StartDate text,
+	EndDate text,
+	foreign key (User) references Users(UserName),
+	foreign key (VolunteerForSchedule) references VolunteersForSchedule(VFSID)
+);
+insert into DateRangesForSchedule_new select DRFSID, User, VolunteerForSchedule,
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = StartDate),
+	(select printf('%04d-%02d-%02d', Year, Month, Day) from Dates where DateID = EndDate)
+	from DateRangesForSchedule;
+drop table DateRangesForSchedule;
+alter table DateRangesForSchedule_new rename to DateRangesForSchedule;
+drop table Dates;
+`
+
+// execMigration is the Up of a migration that is nothing but schema statements.
+func execMigration(statements string) func(q querier) error {
+	return func(q querier) error {
+		_, err := q.Exec(statements)
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Exec error: %w. Value of statements is `%s`", err, statements)
+		}
+		return nil
 	}
-	if sorted {
-		slices.Sort(result)
+}
+
+// LatestSchemaVersion is the version MigrateDatabase brings a database up to.
+func LatestSchemaVersion() int {
+	return schemaMigrations[len(schemaMigrations)-1].Version
+}
+
+func (vsam VSAModel) tableExists(tableName string) (bool, error) {
+	var count int
+	err := vsam.querier().QueryRow(vsam.sqlDialect().tableExistsQuery(), tableName).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("error in tableExists: sql.Row.Scan error: %w. Value of tableName is `%s`", err, tableName)
 	}
-	return result, nil
+	return count > 0, nil
 }
 
-func (vsam VSAModel) FetchAndSendScheduleData(currentUser string, selectedSchedule string) (result SendReceiveDataStruct, err error) {
-	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: selectedSchedule})
+// RequestSchemaVersion returns the schema version of the database, 0 for an empty one. A database made before SchemaVersion existed is dated by the newest migration whose table it already has.
+func (vsam VSAModel) RequestSchemaVersion() (int, error) {
+	bHasVersionTable, err := vsam.tableExists("SchemaVersion")
 	if err != nil {
-		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendData: %w", err)
+		return 0, fmt.Errorf("error in RequestSchemaVersion: %w", err)
 	}
-	// Do the easy stuff: schedule name, shifts off, volunteers per shift, and user
-	result.ScheduleName = scheduleRecord.ScheduleName
-	result.ShiftsOff = scheduleRecord.ShiftsOff
-	result.VolunteersPerShift = scheduleRecord.VolunteersPerShift
-	result.User = scheduleRecord.User
-	// Get the start date as a string
-	startDate, err := vsam.RequestDate(date{DateID: scheduleRecord.StartDate})
+	if bHasVersionTable {
+		var version int
+		err = vsam.querier().QueryRow(`select Version from SchemaVersion`).Scan(&version)
+		if err != nil {
+			return 0, fmt.Errorf("error in RequestSchemaVersion: sql.Row.Scan error: %w", err)
+		}
+		return version, nil
+	}
+	version := 0
+	for _, migration := range schemaMigrations {
+		if migration.Table == "" {
+			break
+		}
+		bApplied, err := vsam.tableExists(migration.Table)
+		if err != nil {
+			return 0, fmt.Errorf("error in RequestSchemaVersion: %w", err)
+		}
+		if !bApplied {
+			break
+		}
+		version = migration.Version
+	}
+	return version, nil
+}
+
+func (vsam VSAModel) updateSchemaVersion(version int) error {
+	updateSchemaVersionString := `
+	create table if not exists SchemaVersion (
+		Version integer not null
+	);
+	delete from SchemaVersion;
+	`
+	_, err := vsam.querier().Exec(updateSchemaVersionString)
 	if err != nil {
-		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendData: %w", err)
+		return fmt.Errorf("error in updateSchemaVersion: sql.Tx.Exec error: %w. Value of updateSchemaVersionString is `%s`", err, updateSchemaVersionString)
 	}
-	result.StartDate = startDate.ToString()
-	// Get the end date as a string
-	endDate, err := vsam.RequestDate(date{DateID: scheduleRecord.EndDate})
+	_, err = vsam.querier().Exec(`insert into SchemaVersion (Version) values (?)`, version)
 	if err != nil {
-		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendData: %w", err)
+		return fmt.Errorf("error in updateSchemaVersion: sql.Tx.Exec error: %w. Value of version is `%d`", err, version)
 	}
-	result.EndDate = endDate.ToString()
-	// Get the weekdays for schedule
-	weekdaysForSchedule, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	return nil
+}
+
+// MigrateDatabase runs every migration the database has not had yet, oldest first, and returns the versions it went from and to. Saved schedules are kept. It refuses to touch a database from a newer build.
+func (vsam VSAModel) MigrateDatabase() (from int, to int, err error) {
+	from, err = vsam.RequestSchemaVersion()
 	if err != nil {
-		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		return 0, 0, fmt.Errorf("error in MigrateDatabase: %w", err)
 	}
-	for _, val := range weekdaysForSchedule {
-		result.WeekdaysForSchedule = append(result.WeekdaysForSchedule, val.Weekday)
+	if from > LatestSchemaVersion() {
+		return from, from, fmt.Errorf("error in MigrateDatabase: database schema version %d is newer than the latest version this build knows about (%d)", from, LatestSchemaVersion())
 	}
-	// Now for the complicated parts. Get the volunteers for schedule, then for each of those, make a map of volunteer names to a slice of volunteer unavailabilities and then a map of volunteer names to a slice of volunteer schedule dates
-	volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	bHasVersionTable, err := vsam.tableExists("SchemaVersion")
 	if err != nil {
-		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		return from, from, fmt.Errorf("error in MigrateDatabase: %w", err)
 	}
-	result.VolunteerUnavailabilityData = make(map[string][]string, len(volunteersForSchedule))
-	result.VolunteerScheduledData = make(map[string][]string, len(volunteersForSchedule))
-	for _, vfsVal := range volunteersForSchedule {
-		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: vfsVal.Volunteer})
+	if from > 0 && !bHasVersionTable { // record the version worked out for a database made before SchemaVersion existed
+		err = vsam.inTransaction(func(txModel VSAModel) error {
+			return txModel.updateSchemaVersion(from)
+		})
 		if err != nil {
-			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+			return from, from, fmt.Errorf("error in MigrateDatabase: %w", err)
 		}
-		// Do volunteers for schedule
-		result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName] = []string{}
-		// Do unavailabilities for schedule
-		unavailabilitiesForSchedule, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
-		if err != nil {
-			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+	}
+	to = from
+	for _, migration := range schemaMigrations[from:] {
+		inTransaction := vsam.inTransaction
+		if migration.Rebuild {
+			inTransaction = vsam.inTransactionWithoutForeignKeys
 		}
-		for _, ufsVal := range unavailabilitiesForSchedule {
-			ufsDate, err := vsam.RequestDate(date{DateID: ufsVal.Date})
-			if err != nil {
-				return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
-			}
-			result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName] = append(result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName], ufsDate.ToString())
+		up := migration.Up
+		if _, ok := vsam.sqlDialect().(postgresDialect); ok && migration.UpPostgres != nil {
+			up = migration.UpPostgres
 		}
-		// Do scheduled volunteer dates
-		scheduledVolunteersOnDates, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: vfsVal.VFSID}})
+		err = inTransaction(func(txModel VSAModel) error {
+			if err := up(txModel.querier()); err != nil {
+				return err
+			}
+			return txModel.updateSchemaVersion(migration.Version)
+		})
 		if err != nil {
-			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+			return from, to, fmt.Errorf("error in MigrateDatabase: migration %d (%s) failed: %w", migration.Version, migration.Description, err)
 		}
-		for _, svodVal := range scheduledVolunteersOnDates {
-			svodDate, err := vsam.RequestDate(date{DateID: svodVal.Date})
-			if err != nil {
-				return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
-			}
-			result.VolunteerScheduledData[volunteerRecord.VolunteerName] = append(result.VolunteerScheduledData[volunteerRecord.VolunteerName], svodDate.ToString())
+		to = migration.Version
+	}
+	return from, to, nil
+}
+
+// CreateDatabase builds the schema in an empty database by running every migration.
+func (vsam VSAModel) CreateDatabase() error {
+	version, err := vsam.RequestSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("error in CreateDatabase: %w", err)
+	}
+	if version > 0 {
+		return fmt.Errorf("error in CreateDatabase: method failed because the database already has a schema (version %d). Use MigrateDatabase to bring it up to date", version)
+	}
+	_, _, err = vsam.MigrateDatabase()
+	if err != nil {
+		return fmt.Errorf("error in CreateDatabase: %w", err)
+	}
+	return nil
+}
+
+// CreateUser adds a user who signs in with password, which is stored as a bcrypt hash. It returns an error wrapping ErrUserExists for any name already in Users, including users
+// without a password (see ClaimUser). User names and organization names share one namespace, since both own schedules through the User column.
+func (vsam VSAModel) CreateUser(userName string, password string) error {
+	if userName == "" || strings.TrimSpace(userName) != userName {
+		return fmt.Errorf("error in CreateUser: method failed because the user name is empty or starts or ends with a space: \"%s\"", userName)
+	}
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return fmt.Errorf("error in CreateUser: method failed because the password is not between %d and %d characters long", MinPasswordLength, MaxPasswordLength)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error in CreateUser: bcrypt.GenerateFromPassword error: %w", err)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateUser: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	var userCount int
+	err = tx.QueryRow(`select count(*) from Users where UserName = ?`, userName).Scan(&userCount)
+	if err != nil {
+		return fmt.Errorf("error in CreateUser: sql.Row.Scan error: %w", err)
+	}
+	if userCount > 0 {
+		return fmt.Errorf("error in CreateUser: %w: \"%s\"", ErrUserExists, userName)
+	}
+	_, err = tx.Exec(`insert into Users (UserName, Password) values (?, ?)`, userName, passwordHash)
+	if err != nil {
+		return fmt.Errorf("error in CreateUser: sql.Tx.Exec error: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateUser: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// ClaimUser gives password to userName, a user without one, like the Seth that owns everything saved before sign-in existed. Since anyone could otherwise take over their
+// schedules, the caller has to have checked the claim code the operator handed out for userName first. It returns an error wrapping ErrNotFound when there is no such user, and
+// one wrapping ErrUserExists when userName already has a password or is an organization.
+func (vsam VSAModel) ClaimUser(userName string, password string) error {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return fmt.Errorf("error in ClaimUser: method failed because the password is not between %d and %d characters long", MinPasswordLength, MaxPasswordLength)
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("error in ClaimUser: bcrypt.GenerateFromPassword error: %w", err)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in ClaimUser: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	var existingHash []byte
+	var organizationCount int
+	err = tx.QueryRow(`select Password, (select count(*) from Organizations where OrganizationName = ?) from Users where UserName = ?`, userName, userName).Scan(&existingHash, &organizationCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("error in ClaimUser: %w: \"%s\"", ErrNotFound, userName)
+	}
+	if err != nil {
+		return fmt.Errorf("error in ClaimUser: sql.Row.Scan error: %w", err)
+	}
+	if len(existingHash) > 0 || organizationCount > 0 { // organizations have no password either, but are never signed in to
+		return fmt.Errorf("error in ClaimUser: %w: \"%s\"", ErrUserExists, userName)
+	}
+	_, err = tx.Exec(`update Users set Password = ? where UserName = ?`, passwordHash, userName)
+	if err != nil {
+		return fmt.Errorf("error in ClaimUser: sql.Tx.Exec error: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in ClaimUser: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// RequestUnclaimedUsers returns the users ClaimUser can give a password to, sorted by name.
+func (vsam VSAModel) RequestUnclaimedUsers() ([]string, error) {
+	rows, err := vsam.querier().Query(`select UserName from Users where Password is null and UserName not in (select OrganizationName from Organizations) order by UserName`)
+	if err != nil {
+		return nil, fmt.Errorf("error in RequestUnclaimedUsers: sql.DB.Query error: %w", err)
+	}
+	defer rows.Close()
+	result := []string{}
+	for rows.Next() {
+		var userName string
+		if err = rows.Scan(&userName); err != nil {
+			return nil, fmt.Errorf("error in RequestUnclaimedUsers: sql.Rows.Scan error: %w", err)
 		}
+		result = append(result, userName)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error in RequestUnclaimedUsers: sql.Rows error: %w", err)
 	}
 	return result, nil
 }
 
-func (vsam VSAModel) RecieveAndStoreData(currentUser string, data SendReceiveDataStruct, bNewSchedule bool) (err error) {
-	bDidWrite := false
-	scheduleRecord := schedule{ScheduleName: data.ScheduleName}
-	if !bNewSchedule {
-		scheduleRecord, err = vsam.RequestSchedule(currentUser, scheduleRecord)
+// AuthenticateUser returns nil when password is the one userName registered with, and ErrInvalidCredentials when it is not or the user cannot sign in.
+func (vsam VSAModel) AuthenticateUser(userName string, password string) error {
+	var passwordHash []byte
+	err := vsam.querier().QueryRow(`select Password from Users where UserName = ?`, userName).Scan(&passwordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidCredentials
+	}
+	if err != nil {
+		return fmt.Errorf("error in AuthenticateUser: sql.Row.Scan error: %w", err)
+	}
+	if len(passwordHash) == 0 || bcrypt.CompareHashAndPassword(passwordHash, []byte(password)) != nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// EnsureUser adds userName as a user without a password unless they already exist, for users a reverse proxy signed in before the request got here. It returns an error wrapping
+// ErrUserExists when userName is an organization.
+func (vsam VSAModel) EnsureUser(userName string) error {
+	if userName == "" || strings.TrimSpace(userName) != userName {
+		return fmt.Errorf("error in EnsureUser: method failed because the user name is empty or starts or ends with a space: \"%s\"", userName)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in EnsureUser: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	var userCount, organizationCount int
+	err = tx.QueryRow(`select (select count(*) from Users where UserName = ?), (select count(*) from Organizations where OrganizationName = ?)`, userName, userName).Scan(&userCount, &organizationCount)
+	if err != nil {
+		return fmt.Errorf("error in EnsureUser: sql.Row.Scan error: %w", err)
+	}
+	if organizationCount > 0 {
+		return fmt.Errorf("error in EnsureUser: %w: \"%s\" is an organization", ErrUserExists, userName)
+	}
+	if userCount == 0 {
+		_, err = tx.Exec(`insert into Users (UserName) values (?)`, userName)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in EnsureUser: sql.Tx.Exec error: %w", err)
 		}
 	}
-	// Ensure ShiftsOff was set then write it to scheduleRecord
-	if data.ShiftsOff > -1 && scheduleRecord.ShiftsOff != data.ShiftsOff {
-		scheduleRecord.ShiftsOff = data.ShiftsOff
-		bDidWrite = true
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in EnsureUser: sql.Tx.Commit error: %w", err)
 	}
-	// Ensure VolunteersPerShift was set then write it to scheduleRecord
-	if data.VolunteersPerShift > -1 && scheduleRecord.VolunteersPerShift != data.VolunteersPerShift {
-		scheduleRecord.VolunteersPerShift = data.VolunteersPerShift
-		bDidWrite = true
+	return nil
+}
+
+// hashSessionToken is what Sessions stores in place of the token, so a leaked database cannot be used to sign in.
+func hashSessionToken(token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(tokenHash[:])
+}
+
+// newToken is 32 random bytes in a form that can go in a cookie or a URL as is.
+func newToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("rand.Read error: %w", err)
 	}
-	// Validate StartDate then write it to scheduleRecord
-	startDate, err := date{}.FromString(data.StartDate)
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// CreateSession signs userName in for SessionLifetime and returns the token to hand back in a cookie. Expired sessions are cleared out on the way.
+func (vsam VSAModel) CreateSession(userName string) (string, error) {
+	token, err := newToken()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return "", fmt.Errorf("error in CreateSession: %w", err)
 	}
-	startDate, err = vsam.RequestDate(startDate)
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return "", fmt.Errorf("error in CreateSession: sql.DB.Begin error: %w", err)
 	}
-	if scheduleRecord.StartDate != startDate.DateID {
-		scheduleRecord.StartDate = startDate.DateID
-		bDidWrite = true
+	defer tx.Rollback()
+	now := time.Now()
+	_, err = tx.Exec(`delete from Sessions where Expires <= ?`, now.Unix())
+	if err != nil {
+		return "", fmt.Errorf("error in CreateSession: sql.Tx.Exec error: %w", err)
 	}
-	// Validate EndDate then write it to scheduleRecord
-	endDate, err := date{}.FromString(data.EndDate)
+	_, err = tx.Exec(`insert into Sessions (TokenHash, User, Expires) values (?, ?, ?)`, hashSessionToken(token), userName, now.Add(SessionLifetime).Unix())
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return "", fmt.Errorf("error in CreateSession: sql.Tx.Exec error: %w. Value of userName is `%s`", err, userName)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return "", fmt.Errorf("error in CreateSession: sql.Tx.Commit error: %w", err)
+	}
+	return token, nil
+}
+
+// RequestSessionUser returns the user signed in with token, or an error wrapping ErrNotFound when the session does not exist or has expired.
+func (vsam VSAModel) RequestSessionUser(token string) (string, error) {
+	var userName string
+	err := vsam.querier().QueryRow(`select User from Sessions where TokenHash = ? and Expires > ?`, hashSessionToken(token), time.Now().Unix()).Scan(&userName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("error in RequestSessionUser: %w: no current session for the token", ErrNotFound)
 	}
-	endDate, err = vsam.RequestDate(endDate)
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return "", fmt.Errorf("error in RequestSessionUser: sql.Row.Scan error: %w", err)
 	}
-	if scheduleRecord.EndDate != endDate.DateID {
-		scheduleRecord.EndDate = endDate.DateID
-		bDidWrite = true
+	return userName, nil
+}
+
+// DeleteSession signs the session with token out. Deleting a session that does not exist is not an error.
+func (vsam VSAModel) DeleteSession(token string) error {
+	_, err := vsam.querier().Exec(`delete from Sessions where TokenHash = ?`, hashSessionToken(token))
+	if err != nil {
+		return fmt.Errorf("error in DeleteSession: sql.DB.Exec error: %w", err)
 	}
-	bZeroShiftsOff := data.ShiftsOff == 0
-	if bNewSchedule {
-		err = vsam.CreateSchedulesExtended(currentUser, []schedule{scheduleRecord}, bZeroShiftsOff)
+	return nil
+}
+
+// CalendarFeed is what the token of a calendar feed reads: a schedule of a workspace, and only the shifts of Volunteer unless it is "".
+type CalendarFeed struct {
+	Workspace    string
+	ScheduleName string
+	Volunteer    string
+}
+
+// EnsureCalendarFeed returns the token of the calendar feed for volunteerName in the schedule called scheduleName, or for the whole schedule when volunteerName is "", and makes one the first
+// time it is asked for. Unlike Sessions, CalendarFeeds keeps the token itself so the page can show the same feed address every time. It only reads a schedule that anyone with the database
+// can read already.
+func (vsam VSAModel) EnsureCalendarFeed(currentUser string, scheduleName string, volunteerName string) (string, error) {
+	var token string
+	err := vsam.inTransaction(func(txModel VSAModel) error {
+		scheduleRecord, err := txModel.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return err
+		}
+		token, err = txModel.requestCalendarFeedToken(scheduleRecord, volunteerName)
+		if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if token, err = newToken(); err != nil {
+			return err
+		}
+		_, err = txModel.querier().Exec(`insert into CalendarFeeds (Token, User, Schedule, Volunteer) values (?, ?, ?, ?)`, token, currentUser, scheduleRecord.ScheduleID, volunteerName)
+		if err != nil {
+			return fmt.Errorf("sql.Tx.Exec error: %w. Value of volunteerName is `%s`", err, volunteerName)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error in EnsureCalendarFeed: %w", err)
+	}
+	return token, nil
+}
+
+// RequestCalendarFeedToken returns the token of the calendar feed for volunteerName in the schedule called scheduleName, or for the whole schedule when volunteerName is "", like
+// EnsureCalendarFeed, except that it returns an error wrapping ErrNotFound instead of making a feed that does not exist yet.
+func (vsam VSAModel) RequestCalendarFeedToken(currentUser string, scheduleName string, volunteerName string) (string, error) {
+	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})
+	if err != nil {
+		return "", fmt.Errorf("error in RequestCalendarFeedToken: %w", err)
+	}
+	token, err := vsam.requestCalendarFeedToken(scheduleRecord, volunteerName)
+	if err != nil {
+		return "", fmt.Errorf("error in RequestCalendarFeedToken: %w", err)
+	}
+	return token, nil
+}
+
+func (vsam VSAModel) requestCalendarFeedToken(scheduleRecord schedule, volunteerName string) (string, error) {
+	var token string
+	err := vsam.querier().QueryRow(`select Token from CalendarFeeds where Schedule = ? and Volunteer = ?`, scheduleRecord.ScheduleID, volunteerName).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: no calendar feed for \"%s\" in %s", ErrNotFound, volunteerName, scheduleRecord.ScheduleName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sql.Row.Scan error: %w", err)
+	}
+	return token, nil
+}
+
+// RequestCalendarFeed returns what token reads, or an error wrapping ErrNotFound when there is no such feed.
+func (vsam VSAModel) RequestCalendarFeed(token string) (CalendarFeed, error) {
+	var feed CalendarFeed
+	err := vsam.querier().QueryRow(`select CalendarFeeds.User, ScheduleName, Volunteer from CalendarFeeds join Schedules on ScheduleID = Schedule where Token = ?`, token).Scan(&feed.Workspace, &feed.ScheduleName, &feed.Volunteer)
+	if errors.Is(err, sql.ErrNoRows) {
+		return CalendarFeed{}, fmt.Errorf("error in RequestCalendarFeed: %w: no calendar feed for the token", ErrNotFound)
+	}
+	if err != nil {
+		return CalendarFeed{}, fmt.Errorf("error in RequestCalendarFeed: sql.Row.Scan error: %w", err)
+	}
+	return feed, nil
+}
+
+// DeleteCalendarFeeds stops the calendar feeds of the schedule called scheduleName from working. EnsureCalendarFeed makes new tokens for them the next time they are asked for.
+func (vsam VSAModel) DeleteCalendarFeeds(currentUser string, scheduleName string) error {
+	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})
+	if err != nil {
+		return fmt.Errorf("error in DeleteCalendarFeeds: %w", err)
+	}
+	_, err = vsam.querier().Exec(`delete from CalendarFeeds where User = ? and Schedule = ?`, currentUser, scheduleRecord.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("error in DeleteCalendarFeeds: sql.DB.Exec error: %w", err)
+	}
+	return nil
+}
+
+// Membership is one member's role in an organization. RequestWorkspaces also lists the user's own workspace this way, as the owner of an organization named after them.
+type Membership struct {
+	Organization string
+	Member       string
+	Role         string
+}
+
+// CreateOrganization adds an organization with currentUser as its owner. The organization owns the schedules and volunteers its members save, in place of a user, so it is stored
+// as a user that cannot sign in.
+func (vsam VSAModel) CreateOrganization(currentUser string, organizationName string) error {
+	if organizationName == "" || strings.TrimSpace(organizationName) != organizationName {
+		return fmt.Errorf("error in CreateOrganization: method failed because the organization name is empty or starts or ends with a space: \"%s\"", organizationName)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateOrganization: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	var userCount int
+	err = tx.QueryRow(`select count(*) from Users where UserName = ?`, organizationName).Scan(&userCount)
+	if err != nil {
+		return fmt.Errorf("error in CreateOrganization: sql.Row.Scan error: %w", err)
+	}
+	if userCount > 0 {
+		return fmt.Errorf("error in CreateOrganization: %w: \"%s\"", ErrUserExists, organizationName)
+	}
+	for _, statement := range []struct {
+		text string
+		args []any
+	}{
+		{`insert into Users (UserName) values (?)`, []any{organizationName}},
+		{`insert into Organizations (OrganizationName) values (?)`, []any{organizationName}},
+		{`insert into Memberships (Organization, Member, Role) values (?, ?, ?)`, []any{organizationName, currentUser, RoleOwner}},
+	} {
+		_, err = tx.Exec(statement.text, statement.args...)
+		if err != nil {
+			return fmt.Errorf("error in CreateOrganization: sql.Tx.Exec error: %w. Value of statement.text is `%s`", err, statement.text)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateOrganization: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) requestMemberships(funcName string, column string, value string) ([]Membership, error) {
+	rows, err := vsam.querier().Query(fmt.Sprintf(`select Organization, Member, Role from Memberships where %[1]s = ? order by Organization, Member`, column), value)
+	if err != nil {
+		return nil, fmt.Errorf("error in %s: sql.DB.Query error: %w", funcName, err)
+	}
+	defer rows.Close()
+	result := []Membership{}
+	for rows.Next() {
+		var membership Membership
+		if err = rows.Scan(&membership.Organization, &membership.Member, &membership.Role); err != nil {
+			return nil, fmt.Errorf("error in %s: sql.Rows.Scan error: %w", funcName, err)
+		}
+		result = append(result, membership)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error in %s: sql.Rows error: %w", funcName, err)
+	}
+	return result, nil
+}
+
+// RequestWorkspaces lists where currentUser can work on schedules: their own workspace first, then every organization they belong to, by name.
+func (vsam VSAModel) RequestWorkspaces(currentUser string) ([]Membership, error) {
+	memberships, err := vsam.requestMemberships("RequestWorkspaces", "Member", currentUser)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Membership{{Organization: currentUser, Member: currentUser, Role: RoleOwner}}, memberships...), nil
+}
+
+// RequestWorkspaceRole returns currentUser's role in workspace, which is RoleOwner for their own. It returns an error wrapping ErrNotFound when they are not a member.
+func (vsam VSAModel) RequestWorkspaceRole(currentUser string, workspace string) (string, error) {
+	if workspace == currentUser {
+		return RoleOwner, nil
+	}
+	var role string
+	err := vsam.querier().QueryRow(`select Role from Memberships where Organization = ? and Member = ?`, workspace, currentUser).Scan(&role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("error in RequestWorkspaceRole: %w: %s is not a member of %s", ErrNotFound, currentUser, workspace)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error in RequestWorkspaceRole: sql.Row.Scan error: %w", err)
+	}
+	return role, nil
+}
+
+// RequestMembers lists the members of organization by name.
+func (vsam VSAModel) RequestMembers(organization string) ([]Membership, error) {
+	return vsam.requestMemberships("RequestMembers", "Organization", organization)
+}
+
+// checkOwnerRemains returns an error wrapping ErrLastOwner when organization has no owner left in tx.
+func checkOwnerRemains(funcName string, tx modelTx, organization string) error {
+	var ownerCount int
+	err := tx.QueryRow(`select count(*) from Memberships where Organization = ? and Role = ?`, organization, RoleOwner).Scan(&ownerCount)
+	if err != nil {
+		return fmt.Errorf("error in %s: sql.Row.Scan error: %w", funcName, err)
+	}
+	if ownerCount == 0 {
+		return fmt.Errorf("error in %s: %w: \"%s\"", funcName, ErrLastOwner, organization)
+	}
+	return nil
+}
+
+// SaveMembership adds member to organization with role, or changes the role of a member already in it. member has to be a user, and an error wrapping ErrNotFound is returned when
+// there is no such user.
+func (vsam VSAModel) SaveMembership(organization string, member string, role string) error {
+	if !slices.Contains(Roles, role) {
+		return fmt.Errorf("error in SaveMembership: method failed because \"%s\" is not one of the roles %v", role, Roles)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in SaveMembership: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	var userCount int
+	err = tx.QueryRow(`select count(*) from Users where UserName = ? and UserName not in (select OrganizationName from Organizations)`, member).Scan(&userCount)
+	if err != nil {
+		return fmt.Errorf("error in SaveMembership: sql.Row.Scan error: %w", err)
+	}
+	if userCount == 0 {
+		return fmt.Errorf("error in SaveMembership: %w: there is no user named \"%s\"", ErrNotFound, member)
+	}
+	result, err := tx.Exec(`update Memberships set Role = ? where Organization = ? and Member = ?`, role, organization, member)
+	if err != nil {
+		return fmt.Errorf("error in SaveMembership: sql.Tx.Exec error: %w", err)
+	}
+	if rowCount, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("error in SaveMembership: sql.Result.RowsAffected error: %w", err)
+	} else if rowCount == 0 {
+		_, err = tx.Exec(`insert into Memberships (Organization, Member, Role) values (?, ?, ?)`, organization, member, role)
+		if err != nil {
+			return fmt.Errorf("error in SaveMembership: sql.Tx.Exec error: %w. Value of organization is `%s`", err, organization)
+		}
+	}
+	if err = checkOwnerRemains("SaveMembership", tx, organization); err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in SaveMembership: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// DeleteMembership removes member from organization. Removing someone who is not a member is not an error.
+func (vsam VSAModel) DeleteMembership(organization string, member string) error {
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteMembership: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(`delete from Memberships where Organization = ? and Member = ?`, organization, member)
+	if err != nil {
+		return fmt.Errorf("error in DeleteMembership: sql.Tx.Exec error: %w", err)
+	}
+	if err = checkOwnerRemains("DeleteMembership", tx, organization); err != nil {
+		return err
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteMembership: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) SendScheduleNames(currentUser string, sorted bool) (result []string, err error) {
+	scheduleStructs, err := vsam.RequestSchedules(currentUser, []schedule{})
+	if err != nil {
+		return []string{}, fmt.Errorf("error in SendScheduleNames: %w", err)
+	}
+	for i := 0; i < len(scheduleStructs); i++ {
+		result = append(result, scheduleStructs[i].ScheduleName)
+	}
+	if sorted {
+		slices.Sort(result)
+	}
+	return result, nil
+}
+
+func (vsam VSAModel) FetchAndSendScheduleData(currentUser string, selectedSchedule string) (result SendReceiveDataStruct, err error) {
+	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: selectedSchedule})
+	if err != nil {
+		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendData: %w", err)
+	}
+	// Do the easy stuff: schedule name, shifts off, volunteers per shift, and user
+	result.ScheduleName = scheduleRecord.ScheduleName
+	result.ShiftsOff = scheduleRecord.ShiftsOff
+	result.VolunteersPerShift = scheduleRecord.VolunteersPerShift
+	result.User = scheduleRecord.User
+	result.StartDate = scheduleRecord.StartDate
+	result.EndDate = scheduleRecord.EndDate
+	// Get the weekdays for schedule
+	weekdaysForSchedule, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	if err != nil {
+		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+	}
+	for _, val := range weekdaysForSchedule {
+		result.WeekdaysForSchedule = append(result.WeekdaysForSchedule, val.Weekday)
+	}
+	// Get the time slots for schedule (RequestTSFS returns them in SlotOrder)
+	timeSlotsForSchedule, err := vsam.RequestTSFS(currentUser, []timeSlotForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	if err != nil {
+		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+	}
+	result.TimeSlotsForSchedule = map[string][]TimeSlot{}
+	for _, val := range timeSlotsForSchedule {
+		result.TimeSlotsForSchedule[val.Weekday] = append(result.TimeSlotsForSchedule[val.Weekday], TimeSlot{val.SlotName, val.VolunteersPerShift})
+	}
+	// Get the roles for schedule (RequestRFS returns them in RoleOrder)
+	rolesForSchedule, err := vsam.RequestRFS(currentUser, []roleForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	if err != nil {
+		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+	}
+	result.RolesForSchedule = []RoleRequirement{}
+	for _, val := range rolesForSchedule {
+		roleRecord, err := vsam.RequestRole(currentUser, role{RoleID: val.Role})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		result.RolesForSchedule = append(result.RolesForSchedule, RoleRequirement{roleRecord.RoleName, val.VolunteersPerShift})
+	}
+	// Now for the complicated parts. Get the volunteers for schedule, then for each of those, make a map of volunteer names to a slice of volunteer unavailabilities and then a map of volunteer names to a slice of volunteer schedule dates
+	volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+	if err != nil {
+		return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+	}
+	result.VolunteerUnavailabilityData = make(map[string][]string, len(volunteersForSchedule))
+	result.VolunteerScheduledData = make(map[string][]string, len(volunteersForSchedule))
+	result.VolunteerRoleData = map[string][]string{}
+	result.VolunteerUnavailabilityRuleData = map[string][]string{}
+	result.VolunteerUnavailabilityRangeData = map[string][]DateRange{}
+	result.VolunteerPreferenceData = map[string][]string{}
+	result.VolunteerContactData = map[string]VolunteerContact{}
+	for _, vfsVal := range volunteersForSchedule {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: vfsVal.Volunteer})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		// Do the roles the volunteer is qualified for
+		volunteerRoles, err := vsam.RequestVR(currentUser, []volunteerRole{{Volunteer: volunteerRecord.VolunteerID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, vrVal := range volunteerRoles {
+			roleRecord, err := vsam.RequestRole(currentUser, role{RoleID: vrVal.Role})
+			if err != nil {
+				return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+			}
+			result.VolunteerRoleData[volunteerRecord.VolunteerName] = append(result.VolunteerRoleData[volunteerRecord.VolunteerName], roleRecord.RoleName)
+		}
+		slices.Sort(result.VolunteerRoleData[volunteerRecord.VolunteerName])
+		// Do volunteers for schedule
+		result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName] = []string{}
+		if volunteerRecord.Email != "" || volunteerRecord.Phone != "" {
+			result.VolunteerContactData[volunteerRecord.VolunteerName] = VolunteerContact{volunteerRecord.Email, volunteerRecord.Phone}
+		}
+		// Do unavailabilities for schedule
+		unavailabilitiesForSchedule, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, ufsVal := range unavailabilitiesForSchedule {
+			result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName] = append(result.VolunteerUnavailabilityData[volunteerRecord.VolunteerName], ufsVal.Date)
+		}
+		// Do unavailability rules for schedule (RequestURFS returns them in the order they were saved)
+		unavailabilityRulesForSchedule, err := vsam.RequestURFS(currentUser, []unavailabilityRuleForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, urfsVal := range unavailabilityRulesForSchedule {
+			result.VolunteerUnavailabilityRuleData[volunteerRecord.VolunteerName] = append(result.VolunteerUnavailabilityRuleData[volunteerRecord.VolunteerName], urfsVal.Rule)
+		}
+		// Do preferences for schedule (RequestPrFS returns them in the order they were saved)
+		preferencesForSchedule, err := vsam.RequestPrFS(currentUser, []preferenceForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, prfsVal := range preferencesForSchedule {
+			result.VolunteerPreferenceData[volunteerRecord.VolunteerName] = append(result.VolunteerPreferenceData[volunteerRecord.VolunteerName], prfsVal.Preference)
+		}
+		// Do date ranges for schedule
+		dateRangesForSchedule, err := vsam.RequestDRFS(currentUser, []dateRangeForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, drfsVal := range dateRangesForSchedule {
+			result.VolunteerUnavailabilityRangeData[volunteerRecord.VolunteerName] = append(result.VolunteerUnavailabilityRangeData[volunteerRecord.VolunteerName], DateRange{drfsVal.StartDate, drfsVal.EndDate})
+		}
+		slices.SortFunc(result.VolunteerUnavailabilityRangeData[volunteerRecord.VolunteerName], func(a, b DateRange) int {
+			return cmp.Or(strings.Compare(a.Start, b.Start), strings.Compare(a.End, b.End))
+		})
+		// Do scheduled volunteer dates
+		scheduledVolunteersOnDates, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, svodVal := range scheduledVolunteersOnDates {
+			result.VolunteerScheduledData[volunteerRecord.VolunteerName] = append(result.VolunteerScheduledData[volunteerRecord.VolunteerName], ShiftKey{svodVal.Date, svodVal.TimeSlot, svodVal.Role}.ToString())
+		}
+		// Do the pairings this volunteer owns
+		pairingsForSchedule, err := vsam.RequestPFS(currentUser, []pairingForSchedule{{VolunteerForSchedule: vfsVal.VFSID}})
+		if err != nil {
+			return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+		}
+		for _, pfsVal := range pairingsForSchedule {
+			pairedVFS, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{VFSID: pfsVal.PairedVolunteerForSchedule})
+			if err != nil {
+				return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+			}
+			pairedVolunteer, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: pairedVFS.Volunteer})
+			if err != nil {
+				return SendReceiveDataStruct{}, fmt.Errorf("error in FetchAndSendScheduleData: %w", err)
+			}
+			result.VolunteerPairingData = append(result.VolunteerPairingData, VolunteerPairing{volunteerRecord.VolunteerName, pairedVolunteer.VolunteerName, pfsVal.Pairing})
+		}
+	}
+	if result.VolunteerPairingData == nil {
+		result.VolunteerPairingData = []VolunteerPairing{}
+	}
+	slices.SortFunc(result.VolunteerPairingData, func(a, b VolunteerPairing) int {
+		return cmp.Or(strings.Compare(a.Volunteer, b.Volunteer), strings.Compare(a.PairedVolunteer, b.PairedVolunteer))
+	})
+	return result, nil
+}
+
+// RecieveAndStoreData saves data as one transaction, so a save that fails partway leaves the database as it was.
+func (vsam VSAModel) RecieveAndStoreData(currentUser string, data SendReceiveDataStruct, bNewSchedule bool) error {
+	return vsam.inTransaction(func(txModel VSAModel) error {
+		return txModel.recieveAndStoreData(currentUser, data, bNewSchedule)
+	})
+}
+
+func (vsam VSAModel) recieveAndStoreData(currentUser string, data SendReceiveDataStruct, bNewSchedule bool) (err error) {
+	bDidWrite := false
+	scheduleRecord := schedule{ScheduleName: data.ScheduleName}
+	if !bNewSchedule {
+		scheduleRecord, err = vsam.RequestSchedule(currentUser, scheduleRecord)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	// Ensure ShiftsOff was set then write it to scheduleRecord
+	if data.ShiftsOff > -1 && scheduleRecord.ShiftsOff != data.ShiftsOff {
+		scheduleRecord.ShiftsOff = data.ShiftsOff
+		bDidWrite = true
+	}
+	// Ensure VolunteersPerShift was set then write it to scheduleRecord
+	if data.VolunteersPerShift > -1 && scheduleRecord.VolunteersPerShift != data.VolunteersPerShift {
+		scheduleRecord.VolunteersPerShift = data.VolunteersPerShift
+		bDidWrite = true
+	}
+	// Validate StartDate then write it to scheduleRecord
+	startDate, err := date{}.FromString(data.StartDate)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	if scheduleRecord.StartDate != startDate.ToString() {
+		scheduleRecord.StartDate = startDate.ToString()
+		bDidWrite = true
+	}
+	// Validate EndDate then write it to scheduleRecord
+	endDate, err := date{}.FromString(data.EndDate)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	if scheduleRecord.EndDate != endDate.ToString() {
+		scheduleRecord.EndDate = endDate.ToString()
+		bDidWrite = true
+	}
+	bZeroShiftsOff := data.ShiftsOff == 0
+	if bNewSchedule {
+		err = vsam.CreateSchedulesExtended(currentUser, []schedule{scheduleRecord}, bZeroShiftsOff)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	} else if bDidWrite {
+		err = vsam.UpdateSchedulesExtended(currentUser, []schedule{scheduleRecord}, bZeroShiftsOff)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	scheduleRecord, err = vsam.RequestSchedule(currentUser, scheduleRecord)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	// Validate WeekdaysForSchedule weekday names and whether there's already a WFS in the database for it then add it to wfsToCreate
+	wfsToCreate := []weekdayForSchedule{}
+	for _, val := range data.WeekdaysForSchedule {
+		// data.WeekdaysForSchedule is a []string of full weekday names
+		weekdayStruct, err := vsam.RequestWeekday(weekday{WeekdayName: val})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		wfsStruct := weekdayForSchedule{Schedule: scheduleRecord.ScheduleID, Weekday: weekdayStruct.WeekdayName}
+		wfsSlice, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{wfsStruct})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		if len(wfsSlice) == 0 {
+			wfsToCreate = append(wfsToCreate, wfsStruct)
+		}
+	}
+	if len(wfsToCreate) > 0 {
+		err = vsam.CreateWFS(currentUser, wfsToCreate)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	// A nil TimeSlotsForSchedule leaves the saved time slots alone. Otherwise time slots are matched by Weekday and SlotName, then created or updated to match data.
+	// Time slots that are no longer in data (or whose weekday is no longer scheduled) are deleted by CleanOrphansForSchedule below.
+	if data.TimeSlotsForSchedule != nil {
+		tsfsToCreate := []timeSlotForSchedule{}
+		tsfsToUpdate := []timeSlotForSchedule{}
+		for weekdayName, timeSlots := range data.TimeSlotsForSchedule {
+			weekdayStruct, err := vsam.RequestWeekday(weekday{WeekdayName: weekdayName})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			if !slices.Contains(data.WeekdaysForSchedule, weekdayStruct.WeekdayName) {
+				continue
+			}
+			existingTSFS, err := vsam.RequestTSFS(currentUser, []timeSlotForSchedule{{Schedule: scheduleRecord.ScheduleID, Weekday: weekdayStruct.WeekdayName}})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			slotNames := []string{}
+			for i, timeSlot := range timeSlots {
+				if timeSlot.Name == "" || strings.Contains(timeSlot.Name, ShiftKeySeparator) {
+					return fmt.Errorf("error in RecieveAndStoreData: time slot names cannot be empty or contain \"%s\". Value of timeSlot is `%+v`", ShiftKeySeparator, timeSlot)
+				}
+				if timeSlot.VolunteersPerShift < 1 {
+					return fmt.Errorf("error in RecieveAndStoreData: time slot \"%s\" on %s must have at least 1 volunteer per shift", timeSlot.Name, weekdayStruct.WeekdayName)
+				}
+				if slices.Contains(slotNames, timeSlot.Name) {
+					return fmt.Errorf("error in RecieveAndStoreData: time slot \"%s\" is listed more than once for %s", timeSlot.Name, weekdayStruct.WeekdayName)
+				}
+				slotNames = append(slotNames, timeSlot.Name)
+				existingIndex := slices.IndexFunc(existingTSFS, func(tsfs timeSlotForSchedule) bool { return tsfs.SlotName == timeSlot.Name })
+				if existingIndex == -1 {
+					tsfsToCreate = append(tsfsToCreate, timeSlotForSchedule{Schedule: scheduleRecord.ScheduleID, Weekday: weekdayStruct.WeekdayName, SlotName: timeSlot.Name, SlotOrder: i + 1, VolunteersPerShift: timeSlot.VolunteersPerShift})
+				} else if existingTSFS[existingIndex].SlotOrder != i+1 || existingTSFS[existingIndex].VolunteersPerShift != timeSlot.VolunteersPerShift {
+					tsfsToUpdate = append(tsfsToUpdate, timeSlotForSchedule{TSFSID: existingTSFS[existingIndex].TSFSID, SlotOrder: i + 1, VolunteersPerShift: timeSlot.VolunteersPerShift})
+				}
+			}
+		}
+		if len(tsfsToUpdate) > 0 {
+			err = vsam.UpdateTSFS(currentUser, tsfsToUpdate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+		if len(tsfsToCreate) > 0 {
+			err = vsam.CreateTSFS(currentUser, tsfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil RolesForSchedule leaves the saved role requirements alone. Otherwise role requirements are matched by role, then created or updated to match data.
+	// Role requirements that are no longer in data are deleted by CleanOrphansForSchedule below.
+	if data.RolesForSchedule != nil {
+		roleNames := []string{}
+		for _, requirement := range data.RolesForSchedule {
+			if requirement.VolunteersPerShift < 1 {
+				return fmt.Errorf("error in RecieveAndStoreData: role \"%s\" must have at least 1 volunteer per shift", requirement.Name)
+			}
+			roleNames = append(roleNames, requirement.Name)
+		}
+		roleRecords, err := vsam.requestOrCreateRoles(currentUser, roleNames)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		existingRFS, err := vsam.RequestRFS(currentUser, []roleForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		rfsToCreate := []roleForSchedule{}
+		rfsToUpdate := []roleForSchedule{}
+		for i, requirement := range data.RolesForSchedule {
+			existingIndex := slices.IndexFunc(existingRFS, func(rfs roleForSchedule) bool { return rfs.Role == roleRecords[i].RoleID })
+			if existingIndex == -1 {
+				rfsToCreate = append(rfsToCreate, roleForSchedule{Schedule: scheduleRecord.ScheduleID, Role: roleRecords[i].RoleID, RoleOrder: i + 1, VolunteersPerShift: requirement.VolunteersPerShift})
+			} else if existingRFS[existingIndex].RoleOrder != i+1 || existingRFS[existingIndex].VolunteersPerShift != requirement.VolunteersPerShift {
+				rfsToUpdate = append(rfsToUpdate, roleForSchedule{RFSID: existingRFS[existingIndex].RFSID, RoleOrder: i + 1, VolunteersPerShift: requirement.VolunteersPerShift})
+			}
+		}
+		if len(rfsToUpdate) > 0 {
+			err = vsam.UpdateRFS(currentUser, rfsToUpdate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+		if len(rfsToCreate) > 0 {
+			err = vsam.CreateRFS(currentUser, rfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	volunteersToCreate := []volunteer{}
+	for key := range data.VolunteerUnavailabilityData {
+		volunteerStruct := volunteer{VolunteerName: key}
+		volunteerSlice, err := vsam.RequestVolunteers(currentUser, []volunteer{volunteerStruct})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		if len(volunteerSlice) == 0 {
+			volunteersToCreate = append(volunteersToCreate, volunteerStruct)
+		}
+	}
+	if len(volunteersToCreate) > 0 {
+		err = vsam.CreateVolunteers(currentUser, volunteersToCreate)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	vfsToCreate := []volunteerForSchedule{}
+	for key := range data.VolunteerUnavailabilityData {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		vfsStruct := volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID}
+		vfsSlice, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{vfsStruct})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		if len(vfsSlice) == 0 {
+			vfsToCreate = append(vfsToCreate, vfsStruct)
+		}
+	}
+	if len(vfsToCreate) > 0 {
+		err = vsam.CreateVFS(currentUser, vfsToCreate)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	// A nil VolunteerContactData leaves the saved contacts alone. Otherwise each volunteer of the schedule gets the contact in data, and a volunteer left out of it has theirs cleared.
+	// Contacts belong to the volunteer, so they are shared by every schedule the volunteer is on.
+	if data.VolunteerContactData != nil {
+		contactsToUpdate := []volunteer{}
+		for key := range data.VolunteerContactData {
+			if _, ok := data.VolunteerUnavailabilityData[key]; !ok {
+				return fmt.Errorf("error in RecieveAndStoreData: \"%s\" has a contact but is not a volunteer on schedule \"%s\"", key, data.ScheduleName)
+			}
+		}
+		for key := range data.VolunteerUnavailabilityData {
+			volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			contact := data.VolunteerContactData[key]
+			if volunteerRecord.Email != contact.Email || volunteerRecord.Phone != contact.Phone {
+				contactsToUpdate = append(contactsToUpdate, volunteer{VolunteerID: volunteerRecord.VolunteerID, Email: contact.Email, Phone: contact.Phone})
+			}
+		}
+		if len(contactsToUpdate) > 0 {
+			err = vsam.UpdateVolunteerContacts(currentUser, contactsToUpdate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil VolunteerRoleData leaves the saved qualifications alone. Otherwise missing qualifications are created here and the ones no longer in data are deleted by CleanOrphansForSchedule below.
+	// Qualifications belong to the volunteer, so they are shared by every schedule the volunteer is on.
+	if data.VolunteerRoleData != nil {
+		vrToCreate := []volunteerRole{}
+		for key, value := range data.VolunteerRoleData {
+			if _, ok := data.VolunteerUnavailabilityData[key]; !ok {
+				return fmt.Errorf("error in RecieveAndStoreData: \"%s\" has roles but is not a volunteer on schedule \"%s\"", key, data.ScheduleName)
+			}
+			volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			roleRecords, err := vsam.requestOrCreateRoles(currentUser, value)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			for _, roleRecord := range roleRecords {
+				vrStruct := volunteerRole{Volunteer: volunteerRecord.VolunteerID, Role: roleRecord.RoleID}
+				vrSlice, err := vsam.RequestVR(currentUser, []volunteerRole{vrStruct})
+				if err != nil {
+					return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+				}
+				if len(vrSlice) == 0 {
+					vrToCreate = append(vrToCreate, vrStruct)
+				}
+			}
+		}
+		if len(vrToCreate) > 0 {
+			err = vsam.CreateVR(currentUser, vrToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil VolunteerPairingData leaves the saved pairings alone. Otherwise missing pairings are created here and the ones no longer in data are deleted by CleanOrphansForSchedule below.
+	if data.VolunteerPairingData != nil {
+		pairingsForSchedule, err := vsam.resolvePairings(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		pfsToCreate := []pairingForSchedule{}
+		for _, pfsStruct := range pairingsForSchedule {
+			pfsSlice, err := vsam.RequestPFS(currentUser, []pairingForSchedule{pfsStruct})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			if len(pfsSlice) == 0 {
+				pfsToCreate = append(pfsToCreate, pfsStruct)
+			}
+		}
+		if len(pfsToCreate) > 0 {
+			err = vsam.CreatePFS(currentUser, pfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	ufsToCreate := []unavailabilityForSchedule{}
+	for key, value := range data.VolunteerUnavailabilityData {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		for _, dateString := range value {
+			dateStruct, err := date{}.FromString(dateString)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			ufsStruct := unavailabilityForSchedule{VolunteerForSchedule: vfsStruct.VFSID, Date: dateStruct.ToString()}
+			ufsSlice, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{ufsStruct})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			if len(ufsSlice) == 0 {
+				ufsToCreate = append(ufsToCreate, ufsStruct)
+			}
+		}
+	}
+	if len(ufsToCreate) > 0 {
+		err = vsam.CreateUFS(currentUser, ufsToCreate)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+	}
+	// A nil VolunteerUnavailabilityRuleData leaves the saved rules alone. Otherwise missing rules are created here and the ones no longer in data are deleted by CleanOrphansForSchedule below.
+	if data.VolunteerUnavailabilityRuleData != nil {
+		rulesForSchedule, err := vsam.resolveUnavailabilityRules(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		urfsToCreate := []unavailabilityRuleForSchedule{}
+		for _, urfsSlice := range rulesForSchedule {
+			for _, urfsStruct := range urfsSlice {
+				existing, err := vsam.RequestURFS(currentUser, []unavailabilityRuleForSchedule{urfsStruct})
+				if err != nil {
+					return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+				}
+				if len(existing) == 0 {
+					urfsToCreate = append(urfsToCreate, urfsStruct)
+				}
+			}
+		}
+		if len(urfsToCreate) > 0 {
+			err = vsam.CreateURFS(currentUser, urfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil VolunteerPreferenceData leaves the saved preferences alone. Otherwise missing preferences are created here and the ones no longer in data are deleted by CleanOrphansForSchedule below.
+	if data.VolunteerPreferenceData != nil {
+		preferencesForSchedule, err := vsam.resolvePreferences(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		prfsToCreate := []preferenceForSchedule{}
+		for _, prfsSlice := range preferencesForSchedule {
+			for _, prfsStruct := range prfsSlice {
+				existing, err := vsam.RequestPrFS(currentUser, []preferenceForSchedule{prfsStruct})
+				if err != nil {
+					return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+				}
+				if len(existing) == 0 {
+					prfsToCreate = append(prfsToCreate, prfsStruct)
+				}
+			}
+		}
+		if len(prfsToCreate) > 0 {
+			err = vsam.CreatePrFS(currentUser, prfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil VolunteerUnavailabilityRangeData leaves the saved date ranges alone. Otherwise missing date ranges are created here and the ones no longer in data are deleted by CleanOrphansForSchedule below.
+	if data.VolunteerUnavailabilityRangeData != nil {
+		rangesForSchedule, err := vsam.resolveDateRanges(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		}
+		drfsToCreate := []dateRangeForSchedule{}
+		for _, drfsSlice := range rangesForSchedule {
+			for _, drfsStruct := range drfsSlice {
+				existing, err := vsam.RequestDRFS(currentUser, []dateRangeForSchedule{drfsStruct})
+				if err != nil {
+					return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+				}
+				if len(existing) == 0 {
+					drfsToCreate = append(drfsToCreate, drfsStruct)
+				}
+			}
+		}
+		if len(drfsToCreate) > 0 {
+			err = vsam.CreateDRFS(currentUser, drfsToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// A nil VolunteerScheduledData means no completed schedule was sent, so any saved one is left alone. Otherwise existing SVOD rows are kept when their shift (date, time slot, and role) is still scheduled,
+	// reused (updated to a new shift) when they are stale, and any stale rows left over are deleted by CleanOrphansForSchedule below.
+	if data.VolunteerScheduledData != nil {
+		svodToCreate := []scheduledVolunteerOnDate{}
+		svodToUpdate := []scheduledVolunteerOnDate{}
+		for key, value := range data.VolunteerScheduledData {
+			volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+			if err != nil { // I want this to error if somehow we are trying to create an SVOD for a Volunteer without a VFS, because that should have been taken care of already (at the latest by the call to CreateVFS above).
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			scheduledShifts, err := vsam.resolveShiftKeys(value)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			existingSVOD, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: vfsStruct.VFSID}})
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+			existingShifts := []scheduledVolunteerOnDate{}
+			staleSVOD := []scheduledVolunteerOnDate{}
+			for _, svod := range existingSVOD {
+				if shift := (scheduledVolunteerOnDate{Date: svod.Date, TimeSlot: svod.TimeSlot, Role: svod.Role}); slices.Contains(scheduledShifts, shift) {
+					existingShifts = append(existingShifts, shift)
+				} else {
+					staleSVOD = append(staleSVOD, svod)
+				}
+			}
+			for _, shift := range scheduledShifts {
+				if slices.Contains(existingShifts, shift) {
+					continue
+				}
+				if len(staleSVOD) > 0 {
+					svodToUpdate = append(svodToUpdate, scheduledVolunteerOnDate{SVODID: staleSVOD[0].SVODID, Date: shift.Date, TimeSlot: shift.TimeSlot, Role: shift.Role})
+					staleSVOD = staleSVOD[1:]
+				} else {
+					svodToCreate = append(svodToCreate, scheduledVolunteerOnDate{VolunteerForSchedule: vfsStruct.VFSID, Date: shift.Date, TimeSlot: shift.TimeSlot, Role: shift.Role})
+				}
+			}
+		}
+		if len(svodToUpdate) > 0 {
+			err = vsam.UpdateSVOD(currentUser, svodToUpdate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+		if len(svodToCreate) > 0 {
+			err = vsam.CreateSVOD(currentUser, svodToCreate)
+			if err != nil {
+				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			}
+		}
+	}
+	// CleanOrphanedVolunteers is easy, but affects all schedules
+	err = vsam.CleanOrphanedVolunteers(currentUser)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	// Clean orphans related to the schedule specified by data
+	err = vsam.CleanOrphansForSchedule(currentUser, data)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	// Like CleanOrphanedVolunteers, this affects all schedules
+	err = vsam.CleanOrphanedRoles(currentUser)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	return nil
+}
+
+// requestOrCreateRoles returns the role records for roleNames in the same order, creating the roles that do not exist yet. Role names must be non-empty, must not contain ShiftKeySeparator, and must not repeat.
+func (vsam VSAModel) requestOrCreateRoles(currentUser string, roleNames []string) ([]role, error) {
+	rolesToCreate := []role{}
+	for i, roleName := range roleNames {
+		if roleName == "" || strings.Contains(roleName, ShiftKeySeparator) {
+			return []role{}, fmt.Errorf("error in requestOrCreateRoles: role names cannot be empty or contain \"%s\". Value of roleName is `%s`", ShiftKeySeparator, roleName)
+		}
+		if slices.Contains(roleNames[:i], roleName) {
+			return []role{}, fmt.Errorf("error in requestOrCreateRoles: role \"%s\" is listed more than once", roleName)
+		}
+		roleSlice, err := vsam.RequestRoles(currentUser, []role{{RoleName: roleName}})
+		if err != nil {
+			return []role{}, fmt.Errorf("error in requestOrCreateRoles: %w", err)
+		}
+		if len(roleSlice) == 0 {
+			rolesToCreate = append(rolesToCreate, role{RoleName: roleName})
+		}
+	}
+	if len(rolesToCreate) > 0 {
+		err := vsam.CreateRoles(currentUser, rolesToCreate)
+		if err != nil {
+			return []role{}, fmt.Errorf("error in requestOrCreateRoles: %w", err)
+		}
+	}
+	result := make([]role, 0, len(roleNames))
+	for _, roleName := range roleNames {
+		roleRecord, err := vsam.RequestRole(currentUser, role{RoleName: roleName})
+		if err != nil {
+			return []role{}, fmt.Errorf("error in requestOrCreateRoles: %w", err)
+		}
+		result = append(result, roleRecord)
+	}
+	return result, nil
+}
+
+// resolvePairings turns data.VolunteerPairingData into pairingForSchedule structs (without PFSID or User) for the schedule in scheduleRecord. Both volunteers of a pairing must be in
+// data.VolunteerUnavailabilityData and already have a VFS, a volunteer cannot be paired with themselves, and a pair can only be listed once (in either order).
+// The pairing is owned by the volunteer whose name sorts first, so a pair is always stored the same way.
+func (vsam VSAModel) resolvePairings(currentUser string, scheduleRecord schedule, data SendReceiveDataStruct) ([]pairingForSchedule, error) {
+	result := []pairingForSchedule{}
+	for _, pairing := range data.VolunteerPairingData {
+		if pairing.Pairing != PairingTogether && pairing.Pairing != PairingApart {
+			return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: pairing of \"%s\" and \"%s\" must be \"%s\" or \"%s\". Value of Pairing is `%s`", pairing.Volunteer, pairing.PairedVolunteer, PairingTogether, PairingApart, pairing.Pairing)
+		}
+		if pairing.Volunteer == pairing.PairedVolunteer {
+			return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: \"%s\" cannot be paired with themselves", pairing.Volunteer)
+		}
+		vfsIDs := []int{}
+		for _, name := range []string{min(pairing.Volunteer, pairing.PairedVolunteer), max(pairing.Volunteer, pairing.PairedVolunteer)} {
+			if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+				return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: \"%s\" has a pairing but is not a volunteer on schedule \"%s\"", name, data.ScheduleName)
+			}
+			volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: name})
+			if err != nil {
+				return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: %w", err)
+			}
+			vfsRecord, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+			if err != nil {
+				return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: %w", err)
+			}
+			vfsIDs = append(vfsIDs, vfsRecord.VFSID)
+		}
+		if slices.ContainsFunc(result, func(pfs pairingForSchedule) bool {
+			return pfs.VolunteerForSchedule == vfsIDs[0] && pfs.PairedVolunteerForSchedule == vfsIDs[1]
+		}) {
+			return []pairingForSchedule{}, fmt.Errorf("error in resolvePairings: \"%s\" and \"%s\" are paired more than once", pairing.Volunteer, pairing.PairedVolunteer)
+		}
+		result = append(result, pairingForSchedule{VolunteerForSchedule: vfsIDs[0], PairedVolunteerForSchedule: vfsIDs[1], Pairing: pairing.Pairing})
+	}
+	return result, nil
+}
+
+// resolveUnavailabilityRules turns data.VolunteerUnavailabilityRuleData into unavailabilityRuleForSchedule structs (without URFSID or User) for the schedule in scheduleRecord, keyed by the VFS of
+// each volunteer in data.VolunteerUnavailabilityData. Rules are stored in the form made by UnavailabilityRule.ToString, and a rule repeated for the same volunteer is only stored once.
+func (vsam VSAModel) resolveUnavailabilityRules(currentUser string, scheduleRecord schedule, data SendReceiveDataStruct) (map[volunteerForSchedule][]unavailabilityRuleForSchedule, error) {
+	for name := range data.VolunteerUnavailabilityRuleData {
+		if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+			return nil, fmt.Errorf("error in resolveUnavailabilityRules: \"%s\" has unavailability rules but is not a volunteer on schedule \"%s\"", name, data.ScheduleName)
+		}
+	}
+	result := map[volunteerForSchedule][]unavailabilityRuleForSchedule{}
+	for name := range data.VolunteerUnavailabilityData {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: name})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolveUnavailabilityRules: %w", err)
+		}
+		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolveUnavailabilityRules: %w", err)
+		}
+		result[vfsStruct] = []unavailabilityRuleForSchedule{}
+		for _, ruleString := range data.VolunteerUnavailabilityRuleData[name] {
+			rule, err := UnavailabilityRule{}.FromString(ruleString)
+			if err != nil {
+				return nil, fmt.Errorf("error in resolveUnavailabilityRules: %w", err)
+			}
+			urfsStruct := unavailabilityRuleForSchedule{VolunteerForSchedule: vfsStruct.VFSID, Rule: rule.ToString()}
+			if !slices.Contains(result[vfsStruct], urfsStruct) {
+				result[vfsStruct] = append(result[vfsStruct], urfsStruct)
+			}
+		}
+	}
+	return result, nil
+}
+
+// resolvePreferences turns data.VolunteerPreferenceData into preferenceForSchedule structs (without PrFSID or User) for the schedule in scheduleRecord, keyed by the VFS of
+// each volunteer in data.VolunteerUnavailabilityData. Preferences are stored in the form made by VolunteerPreference.ToString, and a preference repeated for the same volunteer is only stored once.
+func (vsam VSAModel) resolvePreferences(currentUser string, scheduleRecord schedule, data SendReceiveDataStruct) (map[volunteerForSchedule][]preferenceForSchedule, error) {
+	for name := range data.VolunteerPreferenceData {
+		if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+			return nil, fmt.Errorf("error in resolvePreferences: \"%s\" has preferences but is not a volunteer on schedule \"%s\"", name, data.ScheduleName)
+		}
+	}
+	result := map[volunteerForSchedule][]preferenceForSchedule{}
+	for name := range data.VolunteerUnavailabilityData {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: name})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolvePreferences: %w", err)
+		}
+		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolvePreferences: %w", err)
+		}
+		result[vfsStruct] = []preferenceForSchedule{}
+		for _, preferenceString := range data.VolunteerPreferenceData[name] {
+			preference, err := VolunteerPreference{}.FromString(preferenceString)
+			if err != nil {
+				return nil, fmt.Errorf("error in resolvePreferences: %w", err)
+			}
+			prfsStruct := preferenceForSchedule{VolunteerForSchedule: vfsStruct.VFSID, Preference: preference.ToString()}
+			if !slices.Contains(result[vfsStruct], prfsStruct) {
+				result[vfsStruct] = append(result[vfsStruct], prfsStruct)
+			}
+		}
+	}
+	return result, nil
+}
+
+// resolveDateRanges turns data.VolunteerUnavailabilityRangeData into dateRangeForSchedule structs (without DRFSID or User) for the schedule in scheduleRecord, keyed by the VFS of each volunteer in
+// data.VolunteerUnavailabilityData. A date range repeated for the same volunteer is only stored once.
+func (vsam VSAModel) resolveDateRanges(currentUser string, scheduleRecord schedule, data SendReceiveDataStruct) (map[volunteerForSchedule][]dateRangeForSchedule, error) {
+	for name := range data.VolunteerUnavailabilityRangeData {
+		if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+			return nil, fmt.Errorf("error in resolveDateRanges: \"%s\" has date ranges but is not a volunteer on schedule \"%s\"", name, data.ScheduleName)
+		}
+	}
+	result := map[volunteerForSchedule][]dateRangeForSchedule{}
+	for name := range data.VolunteerUnavailabilityData {
+		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: name})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolveDateRanges: %w", err)
+		}
+		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
+		if err != nil {
+			return nil, fmt.Errorf("error in resolveDateRanges: %w", err)
+		}
+		result[vfsStruct] = []dateRangeForSchedule{}
+		for _, dateRange := range data.VolunteerUnavailabilityRangeData[name] {
+			drfsStruct := dateRangeForSchedule{VolunteerForSchedule: vfsStruct.VFSID}
+			for _, bound := range []struct {
+				value  string
+				target *string
+			}{{dateRange.Start, &drfsStruct.StartDate}, {dateRange.End, &drfsStruct.EndDate}} {
+				dateStruct, err := date{}.FromString(bound.value)
+				if err != nil {
+					return nil, fmt.Errorf("error in resolveDateRanges: %w", err)
+				}
+				*bound.target = dateStruct.ToString()
+			}
+			if drfsStruct.EndDate < drfsStruct.StartDate {
+				return nil, fmt.Errorf("error in resolveDateRanges: the date range of \"%s\" ends before it starts: %+v", name, dateRange)
+			}
+			if !slices.Contains(result[vfsStruct], drfsStruct) {
+				result[vfsStruct] = append(result[vfsStruct], drfsStruct)
+			}
+		}
+	}
+	return result, nil
+}
+
+// resolveShiftKeys turns ShiftKey strings into scheduledVolunteerOnDate structs with only Date, TimeSlot, and Role set. Duplicate shifts are dropped.
+func (vsam VSAModel) resolveShiftKeys(shiftKeys []string) ([]scheduledVolunteerOnDate, error) {
+	result := []scheduledVolunteerOnDate{}
+	for _, shiftKeyString := range shiftKeys {
+		shiftKey, err := ShiftKey{}.FromString(shiftKeyString)
+		if err != nil {
+			return []scheduledVolunteerOnDate{}, fmt.Errorf("error in resolveShiftKeys: %w", err)
+		}
+		dateStruct, err := date{}.FromString(shiftKey.Date)
+		if err != nil {
+			return []scheduledVolunteerOnDate{}, fmt.Errorf("error in resolveShiftKeys: %w", err)
+		}
+		shift := scheduledVolunteerOnDate{Date: dateStruct.ToString(), TimeSlot: shiftKey.TimeSlot, Role: shiftKey.Role}
+		if !slices.Contains(result, shift) {
+			result = append(result, shift)
+		}
+	}
+	return result, nil
+}
+
+// RecieveAndDeleteData deletes the schedule named in data and everything that hangs off it as one transaction.
+func (vsam VSAModel) RecieveAndDeleteData(currentUser string, data SendReceiveDataStruct) error {
+	return vsam.inTransaction(func(txModel VSAModel) error {
+		return txModel.recieveAndDeleteData(currentUser, data)
+	})
+}
+
+func (vsam VSAModel) recieveAndDeleteData(currentUser string, data SendReceiveDataStruct) error {
+	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: data.ScheduleName})
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	// need to delete the UFS, VFS, WFS, SVODs, and unused volunteers for the provided schedule
+	err = vsam.CleanOrphanedVFS(currentUser, map[schedule][]volunteer{scheduleRecord: {}}, true, true)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.CleanOrphanedTSFS(currentUser, map[schedule][]timeSlotForSchedule{scheduleRecord: {}})
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.CleanOrphanedRFS(currentUser, map[schedule][]role{scheduleRecord: {}})
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.CleanOrphanedWFS(currentUser, map[schedule][]weekday{scheduleRecord: {}})
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.DeleteCalendarFeeds(currentUser, scheduleRecord.ScheduleName)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.DeleteSchedules(currentUser, []schedule{scheduleRecord})
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	err = vsam.CleanOrphanedRoles(currentUser)
+	if err != nil {
+		return fmt.Errorf("error in RecieveAndDeleteData: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CleanOrphansForSchedule(currentUser string, data SendReceiveDataStruct) error {
+	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: data.ScheduleName})
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	}
+	if scheduleRecord.ScheduleID < 1 {
+		return fmt.Errorf("error in CleanOrphansForSchedule: could not locate schedule. Value of data is `%#v`", data)
+	}
+	// Clean orphaned WFS
+	correctWeekdays := []weekday{}
+	for _, value := range data.WeekdaysForSchedule {
+		wd, err := vsam.RequestWeekday(weekday{WeekdayName: value})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		correctWeekdays = append(correctWeekdays, wd)
+	}
+	err = vsam.CleanOrphanedWFS(currentUser, map[schedule][]weekday{scheduleRecord: correctWeekdays})
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	}
+	// Clean orphaned TSFS. Time slots on weekdays that are no longer scheduled are always removed, and when data.TimeSlotsForSchedule is not nil so are the ones missing from it.
+	correctTimeSlots := []timeSlotForSchedule{}
+	if data.TimeSlotsForSchedule != nil {
+		for weekdayName, timeSlots := range data.TimeSlotsForSchedule {
+			for _, timeSlot := range timeSlots {
+				correctTimeSlots = append(correctTimeSlots, timeSlotForSchedule{Weekday: weekdayName, SlotName: timeSlot.Name})
+			}
+		}
+	} else {
+		correctTimeSlots, err = vsam.RequestTSFS(currentUser, []timeSlotForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	correctTimeSlots = slices.DeleteFunc(correctTimeSlots, func(tsfs timeSlotForSchedule) bool { return !slices.Contains(data.WeekdaysForSchedule, tsfs.Weekday) })
+	err = vsam.CleanOrphanedTSFS(currentUser, map[schedule][]timeSlotForSchedule{scheduleRecord: correctTimeSlots})
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	}
+	// Clean orphaned RFS when data.RolesForSchedule is not nil
+	if data.RolesForSchedule != nil {
+		correctRoles := []role{}
+		for _, requirement := range data.RolesForSchedule {
+			r, err := vsam.RequestRole(currentUser, role{RoleName: requirement.Name})
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+			}
+			correctRoles = append(correctRoles, r)
+		}
+		err = vsam.CleanOrphanedRFS(currentUser, map[schedule][]role{scheduleRecord: correctRoles})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned VFS (which optionally does delete UFS for VFS that are going to be cleaned) then clean UFS
+	correctVolunteers := []volunteer{}
+	correctUFS := map[volunteerForSchedule][]date{}
+	for key, value := range data.VolunteerUnavailabilityData {
+		v, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		correctVolunteers = append(correctVolunteers, v)
+		vfs, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Volunteer: v.VolunteerID, Schedule: scheduleRecord.ScheduleID})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		for _, dateString := range value {
+			dateStruct, err := date{}.FromString(dateString)
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+			}
+			correctUFS[vfs] = append(correctUFS[vfs], dateStruct)
+		}
+	}
+	err = vsam.CleanOrphanedVFS(currentUser, map[schedule][]volunteer{scheduleRecord: correctVolunteers}, true, true)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	}
+	err = vsam.CleanOrphanedUFS(currentUser, correctUFS)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	}
+	// Clean orphaned PFS when data.VolunteerPairingData is not nil. Every remaining VFS gets an entry so volunteers who are no longer paired at all lose their PFS rows too.
+	if data.VolunteerPairingData != nil {
+		pairingsForSchedule, err := vsam.resolvePairings(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		correctPFS := map[volunteerForSchedule][]pairingForSchedule{}
+		for _, vfs := range volunteersForSchedule {
+			correctPFS[vfs] = []pairingForSchedule{}
+			for _, pfs := range pairingsForSchedule {
+				if pfs.VolunteerForSchedule == vfs.VFSID {
+					correctPFS[vfs] = append(correctPFS[vfs], pfs)
+				}
+			}
+		}
+		err = vsam.CleanOrphanedPFS(currentUser, correctPFS)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned URFS when data.VolunteerUnavailabilityRuleData is not nil. A volunteer missing from data.VolunteerUnavailabilityRuleData loses all of their rules.
+	if data.VolunteerUnavailabilityRuleData != nil {
+		correctURFS, err := vsam.resolveUnavailabilityRules(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		err = vsam.CleanOrphanedURFS(currentUser, correctURFS)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned PrFS when data.VolunteerPreferenceData is not nil. A volunteer missing from data.VolunteerPreferenceData loses all of their preferences.
+	if data.VolunteerPreferenceData != nil {
+		correctPrFS, err := vsam.resolvePreferences(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		err = vsam.CleanOrphanedPrFS(currentUser, correctPrFS)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned DRFS when data.VolunteerUnavailabilityRangeData is not nil. A volunteer missing from data.VolunteerUnavailabilityRangeData loses all of their date ranges.
+	if data.VolunteerUnavailabilityRangeData != nil {
+		correctDRFS, err := vsam.resolveDateRanges(currentUser, scheduleRecord, data)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		err = vsam.CleanOrphanedDRFS(currentUser, correctDRFS)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned VR for the volunteers on the schedule when data.VolunteerRoleData is not nil. A volunteer missing from data.VolunteerRoleData loses all of their roles.
+	if data.VolunteerRoleData != nil {
+		correctVR := map[volunteer][]role{}
+		for _, v := range correctVolunteers {
+			correctVR[v] = []role{}
+			for _, roleName := range data.VolunteerRoleData[v.VolunteerName] {
+				r, err := vsam.RequestRole(currentUser, role{RoleName: roleName})
+				if err != nil {
+					return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+				}
+				correctVR[v] = append(correctVR[v], r)
+			}
+		}
+		err = vsam.CleanOrphanedVR(currentUser, correctVR)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	// Clean orphaned SVOD. Every remaining VFS gets an entry so volunteers who are no longer scheduled at all lose their SVOD rows too.
+	if data.VolunteerScheduledData != nil {
+		volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: scheduleRecord.ScheduleID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+		correctSVOD := map[volunteerForSchedule][]scheduledVolunteerOnDate{}
+		for _, vfs := range volunteersForSchedule {
+			v, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: vfs.Volunteer})
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+			}
+			correctSVOD[vfs], err = vsam.resolveShiftKeys(data.VolunteerScheduledData[v.VolunteerName])
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+			}
+		}
+		err = vsam.CleanOrphanedSVOD(currentUser, correctSVOD)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// This function exists to validate WeekdayName spelling and provide WeekdayID if needed. There is no RequestWeekdays method
+func (vsam VSAModel) RequestWeekday(weekdayStruct weekday) (weekday, error) {
+	if weekdayStruct == (weekday{}) {
+		return weekday{}, errors.New("error in RequestWeekday: method failed because all of the values in weekdayStruct had an empty/default values")
+	}
+	var weekdays []weekday
+	weekdayQuery := newSQLQuery(`select * from Weekdays where WeekdayID=? or WeekdayName=?`, weekdayStruct.WeekdayID, weekdayStruct.WeekdayName)
+	rows, err := vsam.querier().Query(weekdayQuery.text, weekdayQuery.args...)
+	if err != nil {
+		return weekday{}, fmt.Errorf("error in RequestWeekday: sql.DB.Query error: %w. Value of weekdayQuery is `%s`", err, weekdayQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var weekdayStruct weekday
+		err = rows.Scan(&weekdayStruct.WeekdayID, &weekdayStruct.WeekdayName)
+		if err != nil {
+			return weekday{}, fmt.Errorf("error in RequestWeekday: sql.Rows.Scan error: %w. Value of weekdayStruct is `%+v`", err, weekdayStruct)
+		}
+		weekdays = append(weekdays, weekdayStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return weekday{}, fmt.Errorf("error in RequestWeekday: sql.Rows.Err error: %w", err)
+	}
+	if len(weekdays) != 1 {
+		return weekday{}, fmt.Errorf("error in RequestWeekday: method failed to locate exactly one weekday matching `%+v`. Found %d matches", weekdayStruct, len(weekdays))
+	}
+	return weekdays[0], nil
+}
+
+// This function exists to validate MonthName spelling and provide MonthID if needed. There is no RequestMonths method
+func (vsam VSAModel) RequestMonth(monthStruct month) (month, error) {
+	if monthStruct == (month{}) {
+		return month{}, errors.New("error in RequestMonth: method failed because all of the values in monthStruct had an empty/default values")
+	}
+	var months []month
+	monthQuery := newSQLQuery(`select * from Months where MonthID=? or MonthName=?`, monthStruct.MonthID, monthStruct.MonthName)
+	rows, err := vsam.querier().Query(monthQuery.text, monthQuery.args...)
+	if err != nil {
+		return month{}, fmt.Errorf("error in RequestMonth: sql.DB.Query error: %w. Value of monthQuery is `%s`", err, monthQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var monthStruct month
+		err = rows.Scan(&monthStruct.MonthID, &monthStruct.MonthName)
+		if err != nil {
+			return month{}, fmt.Errorf("error in RequestMonth: sql.Rows.Scan error: %w. Value of monthStruct is `%+v`", err, monthStruct)
+		}
+		months = append(months, monthStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return month{}, fmt.Errorf("error in RequestMonth: sql.Rows.Err error: %w", err)
+	}
+	if len(months) != 1 {
+		return month{}, fmt.Errorf("error in RequestMonth: method failed to locate exactly one month matching `%+v`. Found %d matches", monthStruct, len(months))
+	}
+	return months[0], nil
+}
+
+func (vsam VSAModel) CreateVolunteers(currentUser string, toCreate []volunteer) error {
+	check, err := vsam.RequestVolunteers(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateVolunteers: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteers to be created already exists in the database. Existing volunteer(s): %+v", check)
+	}
+	checkDuplicates := []volunteer{}
+	for _, val := range toCreate { // User and VolunteerID do not need to be provided in the volunteer structs
+		if val.VolunteerName == (volunteer{}.VolunteerName) {
+			return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteer structs in toCreate did not have a value for VolunteerName: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, volunteer{VolunteerName: val.VolunteerName}) {
+			checkDuplicates = append(checkDuplicates, volunteer{VolunteerName: val.VolunteerName})
+		} else {
+			return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteer structs in toCreate was a duplicate of another volunteer struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateVolunteers: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillVolunteersTableString := `insert into Volunteers (VolunteerName, User, Email, Phone) values (?, ?, ?, ?)`
+	fillVolunteersTableStmt, err := tx.Prepare(fillVolunteersTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateVolunteers: sql.Tx.Prepare error: %w. Value of fillVolunteersTableString is `%s`", err, fillVolunteersTableString)
+	}
+	defer fillVolunteersTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillVolunteersTableStmt.Exec(toCreate[i].VolunteerName, currentUser, toCreate[i].Email, toCreate[i].Phone)
+		if err != nil {
+			return fmt.Errorf("error in CreateVolunteers: sql.Stmt.Exec error: %w. toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateVolunteers: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestVolunteer(currentUser string, volunteerStruct volunteer) (volunteer, error) {
+	volunteers, err := vsam.RequestVolunteers(currentUser, []volunteer{volunteerStruct})
+	if err != nil {
+		return volunteer{}, fmt.Errorf("error in RequestVolunteer %+v: %w", volunteerStruct, err)
+	}
+	if len(volunteers) != 1 {
+		return volunteer{}, fmt.Errorf("error in RequestVolunteer %+v. Failed to locate exactly one volunteer matching volunteerStruct. Found %d matches", volunteerStruct, len(volunteers))
+	}
+	return volunteers[0], nil
+}
+
+func (vsam VSAModel) RequestVolunteers(currentUser string, volunteers []volunteer) ([]volunteer, error) {
+	volunteersQuery := newSQLQuery(`select VolunteerID, VolunteerName, User, Email, Phone from Volunteers where User = ?`, currentUser)
+	if len(volunteers) > 0 {
+		if check, failed := testEmpty(volunteers, volunteer{}); check {
+			return []volunteer{}, fmt.Errorf("error in RequestVolunteers: method failed because one of the values in volunteers had an empty/default values volunteer struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(volunteers))
+	for i := 0; i < len(volunteers); i++ {
+		var match sqlMatch
+		if volunteers[i].VolunteerID > 0 {
+			match.equals("VolunteerID", volunteers[i].VolunteerID)
+		}
+		if len(volunteers[i].VolunteerName) > 0 {
+			match.equals("VolunteerName", volunteers[i].VolunteerName)
+		}
+		if len(volunteers[i].User) > 0 {
+			match.equals("User", volunteers[i].User)
+		}
+		matches = append(matches, match)
+	}
+	volunteersQuery.addMatchAny(" and ", matches)
+	volunteersQuery.add(` order by VolunteerID`)
+	//fmt.Println(volunteersQuery)
+	var result []volunteer
+	rows, err := vsam.querier().Query(volunteersQuery.text, volunteersQuery.args...)
+	if err != nil {
+		return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.DB.Query error: %w. Value of volunteersQuery is `%s`", err, volunteersQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var volunteerStruct volunteer
+		err = rows.Scan(&volunteerStruct.VolunteerID, &volunteerStruct.VolunteerName, &volunteerStruct.User, &volunteerStruct.Email, &volunteerStruct.Phone)
+		if err != nil {
+			return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.Rows.Scan error: %w. Value of volunteerStruct is `%+v`", err, volunteerStruct)
+		}
+		result = append(result, volunteerStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+func (vsam VSAModel) UpdateVolunteers(currentUser string, toUpdate []volunteer) error {
+	if check, failed := testEmpty(toUpdate, volunteer{}); check {
+		return fmt.Errorf("error in UpdateVolunteers: method failed because one of the values in toUpdate had an empty/default values volunteer struct: %+v", failed)
+	}
+	checkDuplicates := []volunteer{}
+	for _, val := range toUpdate {
+		if val.VolunteerID == (volunteer{}.VolunteerID) { // User does not need to be provided in the volunteer struct
+			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate had an empty/default value for VolunteerID: %+v", val)
+		} else if val.VolunteerName == (volunteer{}.VolunteerName) {
+			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate had an empty/default value for VolunteerName: %+v", val)
+		} else if check, err := vsam.RequestVolunteers(currentUser, []volunteer{{VolunteerName: val.VolunteerName, User: currentUser}}); len(check) > 0 {
+			if err != nil {
+				return fmt.Errorf("error in UpdateVolunteers `%+v`: %w", val, err)
+			}
+			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate would create a duplicate volunteer (each volunteer name must be unique per user): %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, volunteer{VolunteerName: val.VolunteerName}) {
+			checkDuplicates = append(checkDuplicates, volunteer{VolunteerName: val.VolunteerName})
+		} else {
+			return fmt.Errorf("error in UpdateVolunteers: method failed because at least two of the volunteer structs in toUpdate would create duplicate volunteer structs in the database: %+v", volunteer{VolunteerName: val.VolunteerName})
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteers: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	updateVolunteersString := `update Volunteers set VolunteerName=? where User=? and VolunteerID=?`
+	updateVolunteersStmt, err := tx.Prepare(updateVolunteersString)
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteers: sql.Tx.Prepare error: %w. value of updateVolunteersString is `%s`", err, updateVolunteersString)
+	}
+	defer updateVolunteersStmt.Close()
+	for _, val := range toUpdate {
+		_, err = updateVolunteersStmt.Exec(val.VolunteerName, currentUser, val.VolunteerID)
+		if err != nil {
+			return fmt.Errorf("error in UpdateVolunteers: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteers: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// UpdateVolunteerContacts sets the Email and Phone of the volunteers with the VolunteerID of each volunteer struct. Unlike UpdateVolunteers, the VolunteerName is left alone, and an empty Email
+// or Phone clears it.
+func (vsam VSAModel) UpdateVolunteerContacts(currentUser string, toUpdate []volunteer) error {
+	for _, val := range toUpdate {
+		if val.VolunteerID == (volunteer{}.VolunteerID) {
+			return fmt.Errorf("error in UpdateVolunteerContacts: method failed because one of the volunteer structs in toUpdate had an empty/default value for VolunteerID: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteerContacts: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	updateContactsString := `update Volunteers set Email=?, Phone=? where User=? and VolunteerID=?`
+	updateContactsStmt, err := tx.Prepare(updateContactsString)
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteerContacts: sql.Tx.Prepare error: %w. value of updateContactsString is `%s`", err, updateContactsString)
+	}
+	defer updateContactsStmt.Close()
+	for _, val := range toUpdate {
+		_, err = updateContactsStmt.Exec(val.Email, val.Phone, currentUser, val.VolunteerID)
+		if err != nil {
+			return fmt.Errorf("error in UpdateVolunteerContacts: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in UpdateVolunteerContacts: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Will delete Volunteers database entries that match the VolunteerID or that match the VolunteerName provided in each volunteer struct. If a VolunteerID > 0 is provided, the value for VolunteerName is ignored for that volunteer struct.
+func (vsam VSAModel) DeleteVolunteers(currentUser string, toDelete []volunteer) error {
+	if check, failed := testEmpty(toDelete, volunteer{}); check {
+		return fmt.Errorf("error in DeleteVolunteers: method failed because one of the values in toDelete had an empty/default values volunteer struct: %+v", failed)
+	}
+	for _, val := range toDelete {
+		if val.VolunteerID == (volunteer{}.VolunteerID) && val.VolunteerName == (volunteer{}.VolunteerName) { // User does not need to be provided in the volunteer struct. One of VolunteerID and VolunteerName must be provided
+			return fmt.Errorf("error in DeleteVolunteers: method failed because one of the volunteer structs in toDelete had empty/default values for VolunteerID and VolunteerName (at least one must be provided): %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteVolunteers: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteVolunteerString sqlQuery
+		if val.VolunteerID > 0 {
+			deleteVolunteerString = newSQLQuery(`delete from Volunteers where User=? and VolunteerID=?`, currentUser, val.VolunteerID)
+		} else {
+			deleteVolunteerString = newSQLQuery(`delete from Volunteers where User=? and VolunteerName=?`, currentUser, val.VolunteerName)
+		}
+		_, err := tx.Exec(deleteVolunteerString.text, deleteVolunteerString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteVolunteers: sql.Tx.Exec error %w. Value of deleteVolunteerString is `%s`", err, deleteVolunteerString)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteVolunteers: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Deletes all volunteers who do not have a VFS entry, along with their VolunteerRoles entries
+func (vsam VSAModel) CleanOrphanedVolunteers(currentUser string) error {
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	// role qualifications of the volunteers being deleted go first
+	cleanOrphanedVRString := newSQLQuery(`delete from VolunteerRoles where User = ? and Volunteer not in (select Volunteer from VolunteersForSchedule)`, currentUser)
+	_, err = tx.Exec(cleanOrphanedVRString.text, cleanOrphanedVRString.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.Tx.Exec error: %w. Value of cleanOrphanedVRString is `%s`", err, cleanOrphanedVRString)
+	}
+	cleanOrphanedVolunteersString := newSQLQuery(`delete from Volunteers where User = ? and VolunteerID not in (select Volunteer from VolunteersForSchedule)`, currentUser)
+	_, err = tx.Exec(cleanOrphanedVolunteersString.text, cleanOrphanedVolunteersString.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.Tx.Exec error: %w. Value of cleanOrphanedVolunteersString is `%s`", err, cleanOrphanedVolunteersString)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreateRoles(currentUser string, toCreate []role) error {
+	check, err := vsam.RequestRoles(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateRoles: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateRoles: method failed because at least one of the roles to be created already exists in the database. Existing role(s): %+v", check)
+	}
+	checkDuplicates := []role{}
+	for _, val := range toCreate { // User and RoleID do not need to be provided in the role structs
+		if val.RoleName == (role{}.RoleName) {
+			return fmt.Errorf("error in CreateRoles: method failed because at least one of the role structs in toCreate did not have a value for RoleName: %+v", val)
+		}
+		if strings.Contains(val.RoleName, ShiftKeySeparator) {
+			return fmt.Errorf("error in CreateRoles: method failed because at least one of the role structs in toCreate had a RoleName containing \"%s\": %+v", ShiftKeySeparator, val)
+		}
+		if !slices.Contains(checkDuplicates, role{RoleName: val.RoleName}) {
+			checkDuplicates = append(checkDuplicates, role{RoleName: val.RoleName})
+		} else {
+			return fmt.Errorf("error in CreateRoles: method failed because at least one of the role structs in toCreate was a duplicate of another role struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateRoles: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillRolesTableString := `insert into Roles (RoleName, User) values (?, ?)`
+	fillRolesTableStmt, err := tx.Prepare(fillRolesTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateRoles: sql.Tx.Prepare error: %w. Value of fillRolesTableString is `%s`", err, fillRolesTableString)
+	}
+	defer fillRolesTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillRolesTableStmt.Exec(toCreate[i].RoleName, currentUser)
+		if err != nil {
+			return fmt.Errorf("error in CreateRoles: sql.Stmt.Exec error: %w. toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateRoles: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestRole(currentUser string, roleStruct role) (role, error) {
+	roles, err := vsam.RequestRoles(currentUser, []role{roleStruct})
+	if err != nil {
+		return role{}, fmt.Errorf("error in RequestRole %+v: %w", roleStruct, err)
+	}
+	if len(roles) != 1 {
+		return role{}, fmt.Errorf("error in RequestRole %+v. Failed to locate exactly one role matching roleStruct. Found %d matches", roleStruct, len(roles))
+	}
+	return roles[0], nil
+}
+
+func (vsam VSAModel) RequestRoles(currentUser string, roles []role) ([]role, error) {
+	rolesQuery := newSQLQuery(`select * from Roles where User = ?`, currentUser)
+	if len(roles) > 0 {
+		if check, failed := testEmpty(roles, role{}); check {
+			return []role{}, fmt.Errorf("error in RequestRoles: method failed because one of the values in roles had an empty/default values role struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(roles))
+	for i := 0; i < len(roles); i++ {
+		var match sqlMatch
+		if roles[i].RoleID > 0 {
+			match.equals("RoleID", roles[i].RoleID)
+		}
+		if len(roles[i].RoleName) > 0 {
+			match.equals("RoleName", roles[i].RoleName)
+		}
+		if len(roles[i].User) > 0 {
+			match.equals("User", roles[i].User)
+		}
+		matches = append(matches, match)
+	}
+	rolesQuery.addMatchAny(" and ", matches)
+	rolesQuery.add(` order by RoleID`)
+	var result []role
+	rows, err := vsam.querier().Query(rolesQuery.text, rolesQuery.args...)
+	if err != nil {
+		return []role{}, fmt.Errorf("error in RequestRoles: sql.DB.Query error: %w. Value of rolesQuery is `%s`", err, rolesQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var roleStruct role
+		err = rows.Scan(&roleStruct.RoleID, &roleStruct.RoleName, &roleStruct.User)
+		if err != nil {
+			return []role{}, fmt.Errorf("error in RequestRoles: sql.Rows.Scan error: %w. Value of roleStruct is `%+v`", err, roleStruct)
+		}
+		result = append(result, roleStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []role{}, fmt.Errorf("error in RequestRoles: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+// Will delete Roles database entries that match the RoleID or that match the RoleName provided in each role struct. If a RoleID > 0 is provided, the value for RoleName is ignored for that role struct.
+func (vsam VSAModel) DeleteRoles(currentUser string, toDelete []role) error {
+	if check, failed := testEmpty(toDelete, role{}); check {
+		return fmt.Errorf("error in DeleteRoles: method failed because one of the values in toDelete had an empty/default values role struct: %+v", failed)
+	}
+	for _, val := range toDelete {
+		if val.RoleID == (role{}.RoleID) && val.RoleName == (role{}.RoleName) { // User does not need to be provided in the role struct. One of RoleID and RoleName must be provided
+			return fmt.Errorf("error in DeleteRoles: method failed because one of the role structs in toDelete had empty/default values for RoleID and RoleName (at least one must be provided): %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteRoles: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteRoleString sqlQuery
+		if val.RoleID > 0 {
+			deleteRoleString = newSQLQuery(`delete from Roles where User=? and RoleID=?`, currentUser, val.RoleID)
+		} else {
+			deleteRoleString = newSQLQuery(`delete from Roles where User=? and RoleName=?`, currentUser, val.RoleName)
+		}
+		_, err := tx.Exec(deleteRoleString.text, deleteRoleString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteRoles: sql.Tx.Exec error %w. Value of deleteRoleString is `%s`", err, deleteRoleString)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteRoles: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Deletes all roles that no volunteer is qualified for and no schedule requires
+func (vsam VSAModel) CleanOrphanedRoles(currentUser string) error {
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedRoles: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	cleanOrphanedRolesString := newSQLQuery(`delete from Roles where User = ? and RoleID not in (select Role from VolunteerRoles) and RoleID not in (select Role from RolesForSchedule)`, currentUser)
+	_, err = tx.Exec(cleanOrphanedRolesString.text, cleanOrphanedRolesString.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedRoles: sql.Tx.Exec error: %w. Value of cleanOrphanedRolesString is `%s`", err, cleanOrphanedRolesString)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedRoles: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreateVR(currentUser string, toCreate []volunteerRole) error {
+	check, err := vsam.RequestVR(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateVR: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateVR: method failed because at least one of the volunteerRole entries to be created already exists in the database. Existing volunteerRole(s): %+v", check)
+	}
+	checkDuplicates := []volunteerRole{}
+	for _, val := range toCreate { // User and VRID do not need to be provided in the volunteerRole structs
+		if val.Volunteer == (volunteerRole{}.Volunteer) {
+			return fmt.Errorf("error in CreateVR: method failed because at least one of the volunteerRole structs in toCreate did not have a value for Volunteer: %+v", val)
+		}
+		if val.Role == (volunteerRole{}.Role) {
+			return fmt.Errorf("error in CreateVR: method failed because at least one of the volunteerRole structs in toCreate did not have a value for Role: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, volunteerRole{Volunteer: val.Volunteer, Role: val.Role}) {
+			checkDuplicates = append(checkDuplicates, volunteerRole{Volunteer: val.Volunteer, Role: val.Role})
+		} else {
+			return fmt.Errorf("error in CreateVR: method failed because at least one of the volunteerRole structs in toCreate was a duplicate of another volunteerRole struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateVR: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillVRTableString := `insert into VolunteerRoles (User, Volunteer, Role) values (?, ?, ?)`
+	fillVRTableStmt, err := tx.Prepare(fillVRTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateVR: sql.Tx.Prepare error: %w. Value of fillVRTableString is `%s`", err, fillVRTableString)
+	}
+	defer fillVRTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillVRTableStmt.Exec(currentUser, toCreate[i].Volunteer, toCreate[i].Role)
+		if err != nil {
+			return fmt.Errorf("error in CreateVR: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateVR: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestVRSingle(currentUser string, volunteerRoleStruct volunteerRole) (volunteerRole, error) {
+	volunteerRoles, err := vsam.RequestVR(currentUser, []volunteerRole{volunteerRoleStruct})
+	if err != nil {
+		return volunteerRole{}, fmt.Errorf("error in RequestVRSingle: %w", err)
+	}
+	if len(volunteerRoles) != 1 {
+		return volunteerRole{}, fmt.Errorf("error in RequestVRSingle: method failed to locate exactly one VR matching %+v. Found %d matches", volunteerRoleStruct, len(volunteerRoles))
+	}
+	return volunteerRoles[0], nil
+}
+
+func (vsam VSAModel) RequestVR(currentUser string, volunteerRoles []volunteerRole) ([]volunteerRole, error) {
+	VRQuery := newSQLQuery(`select * from VolunteerRoles where User = ?`, currentUser)
+	if len(volunteerRoles) > 0 {
+		if check, failed := testEmpty(volunteerRoles, volunteerRole{}); check {
+			return []volunteerRole{}, fmt.Errorf("error in RequestVR: method failed because one of the values in volunteerRoles had an empty/default values volunteerRole struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(volunteerRoles))
+	for i := 0; i < len(volunteerRoles); i++ {
+		var match sqlMatch
+		if volunteerRoles[i].VRID > 0 {
+			match.equals("VRID", volunteerRoles[i].VRID)
+		}
+		if len(volunteerRoles[i].User) > 0 {
+			match.equals("User", volunteerRoles[i].User)
+		}
+		if volunteerRoles[i].Volunteer > 0 {
+			match.equals("Volunteer", volunteerRoles[i].Volunteer)
+		}
+		if volunteerRoles[i].Role > 0 {
+			match.equals("Role", volunteerRoles[i].Role)
+		}
+		matches = append(matches, match)
+	}
+	VRQuery.addMatchAny(" and ", matches)
+	VRQuery.add(` order by VRID`)
+	var result []volunteerRole
+	rows, err := vsam.querier().Query(VRQuery.text, VRQuery.args...)
+	if err != nil {
+		return []volunteerRole{}, fmt.Errorf("error in RequestVR: sql.DB.Query error: %w. Value of VRQuery is `%s`", err, VRQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var VRStruct volunteerRole
+		err = rows.Scan(&VRStruct.VRID, &VRStruct.User, &VRStruct.Volunteer, &VRStruct.Role)
+		if err != nil {
+			return []volunteerRole{}, fmt.Errorf("error in RequestVR: sql.Rows.Scan error: %w. Value of VRStruct is `%+v`", err, VRStruct)
+		}
+		result = append(result, VRStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []volunteerRole{}, fmt.Errorf("error in RequestVR: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+// Will delete VR database entries that match the VRID or that match the Volunteer and Role provided in each VR struct. If a VRID > 0 is provided, the values for Volunteer and Role are ignored for that VR struct.
+func (vsam VSAModel) DeleteVR(currentUser string, toDelete []volunteerRole) error {
+	for _, val := range toDelete {
+		if val.VRID < 1 && (val.Volunteer < 1 || val.Role < 1) {
+			return fmt.Errorf("error in DeleteVR: method failed because one of the volunteerRole structs did not have a value for VRID or Volunteer and Role: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteVR: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteVRString sqlQuery
+		if val.VRID > 0 {
+			deleteVRString = newSQLQuery(`delete from VolunteerRoles where User=? and VRID=?`, currentUser, val.VRID)
+		} else {
+			deleteVRString = newSQLQuery(`delete from VolunteerRoles where User=? and Volunteer=? and Role=?`, currentUser, val.Volunteer, val.Role)
+		}
+		_, err := tx.Exec(deleteVRString.text, deleteVRString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteVR: sql.Tx.Exec error: %w. Value of deleteVRString is `%s`", err, deleteVRString)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteVR: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// correctVR is a map with volunteer structs as keys and slices of the roles each volunteer is qualified for as values. If a VR row is linked to a volunteer, but doesn't match one of those roles, delete that VR row.
+func (vsam VSAModel) CleanOrphanedVR(currentUser string, correctVR map[volunteer][]role) error {
+	var VRToDelete []int
+	for key, value := range correctVR {
+		if key.VolunteerID == 0 {
+			return fmt.Errorf("error in CleanOrphanedVR: method failed because one of the provided volunteer structs did not have a VolunteerID: %+v", key)
+		}
+		var roleIDs []int
+		for _, roleStruct := range value {
+			if roleStruct.RoleID == 0 {
+				return fmt.Errorf("error in CleanOrphanedVR: method failed because one of the provided role structs did not have a RoleID: %+v", value)
+			}
+			roleIDs = append(roleIDs, roleStruct.RoleID)
+		}
+		VRCheck, err := vsam.RequestVR(currentUser, []volunteerRole{{Volunteer: key.VolunteerID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVR: %w", err)
+		}
+		for _, VR := range VRCheck {
+			if !slices.Contains(roleIDs, VR.Role) {
+				VRToDelete = append(VRToDelete, VR.VRID)
+			}
+		}
+		tx, err := vsam.begin()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVR: sql.DB.Begin error: %w", err)
+		}
+		defer tx.Rollback()
+		deleteVRQuery := newSQLQuery(`delete from VolunteerRoles where User = ?`, currentUser)
+		deleteVRQuery.addIn(" and ", "VRID", VRToDelete)
+		_, err = tx.Exec(deleteVRQuery.text, deleteVRQuery.args...)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVR: sql.Tx.Exec error: %w. Value of deleteVRQuery is `%s`", err, deleteVRQuery)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVR: sql.Tx.Commit error: %w", err)
+		}
+	}
+	return nil
+}
+
+// This function is the simplified version of CreateSchedulesExtended and does not allow schedules to be created where ShiftsOff = 0
+func (vsam VSAModel) CreateSchedules(currentUser string, toCreate []schedule) error {
+	err := vsam.CreateSchedulesExtended(currentUser, toCreate, false)
+	if err != nil {
+		return fmt.Errorf("error in CreateSchedules: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreateSchedulesExtended(currentUser string, toCreate []schedule, includeShiftsOff0 bool) error {
+	check, err := vsam.RequestSchedulesExtended(currentUser, toCreate, includeShiftsOff0)
+	if err != nil {
+		return fmt.Errorf("error in CreateSchedulesExtended: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedules to be created already exists in the database. Existing schedule(s): %+v", check)
+	}
+	checkDuplicates := []schedule{}
+	for _, val := range toCreate { // User and ScheduleID do not need to be provided in the schedule structs
+		if val.ScheduleName <= (schedule{}.ScheduleName) {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for ScheduleName: %+v", val)
+		}
+		if !includeShiftsOff0 && val.ShiftsOff <= (schedule{}.ShiftsOff) || val.ShiftsOff <= -1 {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for ShiftsOff: %+v", val)
+		}
+		if val.VolunteersPerShift <= (schedule{}.VolunteersPerShift) {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for VolunteersPerShift: %+v", val)
+		}
+		if val.StartDate <= (schedule{}.StartDate) {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for StartDate: %+v", val)
+		}
+		if val.EndDate <= (schedule{}.EndDate) {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for EndDate: %+v", val)
+		}
+		if err := checkDates(val.StartDate, val.EndDate); err != nil {
+			return fmt.Errorf("error in CreateSchedulesExtended: %w", err)
+		}
+		if !slices.Contains(checkDuplicates, schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate}) {
+			checkDuplicates = append(checkDuplicates, schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate})
+		} else {
+			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate was a duplicate of another schedule struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateSchedulesExtended: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillSchedulesTableString := `insert into Schedules (ScheduleName, ShiftsOff, VolunteersPerShift, User, StartDate, EndDate) values (?, ?, ?, ?, ?, ?)`
+	fillSchedulesTableStmt, err := tx.Prepare(fillSchedulesTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateSchedulesExtended: sql.Tx.Prepare error: %w. Value of fillSchedulesTableString is `%s`", err, fillSchedulesTableString)
+	}
+	defer fillSchedulesTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillSchedulesTableStmt.Exec(toCreate[i].ScheduleName, toCreate[i].ShiftsOff, toCreate[i].VolunteersPerShift, currentUser, toCreate[i].StartDate, toCreate[i].EndDate)
+		if err != nil {
+			return fmt.Errorf("error in CreateSchedulesExtended: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateSchedulesExtended: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Calls RequestSchedules with scheduleStruct and verifies exactly one database row matches.
+func (vsam VSAModel) RequestSchedule(currentUser string, scheduleStruct schedule) (schedule, error) {
+	schedules, err := vsam.RequestSchedules(currentUser, []schedule{scheduleStruct})
+	if err != nil {
+		return schedule{}, fmt.Errorf("error in RequestSchedule: %w", err)
+	}
+	if len(schedules) == 0 {
+		return schedule{}, fmt.Errorf("error in RequestSchedule: %w: method failed to locate a schedule matching %+v", ErrNotFound, scheduleStruct)
+	}
+	if len(schedules) != 1 {
+		return schedule{}, fmt.Errorf("error in RequestSchedule: method failed to locate exactly one schedule matching %+v. Found %d matches", scheduleStruct, len(schedules))
+	}
+	return schedules[0], nil
+}
+
+// This function is the simple version of RequestSchedulesExtended and does not allow ShiftsOff = 0 to be queried.
+func (vsam VSAModel) RequestSchedules(currentUser string, schedules []schedule) ([]schedule, error) {
+	retrievedSchedules, err := vsam.RequestSchedulesExtended(currentUser, schedules, false)
+	if err != nil {
+		return []schedule{}, fmt.Errorf("error in RequestSchedules: %w", err)
+	}
+	return retrievedSchedules, nil
+}
+
+// This version of RequestSchedules allows ShiftsOff = 0 to be queried, but any default schedule structs will have ShiftsOff: 0 implicitly, so ShiftsOff must be set to a desired value or to -1 to be ignored.
+func (vsam VSAModel) RequestSchedulesExtended(currentUser string, schedules []schedule, includeShiftsOff0 bool) ([]schedule, error) {
+	schedulesQuery := newSQLQuery(`select * from Schedules where User = ?`, currentUser)
+	if !includeShiftsOff0 { // I have to check for this edge case
+		for _, val := range schedules {
+			if val.ShiftsOff <= -1 {
+				return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: method failed because includeShiftsOff0 is false but one of the schedule structs contains ShiftsOff<=-1: %+v", val)
+			}
+		}
+	}
+	if len(schedules) > 0 {
+		var checkAgainst schedule
+		if includeShiftsOff0 {
+			checkAgainst = schedule{ShiftsOff: -1}
+		} else {
+			checkAgainst = schedule{}
+		}
+		if check, failed := testEmpty(schedules, checkAgainst); check {
+			return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: method failed because one of the values in schedules had an empty/default values schedule struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(schedules))
+	for i := 0; i < len(schedules); i++ {
+		var match sqlMatch
+		if schedules[i].ScheduleID > 0 {
+			match.equals("ScheduleID", schedules[i].ScheduleID)
+		}
+		if len(schedules[i].ScheduleName) > 0 {
+			match.equals("ScheduleName", schedules[i].ScheduleName)
+		}
+		if schedules[i].ShiftsOff > 0 || includeShiftsOff0 && schedules[i].ShiftsOff > -1 {
+			match.equals("ShiftsOff", schedules[i].ShiftsOff)
+		}
+		if schedules[i].VolunteersPerShift > 0 {
+			match.equals("VolunteersPerShift", schedules[i].VolunteersPerShift)
+		}
+		if len(schedules[i].User) > 0 {
+			match.equals("User", schedules[i].User)
+		}
+		if len(schedules[i].StartDate) > 0 {
+			match.equals("StartDate", schedules[i].StartDate)
+		}
+		if len(schedules[i].EndDate) > 0 {
+			match.equals("EndDate", schedules[i].EndDate)
+		}
+		matches = append(matches, match)
+	}
+	schedulesQuery.addMatchAny(" and ", matches)
+	schedulesQuery.add(` order by ScheduleID`)
+	//fmt.Println(schedulesQuery)
+	var result []schedule
+	rows, err := vsam.querier().Query(schedulesQuery.text, schedulesQuery.args...)
+	if err != nil {
+		return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.DB.Query error: %w. Value of schedulesQuery is `%s`", err, schedulesQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var userSchedule schedule
+		err = rows.Scan(&userSchedule.ScheduleID, &userSchedule.ScheduleName, &userSchedule.ShiftsOff, &userSchedule.VolunteersPerShift, &userSchedule.User, &userSchedule.StartDate, &userSchedule.EndDate)
+		if err != nil {
+			return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.Rows.Scan error: %w. Value of userSchedule is `%+v`", err, userSchedule)
+		}
+		result = append(result, userSchedule)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+// This function is the simple version of UpdateSchedulesExtended and does not allow columns to set ShiftsOff = 0.
+func (vsam VSAModel) UpdateSchedules(currentUser string, toUpdate []schedule) error {
+	return vsam.UpdateSchedulesExtended(currentUser, toUpdate, false)
+}
+
+// This version of UpdateSchedulesExtended allows ShiftsOff = 0 to be queried, but any default schedule structs will have ShiftsOff: 0 implicitly, so ShiftsOff must be set to a desired value or to -1 to be ignored.
+func (vsam VSAModel) UpdateSchedulesExtended(currentUser string, toUpdate []schedule, includeShiftsOff0 bool) error {
+	var checkAgainst schedule
+	if includeShiftsOff0 {
+		checkAgainst = schedule{ShiftsOff: -1}
+	} else {
+		checkAgainst = schedule{}
+	}
+	if check, failed := testEmpty(toUpdate, checkAgainst); check {
+		return fmt.Errorf("error in UpdateSchedulesExtended: method failed because one of the values in toUpdate had an empty/default values schedule struct: %+v", failed)
+	}
+	for _, val := range toUpdate {
+		if err := checkDates(val.StartDate, val.EndDate); err != nil {
+			return fmt.Errorf("error in UpdateSchedulesExtended: %w", err)
+		}
+	}
+	head := `update Schedules set`
+	tail := `where User=? and ScheduleID=?`
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateSchedulesExtended: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	checkDuplicates := []schedule{}
+	for _, val := range toUpdate {
+		if val.ScheduleID == 0 {
+			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because one of the values in toUpdate had an empty/default value for ScheduleID: %+v", val)
+		}
+		currentSchedule, err := vsam.RequestSchedule(currentUser, schedule{ScheduleID: val.ScheduleID})
+		if err != nil {
+			return fmt.Errorf("error in UpdateSchedulesExtended: %w", err)
+		}
+		checkStruct := schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate}
+		if !slices.Contains(checkDuplicates, checkStruct) {
+			checkDuplicates = append(checkDuplicates, checkStruct)
+		} else {
+			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because at least two of the schedule structs in toUpdate would create duplicate schedule structs in the database: %+v", checkStruct)
+		}
+		currentSchedule.ScheduleID = 0
+		updateSchedulesString := newSQLQuery(head)
+		var count int
+		if includeShiftsOff0 {
+			count = countGTZero([]int{val.ScheduleID, len(val.ScheduleName), val.ShiftsOff + 1, val.VolunteersPerShift, len(val.User), len(val.StartDate), len(val.EndDate)})
+		} else {
+			count = countGTZero([]int{val.ScheduleID, len(val.ScheduleName), val.ShiftsOff, val.VolunteersPerShift, len(val.User), len(val.StartDate), len(val.EndDate)})
+		}
+		count-- // This is needed because a ScheduleID has been provided (verified at the start of this loop).
+		if count == 0 {
+			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because only one value was provided in a schedule struct. At least two values (a ScheduleID and a value to update) must be provided: %+v", val)
+		}
+		// count is at least 1
+		//fmt.Println(count)
+		//fmt.Println(updateSchedulesString)
+		if len(val.ScheduleName) > 0 {
+			updateSchedulesString.add(` ScheduleName=?`, val.ScheduleName)
+			count--
+			currentSchedule.ScheduleName = val.ScheduleName
+			if count > 0 {
+				updateSchedulesString.add(`,`)
+			}
+			//fmt.Println(count)
+			//fmt.Println(updateSchedulesString)
+		}
+		if val.ShiftsOff > 0 || includeShiftsOff0 && val.ShiftsOff > -1 {
+			updateSchedulesString.add(` ShiftsOff=?`, val.ShiftsOff)
+			count--
+			currentSchedule.ShiftsOff = val.ShiftsOff
+			if count > 0 {
+				updateSchedulesString.add(`,`)
+			}
+			//fmt.Println(count)
+			//fmt.Println(updateSchedulesString)
+		}
+		if val.VolunteersPerShift > 0 {
+			updateSchedulesString.add(` VolunteersPerShift=?`, val.VolunteersPerShift)
+			count--
+			currentSchedule.VolunteersPerShift = val.VolunteersPerShift
+			if count > 0 {
+				updateSchedulesString.add(`,`)
+			}
+			//fmt.Println(count)
+			//fmt.Println(updateSchedulesString)
+		}
+		if len(val.StartDate) > 0 {
+			updateSchedulesString.add(` StartDate=?`, val.StartDate)
+			count--
+			currentSchedule.StartDate = val.StartDate
+			if count > 0 {
+				updateSchedulesString.add(`,`)
+			}
+			//fmt.Println(count)
+			//fmt.Println(updateSchedulesString)
+		}
+		if len(val.EndDate) > 0 {
+			updateSchedulesString.add(` EndDate=?`, val.EndDate)
+			currentSchedule.EndDate = val.EndDate
+			//fmt.Println(count)
+			//fmt.Println(updateSchedulesString)
+		}
+		updateSchedulesString.add(fmt.Sprintf(` %s`, tail), currentUser)
+		//fmt.Println(count)
+		//fmt.Println(updateSchedulesString)
+		if check, err := vsam.RequestSchedulesExtended(currentUser, []schedule{currentSchedule}, includeShiftsOff0); err != nil {
+			return fmt.Errorf("error in UpdateSchedulesExtended: %w", err)
+		} else if len(check) > 0 {
+			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because it would create a duplicate schedule: %+v", val)
+		}
+		updateSchedulesStmt, err := tx.Prepare(updateSchedulesString.text)
+		if err != nil {
+			return fmt.Errorf("error in UpdateSchedulesExtended: sql.Tx.Prepare error: %w. Value of updateSchedulesString is `%s`", err, updateSchedulesString)
+		}
+		defer updateSchedulesStmt.Close()
+		_, err = updateSchedulesStmt.Exec(append(updateSchedulesString.args, val.ScheduleID)...)
+		if err != nil {
+			return fmt.Errorf("error in UpdateSchedulesExtended: sql.Stmt.Exec error: %w. Value of val is %+v", err, val)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in UpdateSchedulesExtended: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Will delete Schedule database entries that match the ScheduleID or that match the ScheduleName provided in each schedule struct. If a ScheduleID > 0 is provided, the value for ScheduleName is ignored for that schedule struct.
+func (vsam VSAModel) DeleteSchedules(currentUser string, toDelete []schedule) error {
+	for _, val := range toDelete {
+		if val.ScheduleID < 1 && len(val.ScheduleName) == 0 {
+			return fmt.Errorf("error in DeleteSchedules: method failed because one of the schedule structs did not have a value for ScheduleID or ScheduleName (at least one must be provided): %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteSchedules: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteScheduleString sqlQuery
+		if val.ScheduleID > 0 {
+			deleteScheduleString = newSQLQuery(`delete from Schedules where User=? and ScheduleID=?`, currentUser, val.ScheduleID)
+		} else {
+			deleteScheduleString = newSQLQuery(`delete from Schedules where User=? and ScheduleName=?`, currentUser, val.ScheduleName)
+		}
+		_, err := tx.Exec(deleteScheduleString.text, deleteScheduleString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteSchedules: sql.Tx.Exec error: %w. Value of deleteScheduleString is %s", err, deleteScheduleString)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteSchedules: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreateWFS(currentUser string, toCreate []weekdayForSchedule) error {
+	check, err := vsam.RequestWFS(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateWFS: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule entries to be created already exists in the database. Existing weekdayForSchedule(s): %+v", check)
+	}
+	checkDuplicates := []weekdayForSchedule{}
+	for _, val := range toCreate { // User and WFSID do not need to be provided in the weekdayForSchedule structs
+		if val.Weekday == (weekdayForSchedule{}.Weekday) {
+			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate did not have a value for Weekday: %+v", val)
+		}
+		if val.Schedule == (weekdayForSchedule{}.Schedule) {
+			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule}) {
+			checkDuplicates = append(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
+		} else {
+			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate was a duplicate of another weekdayForSchedule struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateWFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillWFSTableString := `insert into WeekdaysForSchedule (User, Weekday, Schedule) values (?, ?, ?)`
+	fillWFSTableStmt, err := tx.Prepare(fillWFSTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateWFS: sql.Tx.Prepare error: %w. Value of fillWFSTableString is `%s`", err, fillWFSTableString)
+	}
+	defer fillWFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillWFSTableStmt.Exec(currentUser, toCreate[i].Weekday, toCreate[i].Schedule)
+		if err != nil {
+			return fmt.Errorf("error in CreateWFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateWFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestWFSSingle(currentUser string, volunteerForScheduleStruct weekdayForSchedule) (weekdayForSchedule, error) {
+	weekdaysForSchedule, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{volunteerForScheduleStruct})
+	if err != nil {
+		return weekdayForSchedule{}, fmt.Errorf("error in RequestWFSSingle: %w", err)
+	}
+	if len(weekdaysForSchedule) != 1 {
+		return weekdayForSchedule{}, fmt.Errorf("error in RequestWFSSingle: method failed to locate exactly one WFS matching %+v. Found %d matches", volunteerForScheduleStruct, len(weekdaysForSchedule))
+	}
+	return weekdaysForSchedule[0], nil
+}
+
+func (vsam VSAModel) RequestWFS(currentUser string, weekdaysForSchedule []weekdayForSchedule) ([]weekdayForSchedule, error) {
+	weekdaysForScheduleQuery := newSQLQuery(`select * from WeekdaysForSchedule where User = ?`, currentUser)
+	if len(weekdaysForSchedule) > 0 {
+		if check, failed := testEmpty(weekdaysForSchedule, weekdayForSchedule{}); check {
+			return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: method failed because one of the values in weekdaysForSchedule had an empty/default values weekdayForSchedule struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(weekdaysForSchedule))
+	for i := 0; i < len(weekdaysForSchedule); i++ {
+		var match sqlMatch
+		if weekdaysForSchedule[i].WFSID > 0 {
+			match.equals("WFSID", weekdaysForSchedule[i].WFSID)
+		}
+		if len(weekdaysForSchedule[i].User) > 0 {
+			match.equals("User", weekdaysForSchedule[i].User)
+		}
+		if weekdaysForSchedule[i].Schedule > 0 {
+			match.equals("Schedule", weekdaysForSchedule[i].Schedule)
+		}
+		if len(weekdaysForSchedule[i].Weekday) > 0 {
+			match.equals("Weekday", weekdaysForSchedule[i].Weekday)
+		}
+		matches = append(matches, match)
+	}
+	weekdaysForScheduleQuery.addMatchAny(" and ", matches)
+	weekdaysForScheduleQuery.add(` order by WFSID`)
+	//fmt.Println(weekdaysForScheduleQuery)
+	var result []weekdayForSchedule
+	rows, err := vsam.querier().Query(weekdaysForScheduleQuery.text, weekdaysForScheduleQuery.args...)
+	if err != nil {
+		return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.DB.Query error: %w. Value of weekdaysForScheduleQuery is `%s`", err, weekdaysForScheduleQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var weekdayForScheduleStruct weekdayForSchedule
+		err = rows.Scan(&weekdayForScheduleStruct.WFSID, &weekdayForScheduleStruct.User, &weekdayForScheduleStruct.Weekday, &weekdayForScheduleStruct.Schedule)
+		if err != nil {
+			return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.Rows.Scan error: %w. value of weekdayForScheduleStruct is `%+v`", err, weekdayForScheduleStruct)
+		}
+		result = append(result, weekdayForScheduleStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+func (vsam VSAModel) UpdateWFS(currentUser string, toUpdate []weekdayForSchedule) error {
+	if check, failed := testEmpty(toUpdate, weekdayForSchedule{}); check {
+		return fmt.Errorf("error in UpdateWFS: method failed because one of the values in toUpdate had an empty/default values weekdayForSchedule struct: %+v", failed)
+	}
+	head := `update WeekdaysForSchedule set`
+	tail := `where User=? and WFSID=?`
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateWFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	checkDuplicates := []weekdayForSchedule{}
+	for _, val := range toUpdate {
+		if val.WFSID == 0 {
+			return fmt.Errorf("error in UpdateWFS: method failed because one of the values in toUpdate had an empty/default value for WFSID: %+v", val)
+		}
+		currentWFS, err := vsam.RequestWFSSingle(currentUser, weekdayForSchedule{WFSID: val.WFSID})
+		if err != nil {
+			return fmt.Errorf("error in UpdateWFS: %w", err)
+		}
+		if !slices.Contains(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule}) {
+			checkDuplicates = append(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
+		} else {
+			return fmt.Errorf("error in UpdateWFS: method failed because at least two of the weekdayForSchedule structs in toUpdate would create duplicate weekdayForSchedule structs in the database: %+v", weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
+		}
+		currentWFS.WFSID = 0
+		updateWFSString := newSQLQuery(head)
+		count := countGTZero([]int{val.WFSID, len(val.User), len(val.Weekday), val.Schedule})
+		count-- // This is needed because a WFSID has been provided (verified at the start of this loop).
+		if count == 0 {
+			return fmt.Errorf("error in UpdateWFS: method failed because only one value was provided in a weekdayForSchedule struct. At least two values (a WFSID and a value to update) must be provided: %+v", val)
+		}
+		// count is at least 1
+		//fmt.Println(count)
+		//fmt.Println(updateWFSString)
+		if len(val.Weekday) > 0 {
+			updateWFSString.add(` Weekday=?`, val.Weekday)
+			count--
+			currentWFS.Weekday = val.Weekday
+			if count > 0 {
+				updateWFSString.add(`,`)
+			}
+			//fmt.Println(count)
+			//fmt.Println(updateWFSString)
+		}
+		if val.Schedule > 0 {
+			updateWFSString.add(` Schedule=?`, val.Schedule)
+			//fmt.Println(count)
+			//fmt.Println(updateWFSString)
+			currentWFS.Schedule = val.Schedule
+		}
+		updateWFSString.add(fmt.Sprintf(` %s`, tail), currentUser)
+		//fmt.Println(count)
+		//fmt.Println(updateWFSString)
+		if check, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{currentWFS}); err != nil {
+			return fmt.Errorf("error in UpdateWFS: %w", err)
+		} else if len(check) > 0 {
+			return fmt.Errorf("error in UpdateWFS: method failed because it would create a duplicate WFS: %+v", val)
+		}
+		updateSchedulesStmt, err := tx.Prepare(updateWFSString.text)
+		if err != nil {
+			return fmt.Errorf("error in UpdateWFS: sql.Tx.Prepare error: %w", err)
+		}
+		defer updateSchedulesStmt.Close()
+		_, err = updateSchedulesStmt.Exec(append(updateWFSString.args, val.WFSID)...)
+		if err != nil {
+			return fmt.Errorf("error in UpdateWFS: sql.Stmt.Exec error: %w", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in UpdateWFS sql.Tx.Commit: %w", err)
+	}
+	return nil
+}
+
+// Will delete WFS database entries that match the WFSID or that match the Weekday and Schedule provided in each WFS struct. If a WFSID > 0 is provided, the values for Weekday and Schedule are ignored for that WFS struct.
+func (vsam VSAModel) DeleteWFS(currentUser string, toDelete []weekdayForSchedule) error {
+	for _, val := range toDelete {
+		if val.WFSID < 1 && (len(val.Weekday) == 0 || val.Schedule < 1) {
+			return fmt.Errorf("error in DeleteWFS: method failed because one of the weekdayForSchedule structs did not have a value for WFSID or Weekday and Schedule: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteWFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteWFSString sqlQuery
+		if val.WFSID > 0 {
+			deleteWFSString = newSQLQuery(`delete from WeekdaysForSchedule where User=? and WFSID=?`, currentUser, val.WFSID)
+		} else {
+			deleteWFSString = newSQLQuery(`delete from WeekdaysForSchedule where User=? and Weekday=? and Schedule=?`, currentUser, val.Weekday, val.Schedule)
+		}
+		_, err := tx.Exec(deleteWFSString.text, deleteWFSString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteWFS: sql.Tx.Exec error: %w", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteWFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// correctWFS is a map with schedule structs as keys and slices of weekday structs that define WeekdayName as values. If a WFS row is linked to a schedule, but doesn't have a matching weekday, delete that WFS row.
+func (vsam VSAModel) CleanOrphanedWFS(currentUser string, correctWFS map[schedule][]weekday) error {
+	var WFSToDelete []int
+	for key, value := range correctWFS {
+		if key.ScheduleID == 0 {
+			return fmt.Errorf("error in CleanOrphanedWFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", key)
+		}
+		var weekdays []string
+		for _, weekdayStruct := range value {
+			if len(weekdayStruct.WeekdayName) < 6 { // smallest weekday name is 6 characters long.
+				return fmt.Errorf("error in CleanOrphanedWFS: method failed because one of the provided weekday structs did not have a WeekdayName: %+v", value)
+			}
+			weekdays = append(weekdays, weekdayStruct.WeekdayName)
+		}
+		wfsCheck, _ := vsam.RequestWFS(currentUser, []weekdayForSchedule{{Schedule: key.ScheduleID}}) // catch error when updating this func
+		for _, wfs := range wfsCheck {
+			if !slices.Contains(weekdays, wfs.Weekday) {
+				WFSToDelete = append(WFSToDelete, wfs.WFSID)
+				//fmt.Println(WFSToDelete)
+			}
+		}
+		tx, err := vsam.begin()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedWFS: sql.DB.Begin error: %w", err)
+		}
+		defer tx.Rollback()
+		deleteWFSQuery := newSQLQuery(`delete from WeekdaysForSchedule where User = ?`, currentUser)
+		deleteWFSQuery.addIn(" and ", "WFSID", WFSToDelete)
+		//fmt.Println(deleteWFSQuery)
+		_, err = tx.Exec(deleteWFSQuery.text, deleteWFSQuery.args...)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedWFS: sql.Tx.Exec error: %w. Value of deleteWFSQuery is `%s`", err, deleteWFSQuery)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedWFS: sql.Tx.Commit error: %w", err)
+		}
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreateTSFS(currentUser string, toCreate []timeSlotForSchedule) error {
+	check, err := vsam.RequestTSFS(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateTSFS: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule entries to be created already exists in the database. Existing timeSlotForSchedule(s): %+v", check)
+	}
+	checkDuplicates := []timeSlotForSchedule{}
+	for _, val := range toCreate { // User and TSFSID do not need to be provided in the timeSlotForSchedule structs
+		if val.Schedule == (timeSlotForSchedule{}.Schedule) {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
+		}
+		if val.Weekday == (timeSlotForSchedule{}.Weekday) {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate did not have a value for Weekday: %+v", val)
+		}
+		if val.SlotName == (timeSlotForSchedule{}.SlotName) {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate did not have a value for SlotName: %+v", val)
+		}
+		if strings.Contains(val.SlotName, ShiftKeySeparator) {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate had a SlotName containing \"%s\": %+v", ShiftKeySeparator, val)
+		}
+		if val.SlotOrder < 1 {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate did not have a SlotOrder greater than 0: %+v", val)
+		}
+		if val.VolunteersPerShift < 1 {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate did not have a VolunteersPerShift greater than 0: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, timeSlotForSchedule{Schedule: val.Schedule, Weekday: val.Weekday, SlotName: val.SlotName}) {
+			checkDuplicates = append(checkDuplicates, timeSlotForSchedule{Schedule: val.Schedule, Weekday: val.Weekday, SlotName: val.SlotName})
+		} else {
+			return fmt.Errorf("error in CreateTSFS: method failed because at least one of the timeSlotForSchedule structs in toCreate was a duplicate of another timeSlotForSchedule struct in toCreate: %+v", val)
+		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateTSFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillTSFSTableString := `insert into TimeSlotsForSchedule (User, Schedule, Weekday, SlotName, SlotOrder, VolunteersPerShift) values (?, ?, ?, ?, ?, ?)`
+	fillTSFSTableStmt, err := tx.Prepare(fillTSFSTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateTSFS: sql.Tx.Prepare error: %w. Value of fillTSFSTableString is `%s`", err, fillTSFSTableString)
+	}
+	defer fillTSFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillTSFSTableStmt.Exec(currentUser, toCreate[i].Schedule, toCreate[i].Weekday, toCreate[i].SlotName, toCreate[i].SlotOrder, toCreate[i].VolunteersPerShift)
+		if err != nil {
+			return fmt.Errorf("error in CreateTSFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateTSFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestTSFSSingle(currentUser string, timeSlotForScheduleStruct timeSlotForSchedule) (timeSlotForSchedule, error) {
+	timeSlotsForSchedule, err := vsam.RequestTSFS(currentUser, []timeSlotForSchedule{timeSlotForScheduleStruct})
+	if err != nil {
+		return timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFSSingle: %w", err)
+	}
+	if len(timeSlotsForSchedule) != 1 {
+		return timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFSSingle: method failed to locate exactly one TSFS matching %+v. Found %d matches", timeSlotForScheduleStruct, len(timeSlotsForSchedule))
+	}
+	return timeSlotsForSchedule[0], nil
+}
+
+// Results are sorted by Schedule, Weekday, and SlotOrder. SlotOrder and VolunteersPerShift are not used to filter the results.
+func (vsam VSAModel) RequestTSFS(currentUser string, timeSlotsForSchedule []timeSlotForSchedule) ([]timeSlotForSchedule, error) {
+	TSFSQuery := newSQLQuery(`select * from TimeSlotsForSchedule where User = ?`, currentUser)
+	if len(timeSlotsForSchedule) > 0 {
+		if check, failed := testEmpty(timeSlotsForSchedule, timeSlotForSchedule{}); check {
+			return []timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFS: method failed because one of the values in timeSlotsForSchedule had an empty/default values timeSlotForSchedule struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(timeSlotsForSchedule))
+	for i := 0; i < len(timeSlotsForSchedule); i++ {
+		var match sqlMatch
+		if timeSlotsForSchedule[i].TSFSID > 0 {
+			match.equals("TSFSID", timeSlotsForSchedule[i].TSFSID)
+		}
+		if len(timeSlotsForSchedule[i].User) > 0 {
+			match.equals("User", timeSlotsForSchedule[i].User)
+		}
+		if timeSlotsForSchedule[i].Schedule > 0 {
+			match.equals("Schedule", timeSlotsForSchedule[i].Schedule)
+		}
+		if len(timeSlotsForSchedule[i].Weekday) > 0 {
+			match.equals("Weekday", timeSlotsForSchedule[i].Weekday)
+		}
+		if len(timeSlotsForSchedule[i].SlotName) > 0 {
+			match.equals("SlotName", timeSlotsForSchedule[i].SlotName)
+		}
+		if len(match.conditions) == 0 {
+			return []timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFS: method failed because one of the values in timeSlotsForSchedule only had values for SlotOrder or VolunteersPerShift: %+v", timeSlotsForSchedule[i])
+		}
+		matches = append(matches, match)
+	}
+	TSFSQuery.addMatchAny(" and ", matches)
+	TSFSQuery.add(` order by Schedule, Weekday, SlotOrder`)
+	var result []timeSlotForSchedule
+	rows, err := vsam.querier().Query(TSFSQuery.text, TSFSQuery.args...)
+	if err != nil {
+		return []timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFS: sql.DB.Query error: %w. Value of TSFSQuery is `%s`", err, TSFSQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var TSFSStruct timeSlotForSchedule
+		err = rows.Scan(&TSFSStruct.TSFSID, &TSFSStruct.User, &TSFSStruct.Schedule, &TSFSStruct.Weekday, &TSFSStruct.SlotName, &TSFSStruct.SlotOrder, &TSFSStruct.VolunteersPerShift)
+		if err != nil {
+			return []timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFS: sql.Rows.Scan error: %w. Value of TSFSStruct is `%+v`", err, TSFSStruct)
+		}
+		result = append(result, TSFSStruct)
+	}
+	err = rows.Err()
+	if err != nil {
+		return []timeSlotForSchedule{}, fmt.Errorf("error in RequestTSFS: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+func (vsam VSAModel) UpdateTSFS(currentUser string, toUpdate []timeSlotForSchedule) error {
+	if check, failed := testEmpty(toUpdate, timeSlotForSchedule{}); check {
+		return fmt.Errorf("error in UpdateTSFS: method failed because one of the values in toUpdate had an empty/default values timeSlotForSchedule struct: %+v", failed)
+	}
+	head := `update TimeSlotsForSchedule set`
+	tail := `where User=? and TSFSID=?`
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateTSFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	checkDuplicates := []timeSlotForSchedule{}
+	for _, val := range toUpdate {
+		if val.TSFSID == 0 {
+			return fmt.Errorf("error in UpdateTSFS: method failed because one of the values in toUpdate had an empty/default value for TSFSID: %+v", val)
+		}
+		if strings.Contains(val.SlotName, ShiftKeySeparator) {
+			return fmt.Errorf("error in UpdateTSFS: method failed because one of the values in toUpdate had a SlotName containing \"%s\": %+v", ShiftKeySeparator, val)
+		}
+		currentTSFS, err := vsam.RequestTSFSSingle(currentUser, timeSlotForSchedule{TSFSID: val.TSFSID})
+		if err != nil {
+			return fmt.Errorf("error in UpdateTSFS: %w", err)
+		}
+		currentTSFS.TSFSID = 0
+		updateTSFSString := newSQLQuery(head)
+		updates, updateArgs := []string{}, []any{}
+		if val.Schedule > 0 {
+			updates = append(updates, `Schedule=?`)
+			updateArgs = append(updateArgs, val.Schedule)
+			currentTSFS.Schedule = val.Schedule
+		}
+		if len(val.Weekday) > 0 {
+			updates = append(updates, `Weekday=?`)
+			updateArgs = append(updateArgs, val.Weekday)
+			currentTSFS.Weekday = val.Weekday
+		}
+		if len(val.SlotName) > 0 {
+			updates = append(updates, `SlotName=?`)
+			updateArgs = append(updateArgs, val.SlotName)
+			currentTSFS.SlotName = val.SlotName
+		}
+		if val.SlotOrder > 0 {
+			updates = append(updates, `SlotOrder=?`)
+			updateArgs = append(updateArgs, val.SlotOrder)
+		}
+		if val.VolunteersPerShift > 0 {
+			updates = append(updates, `VolunteersPerShift=?`)
+			updateArgs = append(updateArgs, val.VolunteersPerShift)
+		}
+		if len(updates) == 0 {
+			return fmt.Errorf("error in UpdateTSFS: method failed because only one value was provided in a timeSlotForSchedule struct. At least two values (a TSFSID and a value to update) must be provided: %+v", val)
+		}
+		updateTSFSString.add(fmt.Sprintf(` %s %s`, strings.Join(updates, ", "), tail), append(updateArgs, currentUser)...)
+		// Schedule, Weekday, and SlotName identify a time slot, so only those are checked for duplicates
+		identity := timeSlotForSchedule{Schedule: currentTSFS.Schedule, Weekday: currentTSFS.Weekday, SlotName: currentTSFS.SlotName}
+		if !slices.Contains(checkDuplicates, identity) {
+			checkDuplicates = append(checkDuplicates, identity)
+		} else {
+			return fmt.Errorf("error in UpdateTSFS: method failed because at least two of the timeSlotForSchedule structs in toUpdate would create duplicate timeSlotForSchedule structs in the database: %+v", identity)
+		}
+		if check, err := vsam.RequestTSFS(currentUser, []timeSlotForSchedule{identity}); err != nil {
+			return fmt.Errorf("error in UpdateTSFS: %w", err)
+		} else if slices.ContainsFunc(check, func(existing timeSlotForSchedule) bool { return existing.TSFSID != val.TSFSID }) {
+			return fmt.Errorf("error in UpdateTSFS: method failed because it would create a duplicate TSFS: %+v", val)
+		}
+		updateTSFSStmt, err := tx.Prepare(updateTSFSString.text)
+		if err != nil {
+			return fmt.Errorf("error in UpdateTSFS: sql.Tx.Prepare error: %w. Value of updateTSFSString is `%s`", err, updateTSFSString)
+		}
+		defer updateTSFSStmt.Close()
+		_, err = updateTSFSStmt.Exec(append(updateTSFSString.args, val.TSFSID)...)
+		if err != nil {
+			return fmt.Errorf("error in UpdateTSFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in UpdateTSFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// Will delete TSFS database entries that match the TSFSID or that match the Schedule, Weekday, and SlotName provided in each TSFS struct. If a TSFSID > 0 is provided, the other values are ignored for that TSFS struct.
+func (vsam VSAModel) DeleteTSFS(currentUser string, toDelete []timeSlotForSchedule) error {
+	for _, val := range toDelete {
+		if val.TSFSID < 1 && (val.Schedule < 1 || len(val.Weekday) == 0 || len(val.SlotName) == 0) {
+			return fmt.Errorf("error in DeleteTSFS: method failed because one of the timeSlotForSchedule structs did not have a value for TSFSID or Schedule, Weekday, and SlotName: %+v", val)
 		}
-	} else if bDidWrite {
-		err = vsam.UpdateSchedulesExtended(currentUser, []schedule{scheduleRecord}, bZeroShiftsOff)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in DeleteTSFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteTSFSString sqlQuery
+		if val.TSFSID > 0 {
+			deleteTSFSString = newSQLQuery(`delete from TimeSlotsForSchedule where User=? and TSFSID=?`, currentUser, val.TSFSID)
+		} else {
+			deleteTSFSString = newSQLQuery(`delete from TimeSlotsForSchedule where User=? and Schedule=? and Weekday=? and SlotName=?`, currentUser, val.Schedule, val.Weekday, val.SlotName)
+		}
+		_, err := tx.Exec(deleteTSFSString.text, deleteTSFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in DeleteTSFS: sql.Tx.Exec error: %w. Value of deleteTSFSString is `%s`", err, deleteTSFSString)
 		}
 	}
-	scheduleRecord, err = vsam.RequestSchedule(currentUser, scheduleRecord)
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return fmt.Errorf("error in DeleteTSFS: sql.Tx.Commit error: %w", err)
 	}
-	// Validate WeekdaysForSchedule weekday names and whether there's already a WFS in the database for it then add it to wfsToCreate
-	wfsToCreate := []weekdayForSchedule{}
-	for _, val := range data.WeekdaysForSchedule {
-		// data.WeekdaysForSchedule is a []string of full weekday names
-		weekdayStruct, err := vsam.RequestWeekday(weekday{WeekdayName: val})
-		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	return nil
+}
+
+// correctTSFS is a map with schedule structs as keys and slices of timeSlotForSchedule structs that define Weekday and SlotName as values. If a TSFS row is linked to a schedule, but doesn't match one of those time slots, delete that TSFS row.
+func (vsam VSAModel) CleanOrphanedTSFS(currentUser string, correctTSFS map[schedule][]timeSlotForSchedule) error {
+	var TSFSToDelete []int
+	for key, value := range correctTSFS {
+		if key.ScheduleID == 0 {
+			return fmt.Errorf("error in CleanOrphanedTSFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", key)
 		}
-		wfsStruct := weekdayForSchedule{Schedule: scheduleRecord.ScheduleID, Weekday: weekdayStruct.WeekdayName}
-		wfsSlice, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{wfsStruct})
+		var timeSlots []timeSlotForSchedule
+		for _, tsfsStruct := range value {
+			if len(tsfsStruct.Weekday) == 0 || len(tsfsStruct.SlotName) == 0 {
+				return fmt.Errorf("error in CleanOrphanedTSFS: method failed because one of the provided timeSlotForSchedule structs did not have a Weekday and SlotName: %+v", value)
+			}
+			timeSlots = append(timeSlots, timeSlotForSchedule{Weekday: tsfsStruct.Weekday, SlotName: tsfsStruct.SlotName})
+		}
+		TSFSCheck, err := vsam.RequestTSFS(currentUser, []timeSlotForSchedule{{Schedule: key.ScheduleID}})
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in CleanOrphanedTSFS: %w", err)
 		}
-		if len(wfsSlice) == 0 {
-			wfsToCreate = append(wfsToCreate, wfsStruct)
+		for _, TSFS := range TSFSCheck {
+			if !slices.Contains(timeSlots, timeSlotForSchedule{Weekday: TSFS.Weekday, SlotName: TSFS.SlotName}) {
+				TSFSToDelete = append(TSFSToDelete, TSFS.TSFSID)
+			}
 		}
-	}
-	if len(wfsToCreate) > 0 {
-		err = vsam.CreateWFS(currentUser, wfsToCreate)
+		tx, err := vsam.begin()
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in CleanOrphanedTSFS: sql.DB.Begin error: %w", err)
 		}
-	}
-	volunteersToCreate := []volunteer{}
-	for key := range data.VolunteerUnavailabilityData {
-		volunteerStruct := volunteer{VolunteerName: key}
-		volunteerSlice, err := vsam.RequestVolunteers(currentUser, []volunteer{volunteerStruct})
+		defer tx.Rollback()
+		deleteTSFSQuery := newSQLQuery(`delete from TimeSlotsForSchedule where User = ?`, currentUser)
+		deleteTSFSQuery.addIn(" and ", "TSFSID", TSFSToDelete)
+		_, err = tx.Exec(deleteTSFSQuery.text, deleteTSFSQuery.args...)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in CleanOrphanedTSFS: sql.Tx.Exec error: %w. Value of deleteTSFSQuery is `%s`", err, deleteTSFSQuery)
 		}
-		if len(volunteerSlice) == 0 {
-			volunteersToCreate = append(volunteersToCreate, volunteerStruct)
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedTSFS: sql.Tx.Commit error: %w", err)
 		}
 	}
-	if len(volunteersToCreate) > 0 {
-		vsam.CreateVolunteers(currentUser, volunteersToCreate)
+	return nil
+}
+
+func (vsam VSAModel) CreateRFS(currentUser string, toCreate []roleForSchedule) error {
+	check, err := vsam.RequestRFS(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreateRFS: %w", err)
 	}
-	vfsToCreate := []volunteerForSchedule{}
-	for key := range data.VolunteerUnavailabilityData {
-		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
-		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule entries to be created already exists in the database. Existing roleForSchedule(s): %+v", check)
+	}
+	checkDuplicates := []roleForSchedule{}
+	for _, val := range toCreate { // User and RFSID do not need to be provided in the roleForSchedule structs
+		if val.Schedule == (roleForSchedule{}.Schedule) {
+			return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
 		}
-		vfsStruct := volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID}
-		vfsSlice, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{vfsStruct})
-		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		if val.Role == (roleForSchedule{}.Role) {
+			return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule structs in toCreate did not have a value for Role: %+v", val)
 		}
-		if len(vfsSlice) == 0 {
-			vfsToCreate = append(vfsToCreate, vfsStruct)
+		if val.RoleOrder < 1 {
+			return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule structs in toCreate did not have a RoleOrder greater than 0: %+v", val)
+		}
+		if val.VolunteersPerShift < 1 {
+			return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule structs in toCreate did not have a VolunteersPerShift greater than 0: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, roleForSchedule{Schedule: val.Schedule, Role: val.Role}) {
+			checkDuplicates = append(checkDuplicates, roleForSchedule{Schedule: val.Schedule, Role: val.Role})
+		} else {
+			return fmt.Errorf("error in CreateRFS: method failed because at least one of the roleForSchedule structs in toCreate was a duplicate of another roleForSchedule struct in toCreate: %+v", val)
 		}
 	}
-	if len(vfsToCreate) > 0 {
-		err = vsam.CreateVFS(currentUser, vfsToCreate)
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreateRFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillRFSTableString := `insert into RolesForSchedule (User, Schedule, Role, RoleOrder, VolunteersPerShift) values (?, ?, ?, ?, ?)`
+	fillRFSTableStmt, err := tx.Prepare(fillRFSTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreateRFS: sql.Tx.Prepare error: %w. Value of fillRFSTableString is `%s`", err, fillRFSTableString)
+	}
+	defer fillRFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillRFSTableStmt.Exec(currentUser, toCreate[i].Schedule, toCreate[i].Role, toCreate[i].RoleOrder, toCreate[i].VolunteersPerShift)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in CreateRFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
 	}
-	ufsToCreate := []unavailabilityForSchedule{}
-	for key, value := range data.VolunteerUnavailabilityData {
-		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
-		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreateRFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestRFSSingle(currentUser string, roleForScheduleStruct roleForSchedule) (roleForSchedule, error) {
+	rolesForSchedule, err := vsam.RequestRFS(currentUser, []roleForSchedule{roleForScheduleStruct})
+	if err != nil {
+		return roleForSchedule{}, fmt.Errorf("error in RequestRFSSingle: %w", err)
+	}
+	if len(rolesForSchedule) != 1 {
+		return roleForSchedule{}, fmt.Errorf("error in RequestRFSSingle: method failed to locate exactly one RFS matching %+v. Found %d matches", roleForScheduleStruct, len(rolesForSchedule))
+	}
+	return rolesForSchedule[0], nil
+}
+
+// Results are sorted by Schedule and RoleOrder. RoleOrder and VolunteersPerShift are not used to filter the results.
+func (vsam VSAModel) RequestRFS(currentUser string, rolesForSchedule []roleForSchedule) ([]roleForSchedule, error) {
+	RFSQuery := newSQLQuery(`select * from RolesForSchedule where User = ?`, currentUser)
+	if len(rolesForSchedule) > 0 {
+		if check, failed := testEmpty(rolesForSchedule, roleForSchedule{}); check {
+			return []roleForSchedule{}, fmt.Errorf("error in RequestRFS: method failed because one of the values in rolesForSchedule had an empty/default values roleForSchedule struct: %+v", failed)
 		}
-		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
-		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	}
+	matches := make([]sqlMatch, 0, len(rolesForSchedule))
+	for i := 0; i < len(rolesForSchedule); i++ {
+		var match sqlMatch
+		if rolesForSchedule[i].RFSID > 0 {
+			match.equals("RFSID", rolesForSchedule[i].RFSID)
 		}
-		for _, dateString := range value {
-			dateStruct, err := date{}.FromString(dateString)
-			if err != nil {
-				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-			}
-			dateStruct, err = vsam.RequestDate(dateStruct)
-			if err != nil {
-				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-			}
-			if dateStruct.DateID < 1 {
-				return fmt.Errorf("error in RecieveAndStoreData: date provided for UFS does not exist in database: `%s`", dateString)
-			}
-			ufsStruct := unavailabilityForSchedule{VolunteerForSchedule: vfsStruct.VFSID, Date: dateStruct.DateID}
-			ufsSlice, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{ufsStruct})
-			if err != nil {
-				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-			}
-			if len(ufsSlice) == 0 {
-				ufsToCreate = append(ufsToCreate, ufsStruct)
-			}
+		if len(rolesForSchedule[i].User) > 0 {
+			match.equals("User", rolesForSchedule[i].User)
+		}
+		if rolesForSchedule[i].Schedule > 0 {
+			match.equals("Schedule", rolesForSchedule[i].Schedule)
+		}
+		if rolesForSchedule[i].Role > 0 {
+			match.equals("Role", rolesForSchedule[i].Role)
+		}
+		if len(match.conditions) == 0 {
+			return []roleForSchedule{}, fmt.Errorf("error in RequestRFS: method failed because one of the values in rolesForSchedule only had values for RoleOrder or VolunteersPerShift: %+v", rolesForSchedule[i])
 		}
+		matches = append(matches, match)
 	}
-	if len(ufsToCreate) > 0 {
-		err = vsam.CreateUFS(currentUser, ufsToCreate)
+	RFSQuery.addMatchAny(" and ", matches)
+	RFSQuery.add(` order by Schedule, RoleOrder`)
+	var result []roleForSchedule
+	rows, err := vsam.querier().Query(RFSQuery.text, RFSQuery.args...)
+	if err != nil {
+		return []roleForSchedule{}, fmt.Errorf("error in RequestRFS: sql.DB.Query error: %w. Value of RFSQuery is `%s`", err, RFSQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var RFSStruct roleForSchedule
+		err = rows.Scan(&RFSStruct.RFSID, &RFSStruct.User, &RFSStruct.Schedule, &RFSStruct.Role, &RFSStruct.RoleOrder, &RFSStruct.VolunteersPerShift)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return []roleForSchedule{}, fmt.Errorf("error in RequestRFS: sql.Rows.Scan error: %w. Value of RFSStruct is `%+v`", err, RFSStruct)
 		}
+		result = append(result, RFSStruct)
 	}
-	svodToCreate := []scheduledVolunteerOnDate{}
-	for key, value := range data.VolunteerScheduledData {
-		volunteerRecord, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
+	err = rows.Err()
+	if err != nil {
+		return []roleForSchedule{}, fmt.Errorf("error in RequestRFS: sql.Rows.Err error: %w", err)
+	}
+	return result, nil
+}
+
+func (vsam VSAModel) UpdateRFS(currentUser string, toUpdate []roleForSchedule) error {
+	if check, failed := testEmpty(toUpdate, roleForSchedule{}); check {
+		return fmt.Errorf("error in UpdateRFS: method failed because one of the values in toUpdate had an empty/default values roleForSchedule struct: %+v", failed)
+	}
+	head := `update RolesForSchedule set`
+	tail := `where User=? and RFSID=?`
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateRFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	checkDuplicates := []roleForSchedule{}
+	for _, val := range toUpdate {
+		if val.RFSID == 0 {
+			return fmt.Errorf("error in UpdateRFS: method failed because one of the values in toUpdate had an empty/default value for RFSID: %+v", val)
+		}
+		currentRFS, err := vsam.RequestRFSSingle(currentUser, roleForSchedule{RFSID: val.RFSID})
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in UpdateRFS: %w", err)
 		}
-		vfsStruct, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Schedule: scheduleRecord.ScheduleID, Volunteer: volunteerRecord.VolunteerID})
-		if err != nil { // I want this to error if somehow we are trying to create an SVOD for a Volunteer without a VFS, because that should have been taken care of already (at the latest by the call to CreateVFS above).
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		updateRFSString := newSQLQuery(head)
+		updates, updateArgs := []string{}, []any{}
+		if val.Schedule > 0 {
+			updates = append(updates, `Schedule=?`)
+			updateArgs = append(updateArgs, val.Schedule)
+			currentRFS.Schedule = val.Schedule
 		}
-		for _, dateString := range value {
-			dateStruct, err := date{}.FromString(dateString)
-			if err != nil {
-				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-			}
-			dateStruct, err = vsam.RequestDate(dateStruct)
-			if err != nil {
-				return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-			}
-			if dateStruct.DateID < 1 {
-				return fmt.Errorf("error in RecieveAndStoreData: date provided for SVOD does not exist in database: `%s`", dateString)
-			}
-			svodToCreate = append(svodToCreate, scheduledVolunteerOnDate{VolunteerForSchedule: vfsStruct.VFSID, Date: dateStruct.DateID})
+		if val.Role > 0 {
+			updates = append(updates, `Role=?`)
+			updateArgs = append(updateArgs, val.Role)
+			currentRFS.Role = val.Role
 		}
-	}
-	if len(svodToCreate) > 0 {
-		err = vsam.CreateSVOD(currentUser, svodToCreate)
+		if val.RoleOrder > 0 {
+			updates = append(updates, `RoleOrder=?`)
+			updateArgs = append(updateArgs, val.RoleOrder)
+		}
+		if val.VolunteersPerShift > 0 {
+			updates = append(updates, `VolunteersPerShift=?`)
+			updateArgs = append(updateArgs, val.VolunteersPerShift)
+		}
+		if len(updates) == 0 {
+			return fmt.Errorf("error in UpdateRFS: method failed because only one value was provided in a roleForSchedule struct. At least two values (a RFSID and a value to update) must be provided: %+v", val)
+		}
+		updateRFSString.add(fmt.Sprintf(` %s %s`, strings.Join(updates, ", "), tail), append(updateArgs, currentUser)...)
+		// Schedule and Role identify a role requirement, so only those are checked for duplicates
+		identity := roleForSchedule{Schedule: currentRFS.Schedule, Role: currentRFS.Role}
+		if !slices.Contains(checkDuplicates, identity) {
+			checkDuplicates = append(checkDuplicates, identity)
+		} else {
+			return fmt.Errorf("error in UpdateRFS: method failed because at least two of the roleForSchedule structs in toUpdate would create duplicate roleForSchedule structs in the database: %+v", identity)
+		}
+		if check, err := vsam.RequestRFS(currentUser, []roleForSchedule{identity}); err != nil {
+			return fmt.Errorf("error in UpdateRFS: %w", err)
+		} else if slices.ContainsFunc(check, func(existing roleForSchedule) bool { return existing.RFSID != val.RFSID }) {
+			return fmt.Errorf("error in UpdateRFS: method failed because it would create a duplicate RFS: %+v", val)
+		}
+		updateRFSStmt, err := tx.Prepare(updateRFSString.text)
 		if err != nil {
-			return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+			return fmt.Errorf("error in UpdateRFS: sql.Tx.Prepare error: %w. Value of updateRFSString is `%s`", err, updateRFSString)
+		}
+		defer updateRFSStmt.Close()
+		_, err = updateRFSStmt.Exec(append(updateRFSString.args, val.RFSID)...)
+		if err != nil {
+			return fmt.Errorf("error in UpdateRFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
 		}
 	}
-	// CleanOrphanedVolunteers is easy, but affects all schedules
-	err = vsam.CleanOrphanedVolunteers(currentUser)
-	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
-	}
-	// Clean orphans related to the schedule specified by data
-	err = vsam.CleanOrphansForSchedule(currentUser, data)
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return fmt.Errorf("error in UpdateRFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) RecieveAndDeleteData(currentUser string, data SendReceiveDataStruct) error {
-	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: data.ScheduleName})
-	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+// Will delete RFS database entries that match the RFSID or that match the Schedule and Role provided in each RFS struct. If a RFSID > 0 is provided, the values for Schedule and Role are ignored for that RFS struct.
+func (vsam VSAModel) DeleteRFS(currentUser string, toDelete []roleForSchedule) error {
+	for _, val := range toDelete {
+		if val.RFSID < 1 && (val.Schedule < 1 || val.Role < 1) {
+			return fmt.Errorf("error in DeleteRFS: method failed because one of the roleForSchedule structs did not have a value for RFSID or Schedule and Role: %+v", val)
+		}
 	}
-	// need to delete the UFS, VFS, WFS, SVODs, and unused volunteers for the provided schedule
-	err = vsam.CleanOrphanedVFS(currentUser, map[schedule][]volunteer{scheduleRecord: {}}, true, true)
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return fmt.Errorf("error in DeleteRFS: sql.DB.Begin error: %w", err)
 	}
-	err = vsam.CleanOrphanedWFS(currentUser, map[schedule][]weekday{scheduleRecord: {}})
-	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+	defer tx.Rollback()
+	for _, val := range toDelete {
+		var deleteRFSString sqlQuery
+		if val.RFSID > 0 {
+			deleteRFSString = newSQLQuery(`delete from RolesForSchedule where User=? and RFSID=?`, currentUser, val.RFSID)
+		} else {
+			deleteRFSString = newSQLQuery(`delete from RolesForSchedule where User=? and Schedule=? and Role=?`, currentUser, val.Schedule, val.Role)
+		}
+		_, err := tx.Exec(deleteRFSString.text, deleteRFSString.args...)
+		if err != nil {
+			return fmt.Errorf("error in DeleteRFS: sql.Tx.Exec error: %w. Value of deleteRFSString is `%s`", err, deleteRFSString)
+		}
 	}
-	err = vsam.DeleteSchedules(currentUser, []schedule{scheduleRecord})
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in RecieveAndStoreData: %w", err)
+		return fmt.Errorf("error in DeleteRFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) CleanOrphansForSchedule(currentUser string, data SendReceiveDataStruct) error {
-	scheduleRecord, err := vsam.RequestSchedule(currentUser, schedule{ScheduleName: data.ScheduleName})
-	if err != nil {
-		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
-	}
-	if scheduleRecord.ScheduleID < 1 {
-		return fmt.Errorf("error in CleanOrphansForSchedule: could not locate schedule. Value of data is `%#v`", data)
-	}
-	// Clean orphaned WFS
-	correctWeekdays := []weekday{}
-	for _, value := range data.WeekdaysForSchedule {
-		wd, err := vsam.RequestWeekday(weekday{WeekdayName: value})
+// correctRFS is a map with schedule structs as keys and slices of the roles each schedule requires as values. If a RFS row is linked to a schedule, but doesn't match one of those roles, delete that RFS row.
+func (vsam VSAModel) CleanOrphanedRFS(currentUser string, correctRFS map[schedule][]role) error {
+	var RFSToDelete []int
+	for key, value := range correctRFS {
+		if key.ScheduleID == 0 {
+			return fmt.Errorf("error in CleanOrphanedRFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", key)
+		}
+		var roleIDs []int
+		for _, roleStruct := range value {
+			if roleStruct.RoleID == 0 {
+				return fmt.Errorf("error in CleanOrphanedRFS: method failed because one of the provided role structs did not have a RoleID: %+v", value)
+			}
+			roleIDs = append(roleIDs, roleStruct.RoleID)
+		}
+		RFSCheck, err := vsam.RequestRFS(currentUser, []roleForSchedule{{Schedule: key.ScheduleID}})
 		if err != nil {
-			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+			return fmt.Errorf("error in CleanOrphanedRFS: %w", err)
+		}
+		for _, RFS := range RFSCheck {
+			if !slices.Contains(roleIDs, RFS.Role) {
+				RFSToDelete = append(RFSToDelete, RFS.RFSID)
+			}
+		}
+		tx, err := vsam.begin()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedRFS: sql.DB.Begin error: %w", err)
+		}
+		defer tx.Rollback()
+		deleteRFSQuery := newSQLQuery(`delete from RolesForSchedule where User = ?`, currentUser)
+		deleteRFSQuery.addIn(" and ", "RFSID", RFSToDelete)
+		_, err = tx.Exec(deleteRFSQuery.text, deleteRFSQuery.args...)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedRFS: sql.Tx.Exec error: %w. Value of deleteRFSQuery is `%s`", err, deleteRFSQuery)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedRFS: sql.Tx.Commit error: %w", err)
 		}
-		correctWeekdays = append(correctWeekdays, wd)
 	}
-	err = vsam.CleanOrphanedWFS(currentUser, map[schedule][]weekday{scheduleRecord: correctWeekdays})
+	return nil
+}
+
+func (vsam VSAModel) CreateVFS(currentUser string, toCreate []volunteerForSchedule) error {
+	check, err := vsam.RequestVFS(currentUser, toCreate)
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		return fmt.Errorf("error in CreateVFS: %w", err)
 	}
-	// Clean orphaned VFS (which optionally does delete UFS for VFS that are going to be cleaned) then clean UFS
-	correctVolunteers := []volunteer{}
-	correctUFS := map[volunteerForSchedule][]date{}
-	for key, value := range data.VolunteerUnavailabilityData {
-		v, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: key})
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule entries to be created already exists in the database. Existing volunteerForSchedule entry(s): %+v", check)
+	}
+	checkDuplicates := []volunteerForSchedule{}
+	for _, val := range toCreate { // User and VFSID do not need to be provided in the volunteerForSchedule structs
+		if val.Schedule == (volunteerForSchedule{}.Schedule) {
+			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
 		}
-		correctVolunteers = append(correctVolunteers, v)
-		vfs, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{Volunteer: v.VolunteerID, Schedule: scheduleRecord.ScheduleID})
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		if val.Volunteer == (volunteerForSchedule{}.Volunteer) {
+			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate did not have a value for Volunteer: %+v", val)
 		}
-		for _, dateString := range value {
-			dateStruct, err := date{}.FromString(dateString)
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
-			}
-			dateStruct, err = vsam.RequestDate(dateStruct)
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
-			}
-			correctUFS[vfs] = append(correctUFS[vfs], dateStruct)
+		if !slices.Contains(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer}) {
+			checkDuplicates = append(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
+		} else {
+			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate was a duplicate of another volunteerForSchedule struct in toCreate: %+v", val)
 		}
 	}
-	err = vsam.CleanOrphanedVFS(currentUser, map[schedule][]volunteer{scheduleRecord: correctVolunteers}, true, false)
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		return fmt.Errorf("error in CreateVFS: sql.DB.Begin error: %w", err)
 	}
-	err = vsam.CleanOrphanedUFS(currentUser, correctUFS)
+	defer tx.Rollback()
+	fillVFSTableString := `insert into VolunteersForSchedule (User, Schedule, Volunteer) values (?, ?, ?)`
+	fillVFSTableStmt, err := tx.Prepare(fillVFSTableString)
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		return fmt.Errorf("error in CreateVFS: sql.Tx.Prepare error: %w. Value of fillVFSTableString is `%s`", err, fillVFSTableString)
+	}
+	defer fillVFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillVFSTableStmt.Exec(currentUser, toCreate[i].Schedule, toCreate[i].Volunteer)
+		if err != nil {
+			return fmt.Errorf("error in CreateVFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+		}
 	}
-	// Clean orphaned SVOD
-	correctSVOD := map[volunteerForSchedule][]date{}
-	// TODO Implement this
-	err = vsam.CleanOrphanedSVOD(currentUser, correctSVOD)
+	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphansForSchedule: %w", err)
+		return fmt.Errorf("error in CreateVFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// This function exists to validate WeekdayName spelling and provide WeekdayID if needed. There is no RequestWeekdays method
-func (vsam VSAModel) RequestWeekday(weekdayStruct weekday) (weekday, error) {
-	if weekdayStruct == (weekday{}) {
-		return weekday{}, errors.New("error in RequestWeekday: method failed because all of the values in weekdayStruct had an empty/default values")
-	}
-	var weekdays []weekday
-	weekdayQuery := fmt.Sprintf(`select * from Weekdays where WeekdayID=%d or WeekdayName="%s"`, weekdayStruct.WeekdayID, weekdayStruct.WeekdayName)
-	rows, err := vsam.DB.Query(weekdayQuery)
-	if err != nil {
-		return weekday{}, fmt.Errorf("error in RequestWeekday: sql.DB.Query error: %w. Value of weekdayQuery is `%s`", err, weekdayQuery)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var weekdayStruct weekday
-		err = rows.Scan(&weekdayStruct.WeekdayID, &weekdayStruct.WeekdayName)
-		if err != nil {
-			return weekday{}, fmt.Errorf("error in RequestWeekday: sql.Rows.Scan error: %w. Value of weekdayStruct is `%+v`", err, weekdayStruct)
-		}
-		weekdays = append(weekdays, weekdayStruct)
-	}
-	err = rows.Err()
+func (vsam VSAModel) RequestVFSSingle(currentUser string, volunteerForScheduleStruct volunteerForSchedule) (volunteerForSchedule, error) {
+	volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{volunteerForScheduleStruct})
 	if err != nil {
-		return weekday{}, fmt.Errorf("error in RequestWeekday: sql.Rows.Err error: %w", err)
+		return volunteerForSchedule{}, fmt.Errorf("error in RequestVFSSingle: %w", err)
 	}
-	if len(weekdays) != 1 {
-		return weekday{}, fmt.Errorf("error in RequestWeekday: method failed to locate exactly one weekday matching `%+v`. Found %d matches", weekdayStruct, len(weekdays))
+	if len(volunteersForSchedule) != 1 {
+		return volunteerForSchedule{}, fmt.Errorf("error in RequestVFSSingle: method failed to locate exactly one VFS matching %+v. Found %d matches", volunteerForScheduleStruct, len(volunteersForSchedule))
 	}
-	return weekdays[0], nil
+	return volunteersForSchedule[0], nil
 }
 
-// This function exists to validate MonthName spelling and provide MonthID if needed. There is no RequestMonths method
-func (vsam VSAModel) RequestMonth(monthStruct month) (month, error) {
-	if monthStruct == (month{}) {
-		return month{}, errors.New("error in RequestMonth: method failed because all of the values in monthStruct had an empty/default values")
+func (vsam VSAModel) RequestVFS(currentUser string, volunteersForSchedule []volunteerForSchedule) ([]volunteerForSchedule, error) {
+	VFSQuery := newSQLQuery(`select * from VolunteersForSchedule where User = ?`, currentUser)
+	if len(volunteersForSchedule) > 0 {
+		if check, failed := testEmpty(volunteersForSchedule, volunteerForSchedule{}); check {
+			return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: method failed because one of the values in volunteersForSchedule had an empty/default values volunteerForSchedule struct: %+v", failed)
+		}
 	}
-	var months []month
-	monthQuery := fmt.Sprintf(`select * from Months where MonthID=%d or MonthName="%s"`, monthStruct.MonthID, monthStruct.MonthName)
-	rows, err := vsam.DB.Query(monthQuery)
+	matches := make([]sqlMatch, 0, len(volunteersForSchedule))
+	for i := 0; i < len(volunteersForSchedule); i++ {
+		var match sqlMatch
+		if volunteersForSchedule[i].VFSID > 0 {
+			match.equals("VFSID", volunteersForSchedule[i].VFSID)
+		}
+		if len(volunteersForSchedule[i].User) > 0 {
+			match.equals("User", volunteersForSchedule[i].User)
+		}
+		if volunteersForSchedule[i].Schedule > 0 {
+			match.equals("Schedule", volunteersForSchedule[i].Schedule)
+		}
+		if volunteersForSchedule[i].Volunteer > 0 {
+			match.equals("Volunteer", volunteersForSchedule[i].Volunteer)
+		}
+		matches = append(matches, match)
+	}
+	VFSQuery.addMatchAny(" and ", matches)
+	VFSQuery.add(` order by VFSID`)
+	//fmt.Println(VFSQuery)
+	var result []volunteerForSchedule
+	rows, err := vsam.querier().Query(VFSQuery.text, VFSQuery.args...)
 	if err != nil {
-		return month{}, fmt.Errorf("error in RequestMonth: sql.DB.Query error: %w. Value of monthQuery is `%s`", err, monthQuery)
+		return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.DB.Query error: %w. Value of VFSQuery is `%s`", err, VFSQuery)
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var monthStruct month
-		err = rows.Scan(&monthStruct.MonthID, &monthStruct.MonthName)
+		var VFSStruct volunteerForSchedule
+		err = rows.Scan(&VFSStruct.VFSID, &VFSStruct.User, &VFSStruct.Schedule, &VFSStruct.Volunteer)
 		if err != nil {
-			return month{}, fmt.Errorf("error in RequestMonth: sql.Rows.Scan error: %w. Value of monthStruct is `%+v`", err, monthStruct)
+			return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.Rows.Scan error: %w. Value of VFSStruct is `%+v`", err, VFSStruct)
 		}
-		months = append(months, monthStruct)
+		result = append(result, VFSStruct)
 	}
 	err = rows.Err()
 	if err != nil {
-		return month{}, fmt.Errorf("error in RequestMonth: sql.Rows.Err error: %w", err)
-	}
-	if len(months) != 1 {
-		return month{}, fmt.Errorf("error in RequestMonth: method failed to locate exactly one month matching `%+v`. Found %d matches", monthStruct, len(months))
+		return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.Rows.Err error: %w", err)
 	}
-	return months[0], nil
+	return result, nil
 }
 
-func (vsam VSAModel) RequestDate(dateStruct date) (date, error) {
-	dates, err := vsam.RequestDates([]date{dateStruct})
-	if err != nil {
-		return date{}, fmt.Errorf("error in RequestDate %+v: %w", dateStruct, err)
+func (vsam VSAModel) UpdateVFS(currentUser string, toUpdate []volunteerForSchedule) error {
+	if check, failed := testEmpty(toUpdate, volunteerForSchedule{}); check {
+		return fmt.Errorf("error in UpdateVFS: method failed because one of the values in toUpdate had an empty/default values volunteerForSchedule struct: %+v", failed)
 	}
-	if len(dates) != 1 {
-		return date{}, fmt.Errorf("error in RequestDate %+v: Failed to locate exactly one date matching dateStruct. Found %d matches", dateStruct, len(dates))
+	head := `update VolunteersForSchedule set`
+	tail := `where User=? and VFSID=?`
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in UpdateVFS: sql.DB.Begin error: %w", err)
 	}
-	return dates[0], nil
-}
-
-func (vsam VSAModel) RequestDates(dates []date) ([]date, error) {
-	dateQuery := `select * from Dates`
-	if len(dates) > 0 {
-		if check, failed := testEmpty(dates, date{}); check {
-			return []date{}, fmt.Errorf("error in RequestDates: method failed because one of the values in dates had an empty/default values date struct: %+v", failed)
+	defer tx.Rollback()
+	checkDuplicates := []volunteerForSchedule{}
+	for _, val := range toUpdate {
+		if val.VFSID == 0 {
+			return fmt.Errorf("error in UpdateVFS: method failed because one of the values in toUpdate had an empty/default value for VFSID: %+v", val)
 		}
-		dateQuery = fmt.Sprintf(`%s where (`, dateQuery)
-	} else {
-		return []date{}, errors.New("error in RequestDates: method failed because the dates argument was an empty slice. At least one date must be requested")
-	}
-	for i := 0; i < len(dates); i++ {
-		count := countGTZero([]int{dates[i].DateID, dates[i].Month, dates[i].Day, dates[i].Year, len(dates[i].Weekday)})
-		// count must be at least 1 because the testEmpty check passed
-		//fmt.Println(count)
-		dateQuery = fmt.Sprintf(`%s(`, dateQuery)
-		if dates[i].DateID > 0 {
-			dateQuery = fmt.Sprintf(`%sDateID = %d`, dateQuery, dates[i].DateID)
-			count--
-			if count > 0 {
-				dateQuery = fmt.Sprintf(`%s and `, dateQuery)
-			}
+		currentVFS, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{VFSID: val.VFSID})
+		if err != nil {
+			return fmt.Errorf("error in UpdateWFS: %w", err)
 		}
-		if dates[i].Month > 0 {
-			dateQuery = fmt.Sprintf(`%sMonth = %d`, dateQuery, dates[i].Month)
-			count--
-			if count > 0 {
-				dateQuery = fmt.Sprintf(`%s and `, dateQuery)
-			}
+		if !slices.Contains(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer}) {
+			checkDuplicates = append(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
+		} else {
+			return fmt.Errorf("error in UpdateVFS: method failed because at least two of the volunteerForSchedule structs in toUpdate would create duplicate volunteerForSchedule structs in the database: %+v", volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
 		}
-		if dates[i].Day > 0 {
-			dateQuery = fmt.Sprintf(`%sDay = %d`, dateQuery, dates[i].Day)
-			count--
-			if count > 0 {
-				dateQuery = fmt.Sprintf(`%s and `, dateQuery)
-			}
+		currentVFS.VFSID = 0
+		updateVFSString := newSQLQuery(head)
+		count := countGTZero([]int{val.VFSID, len(val.User), val.Schedule, val.Volunteer})
+		count-- // This is needed because a VFSID has been provided (verified at the start of this loop).
+		if count == 0 {
+			return fmt.Errorf("error in UpdateVFS: method failed because only one value was provided in a volunteerForSchedule struct. At least two values (a VFSID and a value to update) must be provided: %+v", val)
 		}
-		if dates[i].Year > 0 {
-			dateQuery = fmt.Sprintf(`%sYear = %d`, dateQuery, dates[i].Year)
+		// count is at least 1
+		//fmt.Println(count)
+		//fmt.Println(updateVFSString)
+		if val.Schedule > 0 {
+			updateVFSString.add(` Schedule=?`, val.Schedule)
 			count--
+			currentVFS.Schedule = val.Schedule
 			if count > 0 {
-				dateQuery = fmt.Sprintf(`%s and `, dateQuery)
+				updateVFSString.add(`,`)
 			}
+			//fmt.Println(count)
+			//fmt.Println(updateVFSString)
 		}
-		if len(dates[i].Weekday) > 0 {
-			dateQuery = fmt.Sprintf(`%sWeekday = "%s"`, dateQuery, dates[i].Weekday)
-		}
-		dateQuery = fmt.Sprintf(`%s)`, dateQuery)
-		if i+1 < len(dates) {
-			dateQuery = fmt.Sprintf(`%s or `, dateQuery)
+		if val.Volunteer > 0 {
+			updateVFSString.add(` Volunteer=?`, val.Volunteer)
+			//fmt.Println(count)
+			//fmt.Println(updateVFSString)
+			currentVFS.Volunteer = val.Volunteer
 		}
+		updateVFSString.add(fmt.Sprintf(` %s`, tail), currentUser)
 		//fmt.Println(count)
-		//fmt.Println(dateQuery)
-	}
-	if len(dates) > 0 {
-		dateQuery = fmt.Sprintf(`%s)`, dateQuery)
-	}
-	//fmt.Println(dateQuery)
-	var result []date
-	rows, err := vsam.DB.Query(dateQuery)
-	if err != nil {
-		return []date{}, fmt.Errorf("error in RequestDates: sql.DB.Query error: %w. Value of dateQuery is `%s`", err, dateQuery)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var dateStruct date
-		err = rows.Scan(&dateStruct.DateID, &dateStruct.Month, &dateStruct.Day, &dateStruct.Year, &dateStruct.Weekday)
+		//fmt.Println(updateVFSString)
+		if check, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{currentVFS}); err != nil {
+			return fmt.Errorf("error in UpdateVFS: %w", err)
+		} else if len(check) > 0 {
+			return fmt.Errorf("error in UpdateVFS: method failed because it would create a duplicate VFS: %+v", val)
+		}
+		updateSchedulesStmt, err := tx.Prepare(updateVFSString.text)
+		if err != nil {
+			return fmt.Errorf("error in UpdateVFS: sql.Tx.Prepare error: %w. Value of updateVFSString is `%s`", err, updateVFSString)
+		}
+		defer updateSchedulesStmt.Close()
+		_, err = updateSchedulesStmt.Exec(append(updateVFSString.args, val.VFSID)...)
 		if err != nil {
-			return []date{}, fmt.Errorf("error in RequestDates: sql.Rows.Scan error: %w. Value of dateStruct is `%+v`", err, dateStruct)
+			return fmt.Errorf("error in UpdateVFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
 		}
-		result = append(result, dateStruct)
 	}
-	err = rows.Err()
+	err = tx.Commit()
 	if err != nil {
-		return []date{}, fmt.Errorf("error in RequestDates: sql.Rows.Err error: %w", err)
+		return fmt.Errorf("error in UpdateVFS: sql.Tx.Commit error: %w", err)
 	}
-	return result, nil
+	return nil
 }
 
-func (vsam VSAModel) CreateVolunteers(currentUser string, toCreate []volunteer) error {
-	check, err := vsam.RequestVolunteers(currentUser, toCreate)
-	if err != nil {
-		return fmt.Errorf("error in CreateVolunteers: %w", err)
-	}
-	if len(check) > 0 {
-		return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteers to be created already exists in the database. Existing volunteer(s): %+v", check)
-	}
-	checkDuplicates := []volunteer{}
-	for _, val := range toCreate { // User and VolunteerID do not need to be provided in the volunteer structs
-		if val.VolunteerName == (volunteer{}.VolunteerName) {
-			return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteer structs in toCreate did not have a value for VolunteerName: %+v", val)
-		}
-		if !slices.Contains(checkDuplicates, volunteer{VolunteerName: val.VolunteerName}) {
-			checkDuplicates = append(checkDuplicates, volunteer{VolunteerName: val.VolunteerName})
-		} else {
-			return fmt.Errorf("error in CreateVolunteers: method failed because at least one of the volunteer structs in toCreate was a duplicate of another volunteer struct in toCreate: %+v", val)
+// Will delete VFS database entries that match the VFSID or that match the Schedule and Volunteer provided in each VFS struct. If a VFSID > 0 is provided, the values for Schedule and Volunteer are ignored for that VFS struct.
+func (vsam VSAModel) DeleteVFS(currentUser string, toDelete []volunteerForSchedule) error {
+	for _, val := range toDelete {
+		if val.VFSID < 1 && (val.Schedule < 1 || val.Volunteer < 1) {
+			return fmt.Errorf("error in DeleteVFS: method failed because one of the volunteerForSchedule structs did not have a value for VFSID or Schedule and Volunteer: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CreateVolunteers: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeleteVFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	fillVolunteersTableString := `insert into Volunteers (VolunteerName, User) values (?, ?)`
-	fillVolunteersTableStmt, err := tx.Prepare(fillVolunteersTableString)
-	if err != nil {
-		return fmt.Errorf("error in CreateVolunteers: sql.Tx.Prepare error: %w. Value of fillVolunteersTableString is `%s`", err, fillVolunteersTableString)
-	}
-	defer fillVolunteersTableStmt.Close()
-	for i := 0; i < len(toCreate); i++ {
-		_, err = fillVolunteersTableStmt.Exec(toCreate[i].VolunteerName, currentUser)
+	for _, val := range toDelete {
+		var deleteVFSString sqlQuery
+		if val.VFSID > 0 {
+			deleteVFSString = newSQLQuery(`delete from VolunteersForSchedule where User=? and VFSID=?`, currentUser, val.VFSID)
+		} else {
+			deleteVFSString = newSQLQuery(`delete from VolunteersForSchedule where User=? and Schedule=? and Volunteer=?`, currentUser, val.Schedule, val.Volunteer)
+		}
+		_, err := tx.Exec(deleteVFSString.text, deleteVFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in CreateVolunteers: sql.Stmt.Exec error: %w. toCreate[i] is `%+v`", err, toCreate[i])
+			return fmt.Errorf("error in DeleteVFS: sql.Tx.Exec error: %w. Value of deleteVFSString is `%s`", err, deleteVFSString)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CreateVolunteers: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in DeleteVFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) RequestVolunteer(currentUser string, volunteerStruct volunteer) (volunteer, error) {
-	volunteers, err := vsam.RequestVolunteers(currentUser, []volunteer{volunteerStruct})
-	if err != nil {
-		return volunteer{}, fmt.Errorf("error in RequestVolunteer %+v: %w", volunteerStruct, err)
-	}
-	if len(volunteers) != 1 {
-		return volunteer{}, fmt.Errorf("error in RequestVolunteer %+v. Failed to locate exactly one volunteer matching volunteerStruct. Found %d matches", volunteerStruct, len(volunteers))
-	}
-	return volunteers[0], nil
-}
-
-func (vsam VSAModel) RequestVolunteers(currentUser string, volunteers []volunteer) ([]volunteer, error) {
-	volunteersQuery := fmt.Sprintf(`select * from Volunteers where User = "%s"`, currentUser)
-	if len(volunteers) > 0 {
-		if check, failed := testEmpty(volunteers, volunteer{}); check {
-			return []volunteer{}, fmt.Errorf("error in RequestVolunteers: method failed because one of the values in volunteers had an empty/default values volunteer struct: %+v", failed)
-		}
-		volunteersQuery = fmt.Sprintf(`%s and (`, volunteersQuery)
-	}
-	for i := 0; i < len(volunteers); i++ {
-		count := countGTZero([]int{volunteers[i].VolunteerID, len(volunteers[i].VolunteerName), len(volunteers[i].User)})
-		// count must be at least 1 because the testEmpty check passed
-		//fmt.Println(count)
-		volunteersQuery = fmt.Sprintf(`%s(`, volunteersQuery)
-		if volunteers[i].VolunteerID > 0 {
-			volunteersQuery = fmt.Sprintf(`%sVolunteerID = %d`, volunteersQuery, volunteers[i].VolunteerID)
-			count--
-			if count > 0 {
-				volunteersQuery = fmt.Sprintf(`%s and `, volunteersQuery)
-			}
+// correctVFS is a slices of maps with schedule structs as keys and slices of volunteers containing VolunteerNames as values. If a VFS row is linked to a schedule, but doesn't have a matching volunteer, delete that VFS row.
+func (vsam VSAModel) CleanOrphanedVFS(currentUser string, correctVFS map[schedule][]volunteer, deleteChildUFS bool, deleteChildSVOD bool) error {
+	var VFSToDelete []int
+	for key, value := range correctVFS {
+		if key.ScheduleID == 0 {
+			return fmt.Errorf("error in CleanOrphanedVFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", value)
 		}
-		if len(volunteers[i].VolunteerName) > 0 {
-			volunteersQuery = fmt.Sprintf(`%sVolunteerName = "%s"`, volunteersQuery, volunteers[i].VolunteerName)
-			count--
-			if count > 0 {
-				volunteersQuery = fmt.Sprintf(`%s and `, volunteersQuery)
+		var volunteers []string
+		for _, volunteerStruct := range value {
+			if len(volunteerStruct.VolunteerName) < 1 {
+				return fmt.Errorf("error in CleanOrphanedVFS: method failed because one of the provided volunteer structs did not have a VolunteerName: %+v", value)
 			}
+			volunteers = append(volunteers, volunteerStruct.VolunteerName)
 		}
-		if len(volunteers[i].User) > 0 {
-			volunteersQuery = fmt.Sprintf(`%sUser = "%s"`, volunteersQuery, volunteers[i].User)
-		}
-		volunteersQuery = fmt.Sprintf(`%s)`, volunteersQuery)
-		if i+1 < len(volunteers) {
-			volunteersQuery = fmt.Sprintf(`%s or `, volunteersQuery)
-		}
-		//fmt.Println(count)
-		//fmt.Println(volunteersQuery)
-	}
-	if len(volunteers) > 0 {
-		volunteersQuery = fmt.Sprintf(`%s)`, volunteersQuery)
-	}
-	//fmt.Println(volunteersQuery)
-	var result []volunteer
-	rows, err := vsam.DB.Query(volunteersQuery)
-	if err != nil {
-		return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.DB.Query error: %w. Value of volunteersQuery is `%s`", err, volunteersQuery)
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var volunteerStruct volunteer
-		err = rows.Scan(&volunteerStruct.VolunteerID, &volunteerStruct.VolunteerName, &volunteerStruct.User)
+		vfsCheck, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: key.ScheduleID}})
 		if err != nil {
-			return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.Rows.Scan error: %w. Value of volunteerStruct is `%+v`", err, volunteerStruct)
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+		}
+		for _, vfs := range vfsCheck {
+			nameCheck, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: vfs.Volunteer})
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+			}
+			if !slices.Contains(volunteers, nameCheck.VolunteerName) {
+				VFSToDelete = append(VFSToDelete, vfs.VFSID)
+				//log.Printf("VFSToDelete=`%v`; nameCheck.VolunteerName=`%s`", VFSToDelete, nameCheck.VolunteerName)
+			}
 		}
-		result = append(result, volunteerStruct)
 	}
-	err = rows.Err()
+	tx, err := vsam.begin()
 	if err != nil {
-		return []volunteer{}, fmt.Errorf("error in RequestVolunteers: sql.Rows.Err error: %w", err)
+		return fmt.Errorf("error in CleanOrphanedVFS: sql.DB.Begin error: %w", err)
 	}
-	return result, nil
-}
-
-func (vsam VSAModel) UpdateVolunteers(currentUser string, toUpdate []volunteer) error {
-	if check, failed := testEmpty(toUpdate, volunteer{}); check {
-		return fmt.Errorf("error in UpdateVolunteers: method failed because one of the values in toUpdate had an empty/default values volunteer struct: %+v", failed)
+	defer tx.Rollback()
+	if deleteChildUFS {
+		UFSToDelete := []unavailabilityForSchedule{}
+		for _, vfsidInt := range VFSToDelete {
+			ufsSlice, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: vfsidInt}})
+			if err != nil {
+				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+			}
+			UFSToDelete = append(UFSToDelete, ufsSlice...)
+		}
+		err = vsam.DeleteUFS(currentUser, UFSToDelete)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+		}
 	}
-	checkDuplicates := []volunteer{}
-	for _, val := range toUpdate {
-		if val.VolunteerID == (volunteer{}.VolunteerID) { // User does not need to be provided in the volunteer struct
-			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate had an empty/default value for VolunteerID: %+v", val)
-		} else if val.VolunteerName == (volunteer{}.VolunteerName) {
-			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate had an empty/default value for VolunteerName: %+v", val)
-		} else if check, err := vsam.RequestVolunteers(currentUser, []volunteer{{VolunteerName: val.VolunteerName, User: currentUser}}); len(check) > 0 {
+	if deleteChildSVOD {
+		SVODToDelete := []scheduledVolunteerOnDate{}
+		for _, vfsidInt := range VFSToDelete {
+			svodSlice, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: vfsidInt}})
 			if err != nil {
-				return fmt.Errorf("error in UpdateVolunteers `%+v`: %w", val, err)
+				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 			}
-			return fmt.Errorf("error in UpdateVolunteers: method failed because one of the volunteer structs in toUpdate would create a duplicate volunteer (each volunteer name must be unique per user): %+v", val)
+			SVODToDelete = append(SVODToDelete, svodSlice...)
 		}
-		if !slices.Contains(checkDuplicates, volunteer{VolunteerName: val.VolunteerName}) {
-			checkDuplicates = append(checkDuplicates, volunteer{VolunteerName: val.VolunteerName})
-		} else {
-			return fmt.Errorf("error in UpdateVolunteers: method failed because at least two of the volunteer structs in toUpdate would create duplicate volunteer structs in the database: %+v", volunteer{VolunteerName: val.VolunteerName})
+		err = vsam.DeleteSVOD(currentUser, SVODToDelete)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 		}
 	}
-	tx, err := vsam.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("error in UpdateVolunteers: sql.DB.Begin error: %w", err)
+	// pairings always have to go with either of their VFS, since a pairing is meaningless without both volunteers
+	PFSToDelete := []pairingForSchedule{}
+	for _, vfsidInt := range VFSToDelete {
+		pfsSlice, err := vsam.RequestPFS(currentUser, []pairingForSchedule{{VolunteerForSchedule: vfsidInt}, {PairedVolunteerForSchedule: vfsidInt}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+		}
+		PFSToDelete = append(PFSToDelete, pfsSlice...)
 	}
-	defer tx.Rollback()
-	updateVolunteersString := fmt.Sprintf(`update Volunteers set VolunteerName=? where User="%s" and VolunteerID=?`, currentUser)
-	updateVolunteersStmt, err := tx.Prepare(updateVolunteersString)
+	err = vsam.DeletePFS(currentUser, PFSToDelete)
 	if err != nil {
-		return fmt.Errorf("error in UpdateVolunteers: sql.Tx.Prepare error: %w. value of updateVolunteersString is `%s`", err, updateVolunteersString)
+		return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 	}
-	defer updateVolunteersStmt.Close()
-	for _, val := range toUpdate {
-		_, err = updateVolunteersStmt.Exec(val.VolunteerName, val.VolunteerID)
+	// the same goes for unavailability rules, preferences, and date ranges, which are only kept for as long as the volunteer is on the schedule
+	URFSToDelete := []unavailabilityRuleForSchedule{}
+	for _, vfsidInt := range VFSToDelete {
+		urfsSlice, err := vsam.RequestURFS(currentUser, []unavailabilityRuleForSchedule{{VolunteerForSchedule: vfsidInt}})
 		if err != nil {
-			return fmt.Errorf("error in UpdateVolunteers: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 		}
+		URFSToDelete = append(URFSToDelete, urfsSlice...)
 	}
-	err = tx.Commit()
+	err = vsam.DeleteURFS(currentUser, URFSToDelete)
 	if err != nil {
-		return fmt.Errorf("error in UpdateVolunteers: sql.Tx.Commit error: %w", err)
-	}
-	return nil
-}
-
-// Will delete Volunteers database entries that match the VolunteerID or that match the VolunteerName provided in each volunteer struct. If a VolunteerID > 0 is provided, the value for VolunteerName is ignored for that volunteer struct.
-func (vsam VSAModel) DeleteVolunteers(currentUser string, toDelete []volunteer) error {
-	if check, failed := testEmpty(toDelete, volunteer{}); check {
-		return fmt.Errorf("error in DeleteVolunteers: method failed because one of the values in toDelete had an empty/default values volunteer struct: %+v", failed)
+		return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 	}
-	for _, val := range toDelete {
-		if val.VolunteerID == (volunteer{}.VolunteerID) && val.VolunteerName == (volunteer{}.VolunteerName) { // User does not need to be provided in the volunteer struct. One of VolunteerID and VolunteerName must be provided
-			return fmt.Errorf("error in DeleteVolunteers: method failed because one of the volunteer structs in toDelete had empty/default values for VolunteerID and VolunteerName (at least one must be provided): %+v", val)
+	PrFSToDelete := []preferenceForSchedule{}
+	for _, vfsidInt := range VFSToDelete {
+		prfsSlice, err := vsam.RequestPrFS(currentUser, []preferenceForSchedule{{VolunteerForSchedule: vfsidInt}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 		}
+		PrFSToDelete = append(PrFSToDelete, prfsSlice...)
 	}
-	tx, err := vsam.DB.Begin()
+	err = vsam.DeletePrFS(currentUser, PrFSToDelete)
 	if err != nil {
-		return fmt.Errorf("error in DeleteVolunteers: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 	}
-	defer tx.Rollback()
-	for _, val := range toDelete {
-		var deleteVolunteerString string
-		if val.VolunteerID > 0 {
-			deleteVolunteerString = fmt.Sprintf(`delete from Volunteers where User="%s" and VolunteerID=%d`, currentUser, val.VolunteerID)
-		} else {
-			deleteVolunteerString = fmt.Sprintf(`delete from Volunteers where User="%s" and VolunteerName="%s"`, currentUser, val.VolunteerName)
-		}
-		_, err := tx.Exec(deleteVolunteerString)
+	DRFSToDelete := []dateRangeForSchedule{}
+	for _, vfsidInt := range VFSToDelete {
+		drfsSlice, err := vsam.RequestDRFS(currentUser, []dateRangeForSchedule{{VolunteerForSchedule: vfsidInt}})
 		if err != nil {
-			return fmt.Errorf("error in DeleteVolunteers: sql.Tx.Exec error %w. Value of deleteVolunteerString is `%s`", err, deleteVolunteerString)
+			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 		}
+		DRFSToDelete = append(DRFSToDelete, drfsSlice...)
 	}
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("error in DeleteVolunteers: sql.Tx.Commit error: %w", err)
-	}
-	return nil
-}
-
-// Deletes all volunteers who do not have a VFS entry
-func (vsam VSAModel) CleanOrphanedVolunteers(currentUser string) error {
-	tx, err := vsam.DB.Begin()
+	err = vsam.DeleteDRFS(currentUser, DRFSToDelete)
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
 	}
-	defer tx.Rollback()
-	cleanOrphanedVolunteersString := fmt.Sprintf(`delete from Volunteers where User = "%s" and VolunteerID not in (select Volunteer from VolunteersForSchedule)`, currentUser)
-	_, err = tx.Exec(cleanOrphanedVolunteersString)
+	deleteVFSQuery := newSQLQuery(`delete from VolunteersForSchedule where User = ?`, currentUser)
+	deleteVFSQuery.addIn(" and ", "VFSID", VFSToDelete)
+	//fmt.Println(deleteVFSQuery)
+	_, err = tx.Exec(deleteVFSQuery.text, deleteVFSQuery.args...)
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.Tx.Exec error: %w. Value of cleanOrphanedVolunteersString is `%s`", err, cleanOrphanedVolunteersString)
+		return fmt.Errorf("error in CleanOrphanedVFS: sql.Tx.Exec error: %w. Value of deleteVFSQuery is `%s`", err, deleteVFSQuery)
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVolunteers: sql.Tx.Commit error: %w", err)
-	}
-	return nil
-}
-
-// This function is the simplified version of CreateSchedulesExtended and does not allow schedules to be created where ShiftsOff = 0
-func (vsam VSAModel) CreateSchedules(currentUser string, toCreate []schedule) error {
-	err := vsam.CreateSchedulesExtended(currentUser, toCreate, false)
-	if err != nil {
-		return fmt.Errorf("error in CreateSchedules: %w", err)
+		return fmt.Errorf("error in CleanOrphanedVFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) CreateSchedulesExtended(currentUser string, toCreate []schedule, includeShiftsOff0 bool) error {
-	check, err := vsam.RequestSchedulesExtended(currentUser, toCreate, includeShiftsOff0)
+func (vsam VSAModel) CreateUFS(currentUser string, toCreate []unavailabilityForSchedule) error {
+	check, err := vsam.RequestUFS(currentUser, toCreate)
 	if err != nil {
-		return fmt.Errorf("error in CreateSchedulesExtended: %w", err)
+		return fmt.Errorf("error in CreateUFS: %w", err)
 	}
 	if len(check) > 0 {
-		return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedules to be created already exists in the database. Existing schedule(s): %+v", check)
+		return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule entries to be created already exists in the database. Existing unavailabilityForSchedule entry(s): %+v", check)
 	}
-	checkDuplicates := []schedule{}
-	for _, val := range toCreate { // User and ScheduleID do not need to be provided in the schedule structs
-		if val.ScheduleName <= (schedule{}.ScheduleName) {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for ScheduleName: %+v", val)
-		}
-		if !includeShiftsOff0 && val.ShiftsOff <= (schedule{}.ShiftsOff) || val.ShiftsOff <= -1 {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for ShiftsOff: %+v", val)
-		}
-		if val.VolunteersPerShift <= (schedule{}.VolunteersPerShift) {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for VolunteersPerShift: %+v", val)
+	checkDuplicates := []unavailabilityForSchedule{}
+	for _, val := range toCreate { // User and UFSID do not need to be provided in the unavailabilityForSchedule structs
+		if val.VolunteerForSchedule == (unavailabilityForSchedule{}.VolunteerForSchedule) {
+			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
 		}
-		if val.StartDate <= (schedule{}.StartDate) {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for StartDate: %+v", val)
+		if val.Date == (unavailabilityForSchedule{}.Date) {
+			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate did not have a value for Date: %+v", val)
 		}
-		if val.EndDate <= (schedule{}.EndDate) {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate did not have a valid value for EndDate: %+v", val)
+		if err := checkDates(val.Date); err != nil {
+			return fmt.Errorf("error in CreateUFS: %w", err)
 		}
-		if !slices.Contains(checkDuplicates, schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate}) {
-			checkDuplicates = append(checkDuplicates, schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate})
+		if !slices.Contains(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
+			checkDuplicates = append(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
 		} else {
-			return fmt.Errorf("error in CreateSchedulesExtended: method failed because at least one of the schedule structs in toCreate was a duplicate of another schedule struct in toCreate: %+v", val)
+			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate was a duplicate of another unavailabilityForSchedule struct in toCreate: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CreateSchedulesExtended: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in CreateUFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	fillSchedulesTableString := `insert into Schedules (ScheduleName, ShiftsOff, VolunteersPerShift, User, StartDate, EndDate) values (?, ?, ?, ?, ?, ?)`
-	fillSchedulesTableStmt, err := tx.Prepare(fillSchedulesTableString)
+	fillUFSTableString := `insert into UnavailabilitiesForSchedule (User, VolunteerForSchedule, Date) values (?, ?, ?)`
+	fillUFSTableStmt, err := tx.Prepare(fillUFSTableString)
 	if err != nil {
-		return fmt.Errorf("error in CreateSchedulesExtended: sql.Tx.Prepare error: %w. Value of fillSchedulesTableString is `%s`", err, fillSchedulesTableString)
+		return fmt.Errorf("error in CreateUFS: sql.Tx.Prepare error: %w. Value of fillUFSTableString is `%s`", err, fillUFSTableString)
 	}
-	defer fillSchedulesTableStmt.Close()
+	defer fillUFSTableStmt.Close()
 	for i := 0; i < len(toCreate); i++ {
-		_, err = fillSchedulesTableStmt.Exec(toCreate[i].ScheduleName, toCreate[i].ShiftsOff, toCreate[i].VolunteersPerShift, currentUser, toCreate[i].StartDate, toCreate[i].EndDate)
+		_, err = fillUFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Date)
 		if err != nil {
-			return fmt.Errorf("error in CreateSchedulesExtended: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+			return fmt.Errorf("error in CreateUFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CreateSchedulesExtended: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in CreateUFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// Calls RequestSchedules with scheduleStruct and verifies exactly one database row matches.
-func (vsam VSAModel) RequestSchedule(currentUser string, scheduleStruct schedule) (schedule, error) {
-	schedules, err := vsam.RequestSchedules(currentUser, []schedule{scheduleStruct})
+func (vsam VSAModel) RequestUFSSingle(currentUser string, unavailabilityForScheduleStruct unavailabilityForSchedule) (unavailabilityForSchedule, error) {
+	unavailabilitiesForSchedule, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{unavailabilityForScheduleStruct})
 	if err != nil {
-		return schedule{}, fmt.Errorf("error in RequestSchedule: %w", err)
-	}
-	if len(schedules) != 1 {
-		return schedule{}, fmt.Errorf("error in RequestSchedule: method failed to locate exactly one schedule matching %+v. Found %d matches", scheduleStruct, len(schedules))
+		return unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFSSingle: %w", err)
 	}
-	return schedules[0], nil
-}
-
-// This function is the simple version of RequestSchedulesExtended and does not allow ShiftsOff = 0 to be queried.
-func (vsam VSAModel) RequestSchedules(currentUser string, schedules []schedule) ([]schedule, error) {
-	retrievedSchedules, err := vsam.RequestSchedulesExtended(currentUser, schedules, false)
-	if err != nil {
-		return []schedule{}, fmt.Errorf("error in RequestSchedules: %w", err)
+	if len(unavailabilitiesForSchedule) != 1 {
+		return unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFSSingle: method failed to locate exactly one UFS matching %+v. Found %d matches", unavailabilityForScheduleStruct, len(unavailabilitiesForSchedule))
 	}
-	return retrievedSchedules, nil
+	return unavailabilitiesForSchedule[0], nil
 }
 
-// This version of RequestSchedules allows ShiftsOff = 0 to be queried, but any default schedule structs will have ShiftsOff: 0 implicitly, so ShiftsOff must be set to a desired value or to -1 to be ignored.
-func (vsam VSAModel) RequestSchedulesExtended(currentUser string, schedules []schedule, includeShiftsOff0 bool) ([]schedule, error) {
-	schedulesQuery := fmt.Sprintf(`select * from Schedules where User = "%s"`, currentUser)
-	if !includeShiftsOff0 { // I have to check for this edge case
-		for _, val := range schedules {
-			if val.ShiftsOff <= -1 {
-				return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: method failed because includeShiftsOff0 is false but one of the schedule structs contains ShiftsOff<=-1: %+v", val)
-			}
-		}
-	}
-	if len(schedules) > 0 {
-		var checkAgainst schedule
-		if includeShiftsOff0 {
-			checkAgainst = schedule{ShiftsOff: -1}
-		} else {
-			checkAgainst = schedule{}
-		}
-		if check, failed := testEmpty(schedules, checkAgainst); check {
-			return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: method failed because one of the values in schedules had an empty/default values schedule struct: %+v", failed)
+func (vsam VSAModel) RequestUFS(currentUser string, unavailabilitiesForSchedule []unavailabilityForSchedule) ([]unavailabilityForSchedule, error) {
+	UFSQuery := newSQLQuery(`select * from UnavailabilitiesForSchedule where User = ?`, currentUser)
+	if len(unavailabilitiesForSchedule) > 0 {
+		if check, failed := testEmpty(unavailabilitiesForSchedule, unavailabilityForSchedule{}); check {
+			return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: method failed because one of the values in unavailabilitiesForSchedule had an empty/default values unavailabilityForSchedule struct: %+v", failed)
 		}
-		schedulesQuery = fmt.Sprintf(`%s and (`, schedulesQuery)
 	}
-	for i := 0; i < len(schedules); i++ {
-		var count int
-		if includeShiftsOff0 {
-			count = countGTZero([]int{schedules[i].ScheduleID, len(schedules[i].ScheduleName), schedules[i].ShiftsOff + 1, schedules[i].VolunteersPerShift, len(schedules[i].User), schedules[i].StartDate, schedules[i].EndDate})
-		} else {
-			count = countGTZero([]int{schedules[i].ScheduleID, len(schedules[i].ScheduleName), schedules[i].ShiftsOff, schedules[i].VolunteersPerShift, len(schedules[i].User), schedules[i].StartDate, schedules[i].EndDate})
-		}
-		// count must be at least 1 because the testEmpty check passed
-		//fmt.Println(count)
-		schedulesQuery = fmt.Sprintf(`%s(`, schedulesQuery)
-		if schedules[i].ScheduleID > 0 {
-			schedulesQuery = fmt.Sprintf(`%sScheduleID = %d`, schedulesQuery, schedules[i].ScheduleID)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
-		}
-		if len(schedules[i].ScheduleName) > 0 {
-			schedulesQuery = fmt.Sprintf(`%sScheduleName = "%s"`, schedulesQuery, schedules[i].ScheduleName)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
-		}
-		if schedules[i].ShiftsOff > 0 || includeShiftsOff0 && schedules[i].ShiftsOff > -1 {
-			schedulesQuery = fmt.Sprintf(`%sShiftsOff = %d`, schedulesQuery, schedules[i].ShiftsOff)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
-		}
-		if schedules[i].VolunteersPerShift > 0 {
-			schedulesQuery = fmt.Sprintf(`%sVolunteersPerShift = %d`, schedulesQuery, schedules[i].VolunteersPerShift)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
-		}
-		if len(schedules[i].User) > 0 {
-			schedulesQuery = fmt.Sprintf(`%sUser = "%s"`, schedulesQuery, schedules[i].User)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
+	matches := make([]sqlMatch, 0, len(unavailabilitiesForSchedule))
+	for i := 0; i < len(unavailabilitiesForSchedule); i++ {
+		var match sqlMatch
+		if unavailabilitiesForSchedule[i].UFSID > 0 {
+			match.equals("UFSID", unavailabilitiesForSchedule[i].UFSID)
 		}
-		if schedules[i].StartDate > 0 {
-			schedulesQuery = fmt.Sprintf(`%sStartDate = %d`, schedulesQuery, schedules[i].StartDate)
-			count--
-			if count > 0 {
-				schedulesQuery = fmt.Sprintf(`%s and `, schedulesQuery)
-			}
+		if len(unavailabilitiesForSchedule[i].User) > 0 {
+			match.equals("User", unavailabilitiesForSchedule[i].User)
 		}
-		if schedules[i].EndDate > 0 {
-			schedulesQuery = fmt.Sprintf(`%sEndDate = %d`, schedulesQuery, schedules[i].EndDate)
+		if unavailabilitiesForSchedule[i].VolunteerForSchedule > 0 {
+			match.equals("VolunteerForSchedule", unavailabilitiesForSchedule[i].VolunteerForSchedule)
 		}
-		schedulesQuery = fmt.Sprintf(`%s)`, schedulesQuery)
-		if i+1 < len(schedules) {
-			schedulesQuery = fmt.Sprintf(`%s or `, schedulesQuery)
+		if len(unavailabilitiesForSchedule[i].Date) > 0 {
+			match.equals("Date", unavailabilitiesForSchedule[i].Date)
 		}
-		//fmt.Println(count)
-		//fmt.Println(schedulesQuery)
-	}
-	if len(schedules) > 0 {
-		schedulesQuery = fmt.Sprintf(`%s)`, schedulesQuery)
+		matches = append(matches, match)
 	}
-	//fmt.Println(schedulesQuery)
-	var result []schedule
-	rows, err := vsam.DB.Query(schedulesQuery)
+	UFSQuery.addMatchAny(" and ", matches)
+	UFSQuery.add(` order by UFSID`)
+	//fmt.Println(UFSQuery)
+	var result []unavailabilityForSchedule
+	rows, err := vsam.querier().Query(UFSQuery.text, UFSQuery.args...)
 	if err != nil {
-		return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.DB.Query error: %w. Value of schedulesQuery is `%s`", err, schedulesQuery)
+		return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.DB.Query error: %w. Value of UFSQuery is `%s`", err, UFSQuery)
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var userSchedule schedule
-		err = rows.Scan(&userSchedule.ScheduleID, &userSchedule.ScheduleName, &userSchedule.ShiftsOff, &userSchedule.VolunteersPerShift, &userSchedule.User, &userSchedule.StartDate, &userSchedule.EndDate)
+		var UFSStruct unavailabilityForSchedule
+		err = rows.Scan(&UFSStruct.UFSID, &UFSStruct.User, &UFSStruct.VolunteerForSchedule, &UFSStruct.Date)
 		if err != nil {
-			return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.Rows.Scan error: %w. Value of userSchedule is `%+v`", err, userSchedule)
+			return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.Rows.Scan error: %w. Value of UFSStruct is `%+v`", err, UFSStruct)
 		}
-		result = append(result, userSchedule)
+		result = append(result, UFSStruct)
 	}
 	err = rows.Err()
 	if err != nil {
-		return []schedule{}, fmt.Errorf("error in RequestSchedulesExtended: sql.Rows.Err error: %w", err)
+		return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.Rows.Err error: %w", err)
 	}
 	return result, nil
 }
 
-// This function is the simple version of UpdateSchedulesExtended and does not allow columns to set ShiftsOff = 0.
-func (vsam VSAModel) UpdateSchedules(currentUser string, toUpdate []schedule) error {
-	return vsam.UpdateSchedulesExtended(currentUser, toUpdate, false)
-}
-
-// This version of UpdateSchedulesExtended allows ShiftsOff = 0 to be queried, but any default schedule structs will have ShiftsOff: 0 implicitly, so ShiftsOff must be set to a desired value or to -1 to be ignored.
-func (vsam VSAModel) UpdateSchedulesExtended(currentUser string, toUpdate []schedule, includeShiftsOff0 bool) error {
-	var checkAgainst schedule
-	if includeShiftsOff0 {
-		checkAgainst = schedule{ShiftsOff: -1}
-	} else {
-		checkAgainst = schedule{}
+func (vsam VSAModel) UpdateUFS(currentUser string, toUpdate []unavailabilityForSchedule) error {
+	if check, failed := testEmpty(toUpdate, unavailabilityForSchedule{}); check {
+		return fmt.Errorf("error in UpdateUFS: method failed because one of the values in toUpdate had an empty/default values unavailabilityForSchedule struct: %+v", failed)
 	}
-	if check, failed := testEmpty(toUpdate, checkAgainst); check {
-		return fmt.Errorf("error in UpdateSchedulesExtended: method failed because one of the values in toUpdate had an empty/default values schedule struct: %+v", failed)
+	for _, val := range toUpdate {
+		if err := checkDates(val.Date); err != nil {
+			return fmt.Errorf("error in UpdateUFS: %w", err)
+		}
 	}
-	head := `update Schedules set`
-	tail := fmt.Sprintf(`where User="%s" and ScheduleID=?`, currentUser)
-	tx, err := vsam.DB.Begin()
+	head := `update UnavailabilitiesForSchedule set`
+	tail := `where User=? and UFSID=?`
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in UpdateSchedulesExtended: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in UpdateUFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	checkDuplicates := []schedule{}
+	checkDuplicates := []unavailabilityForSchedule{}
 	for _, val := range toUpdate {
-		if val.ScheduleID == 0 {
-			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because one of the values in toUpdate had an empty/default value for ScheduleID: %+v", val)
+		if val.UFSID == 0 {
+			return fmt.Errorf("error in UpdateUFS: method failed because one of the values in toUpdate had an empty/default value for UFSID: %+v", val)
 		}
-		currentSchedule, err := vsam.RequestSchedule(currentUser, schedule{ScheduleID: val.ScheduleID})
+		currentUFS, err := vsam.RequestUFSSingle(currentUser, unavailabilityForSchedule{UFSID: val.UFSID})
 		if err != nil {
-			return fmt.Errorf("error in UpdateSchedulesExtended: %w", err)
-		}
-		checkStruct := schedule{ScheduleName: val.ScheduleName, ShiftsOff: val.ShiftsOff, VolunteersPerShift: val.VolunteersPerShift, StartDate: val.StartDate, EndDate: val.EndDate}
-		if !slices.Contains(checkDuplicates, checkStruct) {
-			checkDuplicates = append(checkDuplicates, checkStruct)
-		} else {
-			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because at least two of the schedule structs in toUpdate would create duplicate schedule structs in the database: %+v", checkStruct)
+			return fmt.Errorf("error in UpdateUFS: %w", err)
 		}
-		currentSchedule.ScheduleID = 0
-		updateSchedulesString := head
-		var count int
-		if includeShiftsOff0 {
-			count = countGTZero([]int{val.ScheduleID, len(val.ScheduleName), val.ShiftsOff + 1, val.VolunteersPerShift, len(val.User), val.StartDate, val.EndDate})
+		if !slices.Contains(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
+			checkDuplicates = append(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
 		} else {
-			count = countGTZero([]int{val.ScheduleID, len(val.ScheduleName), val.ShiftsOff, val.VolunteersPerShift, len(val.User), val.StartDate, val.EndDate})
+			return fmt.Errorf("error in UpdateUFS: method failed because at least two of the unavailabilityForSchedule structs in toUpdate would create duplicate unavailabilityForSchedule structs in the database: %+v", unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
 		}
-		count-- // This is needed because a ScheduleID has been provided (verified at the start of this loop).
+		currentUFS.UFSID = 0
+		updateUFSString := newSQLQuery(head)
+		count := countGTZero([]int{val.UFSID, len(val.User), val.VolunteerForSchedule, len(val.Date)})
+		count-- // This is needed because a UFSID has been provided (verified at the start of this loop).
 		if count == 0 {
-			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because only one value was provided in a schedule struct. At least two values (a ScheduleID and a value to update) must be provided: %+v", val)
+			return fmt.Errorf("error in UpdateUFS: method failed because only one value was provided in an unavailabilityForSchedule struct. At least two values (a UFSID and a value to update) must be provided: %+v", val)
 		}
 		// count is at least 1
 		//fmt.Println(count)
-		//fmt.Println(updateSchedulesString)
-		if len(val.ScheduleName) > 0 {
-			updateSchedulesString = fmt.Sprintf(`%s ScheduleName="%s"`, updateSchedulesString, val.ScheduleName)
-			count--
-			currentSchedule.ScheduleName = val.ScheduleName
-			if count > 0 {
-				updateSchedulesString = fmt.Sprintf(`%s,`, updateSchedulesString)
-			}
-			//fmt.Println(count)
-			//fmt.Println(updateSchedulesString)
-		}
-		if val.ShiftsOff > 0 || includeShiftsOff0 && val.ShiftsOff > -1 {
-			updateSchedulesString = fmt.Sprintf(`%s ShiftsOff=%d`, updateSchedulesString, val.ShiftsOff)
-			count--
-			currentSchedule.ShiftsOff = val.ShiftsOff
-			if count > 0 {
-				updateSchedulesString = fmt.Sprintf(`%s,`, updateSchedulesString)
-			}
-			//fmt.Println(count)
-			//fmt.Println(updateSchedulesString)
-		}
-		if val.VolunteersPerShift > 0 {
-			updateSchedulesString = fmt.Sprintf(`%s VolunteersPerShift=%d`, updateSchedulesString, val.VolunteersPerShift)
-			count--
-			currentSchedule.VolunteersPerShift = val.VolunteersPerShift
-			if count > 0 {
-				updateSchedulesString = fmt.Sprintf(`%s,`, updateSchedulesString)
-			}
-			//fmt.Println(count)
-			//fmt.Println(updateSchedulesString)
-		}
-		if val.StartDate > 0 {
-			updateSchedulesString = fmt.Sprintf(`%s StartDate=%d`, updateSchedulesString, val.StartDate)
+		//fmt.Println(updateUFSString)
+		if val.VolunteerForSchedule > 0 {
+			updateUFSString.add(` VolunteerForSchedule=?`, val.VolunteerForSchedule)
 			count--
-			currentSchedule.StartDate = val.StartDate
+			currentUFS.VolunteerForSchedule = val.VolunteerForSchedule
 			if count > 0 {
-				updateSchedulesString = fmt.Sprintf(`%s,`, updateSchedulesString)
+				updateUFSString.add(`,`)
 			}
 			//fmt.Println(count)
-			//fmt.Println(updateSchedulesString)
+			//fmt.Println(updateUFSString)
 		}
-		if val.EndDate > 0 {
-			updateSchedulesString = fmt.Sprintf(`%s EndDate=%d`, updateSchedulesString, val.EndDate)
-			currentSchedule.EndDate = val.EndDate
+		if len(val.Date) > 0 {
+			updateUFSString.add(` Date=?`, val.Date)
 			//fmt.Println(count)
-			//fmt.Println(updateSchedulesString)
+			//fmt.Println(updateUFSString)
+			currentUFS.Date = val.Date
 		}
-		updateSchedulesString = fmt.Sprintf(`%s %s`, updateSchedulesString, tail)
+		updateUFSString.add(fmt.Sprintf(` %s`, tail), currentUser)
 		//fmt.Println(count)
-		//fmt.Println(updateSchedulesString)
-		if check, err := vsam.RequestSchedulesExtended(currentUser, []schedule{currentSchedule}, includeShiftsOff0); err != nil {
-			return fmt.Errorf("error in UpdateSchedulesExtended: %w", err)
+		//fmt.Println(updateUFSString)
+		if check, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{currentUFS}); err != nil {
+			return fmt.Errorf("error in UpdateUFS: %w", err)
 		} else if len(check) > 0 {
-			return fmt.Errorf("error in UpdateSchedulesExtended: method failed because it would create a duplicate schedule: %+v", val)
+			return fmt.Errorf("error in UpdateUFS: method failed because it would create a duplicate UFS: %+v", val)
 		}
-		updateSchedulesStmt, err := tx.Prepare(updateSchedulesString)
+		updateSchedulesStmt, err := tx.Prepare(updateUFSString.text)
 		if err != nil {
-			return fmt.Errorf("error in UpdateSchedulesExtended: sql.Tx.Prepare error: %w. Value of updateSchedulesString is `%s`", err, updateSchedulesString)
+			return fmt.Errorf("error in UpdateUFS: sql.Stmt.Prepare error: %w. Value of updateUFSString is `%s`", err, updateUFSString)
 		}
 		defer updateSchedulesStmt.Close()
-		_, err = updateSchedulesStmt.Exec(val.ScheduleID)
+		_, err = updateSchedulesStmt.Exec(append(updateUFSString.args, val.UFSID)...)
 		if err != nil {
-			return fmt.Errorf("error in UpdateSchedulesExtended: sql.Stmt.Exec error: %w. Value of val is %+v", err, val)
+			return fmt.Errorf("error in UpdateUFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in UpdateSchedulesExtended: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in UpdateUFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// Will delete Schedule database entries that match the ScheduleID or that match the ScheduleName provided in each schedule struct. If a ScheduleID > 0 is provided, the value for ScheduleName is ignored for that schedule struct.
-func (vsam VSAModel) DeleteSchedules(currentUser string, toDelete []schedule) error {
+// Will delete UFS database entries that match the UFSID or that match the VFS and Date provided in each UFS struct. If a UFSID > 0 is provided, the values for VFS and Date are ignored for that UFS struct.
+func (vsam VSAModel) DeleteUFS(currentUser string, toDelete []unavailabilityForSchedule) error {
 	for _, val := range toDelete {
-		if val.ScheduleID < 1 && len(val.ScheduleName) == 0 {
-			return fmt.Errorf("error in DeleteSchedules: method failed because one of the schedule structs did not have a value for ScheduleID or ScheduleName (at least one must be provided): %+v", val)
+		if val.UFSID < 1 && (val.VolunteerForSchedule < 1 || len(val.Date) < 1) {
+			return fmt.Errorf("error in DeleteUFS: method failed because one of the unavailabilityForSchedule structs did not have a value for UFSID or VolunteerForSchedule and Date: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in DeleteSchedules: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeleteUFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
 	for _, val := range toDelete {
-		var deleteScheduleString string
-		if val.ScheduleID > 0 {
-			deleteScheduleString = fmt.Sprintf(`delete from Schedules where User="%s" and ScheduleID=%d`, currentUser, val.ScheduleID)
+		var deleteUFSString sqlQuery
+		if val.UFSID > 0 {
+			deleteUFSString = newSQLQuery(`delete from UnavailabilitiesForSchedule where User=? and UFSID=?`, currentUser, val.UFSID)
 		} else {
-			deleteScheduleString = fmt.Sprintf(`delete from Schedules where User="%s" and ScheduleName="%s"`, currentUser, val.ScheduleName)
+			deleteUFSString = newSQLQuery(`delete from UnavailabilitiesForSchedule where User=? and VolunteerForSchedule=? and Date=?`, currentUser, val.VolunteerForSchedule, val.Date)
 		}
-		_, err := tx.Exec(deleteScheduleString)
+		_, err := tx.Exec(deleteUFSString.text, deleteUFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in DeleteSchedules: sql.Tx.Exec error: %w. Value of deleteScheduleString is %s", err, deleteScheduleString)
+			return fmt.Errorf("error in DeleteUFS: sql.Tx.Exec error: %w. Value of deleteUFSString is `%s`", err, deleteUFSString)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in DeleteSchedules: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in DeleteUFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) CreateWFS(currentUser string, toCreate []weekdayForSchedule) error {
-	check, err := vsam.RequestWFS(currentUser, toCreate)
-	if err != nil {
-		return fmt.Errorf("error in CreateWFS: %w", err)
-	}
-	if len(check) > 0 {
-		return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule entries to be created already exists in the database. Existing weekdayForSchedule(s): %+v", check)
-	}
-	checkDuplicates := []weekdayForSchedule{}
-	for _, val := range toCreate { // User and WFSID do not need to be provided in the weekdayForSchedule structs
-		if val.Weekday == (weekdayForSchedule{}.Weekday) {
-			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate did not have a value for Weekday: %+v", val)
+// correctUFS is a slices of maps with VFS structs as keys and slices of dates as values. If a UFS row is linked to a VFS, but doesn't have a matching date, delete that VFS row.
+func (vsam VSAModel) CleanOrphanedUFS(currentUser string, correctUFS map[volunteerForSchedule][]date) error {
+	var UFSToDelete []int
+	for key, value := range correctUFS {
+		if key.VFSID == 0 {
+			return fmt.Errorf("error in CleanOrphanedUFS: method failed because one of the provided volunteerForSchedule structs did not have a VFSID: %+v", map[volunteerForSchedule][]date{key: value})
 		}
-		if val.Schedule == (weekdayForSchedule{}.Schedule) {
-			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
+		var dates []string
+		for _, dateStruct := range value {
+			if dateStruct.Year < 1 || dateStruct.Month < 1 || dateStruct.Day < 1 {
+				return fmt.Errorf("error in CleanOrphanedUFS: method failed because one of the provided date structs did not have a Year, Month, and Day: %+v", map[volunteerForSchedule][]date{key: value})
+			}
+			dates = append(dates, dateStruct.ToString())
 		}
-		if !slices.Contains(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule}) {
-			checkDuplicates = append(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
-		} else {
-			return fmt.Errorf("error in CreateWFS: method failed because at least one of the weekdayForSchedule structs in toCreate was a duplicate of another weekdayForSchedule struct in toCreate: %+v", val)
+		ufsCheck, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: key.VFSID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedUFS: %w", err)
 		}
-	}
-	tx, err := vsam.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("error in CreateWFS: sql.DB.Begin error: %w", err)
-	}
-	defer tx.Rollback()
-	fillWFSTableString := `insert into WeekdaysForSchedule (User, Weekday, Schedule) values (?, ?, ?)`
-	fillWFSTableStmt, err := tx.Prepare(fillWFSTableString)
-	if err != nil {
-		return fmt.Errorf("error in CreateWFS: sql.Tx.Prepare error: %w. Value of fillWFSTableString is `%s`", err, fillWFSTableString)
-	}
-	defer fillWFSTableStmt.Close()
-	for i := 0; i < len(toCreate); i++ {
-		_, err = fillWFSTableStmt.Exec(currentUser, toCreate[i].Weekday, toCreate[i].Schedule)
+		for _, ufs := range ufsCheck {
+			if !slices.Contains(dates, ufs.Date) {
+				UFSToDelete = append(UFSToDelete, ufs.UFSID)
+				//log.Printf("UFSToDelete=`%#v`; ufs.Date=`%s", UFSToDelete, ufs.Date)
+			}
+		}
+		tx, err := vsam.begin()
 		if err != nil {
-			return fmt.Errorf("error in CreateWFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+			return fmt.Errorf("error in CleanOrphanedUFS: sql.DB.Begin error: %w", err)
+		}
+		defer tx.Rollback()
+		deleteUFSQuery := newSQLQuery(`delete from UnavailabilitiesForSchedule where User = ?`, currentUser)
+		deleteUFSQuery.addIn(" and ", "UFSID", UFSToDelete)
+		//fmt.Println(deleteUFSQuery)
+		_, err = tx.Exec(deleteUFSQuery.text, deleteUFSQuery.args...)
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedUFS: sql.Tx.Exec error: %w. Value of deleteUFSQuery is `%s`", err, deleteUFSQuery)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedUFS: sql.Tx.Commit error: %w", err)
 		}
-	}
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("error in CreateWFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) RequestWFSSingle(currentUser string, volunteerForScheduleStruct weekdayForSchedule) (weekdayForSchedule, error) {
-	weekdaysForSchedule, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{volunteerForScheduleStruct})
+func (vsam VSAModel) CreatePFS(currentUser string, toCreate []pairingForSchedule) error {
+	check, err := vsam.RequestPFS(currentUser, toCreate)
 	if err != nil {
-		return weekdayForSchedule{}, fmt.Errorf("error in RequestWFSSingle: %w", err)
-	}
-	if len(weekdaysForSchedule) != 1 {
-		return weekdayForSchedule{}, fmt.Errorf("error in RequestWFSSingle: method failed to locate exactly one WFS matching %+v. Found %d matches", volunteerForScheduleStruct, len(weekdaysForSchedule))
+		return fmt.Errorf("error in CreatePFS: %w", err)
 	}
-	return weekdaysForSchedule[0], nil
-}
-
-func (vsam VSAModel) RequestWFS(currentUser string, weekdaysForSchedule []weekdayForSchedule) ([]weekdayForSchedule, error) {
-	weekdaysForScheduleQuery := fmt.Sprintf(`select * from WeekdaysForSchedule where User = "%s"`, currentUser)
-	if len(weekdaysForSchedule) > 0 {
-		if check, failed := testEmpty(weekdaysForSchedule, weekdayForSchedule{}); check {
-			return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: method failed because one of the values in weekdaysForSchedule had an empty/default values weekdayForSchedule struct: %+v", failed)
-		}
-		weekdaysForScheduleQuery = fmt.Sprintf(`%s and (`, weekdaysForScheduleQuery)
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule entries to be created already exists in the database. Existing pairingForSchedule(s): %+v", check)
 	}
-	for i := 0; i < len(weekdaysForSchedule); i++ {
-		count := countGTZero([]int{weekdaysForSchedule[i].WFSID, len(weekdaysForSchedule[i].User), weekdaysForSchedule[i].Schedule, len(weekdaysForSchedule[i].Weekday)})
-		// count must be at least 1 because the testEmpty check passed
-		//fmt.Println(count)
-		weekdaysForScheduleQuery = fmt.Sprintf(`%s(`, weekdaysForScheduleQuery)
-		if weekdaysForSchedule[i].WFSID > 0 {
-			weekdaysForScheduleQuery = fmt.Sprintf(`%sWFSID = %d`, weekdaysForScheduleQuery, weekdaysForSchedule[i].WFSID)
-			count--
-			if count > 0 {
-				weekdaysForScheduleQuery = fmt.Sprintf(`%s and `, weekdaysForScheduleQuery)
-			}
+	checkDuplicates := []pairingForSchedule{}
+	for _, val := range toCreate { // User and PFSID do not need to be provided in the pairingForSchedule structs
+		if val.VolunteerForSchedule == (pairingForSchedule{}.VolunteerForSchedule) {
+			return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
 		}
-		if len(weekdaysForSchedule[i].User) > 0 {
-			weekdaysForScheduleQuery = fmt.Sprintf(`%sUser = "%s"`, weekdaysForScheduleQuery, weekdaysForSchedule[i].User)
-			count--
-			if count > 0 {
-				weekdaysForScheduleQuery = fmt.Sprintf(`%s and `, weekdaysForScheduleQuery)
-			}
+		if val.PairedVolunteerForSchedule == (pairingForSchedule{}.PairedVolunteerForSchedule) {
+			return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule structs in toCreate did not have a value for PairedVolunteerForSchedule: %+v", val)
 		}
-		if weekdaysForSchedule[i].Schedule > 0 {
-			weekdaysForScheduleQuery = fmt.Sprintf(`%sSchedule = %d`, weekdaysForScheduleQuery, weekdaysForSchedule[i].Schedule)
-			count--
-			if count > 0 {
-				weekdaysForScheduleQuery = fmt.Sprintf(`%s and `, weekdaysForScheduleQuery)
-			}
+		if val.VolunteerForSchedule == val.PairedVolunteerForSchedule {
+			return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule structs in toCreate paired a volunteer with themselves: %+v", val)
 		}
-		if len(weekdaysForSchedule[i].Weekday) > 0 {
-			weekdaysForScheduleQuery = fmt.Sprintf(`%sWeekday = "%s"`, weekdaysForScheduleQuery, weekdaysForSchedule[i].Weekday)
+		if val.Pairing != PairingTogether && val.Pairing != PairingApart {
+			return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule structs in toCreate did not have a Pairing of \"%s\" or \"%s\": %+v", PairingTogether, PairingApart, val)
 		}
-		weekdaysForScheduleQuery = fmt.Sprintf(`%s)`, weekdaysForScheduleQuery)
-		if i+1 < len(weekdaysForSchedule) {
-			weekdaysForScheduleQuery = fmt.Sprintf(`%s or `, weekdaysForScheduleQuery)
+		if !slices.Contains(checkDuplicates, pairingForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, PairedVolunteerForSchedule: val.PairedVolunteerForSchedule, Pairing: val.Pairing}) {
+			checkDuplicates = append(checkDuplicates, pairingForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, PairedVolunteerForSchedule: val.PairedVolunteerForSchedule, Pairing: val.Pairing})
+		} else {
+			return fmt.Errorf("error in CreatePFS: method failed because at least one of the pairingForSchedule structs in toCreate was a duplicate of another pairingForSchedule struct in toCreate: %+v", val)
 		}
-		//fmt.Println(count)
-		//fmt.Println(weekdaysForScheduleQuery)
 	}
-	if len(weekdaysForSchedule) > 0 {
-		weekdaysForScheduleQuery = fmt.Sprintf(`%s)`, weekdaysForScheduleQuery)
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreatePFS: sql.DB.Begin error: %w", err)
 	}
-	//fmt.Println(weekdaysForScheduleQuery)
-	var result []weekdayForSchedule
-	rows, err := vsam.DB.Query(weekdaysForScheduleQuery)
+	defer tx.Rollback()
+	fillPFSTableString := `insert into PairingsForSchedule (User, VolunteerForSchedule, PairedVolunteerForSchedule, Pairing) values (?, ?, ?, ?)`
+	fillPFSTableStmt, err := tx.Prepare(fillPFSTableString)
 	if err != nil {
-		return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.DB.Query error: %w. Value of weekdaysForScheduleQuery is `%s`", err, weekdaysForScheduleQuery)
+		return fmt.Errorf("error in CreatePFS: sql.Tx.Prepare error: %w. Value of fillPFSTableString is `%s`", err, fillPFSTableString)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var weekdayForScheduleStruct weekdayForSchedule
-		err = rows.Scan(&weekdayForScheduleStruct.WFSID, &weekdayForScheduleStruct.User, &weekdayForScheduleStruct.Weekday, &weekdayForScheduleStruct.Schedule)
+	defer fillPFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillPFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].PairedVolunteerForSchedule, toCreate[i].Pairing)
 		if err != nil {
-			return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.Rows.Scan error: %w. value of weekdayForScheduleStruct is `%+v`", err, weekdayForScheduleStruct)
+			return fmt.Errorf("error in CreatePFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
-		result = append(result, weekdayForScheduleStruct)
 	}
-	err = rows.Err()
+	err = tx.Commit()
 	if err != nil {
-		return []weekdayForSchedule{}, fmt.Errorf("error in RequestWFS: sql.Rows.Err error: %w", err)
+		return fmt.Errorf("error in CreatePFS: sql.Tx.Commit error: %w", err)
 	}
-	return result, nil
+	return nil
 }
-
-func (vsam VSAModel) UpdateWFS(currentUser string, toUpdate []weekdayForSchedule) error {
-	if check, failed := testEmpty(toUpdate, weekdayForSchedule{}); check {
-		return fmt.Errorf("error in UpdateWFS: method failed because one of the values in toUpdate had an empty/default values weekdayForSchedule struct: %+v", failed)
-	}
-	head := `update WeekdaysForSchedule set`
-	tail := fmt.Sprintf(`where User="%s" and WFSID=?`, currentUser)
-	tx, err := vsam.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("error in UpdateWFS: sql.DB.Begin error: %w", err)
-	}
-	defer tx.Rollback()
-	checkDuplicates := []weekdayForSchedule{}
-	for _, val := range toUpdate {
-		if val.WFSID == 0 {
-			return fmt.Errorf("error in UpdateWFS: method failed because one of the values in toUpdate had an empty/default value for WFSID: %+v", val)
-		}
-		currentWFS, err := vsam.RequestWFSSingle(currentUser, weekdayForSchedule{WFSID: val.WFSID})
-		if err != nil {
-			return fmt.Errorf("error in UpdateWFS: %w", err)
-		}
-		if !slices.Contains(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule}) {
-			checkDuplicates = append(checkDuplicates, weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
-		} else {
-			return fmt.Errorf("error in UpdateWFS: method failed because at least two of the weekdayForSchedule structs in toUpdate would create duplicate weekdayForSchedule structs in the database: %+v", weekdayForSchedule{Weekday: val.Weekday, Schedule: val.Schedule})
+
+func (vsam VSAModel) RequestPFSSingle(currentUser string, pairingForScheduleStruct pairingForSchedule) (pairingForSchedule, error) {
+	pairingsForSchedule, err := vsam.RequestPFS(currentUser, []pairingForSchedule{pairingForScheduleStruct})
+	if err != nil {
+		return pairingForSchedule{}, fmt.Errorf("error in RequestPFSSingle: %w", err)
+	}
+	if len(pairingsForSchedule) != 1 {
+		return pairingForSchedule{}, fmt.Errorf("error in RequestPFSSingle: method failed to locate exactly one PFS matching %+v. Found %d matches", pairingForScheduleStruct, len(pairingsForSchedule))
+	}
+	return pairingsForSchedule[0], nil
+}
+
+func (vsam VSAModel) RequestPFS(currentUser string, pairingsForSchedule []pairingForSchedule) ([]pairingForSchedule, error) {
+	PFSQuery := newSQLQuery(`select * from PairingsForSchedule where User = ?`, currentUser)
+	if len(pairingsForSchedule) > 0 {
+		if check, failed := testEmpty(pairingsForSchedule, pairingForSchedule{}); check {
+			return []pairingForSchedule{}, fmt.Errorf("error in RequestPFS: method failed because one of the values in pairingsForSchedule had an empty/default values pairingForSchedule struct: %+v", failed)
 		}
-		currentWFS.WFSID = 0
-		updateWFSString := head
-		count := countGTZero([]int{val.WFSID, len(val.User), len(val.Weekday), val.Schedule})
-		count-- // This is needed because a WFSID has been provided (verified at the start of this loop).
-		if count == 0 {
-			return fmt.Errorf("error in UpdateWFS: method failed because only one value was provided in a weekdayForSchedule struct. At least two values (a WFSID and a value to update) must be provided: %+v", val)
+	}
+	matches := make([]sqlMatch, 0, len(pairingsForSchedule))
+	for i := 0; i < len(pairingsForSchedule); i++ {
+		var match sqlMatch
+		if pairingsForSchedule[i].PFSID > 0 {
+			match.equals("PFSID", pairingsForSchedule[i].PFSID)
 		}
-		// count is at least 1
-		//fmt.Println(count)
-		//fmt.Println(updateWFSString)
-		if len(val.Weekday) > 0 {
-			updateWFSString = fmt.Sprintf(`%s Weekday="%s"`, updateWFSString, val.Weekday)
-			count--
-			currentWFS.Weekday = val.Weekday
-			if count > 0 {
-				updateWFSString = fmt.Sprintf(`%s,`, updateWFSString)
-			}
-			//fmt.Println(count)
-			//fmt.Println(updateWFSString)
+		if len(pairingsForSchedule[i].User) > 0 {
+			match.equals("User", pairingsForSchedule[i].User)
 		}
-		if val.Schedule > 0 {
-			updateWFSString = fmt.Sprintf(`%s Schedule=%d`, updateWFSString, val.Schedule)
-			//fmt.Println(count)
-			//fmt.Println(updateWFSString)
-			currentWFS.Schedule = val.Schedule
+		if pairingsForSchedule[i].VolunteerForSchedule > 0 {
+			match.equals("VolunteerForSchedule", pairingsForSchedule[i].VolunteerForSchedule)
 		}
-		updateWFSString = fmt.Sprintf(`%s %s`, updateWFSString, tail)
-		//fmt.Println(count)
-		//fmt.Println(updateWFSString)
-		if check, err := vsam.RequestWFS(currentUser, []weekdayForSchedule{currentWFS}); err != nil {
-			return fmt.Errorf("error in UpdateWFS: %w", err)
-		} else if len(check) > 0 {
-			return fmt.Errorf("error in UpdateWFS: method failed because it would create a duplicate WFS: %+v", val)
+		if pairingsForSchedule[i].PairedVolunteerForSchedule > 0 {
+			match.equals("PairedVolunteerForSchedule", pairingsForSchedule[i].PairedVolunteerForSchedule)
 		}
-		updateSchedulesStmt, err := tx.Prepare(updateWFSString)
-		if err != nil {
-			return fmt.Errorf("error in UpdateWFS: sql.Tx.Prepare error: %w", err)
+		if len(pairingsForSchedule[i].Pairing) > 0 {
+			match.equals("Pairing", pairingsForSchedule[i].Pairing)
 		}
-		defer updateSchedulesStmt.Close()
-		_, err = updateSchedulesStmt.Exec(val.WFSID)
+		matches = append(matches, match)
+	}
+	PFSQuery.addMatchAny(" and ", matches)
+	PFSQuery.add(` order by PFSID`)
+	var result []pairingForSchedule
+	rows, err := vsam.querier().Query(PFSQuery.text, PFSQuery.args...)
+	if err != nil {
+		return []pairingForSchedule{}, fmt.Errorf("error in RequestPFS: sql.DB.Query error: %w. Value of PFSQuery is `%s`", err, PFSQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var PFSStruct pairingForSchedule
+		err = rows.Scan(&PFSStruct.PFSID, &PFSStruct.User, &PFSStruct.VolunteerForSchedule, &PFSStruct.PairedVolunteerForSchedule, &PFSStruct.Pairing)
 		if err != nil {
-			return fmt.Errorf("error in UpdateWFS: sql.Stmt.Exec error: %w", err)
+			return []pairingForSchedule{}, fmt.Errorf("error in RequestPFS: sql.Rows.Scan error: %w. Value of PFSStruct is `%+v`", err, PFSStruct)
 		}
+		result = append(result, PFSStruct)
 	}
-	err = tx.Commit()
+	err = rows.Err()
 	if err != nil {
-		return fmt.Errorf("error in UpdateWFS sql.Tx.Commit: %w", err)
+		return []pairingForSchedule{}, fmt.Errorf("error in RequestPFS: sql.Rows.Err error: %w", err)
 	}
-	return nil
+	return result, nil
 }
 
-// Will delete WFS database entries that match the WFSID or that match the Weekday and Schedule provided in each WFS struct. If a WFSID > 0 is provided, the values for Weekday and Schedule are ignored for that WFS struct.
-func (vsam VSAModel) DeleteWFS(currentUser string, toDelete []weekdayForSchedule) error {
+// Will delete PFS database entries that match the PFSID or that match the VolunteerForSchedule and PairedVolunteerForSchedule provided in each PFS struct. If a PFSID > 0 is provided, the other values are ignored for that PFS struct.
+func (vsam VSAModel) DeletePFS(currentUser string, toDelete []pairingForSchedule) error {
 	for _, val := range toDelete {
-		if val.WFSID < 1 && (len(val.Weekday) == 0 || val.Schedule < 1) {
-			return fmt.Errorf("error in DeleteWFS: method failed because one of the weekdayForSchedule structs did not have a value for WFSID or Weekday and Schedule: %+v", val)
+		if val.PFSID < 1 && (val.VolunteerForSchedule < 1 || val.PairedVolunteerForSchedule < 1) {
+			return fmt.Errorf("error in DeletePFS: method failed because one of the pairingForSchedule structs did not have a value for PFSID or VolunteerForSchedule and PairedVolunteerForSchedule: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in DeleteWFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeletePFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
 	for _, val := range toDelete {
-		var deleteWFSString string
-		if val.WFSID > 0 {
-			deleteWFSString = fmt.Sprintf(`delete from WeekdaysForSchedule where User="%s" and WFSID=%d`, currentUser, val.WFSID)
+		var deletePFSString sqlQuery
+		if val.PFSID > 0 {
+			deletePFSString = newSQLQuery(`delete from PairingsForSchedule where User=? and PFSID=?`, currentUser, val.PFSID)
 		} else {
-			deleteWFSString = fmt.Sprintf(`delete from WeekdaysForSchedule where User="%s" and Weekday="%s" and Schedule=%d`, currentUser, val.Weekday, val.Schedule)
+			deletePFSString = newSQLQuery(`delete from PairingsForSchedule where User=? and VolunteerForSchedule=? and PairedVolunteerForSchedule=?`, currentUser, val.VolunteerForSchedule, val.PairedVolunteerForSchedule)
 		}
-		_, err := tx.Exec(deleteWFSString)
+		_, err := tx.Exec(deletePFSString.text, deletePFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in DeleteWFS: sql.Tx.Exec error: %w", err)
+			return fmt.Errorf("error in DeletePFS: sql.Tx.Exec error: %w. Value of deletePFSString is `%s`", err, deletePFSString)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in DeleteWFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in DeletePFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// correctWFS is a map with schedule structs as keys and slices of weekday structs that define WeekdayName as values. If a WFS row is linked to a schedule, but doesn't have a matching weekday, delete that WFS row.
-func (vsam VSAModel) CleanOrphanedWFS(currentUser string, correctWFS map[schedule][]weekday) error {
-	var WFSToDelete []string
-	for key, value := range correctWFS {
-		if key.ScheduleID == 0 {
-			return fmt.Errorf("error in CleanOrphanedWFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", key)
-		}
-		var weekdays []string
-		for _, weekdayStruct := range value {
-			if len(weekdayStruct.WeekdayName) < 6 { // smallest weekday name is 6 characters long.
-				return fmt.Errorf("error in CleanOrphanedWFS: method failed because one of the provided weekday structs did not have a WeekdayName: %+v", value)
-			}
-			weekdays = append(weekdays, weekdayStruct.WeekdayName)
+// correctPFS is a map with VFS structs as keys and slices of the pairings that VFS owns (is the VolunteerForSchedule of) as values. Only PairedVolunteerForSchedule and Pairing need to be set in the pairings.
+// If a PFS row is owned by one of the VFS, but doesn't match one of its pairings, delete that PFS row.
+func (vsam VSAModel) CleanOrphanedPFS(currentUser string, correctPFS map[volunteerForSchedule][]pairingForSchedule) error {
+	var PFSToDelete []int
+	for key, value := range correctPFS {
+		if key.VFSID == 0 {
+			return fmt.Errorf("error in CleanOrphanedPFS: method failed because one of the provided VFS structs did not have a VFSID: %+v", key)
 		}
-		wfsCheck, _ := vsam.RequestWFS(currentUser, []weekdayForSchedule{{Schedule: key.ScheduleID}}) // catch error when updating this func
-		for _, wfs := range wfsCheck {
-			if !slices.Contains(weekdays, wfs.Weekday) {
-				WFSToDelete = append(WFSToDelete, strconv.Itoa(wfs.WFSID))
-				//fmt.Println(WFSToDelete)
+		var pairings []pairingForSchedule
+		for _, pfsStruct := range value {
+			if pfsStruct.PairedVolunteerForSchedule == 0 || pfsStruct.Pairing == "" {
+				return fmt.Errorf("error in CleanOrphanedPFS: method failed because one of the provided PFS structs did not have a PairedVolunteerForSchedule or Pairing: %+v", value)
 			}
+			pairings = append(pairings, pairingForSchedule{PairedVolunteerForSchedule: pfsStruct.PairedVolunteerForSchedule, Pairing: pfsStruct.Pairing})
 		}
-		tx, err := vsam.DB.Begin()
+		PFSCheck, err := vsam.RequestPFS(currentUser, []pairingForSchedule{{VolunteerForSchedule: key.VFSID}})
 		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedWFS: sql.DB.Begin error: %w", err)
-		}
-		defer tx.Rollback()
-		deleteWFSQuery := fmt.Sprintf(`delete from WeekdaysForSchedule where User = "%s" and WFSID in (%s)`, currentUser, CsvSlice(WFSToDelete, true))
-		//fmt.Println(deleteWFSQuery)
-		_, err = tx.Exec(deleteWFSQuery)
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedWFS: sql.Tx.Exec error: %w. Value of deleteWFSQuery is `%s`", err, deleteWFSQuery)
+			return fmt.Errorf("error in CleanOrphanedPFS: %w", err)
 		}
-		err = tx.Commit()
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedWFS: sql.Tx.Commit error: %w", err)
+		for _, PFS := range PFSCheck {
+			if !slices.Contains(pairings, pairingForSchedule{PairedVolunteerForSchedule: PFS.PairedVolunteerForSchedule, Pairing: PFS.Pairing}) {
+				PFSToDelete = append(PFSToDelete, PFS.PFSID)
+			}
 		}
 	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedPFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	deletePFSQuery := newSQLQuery(`delete from PairingsForSchedule where User = ?`, currentUser)
+	deletePFSQuery.addIn(" and ", "PFSID", PFSToDelete)
+	_, err = tx.Exec(deletePFSQuery.text, deletePFSQuery.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedPFS: sql.Tx.Exec error: %w. Value of deletePFSQuery is `%s`", err, deletePFSQuery)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedPFS: sql.Tx.Commit error: %w", err)
+	}
 	return nil
 }
 
-func (vsam VSAModel) CreateVFS(currentUser string, toCreate []volunteerForSchedule) error {
-	check, err := vsam.RequestVFS(currentUser, toCreate)
+func (vsam VSAModel) CreateURFS(currentUser string, toCreate []unavailabilityRuleForSchedule) error {
+	check, err := vsam.RequestURFS(currentUser, toCreate)
 	if err != nil {
-		return fmt.Errorf("error in CreateVFS: %w", err)
+		return fmt.Errorf("error in CreateURFS: %w", err)
 	}
 	if len(check) > 0 {
-		return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule entries to be created already exists in the database. Existing volunteerForSchedule entry(s): %+v", check)
+		return fmt.Errorf("error in CreateURFS: method failed because at least one of the unavailabilityRuleForSchedule entries to be created already exists in the database. Existing unavailabilityRuleForSchedule(s): %+v", check)
 	}
-	checkDuplicates := []volunteerForSchedule{}
-	for _, val := range toCreate { // User and VFSID do not need to be provided in the volunteerForSchedule structs
-		if val.Schedule == (volunteerForSchedule{}.Schedule) {
-			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate did not have a value for Schedule: %+v", val)
+	checkDuplicates := []unavailabilityRuleForSchedule{}
+	for _, val := range toCreate { // User and URFSID do not need to be provided in the unavailabilityRuleForSchedule structs
+		if val.VolunteerForSchedule == (unavailabilityRuleForSchedule{}.VolunteerForSchedule) {
+			return fmt.Errorf("error in CreateURFS: method failed because at least one of the unavailabilityRuleForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
 		}
-		if val.Volunteer == (volunteerForSchedule{}.Volunteer) {
-			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate did not have a value for Volunteer: %+v", val)
+		rule, err := UnavailabilityRule{}.FromString(val.Rule)
+		if err != nil {
+			return fmt.Errorf("error in CreateURFS: %w", err)
 		}
-		if !slices.Contains(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer}) {
-			checkDuplicates = append(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
+		if rule.ToString() != val.Rule {
+			return fmt.Errorf("error in CreateURFS: method failed because at least one of the unavailabilityRuleForSchedule structs in toCreate did not have its Rule in the form made by UnavailabilityRule.ToString (\"%s\"): %+v", rule.ToString(), val)
+		}
+		if !slices.Contains(checkDuplicates, unavailabilityRuleForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Rule: val.Rule}) {
+			checkDuplicates = append(checkDuplicates, unavailabilityRuleForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Rule: val.Rule})
 		} else {
-			return fmt.Errorf("error in CreateVFS: method failed because at least one of the volunteerForSchedule structs in toCreate was a duplicate of another volunteerForSchedule struct in toCreate: %+v", val)
+			return fmt.Errorf("error in CreateURFS: method failed because at least one of the unavailabilityRuleForSchedule structs in toCreate was a duplicate of another unavailabilityRuleForSchedule struct in toCreate: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CreateVFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in CreateURFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	fillVFSTableString := `insert into VolunteersForSchedule (User, Schedule, Volunteer) values (?, ?, ?)`
-	fillVFSTableStmt, err := tx.Prepare(fillVFSTableString)
+	fillURFSTableString := `insert into UnavailabilityRulesForSchedule (User, VolunteerForSchedule, Rule) values (?, ?, ?)`
+	fillURFSTableStmt, err := tx.Prepare(fillURFSTableString)
 	if err != nil {
-		return fmt.Errorf("error in CreateVFS: sql.Tx.Prepare error: %w. Value of fillVFSTableString is `%s`", err, fillVFSTableString)
+		return fmt.Errorf("error in CreateURFS: sql.Tx.Prepare error: %w. Value of fillURFSTableString is `%s`", err, fillURFSTableString)
 	}
-	defer fillVFSTableStmt.Close()
+	defer fillURFSTableStmt.Close()
 	for i := 0; i < len(toCreate); i++ {
-		_, err = fillVFSTableStmt.Exec(currentUser, toCreate[i].Schedule, toCreate[i].Volunteer)
+		_, err = fillURFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Rule)
 		if err != nil {
-			return fmt.Errorf("error in CreateVFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+			return fmt.Errorf("error in CreateURFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CreateVFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in CreateURFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) RequestVFSSingle(currentUser string, volunteerForScheduleStruct volunteerForSchedule) (volunteerForSchedule, error) {
-	volunteersForSchedule, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{volunteerForScheduleStruct})
+func (vsam VSAModel) RequestURFSSingle(currentUser string, unavailabilityRuleForScheduleStruct unavailabilityRuleForSchedule) (unavailabilityRuleForSchedule, error) {
+	unavailabilityRulesForSchedule, err := vsam.RequestURFS(currentUser, []unavailabilityRuleForSchedule{unavailabilityRuleForScheduleStruct})
 	if err != nil {
-		return volunteerForSchedule{}, fmt.Errorf("error in RequestVFSSingle: %w", err)
+		return unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFSSingle: %w", err)
 	}
-	if len(volunteersForSchedule) != 1 {
-		return volunteerForSchedule{}, fmt.Errorf("error in RequestVFSSingle: method failed to locate exactly one VFS matching %+v. Found %d matches", volunteerForScheduleStruct, len(volunteersForSchedule))
+	if len(unavailabilityRulesForSchedule) != 1 {
+		return unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFSSingle: method failed to locate exactly one URFS matching %+v. Found %d matches", unavailabilityRuleForScheduleStruct, len(unavailabilityRulesForSchedule))
 	}
-	return volunteersForSchedule[0], nil
+	return unavailabilityRulesForSchedule[0], nil
 }
 
-func (vsam VSAModel) RequestVFS(currentUser string, volunteersForSchedule []volunteerForSchedule) ([]volunteerForSchedule, error) {
-	VFSQuery := fmt.Sprintf(`select * from VolunteersForSchedule where User = "%s"`, currentUser)
-	if len(volunteersForSchedule) > 0 {
-		if check, failed := testEmpty(volunteersForSchedule, volunteerForSchedule{}); check {
-			return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: method failed because one of the values in volunteersForSchedule had an empty/default values volunteerForSchedule struct: %+v", failed)
+func (vsam VSAModel) RequestURFS(currentUser string, unavailabilityRulesForSchedule []unavailabilityRuleForSchedule) ([]unavailabilityRuleForSchedule, error) {
+	URFSQuery := newSQLQuery(`select * from UnavailabilityRulesForSchedule where User = ?`, currentUser)
+	if len(unavailabilityRulesForSchedule) > 0 {
+		if check, failed := testEmpty(unavailabilityRulesForSchedule, unavailabilityRuleForSchedule{}); check {
+			return []unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFS: method failed because one of the values in unavailabilityRulesForSchedule had an empty/default values unavailabilityRuleForSchedule struct: %+v", failed)
 		}
-		VFSQuery = fmt.Sprintf(`%s and (`, VFSQuery)
 	}
-	for i := 0; i < len(volunteersForSchedule); i++ {
-		count := countGTZero([]int{volunteersForSchedule[i].VFSID, len(volunteersForSchedule[i].User), volunteersForSchedule[i].Schedule, volunteersForSchedule[i].Volunteer})
-		VFSQuery = fmt.Sprintf(`%s(`, VFSQuery)
-		if volunteersForSchedule[i].VFSID > 0 {
-			VFSQuery = fmt.Sprintf(`%sVFSID = %d`, VFSQuery, volunteersForSchedule[i].VFSID)
-			count--
-			if count > 0 {
-				VFSQuery = fmt.Sprintf(`%s and `, VFSQuery)
-			}
-		}
-		if len(volunteersForSchedule[i].User) > 0 {
-			VFSQuery = fmt.Sprintf(`%sUser = "%s"`, VFSQuery, volunteersForSchedule[i].User)
-			count--
-			if count > 0 {
-				VFSQuery = fmt.Sprintf(`%s and `, VFSQuery)
-			}
+	matches := make([]sqlMatch, 0, len(unavailabilityRulesForSchedule))
+	for i := 0; i < len(unavailabilityRulesForSchedule); i++ {
+		var match sqlMatch
+		if unavailabilityRulesForSchedule[i].URFSID > 0 {
+			match.equals("URFSID", unavailabilityRulesForSchedule[i].URFSID)
 		}
-		if volunteersForSchedule[i].Schedule > 0 {
-			VFSQuery = fmt.Sprintf(`%sSchedule = %d`, VFSQuery, volunteersForSchedule[i].Schedule)
-			count--
-			if count > 0 {
-				VFSQuery = fmt.Sprintf(`%s and `, VFSQuery)
-			}
+		if len(unavailabilityRulesForSchedule[i].User) > 0 {
+			match.equals("User", unavailabilityRulesForSchedule[i].User)
 		}
-		if volunteersForSchedule[i].Volunteer > 0 {
-			VFSQuery = fmt.Sprintf(`%sVolunteer = %d`, VFSQuery, volunteersForSchedule[i].Volunteer)
+		if unavailabilityRulesForSchedule[i].VolunteerForSchedule > 0 {
+			match.equals("VolunteerForSchedule", unavailabilityRulesForSchedule[i].VolunteerForSchedule)
 		}
-		VFSQuery = fmt.Sprintf(`%s)`, VFSQuery)
-		if i+1 < len(volunteersForSchedule) {
-			VFSQuery = fmt.Sprintf(`%s or `, VFSQuery)
+		if len(unavailabilityRulesForSchedule[i].Rule) > 0 {
+			match.equals("Rule", unavailabilityRulesForSchedule[i].Rule)
 		}
-		//fmt.Println(count)
-		//fmt.Println(VFSQuery)
+		matches = append(matches, match)
 	}
-	if len(volunteersForSchedule) > 0 {
-		VFSQuery = fmt.Sprintf(`%s)`, VFSQuery)
-	}
-	//fmt.Println(VFSQuery)
-	var result []volunteerForSchedule
-	rows, err := vsam.DB.Query(VFSQuery)
+	URFSQuery.addMatchAny(" and ", matches)
+	URFSQuery.add(` order by URFSID`)
+	var result []unavailabilityRuleForSchedule
+	rows, err := vsam.querier().Query(URFSQuery.text, URFSQuery.args...)
 	if err != nil {
-		return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.DB.Query error: %w. Value of VFSQuery is `%s`", err, VFSQuery)
+		return []unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFS: sql.DB.Query error: %w. Value of URFSQuery is `%s`", err, URFSQuery)
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var VFSStruct volunteerForSchedule
-		err = rows.Scan(&VFSStruct.VFSID, &VFSStruct.User, &VFSStruct.Schedule, &VFSStruct.Volunteer)
+		var URFSStruct unavailabilityRuleForSchedule
+		err = rows.Scan(&URFSStruct.URFSID, &URFSStruct.User, &URFSStruct.VolunteerForSchedule, &URFSStruct.Rule)
 		if err != nil {
-			return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.Rows.Scan error: %w. Value of VFSStruct is `%+v`", err, VFSStruct)
+			return []unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFS: sql.Rows.Scan error: %w. Value of URFSStruct is `%+v`", err, URFSStruct)
 		}
-		result = append(result, VFSStruct)
+		result = append(result, URFSStruct)
 	}
 	err = rows.Err()
 	if err != nil {
-		return []volunteerForSchedule{}, fmt.Errorf("error in RequestVFS: sql.Rows.Err error: %w", err)
+		return []unavailabilityRuleForSchedule{}, fmt.Errorf("error in RequestURFS: sql.Rows.Err error: %w", err)
 	}
 	return result, nil
 }
 
-func (vsam VSAModel) UpdateVFS(currentUser string, toUpdate []volunteerForSchedule) error {
-	if check, failed := testEmpty(toUpdate, volunteerForSchedule{}); check {
-		return fmt.Errorf("error in UpdateVFS: method failed because one of the values in toUpdate had an empty/default values volunteerForSchedule struct: %+v", failed)
+// Will delete URFS database entries that match the URFSID or that match the VolunteerForSchedule and Rule provided in each URFS struct. If a URFSID > 0 is provided, the other values are ignored for that URFS struct.
+func (vsam VSAModel) DeleteURFS(currentUser string, toDelete []unavailabilityRuleForSchedule) error {
+	for _, val := range toDelete {
+		if val.URFSID < 1 && (val.VolunteerForSchedule < 1 || val.Rule == "") {
+			return fmt.Errorf("error in DeleteURFS: method failed because one of the unavailabilityRuleForSchedule structs did not have a value for URFSID or VolunteerForSchedule and Rule: %+v", val)
+		}
 	}
-	head := `update VolunteersForSchedule set`
-	tail := fmt.Sprintf(`where User="%s" and VFSID=?`, currentUser)
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in UpdateVFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeleteURFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	checkDuplicates := []volunteerForSchedule{}
-	for _, val := range toUpdate {
-		if val.VFSID == 0 {
-			return fmt.Errorf("error in UpdateVFS: method failed because one of the values in toUpdate had an empty/default value for VFSID: %+v", val)
+	for _, val := range toDelete {
+		var deleteURFSString sqlQuery
+		if val.URFSID > 0 {
+			deleteURFSString = newSQLQuery(`delete from UnavailabilityRulesForSchedule where User=? and URFSID=?`, currentUser, val.URFSID)
+		} else {
+			deleteURFSString = newSQLQuery(`delete from UnavailabilityRulesForSchedule where User=? and VolunteerForSchedule=? and Rule=?`, currentUser, val.VolunteerForSchedule, val.Rule)
 		}
-		currentVFS, err := vsam.RequestVFSSingle(currentUser, volunteerForSchedule{VFSID: val.VFSID})
+		_, err := tx.Exec(deleteURFSString.text, deleteURFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in UpdateWFS: %w", err)
+			return fmt.Errorf("error in DeleteURFS: sql.Tx.Exec error: %w. Value of deleteURFSString is `%s`", err, deleteURFSString)
 		}
-		if !slices.Contains(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer}) {
-			checkDuplicates = append(checkDuplicates, volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
-		} else {
-			return fmt.Errorf("error in UpdateVFS: method failed because at least two of the volunteerForSchedule structs in toUpdate would create duplicate volunteerForSchedule structs in the database: %+v", volunteerForSchedule{Schedule: val.Schedule, Volunteer: val.Volunteer})
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in DeleteURFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+// correctURFS is a map with VFS structs as keys and slices of the rules that belong to that VFS as values. Only Rule needs to be set in the rules.
+// If a URFS row belongs to one of the VFS, but doesn't match one of its rules, delete that URFS row.
+func (vsam VSAModel) CleanOrphanedURFS(currentUser string, correctURFS map[volunteerForSchedule][]unavailabilityRuleForSchedule) error {
+	var URFSToDelete []int
+	for key, value := range correctURFS {
+		if key.VFSID == 0 {
+			return fmt.Errorf("error in CleanOrphanedURFS: method failed because one of the provided VFS structs did not have a VFSID: %+v", key)
 		}
-		currentVFS.VFSID = 0
-		updateVFSString := head
-		count := countGTZero([]int{val.VFSID, len(val.User), val.Schedule, val.Volunteer})
-		count-- // This is needed because a VFSID has been provided (verified at the start of this loop).
-		if count == 0 {
-			return fmt.Errorf("error in UpdateVFS: method failed because only one value was provided in a volunteerForSchedule struct. At least two values (a VFSID and a value to update) must be provided: %+v", val)
+		var rules []string
+		for _, urfsStruct := range value {
+			if urfsStruct.Rule == "" {
+				return fmt.Errorf("error in CleanOrphanedURFS: method failed because one of the provided URFS structs did not have a Rule: %+v", value)
+			}
+			rules = append(rules, urfsStruct.Rule)
 		}
-		// count is at least 1
-		//fmt.Println(count)
-		//fmt.Println(updateVFSString)
-		if val.Schedule > 0 {
-			updateVFSString = fmt.Sprintf(`%s Schedule=%d`, updateVFSString, val.Schedule)
-			count--
-			currentVFS.Schedule = val.Schedule
-			if count > 0 {
-				updateVFSString = fmt.Sprintf(`%s,`, updateVFSString)
+		URFSCheck, err := vsam.RequestURFS(currentUser, []unavailabilityRuleForSchedule{{VolunteerForSchedule: key.VFSID}})
+		if err != nil {
+			return fmt.Errorf("error in CleanOrphanedURFS: %w", err)
+		}
+		for _, URFS := range URFSCheck {
+			if !slices.Contains(rules, URFS.Rule) {
+				URFSToDelete = append(URFSToDelete, URFS.URFSID)
 			}
-			//fmt.Println(count)
-			//fmt.Println(updateVFSString)
 		}
-		if val.Volunteer > 0 {
-			updateVFSString = fmt.Sprintf(`%s Volunteer=%d`, updateVFSString, val.Volunteer)
-			//fmt.Println(count)
-			//fmt.Println(updateVFSString)
-			currentVFS.Volunteer = val.Volunteer
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedURFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	deleteURFSQuery := newSQLQuery(`delete from UnavailabilityRulesForSchedule where User = ?`, currentUser)
+	deleteURFSQuery.addIn(" and ", "URFSID", URFSToDelete)
+	_, err = tx.Exec(deleteURFSQuery.text, deleteURFSQuery.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedURFS: sql.Tx.Exec error: %w. Value of deleteURFSQuery is `%s`", err, deleteURFSQuery)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedURFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) CreatePrFS(currentUser string, toCreate []preferenceForSchedule) error {
+	check, err := vsam.RequestPrFS(currentUser, toCreate)
+	if err != nil {
+		return fmt.Errorf("error in CreatePrFS: %w", err)
+	}
+	if len(check) > 0 {
+		return fmt.Errorf("error in CreatePrFS: method failed because at least one of the preferenceForSchedule entries to be created already exists in the database. Existing preferenceForSchedule(s): %+v", check)
+	}
+	checkDuplicates := []preferenceForSchedule{}
+	for _, val := range toCreate { // User and PrFSID do not need to be provided in the preferenceForSchedule structs
+		if val.VolunteerForSchedule == (preferenceForSchedule{}.VolunteerForSchedule) {
+			return fmt.Errorf("error in CreatePrFS: method failed because at least one of the preferenceForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
 		}
-		updateVFSString = fmt.Sprintf(`%s %s`, updateVFSString, tail)
-		//fmt.Println(count)
-		//fmt.Println(updateVFSString)
-		if check, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{currentVFS}); err != nil {
-			return fmt.Errorf("error in UpdateVFS: %w", err)
-		} else if len(check) > 0 {
-			return fmt.Errorf("error in UpdateVFS: method failed because it would create a duplicate VFS: %+v", val)
+		preference, err := VolunteerPreference{}.FromString(val.Preference)
+		if err != nil {
+			return fmt.Errorf("error in CreatePrFS: %w", err)
+		}
+		if preference.ToString() != val.Preference {
+			return fmt.Errorf("error in CreatePrFS: method failed because at least one of the preferenceForSchedule structs in toCreate did not have its Preference in the form made by VolunteerPreference.ToString (\"%s\"): %+v", preference.ToString(), val)
+		}
+		if !slices.Contains(checkDuplicates, preferenceForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Preference: val.Preference}) {
+			checkDuplicates = append(checkDuplicates, preferenceForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Preference: val.Preference})
+		} else {
+			return fmt.Errorf("error in CreatePrFS: method failed because at least one of the preferenceForSchedule structs in toCreate was a duplicate of another preferenceForSchedule struct in toCreate: %+v", val)
 		}
-		updateSchedulesStmt, err := tx.Prepare(updateVFSString)
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CreatePrFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	fillPrFSTableString := `insert into PreferencesForSchedule (User, VolunteerForSchedule, Preference) values (?, ?, ?)`
+	fillPrFSTableStmt, err := tx.Prepare(fillPrFSTableString)
+	if err != nil {
+		return fmt.Errorf("error in CreatePrFS: sql.Tx.Prepare error: %w. Value of fillPrFSTableString is `%s`", err, fillPrFSTableString)
+	}
+	defer fillPrFSTableStmt.Close()
+	for i := 0; i < len(toCreate); i++ {
+		_, err = fillPrFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Preference)
 		if err != nil {
-			return fmt.Errorf("error in UpdateVFS: sql.Tx.Prepare error: %w. Value of updateVFSString is `%s`", err, updateVFSString)
+			return fmt.Errorf("error in CreatePrFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
-		defer updateSchedulesStmt.Close()
-		_, err = updateSchedulesStmt.Exec(val.VFSID)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CreatePrFS: sql.Tx.Commit error: %w", err)
+	}
+	return nil
+}
+
+func (vsam VSAModel) RequestPrFSSingle(currentUser string, preferenceForScheduleStruct preferenceForSchedule) (preferenceForSchedule, error) {
+	preferencesForSchedule, err := vsam.RequestPrFS(currentUser, []preferenceForSchedule{preferenceForScheduleStruct})
+	if err != nil {
+		return preferenceForSchedule{}, fmt.Errorf("error in RequestPrFSSingle: %w", err)
+	}
+	if len(preferencesForSchedule) != 1 {
+		return preferenceForSchedule{}, fmt.Errorf("error in RequestPrFSSingle: method failed to locate exactly one PrFS matching %+v. Found %d matches", preferenceForScheduleStruct, len(preferencesForSchedule))
+	}
+	return preferencesForSchedule[0], nil
+}
+
+func (vsam VSAModel) RequestPrFS(currentUser string, preferencesForSchedule []preferenceForSchedule) ([]preferenceForSchedule, error) {
+	PrFSQuery := newSQLQuery(`select * from PreferencesForSchedule where User = ?`, currentUser)
+	if len(preferencesForSchedule) > 0 {
+		if check, failed := testEmpty(preferencesForSchedule, preferenceForSchedule{}); check {
+			return []preferenceForSchedule{}, fmt.Errorf("error in RequestPrFS: method failed because one of the values in preferencesForSchedule had an empty/default values preferenceForSchedule struct: %+v", failed)
+		}
+	}
+	matches := make([]sqlMatch, 0, len(preferencesForSchedule))
+	for i := 0; i < len(preferencesForSchedule); i++ {
+		var match sqlMatch
+		if preferencesForSchedule[i].PrFSID > 0 {
+			match.equals("PrFSID", preferencesForSchedule[i].PrFSID)
+		}
+		if len(preferencesForSchedule[i].User) > 0 {
+			match.equals("User", preferencesForSchedule[i].User)
+		}
+		if preferencesForSchedule[i].VolunteerForSchedule > 0 {
+			match.equals("VolunteerForSchedule", preferencesForSchedule[i].VolunteerForSchedule)
+		}
+		if len(preferencesForSchedule[i].Preference) > 0 {
+			match.equals("Preference", preferencesForSchedule[i].Preference)
+		}
+		matches = append(matches, match)
+	}
+	PrFSQuery.addMatchAny(" and ", matches)
+	PrFSQuery.add(` order by PrFSID`)
+	var result []preferenceForSchedule
+	rows, err := vsam.querier().Query(PrFSQuery.text, PrFSQuery.args...)
+	if err != nil {
+		return []preferenceForSchedule{}, fmt.Errorf("error in RequestPrFS: sql.DB.Query error: %w. Value of PrFSQuery is `%s`", err, PrFSQuery)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var PrFSStruct preferenceForSchedule
+		err = rows.Scan(&PrFSStruct.PrFSID, &PrFSStruct.User, &PrFSStruct.VolunteerForSchedule, &PrFSStruct.Preference)
 		if err != nil {
-			return fmt.Errorf("error in UpdateVFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
+			return []preferenceForSchedule{}, fmt.Errorf("error in RequestPrFS: sql.Rows.Scan error: %w. Value of PrFSStruct is `%+v`", err, PrFSStruct)
 		}
+		result = append(result, PrFSStruct)
 	}
-	err = tx.Commit()
+	err = rows.Err()
 	if err != nil {
-		return fmt.Errorf("error in UpdateVFS: sql.Tx.Commit error: %w", err)
+		return []preferenceForSchedule{}, fmt.Errorf("error in RequestPrFS: sql.Rows.Err error: %w", err)
 	}
-	return nil
+	return result, nil
 }
 
-// Will delete VFS database entries that match the VFSID or that match the Schedule and Volunteer provided in each VFS struct. If a VFSID > 0 is provided, the values for Schedule and Volunteer are ignored for that VFS struct.
-func (vsam VSAModel) DeleteVFS(currentUser string, toDelete []volunteerForSchedule) error {
+// Will delete PrFS database entries that match the PrFSID or that match the VolunteerForSchedule and Preference provided in each PrFS struct. If a PrFSID > 0 is provided, the other values are ignored for that PrFS struct.
+func (vsam VSAModel) DeletePrFS(currentUser string, toDelete []preferenceForSchedule) error {
 	for _, val := range toDelete {
-		if val.VFSID < 1 && (val.Schedule < 1 || val.Volunteer < 1) {
-			return fmt.Errorf("error in DeleteVFS: method failed because one of the volunteerForSchedule structs did not have a value for VFSID or Schedule and Volunteer: %+v", val)
+		if val.PrFSID < 1 && (val.VolunteerForSchedule < 1 || val.Preference == "") {
+			return fmt.Errorf("error in DeletePrFS: method failed because one of the preferenceForSchedule structs did not have a value for PrFSID or VolunteerForSchedule and Preference: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in DeleteVFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeletePrFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
 	for _, val := range toDelete {
-		var deleteVFSString string
-		if val.VFSID > 0 {
-			deleteVFSString = fmt.Sprintf(`delete from VolunteersForSchedule where User="%s" and VFSID=%d`, currentUser, val.VFSID)
+		var deletePrFSString sqlQuery
+		if val.PrFSID > 0 {
+			deletePrFSString = newSQLQuery(`delete from PreferencesForSchedule where User=? and PrFSID=?`, currentUser, val.PrFSID)
 		} else {
-			deleteVFSString = fmt.Sprintf(`delete from VolunteersForSchedule where User="%s" and Schedule=%d and Volunteer=%d`, currentUser, val.Schedule, val.Volunteer)
+			deletePrFSString = newSQLQuery(`delete from PreferencesForSchedule where User=? and VolunteerForSchedule=? and Preference=?`, currentUser, val.VolunteerForSchedule, val.Preference)
 		}
-		_, err := tx.Exec(deleteVFSString)
+		_, err := tx.Exec(deletePrFSString.text, deletePrFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in DeleteVFS: sql.Tx.Exec error: %w. Value of deleteVFSString is `%s`", err, deleteVFSString)
+			return fmt.Errorf("error in DeletePrFS: sql.Tx.Exec error: %w. Value of deletePrFSString is `%s`", err, deletePrFSString)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in DeleteVFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in DeletePrFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// correctVFS is a slices of maps with schedule structs as keys and slices of volunteers containing VolunteerNames as values. If a VFS row is linked to a schedule, but doesn't have a matching volunteer, delete that VFS row.
-func (vsam VSAModel) CleanOrphanedVFS(currentUser string, correctVFS map[schedule][]volunteer, deleteChildUFS bool, deleteChildSVOD bool) error {
-	var VFSToDelete []string
-	for key, value := range correctVFS {
-		if key.ScheduleID == 0 {
-			return fmt.Errorf("error in CleanOrphanedVFS: method failed because one of the provided schedule structs did not have a ScheduleID: %+v", value)
+// correctPrFS is a map with VFS structs as keys and slices of the preferences that belong to that VFS as values. Only Preference needs to be set in the preferences.
+// If a PrFS row belongs to one of the VFS, but doesn't match one of its preferences, delete that PrFS row.
+func (vsam VSAModel) CleanOrphanedPrFS(currentUser string, correctPrFS map[volunteerForSchedule][]preferenceForSchedule) error {
+	var PrFSToDelete []int
+	for key, value := range correctPrFS {
+		if key.VFSID == 0 {
+			return fmt.Errorf("error in CleanOrphanedPrFS: method failed because one of the provided VFS structs did not have a VFSID: %+v", key)
 		}
-		var volunteers []string
-		for _, volunteerStruct := range value {
-			if len(volunteerStruct.VolunteerName) < 1 {
-				return fmt.Errorf("error in CleanOrphanedVFS: method failed because one of the provided volunteer structs did not have a VolunteerName: %+v", value)
+		var preferences []string
+		for _, prfsStruct := range value {
+			if prfsStruct.Preference == "" {
+				return fmt.Errorf("error in CleanOrphanedPrFS: method failed because one of the provided PrFS structs did not have a Preference: %+v", value)
 			}
-			volunteers = append(volunteers, volunteerStruct.VolunteerName)
+			preferences = append(preferences, prfsStruct.Preference)
 		}
-		vfsCheck, err := vsam.RequestVFS(currentUser, []volunteerForSchedule{{Schedule: key.ScheduleID}})
+		PrFSCheck, err := vsam.RequestPrFS(currentUser, []preferenceForSchedule{{VolunteerForSchedule: key.VFSID}})
 		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
+			return fmt.Errorf("error in CleanOrphanedPrFS: %w", err)
 		}
-		for _, vfs := range vfsCheck {
-			nameCheck, err := vsam.RequestVolunteer(currentUser, volunteer{VolunteerID: vfs.Volunteer})
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
-			}
-			if !slices.Contains(volunteers, nameCheck.VolunteerName) {
-				VFSToDelete = append(VFSToDelete, strconv.Itoa(vfs.VFSID))
-				//log.Printf("VFSToDelete=`%v`; nameCheck.VolunteerName=`%s`", VFSToDelete, nameCheck.VolunteerName)
+		for _, PrFS := range PrFSCheck {
+			if !slices.Contains(preferences, PrFS.Preference) {
+				PrFSToDelete = append(PrFSToDelete, PrFS.PrFSID)
 			}
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVFS: sql.DB.begin error: %w", err)
+		return fmt.Errorf("error in CleanOrphanedPrFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	if deleteChildUFS {
-		UFSToDelete := []unavailabilityForSchedule{}
-		for _, vfsidString := range VFSToDelete {
-			vfsidInt, err := strconv.Atoi(vfsidString)
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
-			}
-			ufsSlice, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: vfsidInt}})
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
-			}
-			UFSToDelete = append(UFSToDelete, ufsSlice...)
-		}
-		vsam.DeleteUFS(currentUser, UFSToDelete)
-	}
-	if deleteChildSVOD {
-		SVODToDelete := []scheduledVolunteerOnDate{}
-		for _, vfsidString := range VFSToDelete {
-			vfsidInt, err := strconv.Atoi(vfsidString)
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
-			}
-			svodSlice, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: vfsidInt}})
-			if err != nil {
-				return fmt.Errorf("error in CleanOrphanedVFS: %w", err)
-			}
-			SVODToDelete = append(SVODToDelete, svodSlice...)
-		}
-		vsam.DeleteSVOD(currentUser, SVODToDelete)
-	}
-	deleteVFSQuery := fmt.Sprintf(`delete from VolunteersForSchedule where User = "%s" and VFSID in (%s)`, currentUser, CsvSlice(VFSToDelete, true))
-	//fmt.Println(deleteVFSQuery)
-	_, err = tx.Exec(deleteVFSQuery)
+	deletePrFSQuery := newSQLQuery(`delete from PreferencesForSchedule where User = ?`, currentUser)
+	deletePrFSQuery.addIn(" and ", "PrFSID", PrFSToDelete)
+	_, err = tx.Exec(deletePrFSQuery.text, deletePrFSQuery.args...)
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVFS: sql.Tx.Exec error: %w. Value of deleteVFSQuery is `%s`", err, deleteVFSQuery)
+		return fmt.Errorf("error in CleanOrphanedPrFS: sql.Tx.Exec error: %w. Value of deletePrFSQuery is `%s`", err, deletePrFSQuery)
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CleanOrphanedVFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in CleanOrphanedPrFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) CreateUFS(currentUser string, toCreate []unavailabilityForSchedule) error {
-	check, err := vsam.RequestUFS(currentUser, toCreate)
+func (vsam VSAModel) CreateDRFS(currentUser string, toCreate []dateRangeForSchedule) error {
+	check, err := vsam.RequestDRFS(currentUser, toCreate)
 	if err != nil {
-		return fmt.Errorf("error in CreateUFS: %w", err)
+		return fmt.Errorf("error in CreateDRFS: %w", err)
 	}
 	if len(check) > 0 {
-		return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule entries to be created already exists in the database. Existing unavailabilityForSchedule entry(s): %+v", check)
+		return fmt.Errorf("error in CreateDRFS: method failed because at least one of the dateRangeForSchedule entries to be created already exists in the database. Existing dateRangeForSchedule(s): %+v", check)
 	}
-	checkDuplicates := []unavailabilityForSchedule{}
-	for _, val := range toCreate { // User and UFSID do not need to be provided in the unavailabilityForSchedule structs
-		if val.VolunteerForSchedule == (unavailabilityForSchedule{}.VolunteerForSchedule) {
-			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
+	checkDuplicates := []dateRangeForSchedule{}
+	for _, val := range toCreate { // User and DRFSID do not need to be provided in the dateRangeForSchedule structs
+		if val.VolunteerForSchedule == (dateRangeForSchedule{}.VolunteerForSchedule) {
+			return fmt.Errorf("error in CreateDRFS: method failed because at least one of the dateRangeForSchedule structs in toCreate did not have a value for VolunteerForSchedule: %+v", val)
 		}
-		if val.Date == (unavailabilityForSchedule{}.Date) {
-			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate did not have a value for Date: %+v", val)
+		if val.StartDate == (dateRangeForSchedule{}.StartDate) || val.EndDate == (dateRangeForSchedule{}.EndDate) {
+			return fmt.Errorf("error in CreateDRFS: method failed because at least one of the dateRangeForSchedule structs in toCreate did not have a value for StartDate or EndDate: %+v", val)
 		}
-		if !slices.Contains(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
-			checkDuplicates = append(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
+		if err := checkDates(val.StartDate, val.EndDate); err != nil {
+			return fmt.Errorf("error in CreateDRFS: %w", err)
+		}
+		if val.EndDate < val.StartDate { // ISO dates compare in date order as text
+			return fmt.Errorf("error in CreateDRFS: method failed because at least one of the dateRangeForSchedule structs in toCreate ends before it starts: %+v", val)
+		}
+		if !slices.Contains(checkDuplicates, dateRangeForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, StartDate: val.StartDate, EndDate: val.EndDate}) {
+			checkDuplicates = append(checkDuplicates, dateRangeForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, StartDate: val.StartDate, EndDate: val.EndDate})
 		} else {
-			return fmt.Errorf("error in CreateUFS: method failed because at least one of the unavailabilityForSchedule structs in toCreate was a duplicate of another unavailabilityForSchedule struct in toCreate: %+v", val)
+			return fmt.Errorf("error in CreateDRFS: method failed because at least one of the dateRangeForSchedule structs in toCreate was a duplicate of another dateRangeForSchedule struct in toCreate: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in CreateUFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in CreateDRFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	fillUFSTableString := `insert into UnavailabilitiesForSchedule (User, VolunteerForSchedule, Date) values (?, ?, ?)`
-	fillUFSTableStmt, err := tx.Prepare(fillUFSTableString)
+	fillDRFSTableString := `insert into DateRangesForSchedule (User, VolunteerForSchedule, StartDate, EndDate) values (?, ?, ?, ?)`
+	fillDRFSTableStmt, err := tx.Prepare(fillDRFSTableString)
 	if err != nil {
-		return fmt.Errorf("error in CreateUFS: sql.Tx.Prepare error: %w. Value of fillUFSTableString is `%s`", err, fillUFSTableString)
+		return fmt.Errorf("error in CreateDRFS: sql.Tx.Prepare error: %w. Value of fillDRFSTableString is `%s`", err, fillDRFSTableString)
 	}
-	defer fillUFSTableStmt.Close()
+	defer fillDRFSTableStmt.Close()
 	for i := 0; i < len(toCreate); i++ {
-		_, err = fillUFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Date)
+		_, err = fillDRFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].StartDate, toCreate[i].EndDate)
 		if err != nil {
-			return fmt.Errorf("error in CreateUFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
+			return fmt.Errorf("error in CreateDRFS: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in CreateUFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in CreateDRFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-func (vsam VSAModel) RequestUFSSingle(currentUser string, unavailabilityForScheduleStruct unavailabilityForSchedule) (unavailabilityForSchedule, error) {
-	unavailabilitiesForSchedule, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{unavailabilityForScheduleStruct})
+func (vsam VSAModel) RequestDRFSSingle(currentUser string, dateRangeForScheduleStruct dateRangeForSchedule) (dateRangeForSchedule, error) {
+	dateRangesForSchedule, err := vsam.RequestDRFS(currentUser, []dateRangeForSchedule{dateRangeForScheduleStruct})
 	if err != nil {
-		return unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFSSingle: %w", err)
+		return dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFSSingle: %w", err)
 	}
-	if len(unavailabilitiesForSchedule) != 1 {
-		return unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFSSingle: method failed to locate exactly one UFS matching %+v. Found %d matches", unavailabilityForScheduleStruct, len(unavailabilitiesForSchedule))
+	if len(dateRangesForSchedule) != 1 {
+		return dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFSSingle: method failed to locate exactly one DRFS matching %+v. Found %d matches", dateRangeForScheduleStruct, len(dateRangesForSchedule))
 	}
-	return unavailabilitiesForSchedule[0], nil
+	return dateRangesForSchedule[0], nil
 }
 
-func (vsam VSAModel) RequestUFS(currentUser string, unavailabilitiesForSchedule []unavailabilityForSchedule) ([]unavailabilityForSchedule, error) {
-	UFSQuery := fmt.Sprintf(`select * from UnavailabilitiesForSchedule where User = "%s"`, currentUser)
-	if len(unavailabilitiesForSchedule) > 0 {
-		if check, failed := testEmpty(unavailabilitiesForSchedule, unavailabilityForSchedule{}); check {
-			return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: method failed because one of the values in unavailabilitiesForSchedule had an empty/default values unavailabilityForSchedule struct: %+v", failed)
+func (vsam VSAModel) RequestDRFS(currentUser string, dateRangesForSchedule []dateRangeForSchedule) ([]dateRangeForSchedule, error) {
+	DRFSQuery := newSQLQuery(`select * from DateRangesForSchedule where User = ?`, currentUser)
+	if len(dateRangesForSchedule) > 0 {
+		if check, failed := testEmpty(dateRangesForSchedule, dateRangeForSchedule{}); check {
+			return []dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFS: method failed because one of the values in dateRangesForSchedule had an empty/default values dateRangeForSchedule struct: %+v", failed)
 		}
-		UFSQuery = fmt.Sprintf(`%s and (`, UFSQuery)
 	}
-	for i := 0; i < len(unavailabilitiesForSchedule); i++ {
-		count := countGTZero([]int{unavailabilitiesForSchedule[i].UFSID, len(unavailabilitiesForSchedule[i].User), unavailabilitiesForSchedule[i].VolunteerForSchedule, unavailabilitiesForSchedule[i].Date})
-		// count must be at least 1 because the testEmpty check passed
-		//fmt.Println(count)
-		UFSQuery = fmt.Sprintf(`%s(`, UFSQuery)
-		if unavailabilitiesForSchedule[i].UFSID > 0 {
-			UFSQuery = fmt.Sprintf(`%sUFSID = %d`, UFSQuery, unavailabilitiesForSchedule[i].UFSID)
-			count--
-			if count > 0 {
-				UFSQuery = fmt.Sprintf(`%s and `, UFSQuery)
-			}
+	matches := make([]sqlMatch, 0, len(dateRangesForSchedule))
+	for i := 0; i < len(dateRangesForSchedule); i++ {
+		var match sqlMatch
+		if dateRangesForSchedule[i].DRFSID > 0 {
+			match.equals("DRFSID", dateRangesForSchedule[i].DRFSID)
 		}
-		if len(unavailabilitiesForSchedule[i].User) > 0 {
-			UFSQuery = fmt.Sprintf(`%sUser = "%s"`, UFSQuery, unavailabilitiesForSchedule[i].User)
-			count--
-			if count > 0 {
-				UFSQuery = fmt.Sprintf(`%s and `, UFSQuery)
-			}
+		if len(dateRangesForSchedule[i].User) > 0 {
+			match.equals("User", dateRangesForSchedule[i].User)
 		}
-		if unavailabilitiesForSchedule[i].VolunteerForSchedule > 0 {
-			UFSQuery = fmt.Sprintf(`%sVolunteerForSchedule = %d`, UFSQuery, unavailabilitiesForSchedule[i].VolunteerForSchedule)
-			count--
-			if count > 0 {
-				UFSQuery = fmt.Sprintf(`%s and `, UFSQuery)
-			}
+		if dateRangesForSchedule[i].VolunteerForSchedule > 0 {
+			match.equals("VolunteerForSchedule", dateRangesForSchedule[i].VolunteerForSchedule)
 		}
-		if unavailabilitiesForSchedule[i].Date > 0 {
-			UFSQuery = fmt.Sprintf(`%sDate = %d`, UFSQuery, unavailabilitiesForSchedule[i].Date)
+		if len(dateRangesForSchedule[i].StartDate) > 0 {
+			match.equals("StartDate", dateRangesForSchedule[i].StartDate)
 		}
-		UFSQuery = fmt.Sprintf(`%s)`, UFSQuery)
-		if i+1 < len(unavailabilitiesForSchedule) {
-			UFSQuery = fmt.Sprintf(`%s or `, UFSQuery)
+		if len(dateRangesForSchedule[i].EndDate) > 0 {
+			match.equals("EndDate", dateRangesForSchedule[i].EndDate)
 		}
-		//fmt.Println(count)
-		//fmt.Println(UFSQuery)
-	}
-	if len(unavailabilitiesForSchedule) > 0 {
-		UFSQuery = fmt.Sprintf(`%s)`, UFSQuery)
+		matches = append(matches, match)
 	}
-	//fmt.Println(UFSQuery)
-	var result []unavailabilityForSchedule
-	rows, err := vsam.DB.Query(UFSQuery)
+	DRFSQuery.addMatchAny(" and ", matches)
+	DRFSQuery.add(` order by DRFSID`)
+	var result []dateRangeForSchedule
+	rows, err := vsam.querier().Query(DRFSQuery.text, DRFSQuery.args...)
 	if err != nil {
-		return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.DB.Query error: %w. Value of UFSQuery is `%s`", err, UFSQuery)
+		return []dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFS: sql.DB.Query error: %w. Value of DRFSQuery is `%s`", err, DRFSQuery)
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var UFSStruct unavailabilityForSchedule
-		err = rows.Scan(&UFSStruct.UFSID, &UFSStruct.User, &UFSStruct.VolunteerForSchedule, &UFSStruct.Date)
+		var DRFSStruct dateRangeForSchedule
+		err = rows.Scan(&DRFSStruct.DRFSID, &DRFSStruct.User, &DRFSStruct.VolunteerForSchedule, &DRFSStruct.StartDate, &DRFSStruct.EndDate)
 		if err != nil {
-			return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.Rows.Scan error: %w. Value of UFSStruct is `%+v`", err, UFSStruct)
+			return []dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFS: sql.Rows.Scan error: %w. Value of DRFSStruct is `%+v`", err, DRFSStruct)
 		}
-		result = append(result, UFSStruct)
+		result = append(result, DRFSStruct)
 	}
 	err = rows.Err()
 	if err != nil {
-		return []unavailabilityForSchedule{}, fmt.Errorf("error in RequestUFS: sql.Rows.Err error: %w", err)
+		return []dateRangeForSchedule{}, fmt.Errorf("error in RequestDRFS: sql.Rows.Err error: %w", err)
 	}
 	return result, nil
 }
 
-func (vsam VSAModel) UpdateUFS(currentUser string, toUpdate []unavailabilityForSchedule) error {
-	if check, failed := testEmpty(toUpdate, unavailabilityForSchedule{}); check {
-		return fmt.Errorf("error in UpdateUFS: method failed because one of the values in toUpdate had an empty/default values unavailabilityForSchedule struct: %+v", failed)
-	}
-	head := `update UnavailabilitiesForSchedule set`
-	tail := fmt.Sprintf(`where User="%s" and UFSID=?`, currentUser)
-	tx, err := vsam.DB.Begin()
-	if err != nil {
-		return fmt.Errorf("error in UpdateUFS: sql.DB.Begin error: %w", err)
-	}
-	defer tx.Rollback()
-	checkDuplicates := []unavailabilityForSchedule{}
-	for _, val := range toUpdate {
-		if val.UFSID == 0 {
-			return fmt.Errorf("error in UpdateUFS: method failed because one of the values in toUpdate had an empty/default value for UFSID: %+v", val)
-		}
-		currentUFS, err := vsam.RequestUFSSingle(currentUser, unavailabilityForSchedule{UFSID: val.UFSID})
-		if err != nil {
-			return fmt.Errorf("error in UpdateUFS: %w", err)
-		}
-		if !slices.Contains(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
-			checkDuplicates = append(checkDuplicates, unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
-		} else {
-			return fmt.Errorf("error in UpdateUFS: method failed because at least two of the unavailabilityForSchedule structs in toUpdate would create duplicate unavailabilityForSchedule structs in the database: %+v", unavailabilityForSchedule{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
-		}
-		currentUFS.UFSID = 0
-		updateUFSString := head
-		count := countGTZero([]int{val.UFSID, len(val.User), val.VolunteerForSchedule, val.Date})
-		count-- // This is needed because a UFSID has been provided (verified at the start of this loop).
-		if count == 0 {
-			return fmt.Errorf("error in UpdateUFS: method failed because only one value was provided in an unavailabilityForSchedule struct. At least two values (a UFSID and a value to update) must be provided: %+v", val)
-		}
-		// count is at least 1
-		//fmt.Println(count)
-		//fmt.Println(updateUFSString)
-		if val.VolunteerForSchedule > 0 {
-			updateUFSString = fmt.Sprintf(`%s VolunteerForSchedule=%d`, updateUFSString, val.VolunteerForSchedule)
-			count--
-			currentUFS.VolunteerForSchedule = val.VolunteerForSchedule
-			if count > 0 {
-				updateUFSString = fmt.Sprintf(`%s,`, updateUFSString)
-			}
-			//fmt.Println(count)
-			//fmt.Println(updateUFSString)
-		}
-		if val.Date > 0 {
-			updateUFSString = fmt.Sprintf(`%s Date=%d`, updateUFSString, val.Date)
-			//fmt.Println(count)
-			//fmt.Println(updateUFSString)
-			currentUFS.Date = val.Date
-		}
-		updateUFSString = fmt.Sprintf(`%s %s`, updateUFSString, tail)
-		//fmt.Println(count)
-		//fmt.Println(updateUFSString)
-		if check, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{currentUFS}); err != nil {
-			return fmt.Errorf("error in UpdateUFS: %w", err)
-		} else if len(check) > 0 {
-			return fmt.Errorf("error in UpdateUFS: method failed because it would create a duplicate UFS: %+v", val)
-		}
-		updateSchedulesStmt, err := tx.Prepare(updateUFSString)
-		if err != nil {
-			return fmt.Errorf("error in UpdateUFS: sql.Stmt.Prepare error: %w. Value of updateUFSString is `%s`", err, updateUFSString)
-		}
-		defer updateSchedulesStmt.Close()
-		_, err = updateSchedulesStmt.Exec(val.UFSID)
-		if err != nil {
-			return fmt.Errorf("error in UpdateUFS: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
-		}
-	}
-	err = tx.Commit()
-	if err != nil {
-		return fmt.Errorf("error in UpdateUFS: sql.Tx.Commit error: %w", err)
-	}
-	return nil
-}
-
-// Will delete UFS database entries that match the UFSID or that match the VFS and Date provided in each UFS struct. If a UFSID > 0 is provided, the values for VFS and Date are ignored for that UFS struct.
-func (vsam VSAModel) DeleteUFS(currentUser string, toDelete []unavailabilityForSchedule) error {
+// Will delete DRFS database entries that match the DRFSID or that match the VolunteerForSchedule, StartDate, and EndDate provided in each DRFS struct. If a DRFSID > 0 is provided, the other values are ignored for that DRFS struct.
+func (vsam VSAModel) DeleteDRFS(currentUser string, toDelete []dateRangeForSchedule) error {
 	for _, val := range toDelete {
-		if val.UFSID < 1 && (val.VolunteerForSchedule < 1 || val.Date < 1) {
-			return fmt.Errorf("error in DeleteUFS: method failed because one of the unavailabilityForSchedule structs did not have a value for UFSID or VolunteerForSchedule and Date: %+v", val)
+		if val.DRFSID < 1 && (val.VolunteerForSchedule < 1 || len(val.StartDate) < 1 || len(val.EndDate) < 1) {
+			return fmt.Errorf("error in DeleteDRFS: method failed because one of the dateRangeForSchedule structs did not have a value for DRFSID or VolunteerForSchedule, StartDate, and EndDate: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
-		return fmt.Errorf("error in DeleteUFS: sql.DB.Begin error: %w", err)
+		return fmt.Errorf("error in DeleteDRFS: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
 	for _, val := range toDelete {
-		var deleteUFSString string
-		if val.UFSID > 0 {
-			deleteUFSString = fmt.Sprintf(`delete from UnavailabilitiesForSchedule where User="%s" and UFSID=%d`, currentUser, val.UFSID)
+		var deleteDRFSString sqlQuery
+		if val.DRFSID > 0 {
+			deleteDRFSString = newSQLQuery(`delete from DateRangesForSchedule where User=? and DRFSID=?`, currentUser, val.DRFSID)
 		} else {
-			deleteUFSString = fmt.Sprintf(`delete from UnavailabilitiesForSchedule where User="%s" and VolunteerForSchedule=%d and Date=%d`, currentUser, val.VolunteerForSchedule, val.Date)
+			deleteDRFSString = newSQLQuery(`delete from DateRangesForSchedule where User=? and VolunteerForSchedule=? and StartDate=? and EndDate=?`, currentUser, val.VolunteerForSchedule, val.StartDate, val.EndDate)
 		}
-		_, err := tx.Exec(deleteUFSString)
+		_, err := tx.Exec(deleteDRFSString.text, deleteDRFSString.args...)
 		if err != nil {
-			return fmt.Errorf("error in DeleteUFS: sql.Tx.Exec error: %w. Value of deleteUFSString is `%s`", err, deleteUFSString)
+			return fmt.Errorf("error in DeleteDRFS: sql.Tx.Exec error: %w. Value of deleteDRFSString is `%s`", err, deleteDRFSString)
 		}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("error in DeleteUFS: sql.Tx.Commit error: %w", err)
+		return fmt.Errorf("error in DeleteDRFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
 
-// correctUFS is a slices of maps with VFS structs as keys and slices of dates containing DateIDs as values. If a UFS row is linked to a VFS, but doesn't have a matching date, delete that VFS row.
-func (vsam VSAModel) CleanOrphanedUFS(currentUser string, correctUFS map[volunteerForSchedule][]date) error {
-	var UFSToDelete []string
-	for key, value := range correctUFS {
+// correctDRFS is a map with VFS structs as keys and slices of the date ranges that belong to that VFS as values. Only StartDate and EndDate need to be set in the date ranges.
+// If a DRFS row belongs to one of the VFS, but doesn't match one of its date ranges, delete that DRFS row.
+func (vsam VSAModel) CleanOrphanedDRFS(currentUser string, correctDRFS map[volunteerForSchedule][]dateRangeForSchedule) error {
+	var DRFSToDelete []int
+	for key, value := range correctDRFS {
 		if key.VFSID == 0 {
-			return fmt.Errorf("error in CleanOrphanedUFS: method failed because one of the provided volunteerForSchedule structs did not have a VFSID: %+v", map[volunteerForSchedule][]date{key: value})
+			return fmt.Errorf("error in CleanOrphanedDRFS: method failed because one of the provided VFS structs did not have a VFSID: %+v", key)
 		}
-		var dates []int
-		for _, dateStruct := range value {
-			if dateStruct.DateID < 1 {
-				return fmt.Errorf("error in CleanOrphanedUFS: method failed because one of the provided date structs did not have a DateID: %+v", map[volunteerForSchedule][]date{key: value})
+		var dateRanges []dateRangeForSchedule
+		for _, drfsStruct := range value {
+			if drfsStruct.StartDate == "" || drfsStruct.EndDate == "" {
+				return fmt.Errorf("error in CleanOrphanedDRFS: method failed because one of the provided DRFS structs did not have a StartDate or EndDate: %+v", value)
 			}
-			dates = append(dates, dateStruct.DateID)
+			dateRanges = append(dateRanges, dateRangeForSchedule{StartDate: drfsStruct.StartDate, EndDate: drfsStruct.EndDate})
 		}
-		ufsCheck, err := vsam.RequestUFS(currentUser, []unavailabilityForSchedule{{VolunteerForSchedule: key.VFSID}})
+		DRFSCheck, err := vsam.RequestDRFS(currentUser, []dateRangeForSchedule{{VolunteerForSchedule: key.VFSID}})
 		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedUFS: %w", err)
+			return fmt.Errorf("error in CleanOrphanedDRFS: %w", err)
 		}
-		for _, ufs := range ufsCheck {
-			if !slices.Contains(dates, ufs.Date) {
-				UFSToDelete = append(UFSToDelete, strconv.Itoa(ufs.UFSID))
-				//log.Printf("UFSToDelete=`%#v`; ufs.Date=`%d", UFSToDelete, ufs.Date)
+		for _, DRFS := range DRFSCheck {
+			if !slices.Contains(dateRanges, dateRangeForSchedule{StartDate: DRFS.StartDate, EndDate: DRFS.EndDate}) {
+				DRFSToDelete = append(DRFSToDelete, DRFS.DRFSID)
 			}
 		}
-		tx, err := vsam.DB.Begin()
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedUFS: sql.DB.Begin error: %w", err)
-		}
-		defer tx.Rollback()
-		deleteUFSQuery := fmt.Sprintf(`delete from UnavailabilitiesForSchedule where User = "%s" and UFSID in (%s)`, currentUser, CsvSlice(UFSToDelete, true))
-		//fmt.Println(deleteUFSQuery)
-		_, err = tx.Exec(deleteUFSQuery)
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedUFS: sql.Tx.Exec error: %w. Value of deleteUFSQuery is `%s`", err, deleteUFSQuery)
-		}
-		err = tx.Commit()
-		if err != nil {
-			return fmt.Errorf("error in CleanOrphanedUFS: sql.Tx.Commit error: %w", err)
-		}
+	}
+	tx, err := vsam.begin()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedDRFS: sql.DB.Begin error: %w", err)
+	}
+	defer tx.Rollback()
+	deleteDRFSQuery := newSQLQuery(`delete from DateRangesForSchedule where User = ?`, currentUser)
+	deleteDRFSQuery.addIn(" and ", "DRFSID", DRFSToDelete)
+	_, err = tx.Exec(deleteDRFSQuery.text, deleteDRFSQuery.args...)
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedDRFS: sql.Tx.Exec error: %w. Value of deleteDRFSQuery is `%s`", err, deleteDRFSQuery)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("error in CleanOrphanedDRFS: sql.Tx.Commit error: %w", err)
 	}
 	return nil
 }
@@ -2228,6 +6028,12 @@ func (vsam VSAModel) CreateSVOD(currentUser string, toCreate []scheduledVoluntee
 	if err != nil {
 		return fmt.Errorf("error in CreateSVOD: %w", err)
 	}
+	// RequestSVOD does not filter on an empty TimeSlot or Role, so only keep the rows that match a shift in toCreate exactly
+	check = slices.DeleteFunc(check, func(existing scheduledVolunteerOnDate) bool {
+		return !slices.ContainsFunc(toCreate, func(val scheduledVolunteerOnDate) bool {
+			return val.VolunteerForSchedule == existing.VolunteerForSchedule && val.Date == existing.Date && val.TimeSlot == existing.TimeSlot && val.Role == existing.Role
+		})
+	})
 	if len(check) > 0 {
 		return fmt.Errorf("error in CreateSVOD: method failed because at least one of the scheduledVolunteerOnDate entries to be created already exists in the database. Existing scheduledVolunteerOnDate entry(s): %+v", check)
 	}
@@ -2239,25 +6045,31 @@ func (vsam VSAModel) CreateSVOD(currentUser string, toCreate []scheduledVoluntee
 		if val.Date == (scheduledVolunteerOnDate{}.Date) {
 			return fmt.Errorf("error in CreateSVOD: method failed because at least one of the scheduledVolunteerOnDate structs in toCreate did not have a value for Date: %+v", val)
 		}
-		if !slices.Contains(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
-			checkDuplicates = append(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
+		if err := checkDates(val.Date); err != nil {
+			return fmt.Errorf("error in CreateSVOD: %w", err)
+		}
+		if strings.Contains(val.TimeSlot, ShiftKeySeparator) || strings.Contains(val.Role, ShiftKeySeparator) {
+			return fmt.Errorf("error in CreateSVOD: method failed because at least one of the scheduledVolunteerOnDate structs in toCreate had a TimeSlot or Role containing \"%s\": %+v", ShiftKeySeparator, val)
+		}
+		if !slices.Contains(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date, TimeSlot: val.TimeSlot, Role: val.Role}) {
+			checkDuplicates = append(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date, TimeSlot: val.TimeSlot, Role: val.Role})
 		} else {
 			return fmt.Errorf("error in CreateSVOD: method failed because at least one of the scheduledVolunteerOnDate structs in toCreate was a duplicate of another scheduledVolunteerOnDate struct in toCreate: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
 		return fmt.Errorf("error in CreateSVOD: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
-	fillSVODTableString := `insert into ScheduledVolunteersOnDates (User, VolunteerForSchedule, Date) values (?, ?, ?)`
+	fillSVODTableString := `insert into ScheduledVolunteersOnDates (User, VolunteerForSchedule, Date, TimeSlot, Role) values (?, ?, ?, ?, ?)`
 	fillVFSTableStmt, err := tx.Prepare(fillSVODTableString)
 	if err != nil {
 		return fmt.Errorf("error in CreateSVOD: sql.Tx.Prepare error: %w. Value of fillSVODTableString is `%s`", err, fillSVODTableString)
 	}
 	defer fillVFSTableStmt.Close()
 	for i := 0; i < len(toCreate); i++ {
-		_, err = fillVFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Date)
+		_, err = fillVFSTableStmt.Exec(currentUser, toCreate[i].VolunteerForSchedule, toCreate[i].Date, toCreate[i].TimeSlot, toCreate[i].Role)
 		if err != nil {
 			return fmt.Errorf("error in CreateSVOD: sql.Stmt.Exec error: %w. Value of toCreate[i] is `%+v`", err, toCreate[i])
 		}
@@ -2281,60 +6093,47 @@ func (vsam VSAModel) RequestSVODSingle(currentUser string, scheduledVolunteerOnD
 }
 
 func (vsam VSAModel) RequestSVOD(currentUser string, scheduledVolunteersOnDates []scheduledVolunteerOnDate) ([]scheduledVolunteerOnDate, error) { // TODO
-	SVODQuery := fmt.Sprintf(`select * from scheduledVolunteersOnDates where User = "%s"`, currentUser)
+	SVODQuery := newSQLQuery(`select SVODID, User, VolunteerForSchedule, Date, TimeSlot, Role from scheduledVolunteersOnDates where User = ?`, currentUser)
 	if len(scheduledVolunteersOnDates) > 0 {
 		if check, failed := testEmpty(scheduledVolunteersOnDates, scheduledVolunteerOnDate{}); check {
 			return []scheduledVolunteerOnDate{}, fmt.Errorf("error in RequestSVOD: method failed because one of the values in scheduledVolunteersOnDates had an empty/default values volunteerForSchedule struct: %+v", failed)
 		}
-		SVODQuery = fmt.Sprintf(`%s and (`, SVODQuery)
 	}
+	matches := make([]sqlMatch, 0, len(scheduledVolunteersOnDates))
 	for i := 0; i < len(scheduledVolunteersOnDates); i++ {
-		count := countGTZero([]int{scheduledVolunteersOnDates[i].SVODID, len(scheduledVolunteersOnDates[i].User), scheduledVolunteersOnDates[i].VolunteerForSchedule, scheduledVolunteersOnDates[i].Date})
-		SVODQuery = fmt.Sprintf(`%s(`, SVODQuery)
+		var match sqlMatch
 		if scheduledVolunteersOnDates[i].SVODID > 0 {
-			SVODQuery = fmt.Sprintf(`%sSVODID = %d`, SVODQuery, scheduledVolunteersOnDates[i].SVODID)
-			count--
-			if count > 0 {
-				SVODQuery = fmt.Sprintf(`%s and `, SVODQuery)
-			}
+			match.equals("SVODID", scheduledVolunteersOnDates[i].SVODID)
 		}
 		if len(scheduledVolunteersOnDates[i].User) > 0 {
-			SVODQuery = fmt.Sprintf(`%sUser = "%s"`, SVODQuery, scheduledVolunteersOnDates[i].User)
-			count--
-			if count > 0 {
-				SVODQuery = fmt.Sprintf(`%s and `, SVODQuery)
-			}
+			match.equals("User", scheduledVolunteersOnDates[i].User)
 		}
 		if scheduledVolunteersOnDates[i].VolunteerForSchedule > 0 {
-			SVODQuery = fmt.Sprintf(`%sVolunteerForSchedule = %d`, SVODQuery, scheduledVolunteersOnDates[i].VolunteerForSchedule)
-			count--
-			if count > 0 {
-				SVODQuery = fmt.Sprintf(`%s and `, SVODQuery)
-			}
+			match.equals("VolunteerForSchedule", scheduledVolunteersOnDates[i].VolunteerForSchedule)
 		}
-		if scheduledVolunteersOnDates[i].Date > 0 {
-			SVODQuery = fmt.Sprintf(`%sDate = %d`, SVODQuery, scheduledVolunteersOnDates[i].Date)
+		if len(scheduledVolunteersOnDates[i].Date) > 0 {
+			match.equals("Date", scheduledVolunteersOnDates[i].Date)
 		}
-		SVODQuery = fmt.Sprintf(`%s)`, SVODQuery)
-		if i+1 < len(scheduledVolunteersOnDates) {
-			SVODQuery = fmt.Sprintf(`%s or `, SVODQuery)
+		if len(scheduledVolunteersOnDates[i].TimeSlot) > 0 {
+			match.equals("TimeSlot", scheduledVolunteersOnDates[i].TimeSlot)
 		}
-		//fmt.Println(count)
-		//fmt.Println(SVODQuery)
-	}
-	if len(scheduledVolunteersOnDates) > 0 {
-		SVODQuery = fmt.Sprintf(`%s)`, SVODQuery)
+		if len(scheduledVolunteersOnDates[i].Role) > 0 {
+			match.equals("Role", scheduledVolunteersOnDates[i].Role)
+		}
+		matches = append(matches, match)
 	}
+	SVODQuery.addMatchAny(" and ", matches)
+	SVODQuery.add(` order by SVODID`)
 	//fmt.Println(SVODQuery)
 	var result []scheduledVolunteerOnDate
-	rows, err := vsam.DB.Query(SVODQuery)
+	rows, err := vsam.querier().Query(SVODQuery.text, SVODQuery.args...)
 	if err != nil {
 		return []scheduledVolunteerOnDate{}, fmt.Errorf("error in RequestSVOD: sql.DB.Query error: %w. Value of SVODQuery is `%s`", err, SVODQuery)
 	}
 	defer rows.Close()
 	for rows.Next() {
 		var SVODStruct scheduledVolunteerOnDate
-		err = rows.Scan(&SVODStruct.SVODID, &SVODStruct.User, &SVODStruct.VolunteerForSchedule, &SVODStruct.Date)
+		err = rows.Scan(&SVODStruct.SVODID, &SVODStruct.User, &SVODStruct.VolunteerForSchedule, &SVODStruct.Date, &SVODStruct.TimeSlot, &SVODStruct.Role)
 		if err != nil {
 			return []scheduledVolunteerOnDate{}, fmt.Errorf("error in RequestSVOD: sql.Rows.Scan error: %w. Value of SVODStruct is `%+v`", err, SVODStruct)
 		}
@@ -2351,9 +6150,14 @@ func (vsam VSAModel) UpdateSVOD(currentUser string, toUpdate []scheduledVoluntee
 	if check, failed := testEmpty(toUpdate, scheduledVolunteerOnDate{}); check {
 		return fmt.Errorf("error in UpdateSVOD: method failed because one of the values in toUpdate had an empty/default values scheduledVolunteerOnDate struct: %+v", failed)
 	}
+	for _, val := range toUpdate {
+		if err := checkDates(val.Date); err != nil {
+			return fmt.Errorf("error in UpdateSVOD: %w", err)
+		}
+	}
 	head := `update scheduledVolunteersOnDates set`
-	tail := fmt.Sprintf(`where User="%s" and SVODID=?`, currentUser)
-	tx, err := vsam.DB.Begin()
+	tail := `where User=? and SVODID=?`
+	tx, err := vsam.begin()
 	if err != nil {
 		return fmt.Errorf("error in UpdateSVOD: sql.DB.Begin error: %w", err)
 	}
@@ -2367,14 +6171,17 @@ func (vsam VSAModel) UpdateSVOD(currentUser string, toUpdate []scheduledVoluntee
 		if err != nil {
 			return fmt.Errorf("error in UpdateSVOD: %w", err)
 		}
-		if !slices.Contains(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date}) {
-			checkDuplicates = append(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
+		if strings.Contains(val.TimeSlot, ShiftKeySeparator) || strings.Contains(val.Role, ShiftKeySeparator) {
+			return fmt.Errorf("error in UpdateSVOD: method failed because one of the values in toUpdate had a TimeSlot or Role containing \"%s\": %+v", ShiftKeySeparator, val)
+		}
+		if !slices.Contains(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date, TimeSlot: val.TimeSlot, Role: val.Role}) {
+			checkDuplicates = append(checkDuplicates, scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date, TimeSlot: val.TimeSlot, Role: val.Role})
 		} else {
-			return fmt.Errorf("error in UpdateSVOD: method failed because at least two of the scheduledVolunteerOnDate structs in toUpdate would create duplicate scheduledVolunteerOnDate structs in the database: %+v", scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date})
+			return fmt.Errorf("error in UpdateSVOD: method failed because at least two of the scheduledVolunteerOnDate structs in toUpdate would create duplicate scheduledVolunteerOnDate structs in the database: %+v", scheduledVolunteerOnDate{VolunteerForSchedule: val.VolunteerForSchedule, Date: val.Date, TimeSlot: val.TimeSlot, Role: val.Role})
 		}
 		currentSVOD.SVODID = 0
-		updateSVODString := head
-		count := countGTZero([]int{val.SVODID, len(val.User), val.VolunteerForSchedule, val.Date})
+		updateSVODString := newSQLQuery(head)
+		count := countGTZero([]int{val.SVODID, len(val.User), val.VolunteerForSchedule, len(val.Date), len(val.TimeSlot), len(val.Role)})
 		count-- // This is needed because a SVODID has been provided (verified at the start of this loop).
 		if count == 0 {
 			return fmt.Errorf("error in UpdateSVOD: method failed because only one value was provided in an scheduledVolunteerOnDate struct. At least two values (a SVODID and a value to update) must be provided: %+v", val)
@@ -2383,35 +6190,54 @@ func (vsam VSAModel) UpdateSVOD(currentUser string, toUpdate []scheduledVoluntee
 		//fmt.Println(count)
 		//fmt.Println(updateSVODString)
 		if val.VolunteerForSchedule > 0 {
-			updateSVODString = fmt.Sprintf(`%s VolunteerForSchedule=%d`, updateSVODString, val.VolunteerForSchedule)
+			updateSVODString.add(` VolunteerForSchedule=?`, val.VolunteerForSchedule)
 			count--
 			currentSVOD.VolunteerForSchedule = val.VolunteerForSchedule
 			if count > 0 {
-				updateSVODString = fmt.Sprintf(`%s,`, updateSVODString)
+				updateSVODString.add(`,`)
 			}
 			//fmt.Println(count)
 			//fmt.Println(updateSVODString)
 		}
-		if val.Date > 0 {
-			updateSVODString = fmt.Sprintf(`%s Date=%d`, updateSVODString, val.Date)
+		// Date, TimeSlot, and Role identify the volunteer's place on a shift together, so TimeSlot and Role are always written along with Date (an empty TimeSlot moves the SVOD to the date's unnamed shift
+		// and an empty Role takes the volunteer out of any role)
+		if len(val.Date) > 0 {
+			updateSVODString.add(` Date=?, TimeSlot=?, Role=?`, val.Date, val.TimeSlot, val.Role)
 			//fmt.Println(count)
 			//fmt.Println(updateSVODString)
 			currentSVOD.Date = val.Date
+			currentSVOD.TimeSlot = val.TimeSlot
+			currentSVOD.Role = val.Role
+		} else if len(val.TimeSlot) > 0 || len(val.Role) > 0 {
+			updates, updateArgs := []string{}, []any{}
+			if len(val.TimeSlot) > 0 {
+				updates = append(updates, `TimeSlot=?`)
+				updateArgs = append(updateArgs, val.TimeSlot)
+				currentSVOD.TimeSlot = val.TimeSlot
+			}
+			if len(val.Role) > 0 {
+				updates = append(updates, `Role=?`)
+				updateArgs = append(updateArgs, val.Role)
+				currentSVOD.Role = val.Role
+			}
+			updateSVODString.add(fmt.Sprintf(` %s`, strings.Join(updates, ", ")), updateArgs...)
 		}
-		updateSVODString = fmt.Sprintf(`%s %s`, updateSVODString, tail)
+		updateSVODString.add(fmt.Sprintf(` %s`, tail), currentUser)
 		//fmt.Println(count)
 		//fmt.Println(updateSVODString)
 		if check, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{currentSVOD}); err != nil {
 			return fmt.Errorf("error in UpdateSVOD: %w", err)
-		} else if len(check) > 0 {
+		} else if slices.ContainsFunc(check, func(existing scheduledVolunteerOnDate) bool {
+			return existing.TimeSlot == currentSVOD.TimeSlot && existing.Role == currentSVOD.Role
+		}) {
 			return fmt.Errorf("error in UpdateSVOD: method failed because it would create a duplicate SVOD: %+v", val)
 		}
-		updateSchedulesStmt, err := tx.Prepare(updateSVODString)
+		updateSchedulesStmt, err := tx.Prepare(updateSVODString.text)
 		if err != nil {
 			return fmt.Errorf("error in UpdateSVOD: sql.Stmt.Prepare error: %w. Value of updateSVODString is `%s`", err, updateSVODString)
 		}
 		defer updateSchedulesStmt.Close()
-		_, err = updateSchedulesStmt.Exec(val.SVODID)
+		_, err = updateSchedulesStmt.Exec(append(updateSVODString.args, val.SVODID)...)
 		if err != nil {
 			return fmt.Errorf("error in UpdateSVOD: sql.Stmt.Exec error: %w. Value of val is `%+v`", err, val)
 		}
@@ -2423,26 +6249,26 @@ func (vsam VSAModel) UpdateSVOD(currentUser string, toUpdate []scheduledVoluntee
 	return nil
 }
 
-// Will delete SVOD database entries that match the SVODID or that match the VFS and Date provided in each SVOD struct. If a SVODID > 0 is provided, the values for VFS and Date are ignored for that SVOD struct.
+// Will delete SVOD database entries that match the SVODID or that match the VFS, Date, TimeSlot, and Role provided in each SVOD struct. If a SVODID > 0 is provided, the values for VFS, Date, TimeSlot, and Role are ignored for that SVOD struct.
 func (vsam VSAModel) DeleteSVOD(currentUser string, toDelete []scheduledVolunteerOnDate) error { // TODO
 	for _, val := range toDelete {
-		if val.SVODID < 1 && (val.VolunteerForSchedule < 1 || val.Date < 1) {
+		if val.SVODID < 1 && (val.VolunteerForSchedule < 1 || len(val.Date) < 1) {
 			return fmt.Errorf("error in DeleteSVOD: method failed because one of the scheduledVolunteerOnDate structs did not have a value for SVODID or VolunteerForSchedule and Date: %+v", val)
 		}
 	}
-	tx, err := vsam.DB.Begin()
+	tx, err := vsam.begin()
 	if err != nil {
 		return fmt.Errorf("error in DeleteSVOD: sql.DB.Begin error: %w", err)
 	}
 	defer tx.Rollback()
 	for _, val := range toDelete {
-		var deleteSVODString string
+		var deleteSVODString sqlQuery
 		if val.SVODID > 0 {
-			deleteSVODString = fmt.Sprintf(`delete from scheduledVolunteersOnDates where User="%s" and SVODID=%d`, currentUser, val.SVODID)
+			deleteSVODString = newSQLQuery(`delete from scheduledVolunteersOnDates where User=? and SVODID=?`, currentUser, val.SVODID)
 		} else {
-			deleteSVODString = fmt.Sprintf(`delete from scheduledVolunteersOnDates where User="%s" and VolunteerForSchedule=%d and Date=%d`, currentUser, val.VolunteerForSchedule, val.Date)
+			deleteSVODString = newSQLQuery(`delete from scheduledVolunteersOnDates where User=? and VolunteerForSchedule=? and Date=? and TimeSlot=? and Role=?`, currentUser, val.VolunteerForSchedule, val.Date, val.TimeSlot, val.Role)
 		}
-		_, err := tx.Exec(deleteSVODString)
+		_, err := tx.Exec(deleteSVODString.text, deleteSVODString.args...)
 		if err != nil {
 			return fmt.Errorf("error in DeleteSVOD: sql.Tx.Exec error: %w. Value of deleteSVODString is `%s`", err, deleteSVODString)
 		}
@@ -2454,37 +6280,39 @@ func (vsam VSAModel) DeleteSVOD(currentUser string, toDelete []scheduledVoluntee
 	return nil
 }
 
-func (vsam VSAModel) CleanOrphanedSVOD(currentUser string, correctSVOD map[volunteerForSchedule][]date) error {
-	var SVODToDelete []string
+// correctSVOD is a map with VFS structs as keys and slices of SVOD structs that define the Date, TimeSlot, and Role of each shift the volunteer is scheduled for as values. If a SVOD row is linked to a VFS, but doesn't match one of those shifts, delete that SVOD row.
+func (vsam VSAModel) CleanOrphanedSVOD(currentUser string, correctSVOD map[volunteerForSchedule][]scheduledVolunteerOnDate) error {
+	var SVODToDelete []int
 	for key, value := range correctSVOD {
 		if key.VFSID == 0 {
-			return fmt.Errorf("error in CleanOrphanedSVOD: method failed because one of the provided volunteerForSchedule structs did not have a VFSID: %+v", map[volunteerForSchedule][]date{key: value})
+			return fmt.Errorf("error in CleanOrphanedSVOD: method failed because one of the provided volunteerForSchedule structs did not have a VFSID: %+v", map[volunteerForSchedule][]scheduledVolunteerOnDate{key: value})
 		}
-		var dates []int
-		for _, dateStruct := range value {
-			if dateStruct.DateID < 1 {
-				return fmt.Errorf("error in CleanOrphanedSVOD: method failed because one of the provided date structs did not have a DateID: %+v", map[volunteerForSchedule][]date{key: value})
+		var shifts []scheduledVolunteerOnDate
+		for _, svodStruct := range value {
+			if len(svodStruct.Date) < 1 {
+				return fmt.Errorf("error in CleanOrphanedSVOD: method failed because one of the provided scheduledVolunteerOnDate structs did not have a Date: %+v", map[volunteerForSchedule][]scheduledVolunteerOnDate{key: value})
 			}
-			dates = append(dates, dateStruct.DateID)
+			shifts = append(shifts, scheduledVolunteerOnDate{Date: svodStruct.Date, TimeSlot: svodStruct.TimeSlot, Role: svodStruct.Role})
 		}
 		SVODCheck, err := vsam.RequestSVOD(currentUser, []scheduledVolunteerOnDate{{VolunteerForSchedule: key.VFSID}})
 		if err != nil {
 			return fmt.Errorf("error in CleanOrphanedSVOD: %w", err)
 		}
 		for _, SVOD := range SVODCheck {
-			if !slices.Contains(dates, SVOD.Date) {
-				SVODToDelete = append(SVODToDelete, strconv.Itoa(SVOD.SVODID))
+			if !slices.Contains(shifts, scheduledVolunteerOnDate{Date: SVOD.Date, TimeSlot: SVOD.TimeSlot, Role: SVOD.Role}) {
+				SVODToDelete = append(SVODToDelete, SVOD.SVODID)
 				//fmt.Println(SVODToDelete)
 			}
 		}
-		tx, err := vsam.DB.Begin()
+		tx, err := vsam.begin()
 		if err != nil {
 			return fmt.Errorf("error in CleanOrphanedSVOD: sql.DB.Begin error: %w", err)
 		}
 		defer tx.Rollback()
-		deleteSVODQuery := fmt.Sprintf(`delete from scheduledVolunteersOnDates where User = "%s" and SVODID in (%s)`, currentUser, CsvSlice(SVODToDelete, true))
+		deleteSVODQuery := newSQLQuery(`delete from scheduledVolunteersOnDates where User = ?`, currentUser)
+		deleteSVODQuery.addIn(" and ", "SVODID", SVODToDelete)
 		//fmt.Println(deleteSVODQuery)
-		_, err = tx.Exec(deleteSVODQuery)
+		_, err = tx.Exec(deleteSVODQuery.text, deleteSVODQuery.args...)
 		if err != nil {
 			return fmt.Errorf("error in CleanOrphanedSVOD: sql.Tx.Exec error: %w. Value of deleteSVODQuery is `%s`", err, deleteSVODQuery)
 		}
@@ -2515,24 +6343,24 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 			ScheduleName:       "test1",
 			ShiftsOff:          3,
 			VolunteersPerShift: 3,
-			StartDate:          Must(DbModel.RequestDate(date{Month: 1, Day: 1, Year: 2024})).DateID,
-			EndDate:            Must(DbModel.RequestDate(date{Month: 3, Day: 1, Year: 2024})).DateID,
+			StartDate:          date{Month: 1, Day: 1, Year: 2024}.ToString(),
+			EndDate:            date{Month: 3, Day: 1, Year: 2024}.ToString(),
 			User:               currentUser,
 		},
 		{
 			ScheduleName:       "test2",
 			ShiftsOff:          3,
 			VolunteersPerShift: 3,
-			StartDate:          Must(DbModel.RequestDate(date{Month: 3, Day: 1, Year: 2024})).DateID,
-			EndDate:            Must(DbModel.RequestDate(date{Month: 6, Day: 1, Year: 2024})).DateID,
+			StartDate:          date{Month: 3, Day: 1, Year: 2024}.ToString(),
+			EndDate:            date{Month: 6, Day: 1, Year: 2024}.ToString(),
 			User:               currentUser,
 		},
 		{
 			ScheduleName:       "test3",
 			ShiftsOff:          3,
 			VolunteersPerShift: 3,
-			StartDate:          Must(DbModel.RequestDate(date{Month: 6, Day: 1, Year: 2024})).DateID,
-			EndDate:            Must(DbModel.RequestDate(date{Month: 9, Day: 1, Year: 2024})).DateID,
+			StartDate:          date{Month: 6, Day: 1, Year: 2024}.ToString(),
+			EndDate:            date{Month: 9, Day: 1, Year: 2024}.ToString(),
 			User:               currentUser,
 		},
 	}
@@ -2651,7 +6479,7 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "Tim"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 1, Day: 14, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 14, Year: 2024}.ToString(),
 		},
 		{
 			User: currentUser,
@@ -2659,7 +6487,7 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 1, Day: 21, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 21, Year: 2024}.ToString(),
 		},
 		{
 			User: currentUser,
@@ -2667,7 +6495,7 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bob"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 5, Day: 12, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 12, Year: 2024}.ToString(),
 		},
 		{
 			User: currentUser,
@@ -2675,7 +6503,7 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "Lance"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 5, Day: 19, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 19, Year: 2024}.ToString(),
 		},
 		{
 			User: currentUser,
@@ -2683,7 +6511,7 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "Jack"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 8, Day: 11, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 11, Year: 2024}.ToString(),
 		},
 		{
 			User: currentUser,
@@ -2691,30 +6519,29 @@ func FillInSampleDB(currentUser string, DbModel VSAModel) {
 				Schedule:  Must(DbModel.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(DbModel.RequestVolunteer(currentUser, volunteer{VolunteerName: "George"})).VolunteerID,
 			})).VFSID,
-			Date: Must(DbModel.RequestDate(date{Month: 8, Day: 18, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 18, Year: 2024}.ToString(),
 		},
 	}
 	DbModel.CreateUFS(currentUser, unavailabilitiesForSchedule)
 }
 
 func main() {
-	dbExists := false
-	if _, err := os.Stat(DbName); err == nil {
-		dbExists = true
-	}
-	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_foreign_keys=on", DbName))
+	sample, err := Open(DbName)
 	if err != nil {
 		log.Fatal(err)
 	}
 	env := &SampleEnv{
-		Sample:       VSAModel{DB: db},
+		Sample:       sample,
 		LoggedInUser: "Seth",
 	}
-	defer env.Sample.DB.Close()
-	if !dbExists {
-		if err = env.Sample.CreateDatabase(); err != nil {
-			log.Fatalf("Crashed in main() with error: %v", err)
-		}
+	defer env.Sample.Close()
+	// bring vsa.db up to the current schema, creating it if it is new
+	fromVersion, toVersion, err := env.Sample.MigrateDatabase()
+	if err != nil {
+		log.Fatalf("Crashed in main() with error: %v", err)
+	}
+	if fromVersion != toVersion {
+		log.Printf("Migrated %s from schema version %d to %d", DbName, fromVersion, toVersion)
 	}
 	FillInSampleDB(env.LoggedInUser, env.Sample)
 	fmt.Println("Done. Press enter to exit executable.")
@@ -4,18 +4,27 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 const testDbName = "vsaTEST.db"
 
+// testPostgresEnvVar names a postgres:// URL to run this suite against instead of SQLite, for example postgres://localhost/vsatest?sslmode=disable. Every test drops and recreates the public schema of that database, so never point it at one that matters.
+const testPostgresEnvVar = "VSA_TEST_POSTGRES_DSN"
+
 var sampleVolunteers = []volunteer{
 	{
 		VolunteerName: "Tim",
@@ -68,34 +77,75 @@ func simulateUpdatedSampleVolunteers(currentUser string) (result []volunteer) {
 	return
 }
 
+var sampleRoles = []role{
+	{
+		RoleName: "Greeter lead",
+	},
+	{
+		RoleName: "Usher",
+	},
+	{
+		RoleName: "Sound tech",
+	},
+}
+
+func simulateCreatedSampleRoles(currentUser string) (result []role) {
+	for i, val := range sampleRoles {
+		val.RoleID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func generateSampleVR(currentUser string, vsam VSAModel) (result []volunteerRole) {
+	for volunteerName, roleName := range map[string]string{"Tim": "Usher", "Bill": "Usher", "Jack": "Greeter lead", "George": "Sound tech"} {
+		result = append(result, volunteerRole{
+			Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: volunteerName})).VolunteerID,
+			Role:      Must(vsam.RequestRole(currentUser, role{RoleName: roleName})).RoleID,
+		})
+	}
+	slices.SortFunc(result, func(a, b volunteerRole) int { return a.Volunteer - b.Volunteer })
+	return
+}
+
+func simulateCreatedSampleVR(currentUser string, generatedVR []volunteerRole) (result []volunteerRole) {
+	for i, val := range generatedVR {
+		val.VRID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
 func generateSampleSchedules(vsam VSAModel) (result []schedule) {
 	result = append(result, schedule{
 		ScheduleName:       "test0",
 		ShiftsOff:          0,
 		VolunteersPerShift: 1,
-		StartDate:          Must(vsam.RequestDate(date{Month: 8, Day: 1, Year: 2023})).DateID,
-		EndDate:            Must(vsam.RequestDate(date{Month: 9, Day: 1, Year: 2023})).DateID,
+		StartDate:          date{Month: 8, Day: 1, Year: 2023}.ToString(),
+		EndDate:            date{Month: 9, Day: 1, Year: 2023}.ToString(),
 	})
 	result = append(result, schedule{
 		ScheduleName:       "test1",
 		ShiftsOff:          3,
 		VolunteersPerShift: 3,
-		StartDate:          Must(vsam.RequestDate(date{Month: 1, Day: 1, Year: 2024})).DateID,
-		EndDate:            Must(vsam.RequestDate(date{Month: 3, Day: 1, Year: 2024})).DateID,
+		StartDate:          date{Month: 1, Day: 1, Year: 2024}.ToString(),
+		EndDate:            date{Month: 3, Day: 1, Year: 2024}.ToString(),
 	})
 	result = append(result, schedule{
 		ScheduleName:       "test2",
 		ShiftsOff:          3,
 		VolunteersPerShift: 3,
-		StartDate:          Must(vsam.RequestDate(date{Month: 3, Day: 1, Year: 2024})).DateID,
-		EndDate:            Must(vsam.RequestDate(date{Month: 6, Day: 1, Year: 2024})).DateID,
+		StartDate:          date{Month: 3, Day: 1, Year: 2024}.ToString(),
+		EndDate:            date{Month: 6, Day: 1, Year: 2024}.ToString(),
 	})
 	result = append(result, schedule{
 		ScheduleName:       "test3",
 		ShiftsOff:          3,
 		VolunteersPerShift: 3,
-		StartDate:          Must(vsam.RequestDate(date{Month: 6, Day: 1, Year: 2024})).DateID,
-		EndDate:            Must(vsam.RequestDate(date{Month: 9, Day: 1, Year: 2024})).DateID,
+		StartDate:          date{Month: 6, Day: 1, Year: 2024}.ToString(),
+		EndDate:            date{Month: 9, Day: 1, Year: 2024}.ToString(),
 	})
 	return
 }
@@ -118,8 +168,8 @@ func simulateUpdatedSampleSchedules(currentUser string, generatedSchedules []sch
 	result[0].ScheduleName = "test1a"
 	result[0].ShiftsOff = 10
 	result[0].VolunteersPerShift = 10
-	result[0].StartDate = 550
-	result[0].EndDate = 556
+	result[0].StartDate = "2024-07-03"
+	result[0].EndDate = "2024-07-09"
 	return
 }
 
@@ -163,6 +213,92 @@ func simulateUpdatedSampleWFS(currentUser string, generatedWFS []weekdayForSched
 	return
 }
 
+func generateSampleTSFS(currentUser string, vsam VSAModel) (result []timeSlotForSchedule) {
+	result = append(result, timeSlotForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
+		Weekday:            Must(vsam.RequestWeekday(weekday{WeekdayName: "Sunday"})).WeekdayName,
+		SlotName:           "8am",
+		SlotOrder:          1,
+		VolunteersPerShift: 2,
+	})
+	result = append(result, timeSlotForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
+		Weekday:            Must(vsam.RequestWeekday(weekday{WeekdayName: "Sunday"})).WeekdayName,
+		SlotName:           "11am",
+		SlotOrder:          2,
+		VolunteersPerShift: 3,
+	})
+	result = append(result, timeSlotForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
+		Weekday:            Must(vsam.RequestWeekday(weekday{WeekdayName: "Wednesday"})).WeekdayName,
+		SlotName:           "evening",
+		SlotOrder:          1,
+		VolunteersPerShift: 1,
+	})
+	return
+}
+
+func simulateCreatedSampleTSFS(currentUser string, generatedTSFS []timeSlotForSchedule) (result []timeSlotForSchedule) {
+	for i, val := range generatedTSFS {
+		val.TSFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func simulateUpdatedSampleTSFS(currentUser string, generatedTSFS []timeSlotForSchedule) (result []timeSlotForSchedule) {
+	for i, val := range generatedTSFS {
+		val.TSFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	result[0].SlotName = "9am"
+	result[0].VolunteersPerShift = 4
+	return
+}
+
+func generateSampleRFS(currentUser string, vsam VSAModel) (result []roleForSchedule) {
+	result = append(result, roleForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
+		Role:               Must(vsam.RequestRole(currentUser, role{RoleName: "Greeter lead"})).RoleID,
+		RoleOrder:          1,
+		VolunteersPerShift: 1,
+	})
+	result = append(result, roleForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
+		Role:               Must(vsam.RequestRole(currentUser, role{RoleName: "Usher"})).RoleID,
+		RoleOrder:          2,
+		VolunteersPerShift: 2,
+	})
+	result = append(result, roleForSchedule{
+		Schedule:           Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
+		Role:               Must(vsam.RequestRole(currentUser, role{RoleName: "Sound tech"})).RoleID,
+		RoleOrder:          1,
+		VolunteersPerShift: 1,
+	})
+	return
+}
+
+func simulateCreatedSampleRFS(currentUser string, generatedRFS []roleForSchedule) (result []roleForSchedule) {
+	for i, val := range generatedRFS {
+		val.RFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func simulateUpdatedSampleRFS(currentUser string, generatedRFS []roleForSchedule) (result []roleForSchedule) {
+	for i, val := range generatedRFS {
+		val.RFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	result[1].VolunteersPerShift = 3
+	return
+}
+
 func generateSampleVFS(currentUser string, vsam VSAModel) (result []volunteerForSchedule) {
 	result = append(result, []volunteerForSchedule{
 		{
@@ -239,42 +375,42 @@ func generateSampleUFS(currentUser string, vsam VSAModel) (result []unavailabili
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Tim"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 1, Day: 14, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 14, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 1, Day: 21, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 21, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bob"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 5, Day: 12, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 12, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Lance"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 5, Day: 19, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 19, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Jack"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 8, Day: 11, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 11, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "George"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 8, Day: 18, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 18, Year: 2024}.ToString(),
 		}}...)
 	return
 }
@@ -295,7 +431,106 @@ func simulateUpdatedSampleUFS(currentUser string, generatedUFS []unavailabilityF
 		result = append(result, val)
 	}
 	result[0].VolunteerForSchedule = 2
-	result[0].Date = 385
+	result[0].Date = "2024-01-20"
+	return
+}
+
+func generateSampleURFS(currentUser string, vsam VSAModel) (result []unavailabilityRuleForSchedule) {
+	vfsID := func(scheduleName string, volunteerName string) int {
+		return Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
+			Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})).ScheduleID,
+			Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: volunteerName})).VolunteerID,
+		})).VFSID
+	}
+	result = append(result, []unavailabilityRuleForSchedule{
+		{VolunteerForSchedule: vfsID("test1", "Tim"), Rule: "1st Sunday"},
+		{VolunteerForSchedule: vfsID("test1", "Tim"), Rule: "July"},
+		{VolunteerForSchedule: vfsID("test1", "Jack"), Rule: "every 2 weeks from 2024-01-07"},
+		{VolunteerForSchedule: vfsID("test2", "Bob"), Rule: "2024-07-01 to 2024-07-14"},
+	}...)
+	return
+}
+
+func simulateCreatedSampleURFS(currentUser string, generatedURFS []unavailabilityRuleForSchedule) (result []unavailabilityRuleForSchedule) {
+	for i, val := range generatedURFS {
+		val.URFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func generateSamplePrFS(currentUser string, vsam VSAModel) (result []preferenceForSchedule) {
+	vfsID := func(scheduleName string, volunteerName string) int {
+		return Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
+			Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})).ScheduleID,
+			Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: volunteerName})).VolunteerID,
+		})).VFSID
+	}
+	result = append(result, []preferenceForSchedule{
+		{VolunteerForSchedule: vfsID("test1", "Tim"), Preference: "Saturday"},
+		{VolunteerForSchedule: vfsID("test1", "Tim"), Preference: "at most 2 shifts"},
+		{VolunteerForSchedule: vfsID("test1", "Jack"), Preference: "2024-03-31 x3"},
+		{VolunteerForSchedule: vfsID("test2", "Bob"), Preference: "Sunday x2"},
+	}...)
+	return
+}
+
+func simulateCreatedSamplePrFS(currentUser string, generatedPrFS []preferenceForSchedule) (result []preferenceForSchedule) {
+	for i, val := range generatedPrFS {
+		val.PrFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func generateSampleDRFS(currentUser string, vsam VSAModel) (result []dateRangeForSchedule) {
+	vfsID := func(scheduleName string, volunteerName string) int {
+		return Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
+			Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})).ScheduleID,
+			Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: volunteerName})).VolunteerID,
+		})).VFSID
+	}
+	result = append(result, []dateRangeForSchedule{
+		{VolunteerForSchedule: vfsID("test1", "Tim"), StartDate: "2024-07-01", EndDate: "2024-07-14"},
+		{VolunteerForSchedule: vfsID("test1", "Tim"), StartDate: "2024-12-20", EndDate: "2025-01-02"},
+		{VolunteerForSchedule: vfsID("test1", "Jack"), StartDate: "2024-03-03", EndDate: "2024-03-03"},
+		{VolunteerForSchedule: vfsID("test2", "Bob"), StartDate: "2024-08-01", EndDate: "2024-08-31"},
+	}...)
+	return
+}
+
+func simulateCreatedSampleDRFS(currentUser string, generatedDRFS []dateRangeForSchedule) (result []dateRangeForSchedule) {
+	for i, val := range generatedDRFS {
+		val.DRFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
+	return
+}
+
+func generateSamplePFS(currentUser string, vsam VSAModel) (result []pairingForSchedule) {
+	vfsID := func(scheduleName string, volunteerName string) int {
+		return Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
+			Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: scheduleName})).ScheduleID,
+			Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: volunteerName})).VolunteerID,
+		})).VFSID
+	}
+	result = append(result, []pairingForSchedule{
+		{VolunteerForSchedule: vfsID("test1", "Bill"), PairedVolunteerForSchedule: vfsID("test1", "Tim"), Pairing: PairingTogether},
+		{VolunteerForSchedule: vfsID("test1", "George"), PairedVolunteerForSchedule: vfsID("test1", "Jack"), Pairing: PairingApart},
+		{VolunteerForSchedule: vfsID("test2", "Bob"), PairedVolunteerForSchedule: vfsID("test2", "Lance"), Pairing: PairingApart},
+	}...)
+	return
+}
+
+func simulateCreatedSamplePFS(currentUser string, generatedPFS []pairingForSchedule) (result []pairingForSchedule) {
+	for i, val := range generatedPFS {
+		val.PFSID = i + 1
+		val.User = currentUser
+		result = append(result, val)
+	}
 	return
 }
 
@@ -306,42 +541,42 @@ func generateSampleSVOD(currentUser string, vsam VSAModel) (result []scheduledVo
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Tim"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 1, Day: 14, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 14, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test1"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 1, Day: 21, Year: 2024})).DateID,
+			Date: date{Month: 1, Day: 21, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Bob"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 5, Day: 12, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 12, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test2"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Lance"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 5, Day: 19, Year: 2024})).DateID,
+			Date: date{Month: 5, Day: 19, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "Jack"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 8, Day: 11, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 11, Year: 2024}.ToString(),
 		},
 		{
 			VolunteerForSchedule: Must(vsam.RequestVFSSingle(currentUser, volunteerForSchedule{
 				Schedule:  Must(vsam.RequestSchedule(currentUser, schedule{ScheduleName: "test3"})).ScheduleID,
 				Volunteer: Must(vsam.RequestVolunteer(currentUser, volunteer{VolunteerName: "George"})).VolunteerID,
 			})).VFSID,
-			Date: Must(vsam.RequestDate(date{Month: 8, Day: 18, Year: 2024})).DateID,
+			Date: date{Month: 8, Day: 18, Year: 2024}.ToString(),
 		}}...)
 	return
 }
@@ -362,7 +597,7 @@ func simulateUpdatedSampleSVOD(currentUser string, generatedSVOD []scheduledVolu
 		result = append(result, val)
 	}
 	result[0].VolunteerForSchedule = 2
-	result[0].Date = 385
+	result[0].Date = "2024-01-20"
 	return
 }
 
@@ -439,26 +674,81 @@ func setUpDatabaseModel(t *testing.T) (VSAModel, func(t *testing.T)) {
 
 func setUpDatabase(t *testing.T, testDbPath string) (VSAModel, func(t *testing.T)) {
 	t.Log("running setUpDatabase")
-	if _, err := os.Stat(testDbPath); err == nil { // if it finds the file, err will be nil
+	dsn := testDbPath
+	if postgresDSN := os.Getenv(testPostgresEnvVar); postgresDSN != "" {
+		dsn = postgresDSN
+	} else if _, err := os.Stat(testDbPath); err == nil { // if it finds the file, err will be nil
 		suberr := os.Remove(testDbPath)
 		if suberr != nil {
 			t.Errorf("Error: existing testdb file was not deleted during setUpDatabase: %v", suberr)
 		}
 	}
-	db, err := sql.Open("sqlite3", fmt.Sprintf("%s?_foreign_keys=on", testDbPath))
+	testSample, err := Open(dsn)
 	if err != nil {
-		t.Errorf("Error opening testdb file using sql package: %v", err)
+		t.Fatalf("Error opening testdb: %v", err)
+	}
+	if _, ok := testSample.dialect.(postgresDialect); ok != (dsn != testDbPath) { // Open takes anything but a postgres:// URL for a SQLite file, which would quietly skip PostgreSQL
+		t.Fatalf("Error: %s must be a postgres:// URL, but %s was opened with %T", testPostgresEnvVar, dsn, testSample.dialect)
+	} else if ok {
+		if _, err = testSample.DB.Exec(`drop schema public cascade; create schema public`); err != nil {
+			t.Fatalf("Error emptying the postgres testdb during setUpDatabase: %v", err)
+		}
 	}
-	testSample := VSAModel{DB: db}
 	return testSample, func(t *testing.T) {
 		t.Log("running tearDownDatabase")
-		if err = testSample.DB.Close(); err != nil {
+		if err = testSample.Close(); err != nil {
 			t.Errorf("Error: created testdb file was not closed during tearDownDatabase: %v", err)
 		}
 	}
 }
 
+func TestOpen(t *testing.T) {
+	tests := []struct {
+		name            string
+		options         string // after the path of a SQLite file
+		bFileURI        bool   // the path is a file: URI
+		wantBusyTimeout int    // checks that the options already there were read
+		wantErr         bool
+	}{
+		{name: "A path", wantBusyTimeout: 5000}, // the driver's default
+		{name: "A path with an option", options: "?_busy_timeout=1234", wantBusyTimeout: 1234},
+		{name: "A file URI with options", options: "?mode=rwc&_busy_timeout=1234", bFileURI: true, wantBusyTimeout: 1234},
+		{name: "Foreign keys stay on when turned off", options: "?_foreign_keys=off&_busy_timeout=1234", wantBusyTimeout: 1234},
+		{name: "Foreign keys stay on when turned off by their other name", options: "?_fk=0", wantBusyTimeout: 5000},
+		{name: "Options that are not a query string", options: "?_busy_timeout=%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsn := filepath.Join(t.TempDir(), testDbName) + tt.options
+			if tt.bFileURI {
+				dsn = "file:" + dsn
+			}
+			testSample, err := Open(dsn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Open(%s) error = %v, wantErr %v", dsn, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer testSample.Close()
+			var foreignKeys, busyTimeout int
+			if err = testSample.DB.QueryRow(`pragma foreign_keys`).Scan(&foreignKeys); err != nil {
+				t.Fatalf("Error reading pragma foreign_keys: %v", err)
+			}
+			if err = testSample.DB.QueryRow(`pragma busy_timeout`).Scan(&busyTimeout); err != nil {
+				t.Fatalf("Error reading pragma busy_timeout: %v", err)
+			}
+			if foreignKeys != 1 || busyTimeout != tt.wantBusyTimeout {
+				t.Errorf("Open(%s) foreign_keys = %d, busy_timeout = %d, want 1 and %d", dsn, foreignKeys, busyTimeout, tt.wantBusyTimeout)
+			}
+		})
+	}
+}
+
 func TestCreateDatabase(t *testing.T) {
+	if os.Getenv(testPostgresEnvVar) != "" {
+		t.Skip("the stored hash is of the SQLite file")
+	}
 	testDbPath := fmt.Sprintf("%s\\%s", t.TempDir(), testDbName)
 	testSample, tearDownDatabaseModel := setUpDatabase(t, testDbPath)
 	defer tearDownDatabaseModel(t)
@@ -480,7 +770,7 @@ func TestCreateDatabase(t *testing.T) {
 	if _, err := io.Copy(h, f); err != nil {
 		t.Errorf("Error while hashing testdb file %v", err)
 	}
-	if hex.EncodeToString(h.Sum(nil)) != "24b43ab7681e0e1a19270304241b531016ebf62b8858f9ef387bc7b9421a0543" {
+	if hex.EncodeToString(h.Sum(nil)) != "d60566ec6f4859569835aee61e17433a3a7850ee5391e285d6a20a77c2c1bd29" {
 		t.Errorf("Error: test testdb file does not match stored hash value. Computed hash: %x", h.Sum(nil))
 	}
 	if err = f.Close(); err != nil {
@@ -488,6 +778,564 @@ func TestCreateDatabase(t *testing.T) {
 	}
 }
 
+func TestMigrateDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied int // migrations already run on the database, without a SchemaVersion table, as in databases made before it existed
+	}{
+		{name: "Create an empty database", applied: 0},
+		{name: "Upgrade a database with the original schema", applied: 1},
+		{name: "Upgrade a database with time slots and roles", applied: 3},
+		{name: "Record the version of the newest database made before SchemaVersion", applied: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testSample, tearDownDatabaseModel := setUpDatabase(t, fmt.Sprintf("%s\\%s", t.TempDir(), testDbName))
+			defer tearDownDatabaseModel(t)
+			err := testSample.inTransaction(func(txModel VSAModel) error {
+				for _, migration := range schemaMigrations[:tt.applied] {
+					if err := migration.Up(txModel.querier()); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Error setting up test (running migrations failed): %v", err)
+			}
+			savedSchedule := schedule{ScheduleName: "test1", ShiftsOff: 1, VolunteersPerShift: 1, StartDate: "2024-01-01", EndDate: "2024-02-01"}
+			if tt.applied > 0 { // dates were DateIDs into the Dates table then, which migration 1 filled from 2023-01-01
+				legacyRows := `
+				insert into Schedules (ScheduleName, ShiftsOff, VolunteersPerShift, User, StartDate, EndDate) values ("test1", 1, 1, "Seth", 366, 397);
+				insert into Volunteers (VolunteerName, User) values ("Tim", "Seth");
+				insert into VolunteersForSchedule (User, Schedule, Volunteer) values ("Seth", 1, 1);
+				insert into UnavailabilitiesForSchedule (User, VolunteerForSchedule, Date) values ("Seth", 1, 380);
+				insert into scheduledVolunteersOnDates (User, VolunteerForSchedule, Date) values ("Seth", 1, 366);
+				`
+				if _, err := testSample.querier().Exec(legacyRows); err != nil {
+					t.Fatalf("Error setting up test (inserting rows with DateIDs failed): %v", err)
+				}
+			}
+			from, to, err := testSample.MigrateDatabase()
+			if err != nil || from != tt.applied || to != LatestSchemaVersion() {
+				t.Errorf("got from %d, to %d, error `%v`, want from %d, to %d", from, to, err, tt.applied, LatestSchemaVersion())
+			}
+			if version, err := testSample.RequestSchemaVersion(); err != nil || version != LatestSchemaVersion() {
+				t.Errorf("got schema version %d (error: `%v`), want %d", version, err, LatestSchemaVersion())
+			}
+			if tt.applied > 0 {
+				ans, err := testSample.RequestSchedule("Seth", schedule{ScheduleName: savedSchedule.ScheduleName})
+				if err != nil || ans.StartDate != savedSchedule.StartDate || ans.EndDate != savedSchedule.EndDate {
+					t.Errorf("got %+v, error `%v` looking up the schedule saved before migrating, want dates %s to %s", ans, err, savedSchedule.StartDate, savedSchedule.EndDate)
+				}
+			}
+			if tt.applied > 0 {
+				ufs, err := testSample.RequestUFS("Seth", []unavailabilityForSchedule{{VolunteerForSchedule: 1}})
+				if err != nil || len(ufs) != 1 || ufs[0].Date != "2024-01-15" {
+					t.Errorf("got %+v, error `%v` for the unavailability saved before migrating, want the date 2024-01-15", ufs, err)
+				}
+				svod, err := testSample.RequestSVOD("Seth", []scheduledVolunteerOnDate{{VolunteerForSchedule: 1}})
+				if err != nil || len(svod) != 1 || svod[0].Date != "2024-01-01" {
+					t.Errorf("got %+v, error `%v` for the scheduled date saved before migrating, want the date 2024-01-01", svod, err)
+				}
+			}
+			if bHasDates, err := testSample.tableExists("Dates"); err != nil || bHasDates {
+				t.Errorf("got Dates table: %t (error: `%v`), want it dropped", bHasDates, err)
+			}
+			// the new tables have to be usable, not just present
+			if err := testSample.CreateRoles("Seth", []role{{RoleName: "Usher"}}); err != nil {
+				t.Errorf("got error `%v` creating a role after migrating", err)
+			}
+			if from, to, err = testSample.MigrateDatabase(); err != nil || from != to {
+				t.Errorf("got from %d, to %d, error `%v` migrating a second time, want nothing to run", from, to, err)
+			}
+			if err = testSample.CreateDatabase(); err == nil {
+				t.Errorf("got no error from CreateDatabase on a database that already has a schema")
+			}
+		})
+	}
+	t.Run("Refuse a database from a newer build", func(t *testing.T) {
+		testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+		defer tearDownDatabaseModel(t)
+		if err := testSample.updateSchemaVersion(LatestSchemaVersion() + 1); err != nil {
+			t.Fatalf("Error setting up test (updateSchemaVersion failed): %v", err)
+		}
+		if _, _, err := testSample.MigrateDatabase(); err == nil {
+			t.Errorf("got no error for schema version %d", LatestSchemaVersion()+1)
+		}
+	})
+}
+
+func TestShiftKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ShiftKey
+		wantErr bool
+	}{
+		{name: "Date only", input: "2024-01-07", want: ShiftKey{Date: "2024-01-07"}},
+		{name: "Date and time slot", input: "2024-01-07|8am", want: ShiftKey{Date: "2024-01-07", TimeSlot: "8am"}},
+		{name: "Time slot with spaces", input: "2024-01-07|Evening service", want: ShiftKey{Date: "2024-01-07", TimeSlot: "Evening service"}},
+		{name: "Fail with malformed date", input: "1/7/2024|8am", wantErr: true},
+		{name: "Date, time slot, and role", input: "2024-01-07|8am|Usher", want: ShiftKey{Date: "2024-01-07", TimeSlot: "8am", Role: "Usher"}},
+		{name: "Date and role", input: "2024-01-07||Sound tech", want: ShiftKey{Date: "2024-01-07", Role: "Sound tech"}},
+		{name: "Fail with too many separators", input: "2024-01-07|8am|Usher|extra", wantErr: true},
+		{name: "Fail with an empty role", input: "2024-01-07|8am|", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := ShiftKey{}.FromString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if ans != tt.want {
+				t.Errorf("got %+v, want %+v", ans, tt.want)
+			}
+			if !tt.wantErr && ans.ToString() != tt.input {
+				t.Errorf("got %s from ToString, want %s", ans.ToString(), tt.input)
+			}
+		})
+	}
+}
+
+func TestUnavailabilityRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       UnavailabilityRule
+		wantString string
+		wantErr    bool
+	}{
+		{name: "First weekday of the month", input: "1st Sunday", want: UnavailabilityRule{Kind: RuleWeekdayOfMonth, Weekday: "Sunday", Ordinal: 1}, wantString: "1st Sunday"},
+		{name: "Last weekday of the month spelled in lower case", input: "last sunday", want: UnavailabilityRule{Kind: RuleWeekdayOfMonth, Weekday: "Sunday", Ordinal: -1}, wantString: "last Sunday"},
+		{name: "Spelled out ordinal", input: "Third Wednesday", want: UnavailabilityRule{Kind: RuleWeekdayOfMonth, Weekday: "Wednesday", Ordinal: 3}, wantString: "3rd Wednesday"},
+		{name: "Month", input: "july", want: UnavailabilityRule{Kind: RuleMonth, Month: "July"}, wantString: "July"},
+		{name: "Date range", input: "2024-07-01 to 2024-07-14", want: UnavailabilityRule{Kind: RuleDateRange, Start: "2024-07-01", End: "2024-07-14"}, wantString: "2024-07-01 to 2024-07-14"},
+		{name: "Every N weeks", input: "every 3 weeks from 2024-01-07", want: UnavailabilityRule{Kind: RuleEveryNWeeks, Start: "2024-01-07", Weeks: 3}, wantString: "every 3 weeks from 2024-01-07"},
+		{name: "Every other week", input: "Every other week from 2024-01-07", want: UnavailabilityRule{Kind: RuleEveryNWeeks, Start: "2024-01-07", Weeks: 2}, wantString: "every 2 weeks from 2024-01-07"},
+		{name: "Fail with a sixth weekday", input: "6th Sunday", wantErr: true},
+		{name: "Fail with a misspelled weekday", input: "1st Sundy", wantErr: true},
+		{name: "Fail with a date range that ends before it starts", input: "2024-07-14 to 2024-07-01", wantErr: true},
+		{name: "Fail with zero weeks", input: "every 0 weeks from 2024-01-07", wantErr: true},
+		{name: "Fail with a single date", input: "2024-01-07", wantErr: true},
+		{name: "Fail with an empty rule", input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := UnavailabilityRule{}.FromString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if ans != tt.want {
+				t.Errorf("got %+v, want %+v", ans, tt.want)
+			}
+			if !tt.wantErr && ans.ToString() != tt.wantString {
+				t.Errorf("got %s from ToString, want %s", ans.ToString(), tt.wantString)
+			}
+		})
+	}
+}
+
+func TestVolunteerPreference(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       VolunteerPreference
+		wantString string
+		wantErr    bool
+	}{
+		{name: "Weekday", input: "saturday", want: VolunteerPreference{Kind: PreferenceWeekday, Weekday: "Saturday", Weight: 1}, wantString: "Saturday"},
+		{name: "Date with a weight", input: "2024-03-31 X3", want: VolunteerPreference{Kind: PreferenceDate, Date: "2024-03-31", Weight: 3}, wantString: "2024-03-31 x3"},
+		{name: "Max shifts", input: "At most 2 shifts", want: VolunteerPreference{Kind: PreferenceMaxShifts, MaxShifts: 2, Weight: 1}, wantString: "at most 2 shifts"},
+		{name: "Max shifts in the singular", input: "at most 1 shifts x2", want: VolunteerPreference{Kind: PreferenceMaxShifts, MaxShifts: 1, Weight: 2}, wantString: "at most 1 shift x2"},
+		{name: "Weight of 1 is left out", input: "Sunday x1", want: VolunteerPreference{Kind: PreferenceWeekday, Weekday: "Sunday", Weight: 1}, wantString: "Sunday"},
+		{name: "Fail with a zero weight", input: "Sunday x0", wantErr: true},
+		{name: "Fail with a misspelled weekday", input: "Sundy", wantErr: true},
+		{name: "Fail with negative max shifts", input: "at most -1 shifts", wantErr: true},
+		{name: "Fail with a date range", input: "2024-07-01 to 2024-07-14", wantErr: true},
+		{name: "Fail with only a weight", input: "x2", wantErr: true},
+		{name: "Fail with an empty preference", input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := VolunteerPreference{}.FromString(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if ans != tt.want {
+				t.Errorf("got %+v, want %+v", ans, tt.want)
+			}
+			if !tt.wantErr && ans.ToString() != tt.wantString {
+				t.Errorf("got %s from ToString, want %s", ans.ToString(), tt.wantString)
+			}
+		})
+	}
+}
+
+func TestUnavailabilityRuleMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want []string // among the Sundays from 2024-06-02 through 2024-07-28
+	}{
+		{name: "1st Sunday", rule: "1st Sunday", want: []string{"2024-06-02", "2024-07-07"}},
+		{name: "last Sunday", rule: "last Sunday", want: []string{"2024-06-30", "2024-07-28"}},
+		{name: "5th Sunday", rule: "5th Sunday", want: []string{"2024-06-30"}},
+		{name: "1st Monday", rule: "1st Monday", want: []string{}},
+		{name: "Month", rule: "July", want: []string{"2024-07-07", "2024-07-14", "2024-07-21", "2024-07-28"}},
+		{name: "Date range", rule: "2024-06-10 to 2024-06-23", want: []string{"2024-06-16", "2024-06-23"}},
+		{name: "Every other week", rule: "every 2 weeks from 2024-06-12", want: []string{"2024-06-16", "2024-06-30", "2024-07-14", "2024-07-28"}},
+		{name: "Every 4 weeks", rule: "every 4 weeks from 2024-06-09", want: []string{"2024-06-09", "2024-07-07"}},
+	}
+	sundays := []time.Time{}
+	for day := time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC); day.Month() <= time.July; day = day.AddDate(0, 0, 7) {
+		sundays = append(sundays, day)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := UnavailabilityRule{}.FromString(tt.rule)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			ans := []string{}
+			for _, sunday := range sundays {
+				if rule.Matches(sunday) {
+					ans = append(ans, sunday.Format("2006-01-02"))
+				}
+			}
+			if !slices.Equal(ans, tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(q *sqlQuery)
+		wantText string
+		wantArgs []any
+	}{
+		{name: "No matches leaves the query alone", build: func(q *sqlQuery) { q.addMatchAny(" and ", []sqlMatch{}) }, wantText: `select * from Volunteers where User = ?`, wantArgs: []any{"Seth"}},
+		{name: "One match", build: func(q *sqlQuery) {
+			var match sqlMatch
+			match.equals("VolunteerName", `O"Brien`)
+			q.addMatchAny(" and ", []sqlMatch{match})
+		}, wantText: `select * from Volunteers where User = ? and ((VolunteerName = ?))`, wantArgs: []any{"Seth", `O"Brien`}},
+		{name: "Several matches", build: func(q *sqlQuery) {
+			var first, second sqlMatch
+			first.equals("VolunteerID", 1)
+			second.equals("VolunteerName", "Bill")
+			second.equals("User", "Seth")
+			q.addMatchAny(" and ", []sqlMatch{first, second})
+		}, wantText: `select * from Volunteers where User = ? and ((VolunteerID = ?) or (VolunteerName = ? and User = ?))`, wantArgs: []any{"Seth", 1, "Bill", "Seth"}},
+		{name: "In list", build: func(q *sqlQuery) { q.addIn(" and ", "VolunteerID", []int{3, 5}) }, wantText: `select * from Volunteers where User = ? and VolunteerID in (?, ?)`, wantArgs: []any{"Seth", 3, 5}},
+		{name: "Empty in list matches nothing", build: func(q *sqlQuery) { q.addIn(" and ", "VolunteerID", []int{}) }, wantText: `select * from Volunteers where User = ? and false`, wantArgs: []any{"Seth"}},
+		{name: "Added text", build: func(q *sqlQuery) {
+			q.add(` and VolunteerName = ? order by VolunteerID`, `'); drop table Volunteers; --`)
+		},
+			wantText: `select * from Volunteers where User = ? and VolunteerName = ? order by VolunteerID`, wantArgs: []any{"Seth", `'); drop table Volunteers; --`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newSQLQuery(`select * from Volunteers where User = ?`, "Seth")
+			tt.build(&q)
+			if q.text != tt.wantText || !slices.Equal(q.args, tt.wantArgs) {
+				t.Errorf("got `%s` with %v, want `%s` with %v", q.text, q.args, tt.wantText, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect dialect
+		input   string
+		want    string
+	}{
+		{name: "SQLite statements are left alone", dialect: sqliteDialect{}, input: `select * from Volunteers where User = ? and VolunteerName = "Tim"`, want: `select * from Volunteers where User = ? and VolunteerName = "Tim"`},
+		{name: "Number placeholders and quote the User column", dialect: postgresDialect{}, input: `select * from Volunteers where User = ? and VolunteerID in (?, ?)`, want: `select * from Volunteers where "User" = $1 and VolunteerID in ($2, $3)`},
+		{name: "Leave names that only contain User alone", dialect: postgresDialect{}, input: `insert into Users (UserName) values (?)`, want: `insert into Users (UserName) values ($1)`},
+		{name: "Turn double-quoted literals into single-quoted ones", dialect: postgresDialect{}, input: `insert into Users (UserName) values ("Seth"), ("O'Brien"), ("Say ""hi""")`, want: `insert into Users (UserName) values ('Seth'), ('O''Brien'), ('Say "hi"')`},
+		{name: "Leave single-quoted literals alone", dialect: postgresDialect{}, input: `select 'User?', 'it''s' where User = ?`, want: `select 'User?', 'it''s' where "User" = $1`},
+		{name: "Leave ? inside literals out of the numbering", dialect: postgresDialect{}, input: `select 'Who?', "What?", 'it''s ?' from Users where UserName = ? and Password = ?`, want: `select 'Who?', 'What?', 'it''s ?' from Users where UserName = $1 and Password = $2`},
+		{name: "Keep doubled single quotes inside double-quoted literals", dialect: postgresDialect{}, input: `insert into Volunteers (VolunteerName) values ("it''s")`, want: `insert into Volunteers (VolunteerName) values ('it''''s')`},
+		{name: "Only quote the User column among names containing User", dialect: postgresDialect{}, input: `select CalendarFeeds.User, UserName, Users.UserName, User_2, CurrentUser from Users join CalendarFeeds on CalendarFeeds.User = Users.UserName`,
+			want: `select CalendarFeeds."User", UserName, Users.UserName, User_2, CurrentUser from Users join CalendarFeeds on CalendarFeeds."User" = Users.UserName`},
+		{name: "Rebind a session lookup", dialect: postgresDialect{}, input: `select User from Sessions where TokenHash = ? and Expires > ?`, want: `select "User" from Sessions where TokenHash = $1 and Expires > $2`},
+		{name: "Rebind a match built by addMatchAny", dialect: postgresDialect{}, input: `select * from Volunteers where User = ? and ((VolunteerID = ?) or (VolunteerName = ? and User = ?))`,
+			want: `select * from Volunteers where "User" = $1 and ((VolunteerID = $2) or (VolunteerName = $3 and "User" = $4))`},
+		{name: "Rebind the roles of organization members", dialect: postgresDialect{}, input: `Role text not null check (Role in ("viewer", "editor", "owner")),`, want: `Role text not null check (Role in ('viewer', 'editor', 'owner')),`},
+		{name: "Translate the schema", dialect: postgresDialect{}, input: `create table Users (
+			UserName text primary key,
+			Password blob(64)
+		) without rowid;
+		create table Pairings (
+			PFSID integer primary key autoincrement,
+			User text,
+			Pairing text not null check (Pairing in ("together", "apart")),
+			TimeSlot text not null default "",
+			foreign key (User) references Users(UserName)
+		);`, want: `create table Users (
+			UserName text primary key,
+			Password bytea
+		);
+		create table Pairings (
+			PFSID integer primary key generated by default as identity,
+			"User" text,
+			Pairing text not null check (Pairing in ('together', 'apart')),
+			TimeSlot text not null default '',
+			foreign key ("User") references Users(UserName)
+		);`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.rebind(tt.input); got != tt.want {
+				t.Errorf("got `%s`, want `%s`", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingDialect runs statements on the database's own dialect and remembers the SQLite text of each one, so TestRebindStatements can check what postgresDialect makes of
+// the statements vsadb really sends.
+type recordingDialect struct {
+	dialect
+	statements *[]string
+}
+
+func (d recordingDialect) rebind(query string) string {
+	*d.statements = append(*d.statements, query)
+	return d.dialect.rebind(query)
+}
+
+// errRecorded is what recordingQuerier returns from Prepare, which stops a migration right after recording the statement it would have prepared.
+var errRecorded = errors.New("recorded")
+
+// recordingQuerier remembers the statements a migration runs without running them, so the migrations for either database can be recorded on any database.
+type recordingQuerier struct {
+	statements *[]string
+}
+
+func (rq recordingQuerier) Exec(query string, args ...any) (sql.Result, error) {
+	*rq.statements = append(*rq.statements, query)
+	return nil, nil
+}
+
+func (rq recordingQuerier) Prepare(query string) (*sql.Stmt, error) {
+	*rq.statements = append(*rq.statements, query)
+	return nil, errRecorded
+}
+
+func (rq recordingQuerier) Query(query string, args ...any) (*sql.Rows, error) {
+	*rq.statements = append(*rq.statements, query)
+	return nil, errRecorded
+}
+
+func (rq recordingQuerier) QueryRow(query string, args ...any) *sql.Row {
+	*rq.statements = append(*rq.statements, query)
+	return nil
+}
+
+// postgresProblems lists what in a rebound statement PostgreSQL would reject or read differently from SQLite: placeholders that are not $1, $2, ... in order, double quotes around
+// anything but the User column, a User column left unquoted, and SQLite-only syntax. It returns the number of placeholders too.
+func postgresProblems(query string) (problems []string, placeholders int) {
+	sqliteOnly := []string{"autoincrement", "rowid", "blob", "printf", "sqlite_master", "pragma", "glob", "ifnull"}
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'':
+			end := i + 1
+			for end < len(query) && (query[end] != '\'' || end+1 < len(query) && query[end+1] == '\'') {
+				if query[end] == '\'' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				problems = append(problems, fmt.Sprintf("unterminated literal %s", query[i:]))
+			}
+			i = end + 1
+		case c == '"':
+			if !strings.HasPrefix(query[i:], `"User"`) {
+				problems = append(problems, fmt.Sprintf("double quotes at %q", query[i:min(i+20, len(query))]))
+				i++
+				continue
+			}
+			i += len(`"User"`)
+		case c == '?':
+			problems = append(problems, fmt.Sprintf("unnumbered placeholder at %q", query[i:min(i+20, len(query))]))
+			i++
+		case c == '$':
+			end := i + 1
+			for end < len(query) && '0' <= query[end] && query[end] <= '9' {
+				end++
+			}
+			placeholders++
+			if query[i+1:end] != strconv.Itoa(placeholders) {
+				problems = append(problems, fmt.Sprintf("placeholder %s where $%d belongs", query[i:end], placeholders))
+			}
+			i = end
+		case c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			end := i
+			for end < len(query) && (query[end] == '_' || 'a' <= query[end] && query[end] <= 'z' || 'A' <= query[end] && query[end] <= 'Z' || '0' <= query[end] && query[end] <= '9') {
+				end++
+			}
+			if word := query[i:end]; strings.EqualFold(word, "user") || slices.Contains(sqliteOnly, strings.ToLower(word)) {
+				problems = append(problems, fmt.Sprintf("%s is not valid in PostgreSQL here", word))
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return problems, placeholders
+}
+
+// sqlitePlaceholders counts the ? of a SQLite statement that are placeholders rather than part of a string literal.
+func sqlitePlaceholders(query string) int {
+	count := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		switch {
+		case quote != 0 && query[i] == quote:
+			quote = 0 // a doubled quote closes and reopens the literal, which counts the same
+		case quote != 0:
+		case query[i] == '\'' || query[i] == '"':
+			quote = query[i]
+		case query[i] == '?':
+			count++
+		}
+	}
+	return count
+}
+
+func TestRebindStatements(t *testing.T) {
+	// the migrations, as they run on PostgreSQL
+	statements := []string{postgresDialect{}.tableExistsQuery()}
+	for _, migration := range schemaMigrations {
+		up := migration.Up
+		if migration.UpPostgres != nil {
+			up = migration.UpPostgres
+		}
+		if err := up(recordingQuerier{&statements}); err != nil && !errors.Is(err, errRecorded) {
+			t.Errorf("got error `%v` recording migration %d", err, migration.Version)
+		}
+	}
+	migrationStatements := len(statements)
+	// every statement the Store methods send while saving, reading, and deleting a schedule that uses every feature
+	model, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	recorder := VSAModel{DB: model.DB, dialect: recordingDialect{model.sqlDialect(), &statements}}
+	input := SendReceiveDataStruct{
+		ScheduleName:                     "Q1 'early' \"draft\"?",
+		ShiftsOff:                        1,
+		VolunteersPerShift:               2,
+		StartDate:                        "2024-01-01",
+		EndDate:                          "2024-02-01",
+		WeekdaysForSchedule:              []string{"Sunday", "Wednesday"},
+		TimeSlotsForSchedule:             map[string][]TimeSlot{"Sunday": {{"8am", 2}, {"11am", 3}}},
+		RolesForSchedule:                 []RoleRequirement{{"Usher", 1}},
+		VolunteerRoleData:                map[string][]string{"Tim": {"Usher"}},
+		VolunteerPairingData:             []VolunteerPairing{{Volunteer: "Tim", PairedVolunteer: "Bill", Pairing: PairingApart}},
+		VolunteerUnavailabilityData:      map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}, "Jack": {}},
+		VolunteerUnavailabilityRuleData:  map[string][]string{"Bill": {"1st Sunday"}},
+		VolunteerUnavailabilityRangeData: map[string][]DateRange{"Jack": {{Start: "2024-01-20", End: "2024-01-25"}}},
+		VolunteerPreferenceData:          map[string][]string{"Jack": {"Sunday x2"}},
+		VolunteerScheduledData:           map[string][]string{"Tim": {"2024-01-07|8am|Usher"}, "Bill": {"2024-01-10"}},
+		VolunteerContactData:             map[string]VolunteerContact{"Tim": {Email: "tim@example.com"}},
+	}
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"RequestSchemaVersion", func() error { _, err := recorder.RequestSchemaVersion(); return err }},
+		{"updateSchemaVersion", func() error { return recorder.updateSchemaVersion(LatestSchemaVersion()) }},
+		{"CreateUser", func() error { return recorder.CreateUser("Ann", "correct horse") }},
+		{"AuthenticateUser", func() error { return recorder.AuthenticateUser("Ann", "correct horse") }},
+		{"RequestUnclaimedUsers", func() error { _, err := recorder.RequestUnclaimedUsers(); return err }},
+		{"ClaimUser", func() error { return recorder.ClaimUser("Seth", "battery staple") }},
+		{"EnsureUser", func() error { return recorder.EnsureUser("Proxied") }},
+		{"Sessions", func() error {
+			token, err := recorder.CreateSession("Ann")
+			if err == nil {
+				_, err = recorder.RequestSessionUser(token)
+			}
+			if err == nil {
+				err = recorder.DeleteSession(token)
+			}
+			return err
+		}},
+		{"Organizations", func() error {
+			err := recorder.CreateOrganization("Seth", "First Church")
+			if err == nil {
+				err = recorder.SaveMembership("First Church", "Ann", RoleEditor)
+			}
+			if err == nil {
+				_, err = recorder.RequestWorkspaces("Ann")
+			}
+			if err == nil {
+				_, err = recorder.RequestWorkspaceRole("Ann", "First Church")
+			}
+			if err == nil {
+				_, err = recorder.RequestMembers("First Church")
+			}
+			if err == nil {
+				err = recorder.DeleteMembership("First Church", "Ann")
+			}
+			return err
+		}},
+		{"RecieveAndStoreData a new schedule", func() error { return recorder.RecieveAndStoreData("Seth", input, true) }},
+		{"RecieveAndStoreData changes", func() error {
+			changed := input
+			changed.TimeSlotsForSchedule = map[string][]TimeSlot{"Sunday": {{"9am", 2}}}
+			changed.RolesForSchedule = []RoleRequirement{}
+			changed.VolunteerUnavailabilityData = map[string][]string{"Tim": {}, "Bill": {"2024-01-03"}, "Jack": {}}
+			changed.VolunteerScheduledData = map[string][]string{"Tim": {"2024-01-07|9am"}}
+			return recorder.RecieveAndStoreData("Seth", changed, false)
+		}},
+		{"FetchAndSendScheduleData", func() error { _, err := recorder.FetchAndSendScheduleData("Seth", input.ScheduleName); return err }},
+		{"SendScheduleNames", func() error { _, err := recorder.SendScheduleNames("Seth", true); return err }},
+		{"Calendar feeds", func() error {
+			token, err := recorder.EnsureCalendarFeed("Seth", input.ScheduleName, "Tim")
+			if err == nil {
+				_, err = recorder.RequestCalendarFeed(token)
+			}
+			if err == nil {
+				err = recorder.DeleteCalendarFeeds("Seth", input.ScheduleName)
+			}
+			return err
+		}},
+		{"RecieveAndDeleteData", func() error { return recorder.RecieveAndDeleteData("Seth", input) }},
+	}
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			t.Fatalf("got error `%v` from %s", err, step.name)
+		}
+	}
+	if len(statements)-migrationStatements < len(steps) {
+		t.Fatalf("recorded %d statements, want at least one for each of the %d steps", len(statements)-migrationStatements, len(steps))
+	}
+	t.Logf("recorded %d statements, %d of them from migrations", len(statements), migrationStatements)
+	checked := map[string]bool{(sqliteDialect{}).tableExistsQuery(): true} // recorded by RequestSchemaVersion, and replaced on PostgreSQL rather than rebound
+	for _, statement := range statements {
+		if checked[statement] {
+			continue
+		}
+		checked[statement] = true
+		rebound := postgresDialect{}.rebind(statement)
+		problems, placeholders := postgresProblems(rebound)
+		if want := sqlitePlaceholders(statement); placeholders != want {
+			problems = append(problems, fmt.Sprintf("%d placeholders, want %d", placeholders, want))
+		}
+		if len(problems) > 0 {
+			t.Errorf("rebind(`%s`) = `%s`, which has these problems: %s", statement, rebound, strings.Join(problems, "; "))
+		}
+	}
+}
+
 func TestRequestWeekday(t *testing.T) {
 	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
 	defer tearDownDatabaseModel(t)
@@ -549,71 +1397,461 @@ func TestRequestMonth(t *testing.T) {
 	}
 }
 
-func TestRequestDate(t *testing.T) {
+func TestCreateUser(t *testing.T) {
 	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
 	defer tearDownDatabaseModel(t)
-	var tests = []struct {
-		name  string
-		input date
-		want  date
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		wantErr  bool
 	}{
-		{name: "Get 9/9/2024", input: date{Month: 9, Day: 9, Year: 2024}, want: date{DateID: 618, Month: 9, Day: 9, Year: 2024, Weekday: "Monday"}},
-		{name: "Get DateID 618", input: date{DateID: 618}, want: date{DateID: 618, Month: 9, Day: 9, Year: 2024, Weekday: "Monday"}},
-		{name: "Fail to get DateID 6180000", input: date{DateID: 6180000}, want: date{}},
-		{name: "Get more than one Date", input: date{Month: 9}, want: date{}},
-		{name: "Don't provide any date fields", input: date{}, want: date{}},
+		{name: "Register a new user", userName: "Ann", password: "correct horse"},
+		{name: "Fail to register a user name that is taken", userName: "Ann", password: "another password", wantErr: true},
+		{name: "Fail to register as a user that has no password", userName: "Seth", password: "battery staple", wantErr: true},
+		{name: "Fail with a short password", userName: "Bob", password: "short", wantErr: true},
+		{name: "Fail with a password bcrypt would cut short", userName: "Bob", password: strings.Repeat("a", MaxPasswordLength+1), wantErr: true},
+		{name: "Fail with a blank user name", userName: "", password: "correct horse", wantErr: true},
+		{name: "Fail with a user name ending in a space", userName: "Bob ", password: "correct horse", wantErr: true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := testSample.RequestDate(tt.input)
-			checkResults(t, ans, tt.want, tt.input, err)
+			err := testSample.CreateUser(tt.userName, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
 		})
 	}
+	var storedPassword []byte
+	if err := testSample.querier().QueryRow(`select Password from Users where UserName = ?`, "Ann").Scan(&storedPassword); err != nil || strings.Contains(string(storedPassword), "correct horse") {
+		t.Errorf("got stored password %q (error: `%v`), want a hash", storedPassword, err)
+	}
+	if err := testSample.AuthenticateUser("Seth", "battery staple"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("got error `%v` signing in as Seth after the registration was refused, want ErrInvalidCredentials", err)
+	}
 }
 
-func TestRequestDates(t *testing.T) {
+func TestClaimUser(t *testing.T) {
 	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
 	defer tearDownDatabaseModel(t)
-	var tests = []struct {
-		name  string
-		input []date
-		want  []date
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	if err := testSample.EnsureUser("Proxied"); err != nil {
+		t.Fatalf("Error setting up test (EnsureUser failed): %v", err)
+	}
+	if err := testSample.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	if ans, err := testSample.RequestUnclaimedUsers(); err != nil || !slices.Equal(ans, []string{"Proxied", "Seth"}) {
+		t.Errorf("got unclaimed users %v (error: `%v`), want [Proxied Seth]", ans, err)
+	}
+	tests := []struct {
+		name     string
+		userName string
+		password string
+		wantErr  error
 	}{
-		{name: "Get 9/9/2024", input: []date{{Month: 9, Day: 9, Year: 2024}}, want: []date{{DateID: 618, Month: 9, Day: 9, Year: 2024, Weekday: "Monday"}}},
-		{name: "Get DateID 618", input: []date{{DateID: 618}}, want: []date{{DateID: 618, Month: 9, Day: 9, Year: 2024, Weekday: "Monday"}}},
-		{name: "Fail to get DateID 6180000", input: []date{{DateID: 6180000}}, want: []date{}},
-		{name: "Fail to get DateID 618 due to wrong day field", input: []date{{DateID: 618, Day: 1}}, want: []date{}},
-		{name: "Provide empty slice", input: []date{}, want: []date{}},
-		{name: "Get all Mondays in July 2024", input: []date{{Month: 7, Year: 2024, Weekday: "Monday"}}, want: []date{{DateID: 548, Month: 7, Day: 1, Year: 2024, Weekday: "Monday"}, {DateID: 555, Month: 7, Day: 8, Year: 2024, Weekday: "Monday"}, {DateID: 562, Month: 7, Day: 15, Year: 2024, Weekday: "Monday"}, {DateID: 569, Month: 7, Day: 22, Year: 2024, Weekday: "Monday"}, {DateID: 576, Month: 7, Day: 29, Year: 2024, Weekday: "Monday"}}},
-		{name: "Get DateID 618 and DateID 619", input: []date{{DateID: 618}, {DateID: 619}}, want: []date{{DateID: 618, Month: 9, Day: 9, Year: 2024, Weekday: "Monday"}, {DateID: 619, Month: 9, Day: 10, Year: 2024, Weekday: "Tuesday"}}},
+		{name: "Claim the user that owns schedules from before sign-in", userName: "Seth", password: "battery staple"},
+		{name: "Fail to claim a user twice", userName: "Seth", password: "another password", wantErr: ErrUserExists},
+		{name: "Fail to claim a user with a password", userName: "Ann", password: "another password", wantErr: ErrUserExists},
+		{name: "Fail to claim an organization", userName: "First Church", password: "another password", wantErr: ErrUserExists},
+		{name: "Fail to claim a user that does not exist", userName: "Bob", password: "another password", wantErr: ErrNotFound},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := testSample.RequestDates(tt.input)
-			checkResultsSlice(t, ans, tt.want, tt.input, err)
+			err := testSample.ClaimUser(tt.userName, tt.password)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("got error `%v`, want `%v`", err, tt.wantErr)
+			}
 		})
 	}
+	if err := testSample.AuthenticateUser("Seth", "battery staple"); err != nil {
+		t.Errorf("got error `%v` signing in as the claimed user", err)
+	}
+	if ans, err := testSample.RequestUnclaimedUsers(); err != nil || !slices.Equal(ans, []string{"Proxied"}) {
+		t.Errorf("got unclaimed users %v (error: `%v`) after claiming Seth, want [Proxied]", ans, err)
+	}
 }
 
-func TestCreateVolunteers(t *testing.T) {
-	env, tearDownEnvironment := setUpEnvironment(t)
-	defer tearDownEnvironment(t)
+func TestAuthenticateUser(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
 	tests := []struct {
-		name  string
-		input []volunteer
-		want  []volunteer
+		name     string
+		userName string
+		password string
+		wantErr  error
 	}{
-		{name: "Create volunteers from sampleVolunteers", input: sampleVolunteers, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Create volunteers from sampleVolunteers and one duplicate volunteer", input: append(sampleVolunteers, volunteer{VolunteerName: "Tim"}), want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Create volunteers but provide no volunteers", input: []volunteer{}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Create volunteers from sampleVolunteers but provide one empty volunteer", input: append(sampleVolunteers, volunteer{}), want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Only provide one empty volunteer", input: []volunteer{{}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Fail to provide VolunteerName", input: []volunteer{{User: "Anyone"}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
-		{name: "Fail by providing duplicate input", input: []volunteer{{VolunteerName: "Anyone"}, {User: "Doesn'tMatter", VolunteerName: "Anyone"}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Sign in with the right password", userName: "Ann", password: "correct horse"},
+		{name: "Fail with the wrong password", userName: "Ann", password: "Correct horse", wantErr: ErrInvalidCredentials},
+		{name: "Fail with an unknown user", userName: "Bob", password: "correct horse", wantErr: ErrInvalidCredentials},
+		{name: "Fail for a user without a password", userName: "Seth", password: "", wantErr: ErrInvalidCredentials},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CreateVolunteers(env.LoggedInUser, tt.input)
+			err := testSample.AuthenticateUser(tt.userName, tt.password)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("got error `%v`, want `%v`", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnsureUser(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	if err := testSample.CreateOrganization("Ann", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	tests := []struct {
+		name     string
+		userName string
+		wantErr  bool
+	}{
+		{name: "Add a user", userName: "Bob"},
+		{name: "Keep a user added before", userName: "Bob"},
+		{name: "Keep a registered user", userName: "Ann"},
+		{name: "Fail for an organization", userName: "First Church", wantErr: true},
+		{name: "Fail with a blank user name", userName: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testSample.EnsureUser(tt.userName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+		})
+	}
+	if err := testSample.AuthenticateUser("Ann", "correct horse"); err != nil {
+		t.Errorf("got error `%v` signing in after EnsureUser, want the password kept", err)
+	}
+	if err := testSample.AuthenticateUser("Bob", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("got error `%v` signing in as a user added by EnsureUser, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if _, err := testSample.querier().Exec(`insert into Sessions (TokenHash, User, Expires) values (?, ?, ?)`, hashSessionToken("expired"), "Seth", time.Now().Add(-time.Minute).Unix()); err != nil {
+		t.Fatalf("Error setting up test (inserting an expired session failed): %v", err)
+	}
+	token, err := testSample.CreateSession("Seth")
+	if err != nil {
+		t.Fatalf("Error setting up test (CreateSession failed): %v", err)
+	}
+	otherToken, err := testSample.CreateSession("Seth")
+	if err != nil || otherToken == token {
+		t.Fatalf("got token %q (error: `%v`) for a second session, want a new one", otherToken, err)
+	}
+	var sessionCount int
+	if err = testSample.querier().QueryRow(`select count(*) from Sessions`).Scan(&sessionCount); err != nil || sessionCount != 2 {
+		t.Errorf("got %d sessions (error: `%v`), want the expired one cleared out and 2 left", sessionCount, err)
+	}
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "Look up a session", token: token, want: "Seth"},
+		{name: "Fail to look up an unknown token", token: "not a token", wantErr: true},
+		{name: "Fail to look up the stored hash of a token", token: hashSessionToken(token), wantErr: true},
+		{name: "Fail to look up an expired session", token: "expired", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := testSample.RequestSessionUser(tt.token)
+			if ans != tt.want || (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, ErrNotFound)) {
+				t.Errorf("got %q, error `%v`, want %q, error: %t", ans, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if err = testSample.DeleteSession(token); err != nil {
+		t.Errorf("got error `%v` signing out", err)
+	}
+	if _, err = testSample.RequestSessionUser(token); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error `%v` looking up a deleted session, want ErrNotFound", err)
+	}
+	if ans, err := testSample.RequestSessionUser(otherToken); err != nil || ans != "Seth" {
+		t.Errorf("got %q, error `%v` for the session that was not signed out, want Seth", ans, err)
+	}
+}
+
+func TestCalendarFeeds(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	parameters := SendReceiveDataStruct{
+		ScheduleName:                "test1",
+		ShiftsOff:                   1,
+		VolunteersPerShift:          1,
+		StartDate:                   "2024-01-01",
+		EndDate:                     "2024-02-01",
+		WeekdaysForSchedule:         []string{"Sunday"},
+		VolunteerUnavailabilityData: map[string][]string{"Tim": {}, "Bill": {}},
+	}
+	if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, parameters, true); err != nil {
+		t.Fatalf("Error setting up test (RecieveAndStoreData failed): %v", err)
+	}
+	scheduleToken, err := env.Sample.EnsureCalendarFeed(env.LoggedInUser, "test1", "")
+	if err != nil {
+		t.Fatalf("Error setting up test (EnsureCalendarFeed failed): %v", err)
+	}
+	if again, err := env.Sample.EnsureCalendarFeed(env.LoggedInUser, "test1", ""); err != nil || again != scheduleToken {
+		t.Errorf("got token %q (error: `%v`) asking for the schedule feed again, want the same %q", again, err, scheduleToken)
+	}
+	volunteerToken, err := env.Sample.EnsureCalendarFeed(env.LoggedInUser, "test1", "Tim")
+	if err != nil || volunteerToken == scheduleToken {
+		t.Fatalf("got token %q (error: `%v`) for Tim's feed, want one of its own", volunteerToken, err)
+	}
+	if _, err = env.Sample.EnsureCalendarFeed(env.LoggedInUser, "missing", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error `%v` for the feed of a schedule that does not exist, want ErrNotFound", err)
+	}
+	tokenTests := []struct {
+		name          string
+		scheduleName  string
+		volunteerName string
+		want          string
+		wantErr       bool // wraps ErrNotFound
+	}{
+		{name: "Read the schedule feed", scheduleName: "test1", want: scheduleToken},
+		{name: "Read a volunteer feed", scheduleName: "test1", volunteerName: "Tim", want: volunteerToken},
+		{name: "Leave a volunteer feed that was never made", scheduleName: "test1", volunteerName: "Bill", wantErr: true},
+		{name: "Leave the same volunteer feed unmade when asked again", scheduleName: "test1", volunteerName: "Bill", wantErr: true},
+		{name: "Fail to read a feed of a schedule that does not exist", scheduleName: "missing", wantErr: true},
+	}
+	for _, tt := range tokenTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestCalendarFeedToken(env.LoggedInUser, tt.scheduleName, tt.volunteerName)
+			if ans != tt.want || (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, ErrNotFound)) {
+				t.Errorf("got %q, error `%v`, want %q, error: %t", ans, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	tests := []struct {
+		name    string
+		token   string
+		want    CalendarFeed
+		wantErr bool
+	}{
+		{name: "Look up a schedule feed", token: scheduleToken, want: CalendarFeed{Workspace: "Seth", ScheduleName: "test1"}},
+		{name: "Look up a volunteer feed", token: volunteerToken, want: CalendarFeed{Workspace: "Seth", ScheduleName: "test1", Volunteer: "Tim"}},
+		{name: "Fail to look up an unknown token", token: "not a token", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestCalendarFeed(tt.token)
+			if ans != tt.want || (err != nil) != tt.wantErr || (tt.wantErr && !errors.Is(err, ErrNotFound)) {
+				t.Errorf("got %+v, error `%v`, want %+v, error: %t", ans, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if err = env.Sample.DeleteCalendarFeeds(env.LoggedInUser, "test1"); err != nil {
+		t.Errorf("got error `%v` deleting the feeds", err)
+	}
+	if _, err = env.Sample.RequestCalendarFeed(volunteerToken); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error `%v` looking up a deleted feed, want ErrNotFound", err)
+	}
+	if newToken, err := env.Sample.EnsureCalendarFeed(env.LoggedInUser, "test1", ""); err != nil || newToken == scheduleToken {
+		t.Errorf("got token %q (error: `%v`) after deleting the feeds, want a new one", newToken, err)
+	}
+	if err = env.Sample.RecieveAndDeleteData(env.LoggedInUser, parameters); err != nil {
+		t.Errorf("got error `%v` deleting a schedule with a calendar feed", err)
+	}
+}
+
+func TestCreateOrganization(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	tests := []struct {
+		name             string
+		currentUser      string
+		organizationName string
+		wantErr          error
+	}{
+		{name: "Create an organization", currentUser: "Seth", organizationName: "First Church"},
+		{name: "Fail to create an organization that exists", currentUser: "Ann", organizationName: "First Church", wantErr: ErrUserExists},
+		{name: "Fail to create an organization named after a user", currentUser: "Seth", organizationName: "Ann", wantErr: ErrUserExists},
+		{name: "Fail with a blank organization name", currentUser: "Seth", organizationName: ""},
+		{name: "Fail with an organization name ending in a space", currentUser: "Seth", organizationName: "Second Church "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testSample.CreateOrganization(tt.currentUser, tt.organizationName)
+			wantErr := tt.wantErr != nil || strings.TrimSpace(tt.organizationName) != tt.organizationName || tt.organizationName == ""
+			if (err != nil) != wantErr || !errors.Is(err, tt.wantErr) && tt.wantErr != nil {
+				t.Errorf("got error `%v`, want error: %t (%v)", err, wantErr, tt.wantErr)
+			}
+		})
+	}
+	want := []Membership{{Organization: "First Church", Member: "Seth", Role: RoleOwner}}
+	if ans, err := testSample.RequestMembers("First Church"); err != nil || !reflect.DeepEqual(ans, want) {
+		t.Errorf("got members %v (error: `%v`), want %v", ans, err, want)
+	}
+	if err := testSample.CreateUser("First Church", "correct horse"); !errors.Is(err, ErrUserExists) {
+		t.Errorf("got error `%v` registering the name of an organization, want ErrUserExists", err)
+	}
+	if err := testSample.AuthenticateUser("First Church", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("got error `%v` signing in as an organization, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestSaveMembership(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	for _, userName := range []string{"Ann", "Bob"} {
+		if err := testSample.CreateUser(userName, "correct horse"); err != nil {
+			t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+		}
+	}
+	for _, organizationName := range []string{"First Church", "Food Bank"} {
+		if err := testSample.CreateOrganization("Seth", organizationName); err != nil {
+			t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+		}
+	}
+	tests := []struct {
+		name    string
+		member  string
+		role    string
+		wantErr error
+	}{
+		{name: "Add a viewer", member: "Ann", role: RoleViewer},
+		{name: "Add an editor", member: "Bob", role: RoleEditor},
+		{name: "Make a viewer an owner", member: "Ann", role: RoleOwner},
+		{name: "Let the first owner step down once there is another", member: "Seth", role: RoleEditor},
+		{name: "Fail to add someone who is not a user", member: "Carl", role: RoleViewer, wantErr: ErrNotFound},
+		{name: "Fail to add an organization as a member", member: "Food Bank", role: RoleViewer, wantErr: ErrNotFound},
+		{name: "Fail to demote the last owner", member: "Ann", role: RoleViewer, wantErr: ErrLastOwner},
+		{name: "Fail with a role that does not exist", member: "Bob", role: "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testSample.SaveMembership("First Church", tt.member, tt.role)
+			wantErr := tt.wantErr != nil || !slices.Contains(Roles, tt.role)
+			if (err != nil) != wantErr || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error `%v`, want error: %t (%v)", err, wantErr, tt.wantErr)
+			}
+		})
+	}
+	want := []Membership{
+		{Organization: "First Church", Member: "Ann", Role: RoleOwner},
+		{Organization: "First Church", Member: "Bob", Role: RoleEditor},
+		{Organization: "First Church", Member: "Seth", Role: RoleEditor},
+	}
+	if ans, err := testSample.RequestMembers("First Church"); err != nil || !reflect.DeepEqual(ans, want) {
+		t.Errorf("got members %v (error: `%v`), want %v", ans, err, want)
+	}
+	want = []Membership{
+		{Organization: "Seth", Member: "Seth", Role: RoleOwner},
+		{Organization: "First Church", Member: "Seth", Role: RoleEditor},
+		{Organization: "Food Bank", Member: "Seth", Role: RoleOwner},
+	}
+	if ans, err := testSample.RequestWorkspaces("Seth"); err != nil || !reflect.DeepEqual(ans, want) {
+		t.Errorf("got workspaces %v (error: `%v`), want %v", ans, err, want)
+	}
+}
+
+func TestRequestWorkspaceRole(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	if err := testSample.CreateOrganization("Seth", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	if err := testSample.SaveMembership("First Church", "Ann", RoleViewer); err != nil {
+		t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+	}
+	tests := []struct {
+		name        string
+		currentUser string
+		workspace   string
+		want        string
+		wantErr     error
+	}{
+		{name: "Own the user's own workspace", currentUser: "Ann", workspace: "Ann", want: RoleOwner},
+		{name: "Own a created organization", currentUser: "Seth", workspace: "First Church", want: RoleOwner},
+		{name: "View an organization", currentUser: "Ann", workspace: "First Church", want: RoleViewer},
+		{name: "Fail for another user's workspace", currentUser: "Ann", workspace: "Seth", wantErr: ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := testSample.RequestWorkspaceRole(tt.currentUser, tt.workspace)
+			if ans != tt.want || !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("got %q, error `%v`, want %q, error `%v`", ans, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+	if !RoleAllows(RoleOwner, RoleEditor) || !RoleAllows(RoleEditor, RoleEditor) || RoleAllows(RoleViewer, RoleEditor) || RoleAllows("", RoleViewer) {
+		t.Errorf("RoleAllows does not rank the roles viewer < editor < owner")
+	}
+}
+
+func TestDeleteMembership(t *testing.T) {
+	testSample, tearDownDatabaseModel := setUpDatabaseModel(t)
+	defer tearDownDatabaseModel(t)
+	if err := testSample.CreateUser("Ann", "correct horse"); err != nil {
+		t.Fatalf("Error setting up test (CreateUser failed): %v", err)
+	}
+	if err := testSample.CreateOrganization("Seth", "First Church"); err != nil {
+		t.Fatalf("Error setting up test (CreateOrganization failed): %v", err)
+	}
+	if err := testSample.SaveMembership("First Church", "Ann", RoleEditor); err != nil {
+		t.Fatalf("Error setting up test (SaveMembership failed): %v", err)
+	}
+	tests := []struct {
+		name    string
+		member  string
+		wantErr error
+	}{
+		{name: "Remove an editor", member: "Ann"},
+		{name: "Remove someone who is not a member", member: "Ann"},
+		{name: "Fail to remove the last owner", member: "Seth", wantErr: ErrLastOwner},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testSample.DeleteMembership("First Church", tt.member)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("got error `%v`, want `%v`", err, tt.wantErr)
+			}
+		})
+	}
+	want := []Membership{{Organization: "First Church", Member: "Seth", Role: RoleOwner}}
+	if ans, err := testSample.RequestMembers("First Church"); err != nil || !reflect.DeepEqual(ans, want) {
+		t.Errorf("got members %v (error: `%v`), want %v", ans, err, want)
+	}
+}
+
+func TestCreateVolunteers(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	tests := []struct {
+		name  string
+		input []volunteer
+		want  []volunteer
+	}{
+		{name: "Create volunteers from sampleVolunteers", input: sampleVolunteers, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Create volunteers from sampleVolunteers and one duplicate volunteer", input: append(sampleVolunteers, volunteer{VolunteerName: "Tim"}), want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Create volunteers but provide no volunteers", input: []volunteer{}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Create volunteers from sampleVolunteers but provide one empty volunteer", input: append(sampleVolunteers, volunteer{}), want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Only provide one empty volunteer", input: []volunteer{{}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Fail to provide VolunteerName", input: []volunteer{{User: "Anyone"}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+		{name: "Fail by providing duplicate input", input: []volunteer{{VolunteerName: "Anyone"}, {User: "Doesn'tMatter", VolunteerName: "Anyone"}}, want: simulateCreatedSampleVolunteers(env.LoggedInUser)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateVolunteers(env.LoggedInUser, tt.input)
 			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVolunteers, env.LoggedInUser, []volunteer{})
 		})
 	}
@@ -759,179 +1997,447 @@ func TestCleanOrphanedVolunteers(t *testing.T) {
 	}
 }
 
-func TestCreateSchedulesExtended(t *testing.T) {
+func TestCreateRoles(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
-	generatedSampleSchedules := generateSampleSchedules(env.Sample)
-	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
 	tests := []struct {
 		name  string
-		input []schedule
-		want  []schedule
+		input []role
+		want  []role
 	}{
-		{name: "Create schedules from sampleSchedules", input: generatedSampleSchedules, want: simulatedCreatedSampleSchedules},
+		{name: "Create roles from sampleRoles", input: sampleRoles, want: simulateCreatedSampleRoles(env.LoggedInUser)},
+		{name: "Fail to create a duplicate role", input: []role{{RoleName: "Usher"}}, want: simulateCreatedSampleRoles(env.LoggedInUser)},
+		{name: "Fail by providing one empty role", input: []role{{}}, want: simulateCreatedSampleRoles(env.LoggedInUser)},
+		{name: "Fail to provide RoleName", input: []role{{User: "Anyone"}}, want: simulateCreatedSampleRoles(env.LoggedInUser)},
+		{name: "Fail by providing a RoleName with the ShiftKeySeparator", input: []role{{RoleName: "Usher|lead"}}, want: simulateCreatedSampleRoles(env.LoggedInUser)},
+		{name: "Fail by providing duplicate input", input: []role{{RoleName: "Reader"}, {User: "Doesn'tMatter", RoleName: "Reader"}}, want: simulateCreatedSampleRoles(env.LoggedInUser)},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, tt.input, true)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestSchedules, env.LoggedInUser, []schedule{})
+			err := env.Sample.CreateRoles(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRoles, env.LoggedInUser, []role{})
 		})
 	}
 }
 
-func TestCreateSchedules(t *testing.T) {
+func TestRequestRoles(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
-	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
-	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	err := env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleRoles := simulateCreatedSampleRoles(env.LoggedInUser)
 	tests := []struct {
 		name  string
-		input []schedule
-		want  []schedule
+		input []role
+		want  []role
 	}{
-		{name: "Create schedules from sampleSchedules", input: generatedSampleSchedules, want: simulatedCreatedSampleSchedules},
-		{name: "Fail to create schedules from duplicate schedule", input: []schedule{generatedSampleSchedules[0]}, want: simulatedCreatedSampleSchedules},
-		{name: "Create schedules but provide no schedule structs", input: []schedule{}, want: simulatedCreatedSampleSchedules},
-		{name: "Only provide one empty schedule struct", input: []schedule{{}}, want: simulatedCreatedSampleSchedules},
-		{name: "Do not provide ScheduleName", input: []schedule{
-			{
-				ShiftsOff:          4,
-				VolunteersPerShift: 4,
-				StartDate:          Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-				EndDate:            Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-			}},
-			want: simulatedCreatedSampleSchedules},
-		{name: "Provide negative ShiftsOff", input: []schedule{
-			{
-				ScheduleName:       "test4",
-				VolunteersPerShift: 4,
-				StartDate:          Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-				EndDate:            Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-			}},
-			want: simulatedCreatedSampleSchedules},
-		{name: "Do not provide VolunteersPerShift", input: []schedule{
-			{
-				ScheduleName: "test4",
-				ShiftsOff:    4,
-				StartDate:    Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-				EndDate:      Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-			}},
-			want: simulatedCreatedSampleSchedules},
-		{name: "Do not provide StartDate", input: []schedule{
-			{
-				ScheduleName:       "test4",
-				ShiftsOff:          4,
-				VolunteersPerShift: 4,
-				EndDate:            Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-			}},
-			want: simulatedCreatedSampleSchedules},
-		{name: "Do not provide EndDate", input: []schedule{
-			{
-				ScheduleName:       "test4",
-				ShiftsOff:          4,
-				VolunteersPerShift: 4,
-				StartDate:          Must(env.Sample.RequestDate(date{Month: 4, Day: 4, Year: 2024})).DateID,
-			}},
-			want: simulatedCreatedSampleSchedules},
-		{name: "Provide ShiftsOff = 0", input: []schedule{{ScheduleName: "test01", ShiftsOff: 0, VolunteersPerShift: 1, User: "Seth", StartDate: 213, EndDate: 244}}, want: simulatedCreatedSampleSchedules},
-		{name: "Fail by providing duplicate input", input: []schedule{
-			{
-				ScheduleName: "test01", ShiftsOff: 3, VolunteersPerShift: 1, User: "Seth", StartDate: 213, EndDate: 244,
-			},
-			{
-				ScheduleName: "test01", ShiftsOff: 3, VolunteersPerShift: 1, User: "Doesn'tMatter", StartDate: 213, EndDate: 244,
-			}},
-			want: simulatedCreatedSampleSchedules},
+		{name: "Get 1 role by RoleName", input: []role{{RoleName: "Usher"}}, want: simulatedCreatedSampleRoles[1:2]},
+		{name: "Get 1 role by RoleID", input: []role{{RoleID: 3}}, want: simulatedCreatedSampleRoles[2:]},
+		{name: "Get 2 roles", input: []role{{RoleID: 1}, {RoleName: "Usher"}}, want: simulatedCreatedSampleRoles[:2]},
+		{name: "Get all roles", input: []role{}, want: simulatedCreatedSampleRoles},
+		{name: "Fail by requesting an empty role", input: []role{{}}, want: []role{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CreateSchedules(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestSchedules, env.LoggedInUser, []schedule{})
+			ans, err := env.Sample.RequestRoles(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
 		})
 	}
 }
 
-func TestRequestSchedulesExtended(t *testing.T) {
+func TestDeleteRoles(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
-	generatedSampleSchedules := generateSampleSchedules(env.Sample)
-	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	err := env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	simulatedCreatedSampleRoles := simulateCreatedSampleRoles(env.LoggedInUser)
 	tests := []struct {
 		name  string
-		input []schedule
-		want  []schedule
+		input []role
+		want  []role
 	}{
-		{name: "Request all schedules", input: []schedule{}, want: simulatedCreatedSampleSchedules},
-		{name: "Request all schedules with User", input: []schedule{{ShiftsOff: -1, User: "Seth"}}, want: simulatedCreatedSampleSchedules},
-		{name: "Request fully specified schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test0", ShiftsOff: 0, VolunteersPerShift: 1, User: "Seth", StartDate: 213, EndDate: 244}}, want: simulatedCreatedSampleSchedules[:1]},
-		{name: "Fail due to empty/default struct (manually set ShiftsOff to -1)", input: []schedule{{ShiftsOff: -1}}, want: []schedule{}},
+		{name: "Delete 1 role by RoleID", input: []role{{RoleID: 1}}, want: simulatedCreatedSampleRoles[1:]},
+		{name: "Delete 1 role by RoleName", input: []role{{RoleName: "Usher"}}, want: simulatedCreatedSampleRoles[2:]},
+		{name: "Fail due to empty input struct", input: []role{{}}, want: simulatedCreatedSampleRoles[2:]},
+		{name: "Fail by providing neither RoleID nor RoleName", input: []role{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleRoles[2:]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestSchedulesExtended(env.LoggedInUser, tt.input, true)
-			checkResultsSlice(t, ans, tt.want, tt.input, err)
+			err := env.Sample.DeleteRoles(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRoles, env.LoggedInUser, []role{})
 		})
 	}
 }
 
-func TestRequestSchedules(t *testing.T) {
+func TestCleanOrphanedRoles(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
-	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
-	err := env.Sample.CreateSchedules(env.LoggedInUser, generatedSampleSchedules)
+	err := env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateSchedules failed): %v", err)
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVR(env.LoggedInUser, []volunteerRole{{Volunteer: 1, Role: 2}})
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVR failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
 	tests := []struct {
 		name  string
-		input []schedule
-		want  []schedule
+		input []role
+		want  []role
 	}{
-		{name: "Request all schedules", input: []schedule{}, want: simulatedCreatedSampleSchedules},
-		{name: "Fail due to manually setting ShiftsOff to -1 when using RequestSchedule", input: []schedule{{ShiftsOff: -1}}, want: []schedule{}},
-		{name: "Fail by providing empty input struct", input: []schedule{{}}, want: []schedule{}},
+		{name: "Clean the roles no volunteer or schedule uses", input: simulateCreatedSampleRoles(env.LoggedInUser), want: simulateCreatedSampleRoles(env.LoggedInUser)[1:2]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestSchedules(env.LoggedInUser, tt.input)
-			checkResultsSlice(t, ans, tt.want, tt.input, err)
+			err := env.Sample.CleanOrphanedRoles(env.LoggedInUser)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRoles, env.LoggedInUser, []role{})
 		})
 	}
 }
 
-func TestRequestSchedule(t *testing.T) {
+func TestCreateVR(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
-	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
-	err := env.Sample.CreateSchedules(env.LoggedInUser, generatedSampleSchedules)
+	err := env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateSchedules failed): %v", err)
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVR := generateSampleVR(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleVR := simulateCreatedSampleVR(env.LoggedInUser, generatedSampleVR)
 	tests := []struct {
 		name  string
-		input schedule
-		want  schedule
+		input []volunteerRole
+		want  []volunteerRole
 	}{
-		{name: "Request schedule 1", input: schedule{ScheduleID: 1}, want: simulatedCreatedSampleSchedules[0]},
-		{name: "Fail by providing empty input struct", input: schedule{}, want: schedule{}},
-		{name: "Fail due to manually setting ShiftsOff to -1 when using RequestSchedule", input: schedule{ShiftsOff: -1}, want: schedule{}},
-		{name: "Fail by requesting more than one schedule", input: schedule{User: "Seth"}, want: schedule{}},
+		{name: "Create VR from sampleVR", input: generatedSampleVR, want: simulatedCreatedSampleVR},
+		{name: "Fail to create VR from duplicate VR", input: []volunteerRole{generatedSampleVR[0]}, want: simulatedCreatedSampleVR},
+		{name: "Fail to create VR by providing one empty VR struct", input: []volunteerRole{{}}, want: simulatedCreatedSampleVR},
+		{name: "Fail to create VR by not providing a Role", input: []volunteerRole{{Volunteer: 5}}, want: simulatedCreatedSampleVR},
+		{name: "Fail to create VR by providing a duplicate input", input: []volunteerRole{{Volunteer: 5, Role: 1}, {User: "Doesn'tMatter", Volunteer: 5, Role: 1}}, want: simulatedCreatedSampleVR},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestSchedule(env.LoggedInUser, tt.input)
+			err := env.Sample.CreateVR(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVR, env.LoggedInUser, []volunteerRole{})
+		})
+	}
+}
+
+func TestRequestVR(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	err := env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVR := generateSampleVR(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVR(env.LoggedInUser, generatedSampleVR)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVR failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVR := simulateCreatedSampleVR(env.LoggedInUser, generatedSampleVR)
+	tests := []struct {
+		name  string
+		input []volunteerRole
+		want  []volunteerRole
+	}{
+		{name: "Request all VR", input: []volunteerRole{}, want: simulatedCreatedSampleVR},
+		{name: "Request the VR of one volunteer", input: []volunteerRole{{Volunteer: simulatedCreatedSampleVR[0].Volunteer}}, want: simulatedCreatedSampleVR[:1]},
+		{name: "Request the VR of one role", input: []volunteerRole{{Role: Must(env.Sample.RequestRole(env.LoggedInUser, role{RoleName: "Usher"})).RoleID}}, want: simulatedCreatedSampleVR[:2]},
+		{name: "Request a fully specified VR", input: simulatedCreatedSampleVR[3:], want: simulatedCreatedSampleVR[3:]},
+		{name: "Fail by requesting an empty VR", input: []volunteerRole{{}}, want: []volunteerRole{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestVR(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestDeleteVR(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	err := env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVR := generateSampleVR(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVR(env.LoggedInUser, generatedSampleVR)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVR failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVR := simulateCreatedSampleVR(env.LoggedInUser, generatedSampleVR)
+	tests := []struct {
+		name  string
+		input []volunteerRole
+		want  []volunteerRole
+	}{
+		{name: "Delete one VR by VRID", input: []volunteerRole{{VRID: 1}}, want: simulatedCreatedSampleVR[1:]},
+		{name: "Delete one VR by Volunteer and Role", input: []volunteerRole{{Volunteer: simulatedCreatedSampleVR[1].Volunteer, Role: simulatedCreatedSampleVR[1].Role}}, want: simulatedCreatedSampleVR[2:]},
+		{name: "Fail to delete one VR by providing only Volunteer", input: []volunteerRole{{Volunteer: simulatedCreatedSampleVR[2].Volunteer}}, want: simulatedCreatedSampleVR[2:]},
+		{name: "Fail to delete by providing empty VR struct", input: []volunteerRole{{}}, want: simulatedCreatedSampleVR[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteVR(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVR, env.LoggedInUser, []volunteerRole{})
+		})
+	}
+}
+
+func TestCleanOrphanedVR(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	err := env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVR := generateSampleVR(env.LoggedInUser, env.Sample)
+	tim := Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"}))
+	usher := Must(env.Sample.RequestRole(env.LoggedInUser, role{RoleName: "Usher"}))
+	plusOrphanVR := append(generatedSampleVR, volunteerRole{Volunteer: tim.VolunteerID, Role: Must(env.Sample.RequestRole(env.LoggedInUser, role{RoleName: "Sound tech"})).RoleID})
+	err = env.Sample.CreateVR(env.LoggedInUser, plusOrphanVR)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVR failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVR := simulateCreatedSampleVR(env.LoggedInUser, generatedSampleVR)
+	tests := []struct {
+		name  string
+		input map[volunteer][]role
+		want  []volunteerRole
+	}{
+		{name: "Clean Orphaned VR", input: map[volunteer][]role{tim: {usher}}, want: simulatedCreatedSampleVR},
+		{name: "Fail by not providing a volunteer with a VolunteerID", input: map[volunteer][]role{{VolunteerName: "Tim"}: {}}, want: simulatedCreatedSampleVR},
+		{name: "Fail by not providing a role with a RoleID", input: map[volunteer][]role{tim: {{RoleName: "Usher"}}}, want: simulatedCreatedSampleVR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedVR(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVR, env.LoggedInUser, []volunteerRole{})
+		})
+	}
+}
+
+func TestCreateSchedulesExtended(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	tests := []struct {
+		name  string
+		input []schedule
+		want  []schedule
+	}{
+		{name: "Create schedules from sampleSchedules", input: generatedSampleSchedules, want: simulatedCreatedSampleSchedules},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, tt.input, true)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestSchedules, env.LoggedInUser, []schedule{})
+		})
+	}
+}
+
+func TestCreateSchedules(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
+	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	tests := []struct {
+		name  string
+		input []schedule
+		want  []schedule
+	}{
+		{name: "Create schedules from sampleSchedules", input: generatedSampleSchedules, want: simulatedCreatedSampleSchedules},
+		{name: "Fail to create schedules from duplicate schedule", input: []schedule{generatedSampleSchedules[0]}, want: simulatedCreatedSampleSchedules},
+		{name: "Create schedules but provide no schedule structs", input: []schedule{}, want: simulatedCreatedSampleSchedules},
+		{name: "Only provide one empty schedule struct", input: []schedule{{}}, want: simulatedCreatedSampleSchedules},
+		{name: "Do not provide ScheduleName", input: []schedule{
+			{
+				ShiftsOff:          4,
+				VolunteersPerShift: 4,
+				StartDate:          date{Month: 4, Day: 4, Year: 2024}.ToString(),
+				EndDate:            date{Month: 4, Day: 4, Year: 2024}.ToString(),
+			}},
+			want: simulatedCreatedSampleSchedules},
+		{name: "Provide negative ShiftsOff", input: []schedule{
+			{
+				ScheduleName:       "test4",
+				VolunteersPerShift: 4,
+				StartDate:          date{Month: 4, Day: 4, Year: 2024}.ToString(),
+				EndDate:            date{Month: 4, Day: 4, Year: 2024}.ToString(),
+			}},
+			want: simulatedCreatedSampleSchedules},
+		{name: "Do not provide VolunteersPerShift", input: []schedule{
+			{
+				ScheduleName: "test4",
+				ShiftsOff:    4,
+				StartDate:    date{Month: 4, Day: 4, Year: 2024}.ToString(),
+				EndDate:      date{Month: 4, Day: 4, Year: 2024}.ToString(),
+			}},
+			want: simulatedCreatedSampleSchedules},
+		{name: "Do not provide StartDate", input: []schedule{
+			{
+				ScheduleName:       "test4",
+				ShiftsOff:          4,
+				VolunteersPerShift: 4,
+				EndDate:            date{Month: 4, Day: 4, Year: 2024}.ToString(),
+			}},
+			want: simulatedCreatedSampleSchedules},
+		{name: "Do not provide EndDate", input: []schedule{
+			{
+				ScheduleName:       "test4",
+				ShiftsOff:          4,
+				VolunteersPerShift: 4,
+				StartDate:          date{Month: 4, Day: 4, Year: 2024}.ToString(),
+			}},
+			want: simulatedCreatedSampleSchedules},
+		{name: "Provide ShiftsOff = 0", input: []schedule{{ScheduleName: "test01", ShiftsOff: 0, VolunteersPerShift: 1, User: "Seth", StartDate: "2023-08-01", EndDate: "2023-09-01"}}, want: simulatedCreatedSampleSchedules},
+		{name: "Fail by providing duplicate input", input: []schedule{
+			{
+				ScheduleName: "test01", ShiftsOff: 3, VolunteersPerShift: 1, User: "Seth", StartDate: "2023-08-01", EndDate: "2023-09-01",
+			},
+			{
+				ScheduleName: "test01", ShiftsOff: 3, VolunteersPerShift: 1, User: "Doesn'tMatter", StartDate: "2023-08-01", EndDate: "2023-09-01",
+			}},
+			want: simulatedCreatedSampleSchedules},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateSchedules(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestSchedules, env.LoggedInUser, []schedule{})
+		})
+	}
+}
+
+func TestRequestSchedulesExtended(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	tests := []struct {
+		name  string
+		input []schedule
+		want  []schedule
+	}{
+		{name: "Request all schedules", input: []schedule{}, want: simulatedCreatedSampleSchedules},
+		{name: "Request all schedules with User", input: []schedule{{ShiftsOff: -1, User: "Seth"}}, want: simulatedCreatedSampleSchedules},
+		{name: "Request fully specified schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test0", ShiftsOff: 0, VolunteersPerShift: 1, User: "Seth", StartDate: "2023-08-01", EndDate: "2023-09-01"}}, want: simulatedCreatedSampleSchedules[:1]},
+		{name: "Fail due to empty/default struct (manually set ShiftsOff to -1)", input: []schedule{{ShiftsOff: -1}}, want: []schedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestSchedulesExtended(env.LoggedInUser, tt.input, true)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestRequestSchedules(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
+	err := env.Sample.CreateSchedules(env.LoggedInUser, generatedSampleSchedules)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedules failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	tests := []struct {
+		name  string
+		input []schedule
+		want  []schedule
+	}{
+		{name: "Request all schedules", input: []schedule{}, want: simulatedCreatedSampleSchedules},
+		{name: "Fail due to manually setting ShiftsOff to -1 when using RequestSchedule", input: []schedule{{ShiftsOff: -1}}, want: []schedule{}},
+		{name: "Fail by providing empty input struct", input: []schedule{{}}, want: []schedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestSchedules(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestRequestSchedule(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)[1:]
+	err := env.Sample.CreateSchedules(env.LoggedInUser, generatedSampleSchedules)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedules failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleSchedules := simulateCreatedSampleSchedules(env.LoggedInUser, generatedSampleSchedules)
+	tests := []struct {
+		name  string
+		input schedule
+		want  schedule
+	}{
+		{name: "Request schedule 1", input: schedule{ScheduleID: 1}, want: simulatedCreatedSampleSchedules[0]},
+		{name: "Fail by providing empty input struct", input: schedule{}, want: schedule{}},
+		{name: "Fail due to manually setting ShiftsOff to -1 when using RequestSchedule", input: schedule{ShiftsOff: -1}, want: schedule{}},
+		{name: "Fail by requesting more than one schedule", input: schedule{User: "Seth"}, want: schedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestSchedule(env.LoggedInUser, tt.input)
 			checkResults(t, ans, tt.want, tt.input, err)
 		})
 	}
+	t.Run("Fail with ErrNotFound for a schedule that does not exist", func(t *testing.T) {
+		_, err := env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "missing"})
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error `%v`, want ErrNotFound", err)
+		}
+	})
 }
 
 func TestUpdateSchedulesExtended(t *testing.T) {
@@ -949,11 +2455,11 @@ func TestUpdateSchedulesExtended(t *testing.T) {
 		input []schedule
 		want  []schedule
 	}{
-		{name: "Update 1 schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}}, want: simulatedUpdatedSampleSchedules},
+		{name: "Update 1 schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by providing an empty input struct", input: []schedule{{ShiftsOff: -1}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by not providing a ScheduleID", input: []schedule{{ScheduleName: "test1", ShiftsOff: 4}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by only providing a ScheduleID", input: []schedule{{ScheduleID: 1, ShiftsOff: -1}}, want: simulatedUpdatedSampleSchedules},
-		{name: "Fail to update a schedule because it would create a duplicate schedule", input: []schedule{{ScheduleID: 2, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}}, want: simulatedUpdatedSampleSchedules},
+		{name: "Fail to update a schedule because it would create a duplicate schedule", input: []schedule{{ScheduleID: 2, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}}, want: simulatedUpdatedSampleSchedules},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -978,12 +2484,12 @@ func TestUpdateSchedules(t *testing.T) {
 		input []schedule
 		want  []schedule
 	}{
-		{name: "Update 1 schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}}, want: simulatedUpdatedSampleSchedules},
+		{name: "Update 1 schedule", input: []schedule{{ScheduleID: 1, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by providing an empty input struct", input: []schedule{{}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by not providing a ScheduleID", input: []schedule{{ScheduleName: "test1", ShiftsOff: 4}}, want: simulatedUpdatedSampleSchedules},
 		{name: "Fail to update a schedule by only providing a ScheduleID", input: []schedule{{ScheduleID: 1}}, want: simulatedUpdatedSampleSchedules},
-		{name: "Fail to update because it would create a duplicate Schedule (1 existing, 1 proposed)", input: []schedule{{ScheduleID: 2, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}}, want: simulatedUpdatedSampleSchedules},
-		{name: "Fail to update because it would create a duplicate Schedule (0 existing, 2 proposed)", input: []schedule{{ScheduleID: 2, ScheduleName: "test2a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}, {ScheduleID: 3, ScheduleName: "test2a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: 550, EndDate: 556}}, want: simulatedUpdatedSampleSchedules},
+		{name: "Fail to update because it would create a duplicate Schedule (1 existing, 1 proposed)", input: []schedule{{ScheduleID: 2, ScheduleName: "test1a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}}, want: simulatedUpdatedSampleSchedules},
+		{name: "Fail to update because it would create a duplicate Schedule (0 existing, 2 proposed)", input: []schedule{{ScheduleID: 2, ScheduleName: "test2a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}, {ScheduleID: 3, ScheduleName: "test2a", ShiftsOff: 10, VolunteersPerShift: 10, StartDate: "2024-07-03", EndDate: "2024-07-09"}}, want: simulatedUpdatedSampleSchedules},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1013,8 +2519,8 @@ func TestDeleteSchedules(t *testing.T) {
 			{
 				ShiftsOff:          0,
 				VolunteersPerShift: 1,
-				StartDate:          Must(env.Sample.RequestDate(date{Month: 8, Day: 1, Year: 2023})).DateID,
-				EndDate:            Must(env.Sample.RequestDate(date{Month: 9, Day: 1, Year: 2023})).DateID,
+				StartDate:          date{Month: 8, Day: 1, Year: 2023}.ToString(),
+				EndDate:            date{Month: 9, Day: 1, Year: 2023}.ToString(),
 			}},
 			want: simulatedCreatedSampleSchedules},
 		{name: "Delete one schedule by ScheduleID", input: []schedule{{ScheduleID: 1}}, want: simulatedCreatedSampleSchedules[1:]},
@@ -1172,38 +2678,1121 @@ func TestDeleteWFS(t *testing.T) {
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
 	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleWFS := generateSampleWFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateWFS(env.LoggedInUser, generatedSampleWFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateWFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleWFS := simulateCreatedSampleWFS(env.LoggedInUser, generatedSampleWFS)
+	tests := []struct {
+		name  string
+		input []weekdayForSchedule
+		want  []weekdayForSchedule
+	}{
+		{name: "Delete one WFS by WFSID", input: []weekdayForSchedule{{WFSID: 1}}, want: simulatedCreatedSampleWFS[1:]},
+		{name: "Delete one WFS by Schedule and Weekday", input: []weekdayForSchedule{{Schedule: 2, Weekday: Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"})).WeekdayName}}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Fail to delete one WFS by WFSID", input: []weekdayForSchedule{{WFSID: 1}}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Fail to delete one WFS by providing only Schedule", input: []weekdayForSchedule{{Schedule: 3}}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Fail to delete by not providing any WFS structs", input: []weekdayForSchedule{}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Fail to delete by providing empty WFS struct", input: []weekdayForSchedule{{}}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Fail to delete by not providing Schedule nor Weekday nor WFSID", input: []weekdayForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleWFS[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteWFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestWFS, env.LoggedInUser, []weekdayForSchedule{})
+		})
+	}
+}
+
+func TestCleanOrphanedWFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleWFS := generateSampleWFS(env.LoggedInUser, env.Sample)
+	plusOrphanWFS := append(generatedSampleWFS, weekdayForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Weekday: Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Friday"})).WeekdayName})
+	err = env.Sample.CreateWFS(env.LoggedInUser, plusOrphanWFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateWFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleWFS := simulateCreatedSampleWFS(env.LoggedInUser, generatedSampleWFS)
+	tests := []struct {
+		name  string
+		input map[schedule][]weekday
+		want  []weekdayForSchedule
+	}{
+		{name: "Clean Orphaned WFS", input: map[schedule][]weekday{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"}))},
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Wednesday"}))},
+		}, want: simulatedCreatedSampleWFS},
+		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]weekday{
+			{ScheduleName: "test1"}: {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"}))},
+		}, want: simulatedCreatedSampleWFS},
+		{name: "Fail by not providing a weekday with a WeekdayName", input: map[schedule][]weekday{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{WeekdayID: 3}},
+		}, want: simulatedCreatedSampleWFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedWFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestWFS, env.LoggedInUser, []weekdayForSchedule{})
+		})
+	}
+}
+
+func TestCreateTSFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleTSFS := generateSampleTSFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleTSFS := simulateCreatedSampleTSFS(env.LoggedInUser, generatedSampleTSFS)
+	tests := []struct {
+		name  string
+		input []timeSlotForSchedule
+		want  []timeSlotForSchedule
+	}{
+		{name: "Create TSFS from sampleTSFS", input: generatedSampleTSFS, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS from duplicate TSFS", input: []timeSlotForSchedule{generatedSampleTSFS[0]}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by providing no TSFS structs", input: []timeSlotForSchedule{}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by providing one empty TSFS struct", input: []timeSlotForSchedule{{}}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by not providing a SlotName", input: []timeSlotForSchedule{{Schedule: 3, Weekday: "Friday", SlotOrder: 1, VolunteersPerShift: 1}}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by providing a SlotName with the ShiftKeySeparator", input: []timeSlotForSchedule{{Schedule: 3, Weekday: "Friday", SlotName: "8am|early", SlotOrder: 1, VolunteersPerShift: 1}}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by not providing VolunteersPerShift", input: []timeSlotForSchedule{{Schedule: 3, Weekday: "Friday", SlotName: "noon", SlotOrder: 1}}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail to create TSFS by providing a duplicate input", input: []timeSlotForSchedule{{Schedule: 3, Weekday: "Friday", SlotName: "noon", SlotOrder: 1, VolunteersPerShift: 1}, {User: "Doesn'tMatter", Schedule: 3, Weekday: "Friday", SlotName: "noon", SlotOrder: 2, VolunteersPerShift: 1}}, want: simulatedCreatedSampleTSFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateTSFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestTSFS, env.LoggedInUser, []timeSlotForSchedule{})
+		})
+	}
+}
+
+func TestRequestTSFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleTSFS := generateSampleTSFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateTSFS(env.LoggedInUser, generatedSampleTSFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateTSFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleTSFS := simulateCreatedSampleTSFS(env.LoggedInUser, generatedSampleTSFS)
+	tests := []struct {
+		name  string
+		input []timeSlotForSchedule
+		want  []timeSlotForSchedule
+	}{
+		{name: "Request all TSFS", input: []timeSlotForSchedule{}, want: simulatedCreatedSampleTSFS},
+		{name: "Request the TSFS of one schedule in SlotOrder", input: []timeSlotForSchedule{{Schedule: simulatedCreatedSampleTSFS[0].Schedule}}, want: simulatedCreatedSampleTSFS[:2]},
+		{name: "Request a fully specified TSFS", input: simulatedCreatedSampleTSFS[2:], want: simulatedCreatedSampleTSFS[2:]},
+		{name: "Fail by requesting an empty TSFS", input: []timeSlotForSchedule{{}}, want: []timeSlotForSchedule{}},
+		{name: "Fail by only providing SlotOrder", input: []timeSlotForSchedule{{SlotOrder: 1}}, want: []timeSlotForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestTSFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestUpdateTSFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleTSFS := generateSampleTSFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateTSFS(env.LoggedInUser, generatedSampleTSFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateTSFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedUpdatedSampleTSFS := simulateUpdatedSampleTSFS(env.LoggedInUser, generatedSampleTSFS)
+	tests := []struct {
+		name  string
+		input []timeSlotForSchedule
+		want  []timeSlotForSchedule
+	}{
+		{name: "Update 1 TSFS", input: []timeSlotForSchedule{{TSFSID: 1, SlotName: "9am", VolunteersPerShift: 4}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Update 1 TSFS to the values it already has", input: []timeSlotForSchedule{{TSFSID: 1, SlotName: "9am"}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Fail to update by only providing TSFSID", input: []timeSlotForSchedule{{TSFSID: 1}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Fail to update by not providing TSFSID", input: []timeSlotForSchedule{{SlotName: "10am"}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Fail to update by providing an empty TSFS struct", input: []timeSlotForSchedule{{}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Fail to update because it would create a duplicate TSFS (1 existing, 1 proposed)", input: []timeSlotForSchedule{{TSFSID: 1, SlotName: "11am"}}, want: simulatedUpdatedSampleTSFS},
+		{name: "Fail to update because it would create a duplicate TSFS (0 existing, 2 proposed)", input: []timeSlotForSchedule{{TSFSID: 1, SlotName: "noon"}, {TSFSID: 2, SlotName: "noon"}}, want: simulatedUpdatedSampleTSFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.UpdateTSFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestTSFS, env.LoggedInUser, []timeSlotForSchedule{})
+		})
+	}
+}
+
+func TestDeleteTSFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleTSFS := generateSampleTSFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateTSFS(env.LoggedInUser, generatedSampleTSFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateTSFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleTSFS := simulateCreatedSampleTSFS(env.LoggedInUser, generatedSampleTSFS)
+	tests := []struct {
+		name  string
+		input []timeSlotForSchedule
+		want  []timeSlotForSchedule
+	}{
+		{name: "Delete one TSFS by TSFSID", input: []timeSlotForSchedule{{TSFSID: 1}}, want: simulatedCreatedSampleTSFS[1:]},
+		{name: "Delete one TSFS by Schedule, Weekday, and SlotName", input: []timeSlotForSchedule{{Schedule: 2, Weekday: "Sunday", SlotName: "11am"}}, want: simulatedCreatedSampleTSFS[2:]},
+		{name: "Fail to delete one TSFS by providing only Schedule and Weekday", input: []timeSlotForSchedule{{Schedule: 3, Weekday: "Wednesday"}}, want: simulatedCreatedSampleTSFS[2:]},
+		{name: "Fail to delete by providing empty TSFS struct", input: []timeSlotForSchedule{{}}, want: simulatedCreatedSampleTSFS[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteTSFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestTSFS, env.LoggedInUser, []timeSlotForSchedule{})
+		})
+	}
+}
+
+func TestCleanOrphanedTSFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleTSFS := generateSampleTSFS(env.LoggedInUser, env.Sample)
+	plusOrphanTSFS := append(generatedSampleTSFS, timeSlotForSchedule{Schedule: generatedSampleTSFS[0].Schedule, Weekday: "Sunday", SlotName: "6pm", SlotOrder: 3, VolunteersPerShift: 1})
+	err = env.Sample.CreateTSFS(env.LoggedInUser, plusOrphanTSFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateTSFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleTSFS := simulateCreatedSampleTSFS(env.LoggedInUser, generatedSampleTSFS)
+	tests := []struct {
+		name  string
+		input map[schedule][]timeSlotForSchedule
+		want  []timeSlotForSchedule
+	}{
+		{name: "Clean Orphaned TSFS", input: map[schedule][]timeSlotForSchedule{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {{Weekday: "Sunday", SlotName: "8am"}, {Weekday: "Sunday", SlotName: "11am"}},
+		}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]timeSlotForSchedule{
+			{ScheduleName: "test1"}: {{Weekday: "Sunday", SlotName: "8am"}},
+		}, want: simulatedCreatedSampleTSFS},
+		{name: "Fail by not providing a SlotName", input: map[schedule][]timeSlotForSchedule{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{Weekday: "Wednesday"}},
+		}, want: simulatedCreatedSampleTSFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedTSFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestTSFS, env.LoggedInUser, []timeSlotForSchedule{})
+		})
+	}
+}
+
+func TestCreateRFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleRFS := generateSampleRFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleRFS := simulateCreatedSampleRFS(env.LoggedInUser, generatedSampleRFS)
+	tests := []struct {
+		name  string
+		input []roleForSchedule
+		want  []roleForSchedule
+	}{
+		{name: "Create RFS from sampleRFS", input: generatedSampleRFS, want: simulatedCreatedSampleRFS},
+		{name: "Fail to create RFS from duplicate RFS", input: []roleForSchedule{generatedSampleRFS[0]}, want: simulatedCreatedSampleRFS},
+		{name: "Fail to create RFS by providing one empty RFS struct", input: []roleForSchedule{{}}, want: simulatedCreatedSampleRFS},
+		{name: "Fail to create RFS by not providing a Role", input: []roleForSchedule{{Schedule: 3, RoleOrder: 1, VolunteersPerShift: 1}}, want: simulatedCreatedSampleRFS},
+		{name: "Fail to create RFS by not providing VolunteersPerShift", input: []roleForSchedule{{Schedule: 3, Role: 1, RoleOrder: 1}}, want: simulatedCreatedSampleRFS},
+		{name: "Fail to create RFS by providing a duplicate input", input: []roleForSchedule{{Schedule: 3, Role: 1, RoleOrder: 1, VolunteersPerShift: 1}, {User: "Doesn'tMatter", Schedule: 3, Role: 1, RoleOrder: 2, VolunteersPerShift: 1}}, want: simulatedCreatedSampleRFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRFS, env.LoggedInUser, []roleForSchedule{})
+		})
+	}
+}
+
+func TestRequestRFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleRFS := generateSampleRFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateRFS(env.LoggedInUser, generatedSampleRFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleRFS := simulateCreatedSampleRFS(env.LoggedInUser, generatedSampleRFS)
+	tests := []struct {
+		name  string
+		input []roleForSchedule
+		want  []roleForSchedule
+	}{
+		{name: "Request all RFS", input: []roleForSchedule{}, want: simulatedCreatedSampleRFS},
+		{name: "Request the RFS of one schedule in RoleOrder", input: []roleForSchedule{{Schedule: simulatedCreatedSampleRFS[0].Schedule}}, want: simulatedCreatedSampleRFS[:2]},
+		{name: "Request a fully specified RFS", input: simulatedCreatedSampleRFS[2:], want: simulatedCreatedSampleRFS[2:]},
+		{name: "Fail by requesting an empty RFS", input: []roleForSchedule{{}}, want: []roleForSchedule{}},
+		{name: "Fail by only providing RoleOrder", input: []roleForSchedule{{RoleOrder: 1}}, want: []roleForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestRFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestUpdateRFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleRFS := generateSampleRFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateRFS(env.LoggedInUser, generatedSampleRFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedUpdatedSampleRFS := simulateUpdatedSampleRFS(env.LoggedInUser, generatedSampleRFS)
+	tests := []struct {
+		name  string
+		input []roleForSchedule
+		want  []roleForSchedule
+	}{
+		{name: "Update 1 RFS", input: []roleForSchedule{{RFSID: 2, VolunteersPerShift: 3}}, want: simulatedUpdatedSampleRFS},
+		{name: "Fail to update by only providing RFSID", input: []roleForSchedule{{RFSID: 2}}, want: simulatedUpdatedSampleRFS},
+		{name: "Fail to update by not providing RFSID", input: []roleForSchedule{{VolunteersPerShift: 4}}, want: simulatedUpdatedSampleRFS},
+		{name: "Fail to update by providing an empty RFS struct", input: []roleForSchedule{{}}, want: simulatedUpdatedSampleRFS},
+		{name: "Fail to update because it would create a duplicate RFS (1 existing, 1 proposed)", input: []roleForSchedule{{RFSID: 1, Role: generatedSampleRFS[1].Role}}, want: simulatedUpdatedSampleRFS},
+		{name: "Fail to update because it would create a duplicate RFS (0 existing, 2 proposed)", input: []roleForSchedule{{RFSID: 1, Role: 3}, {RFSID: 2, Role: 3}}, want: simulatedUpdatedSampleRFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.UpdateRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRFS, env.LoggedInUser, []roleForSchedule{})
+		})
+	}
+}
+
+func TestDeleteRFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleRFS := generateSampleRFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateRFS(env.LoggedInUser, generatedSampleRFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleRFS := simulateCreatedSampleRFS(env.LoggedInUser, generatedSampleRFS)
+	tests := []struct {
+		name  string
+		input []roleForSchedule
+		want  []roleForSchedule
+	}{
+		{name: "Delete one RFS by RFSID", input: []roleForSchedule{{RFSID: 1}}, want: simulatedCreatedSampleRFS[1:]},
+		{name: "Delete one RFS by Schedule and Role", input: []roleForSchedule{{Schedule: generatedSampleRFS[1].Schedule, Role: generatedSampleRFS[1].Role}}, want: simulatedCreatedSampleRFS[2:]},
+		{name: "Fail to delete one RFS by providing only Schedule", input: []roleForSchedule{{Schedule: generatedSampleRFS[2].Schedule}}, want: simulatedCreatedSampleRFS[2:]},
+		{name: "Fail to delete by providing empty RFS struct", input: []roleForSchedule{{}}, want: simulatedCreatedSampleRFS[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRFS, env.LoggedInUser, []roleForSchedule{})
+		})
+	}
+}
+
+func TestCleanOrphanedRFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateRoles(env.LoggedInUser, sampleRoles)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRoles failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleRFS := generateSampleRFS(env.LoggedInUser, env.Sample)
+	soundTech := Must(env.Sample.RequestRole(env.LoggedInUser, role{RoleName: "Sound tech"}))
+	plusOrphanRFS := append(generatedSampleRFS, roleForSchedule{Schedule: generatedSampleRFS[0].Schedule, Role: soundTech.RoleID, RoleOrder: 3, VolunteersPerShift: 1})
+	err = env.Sample.CreateRFS(env.LoggedInUser, plusOrphanRFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateRFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleRFS := simulateCreatedSampleRFS(env.LoggedInUser, generatedSampleRFS)
+	tests := []struct {
+		name  string
+		input map[schedule][]role
+		want  []roleForSchedule
+	}{
+		{name: "Clean Orphaned RFS", input: map[schedule][]role{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {{RoleID: generatedSampleRFS[0].Role}, {RoleID: generatedSampleRFS[1].Role}},
+		}, want: simulatedCreatedSampleRFS},
+		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]role{
+			{ScheduleName: "test1"}: {soundTech},
+		}, want: simulatedCreatedSampleRFS},
+		{name: "Fail by not providing a role with a RoleID", input: map[schedule][]role{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{RoleName: "Sound tech"}},
+		}, want: simulatedCreatedSampleRFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestRFS, env.LoggedInUser, []roleForSchedule{})
+		})
+	}
+}
+
+func TestCreateVFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input []volunteerForSchedule
+		want  []volunteerForSchedule
+	}{
+		{name: "Create VFS", input: generatedSampleVFS, want: simulatedCreatedSampleVFS},
+		{name: "Fail by trying to create an existing VFS", input: []volunteerForSchedule{generatedSampleVFS[0]}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by providing duplicate inputs", input: []volunteerForSchedule{{Schedule: 2, Volunteer: 6}, {User: "Doesn'tMatter", Schedule: 2, Volunteer: 6}}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by not providing a Schedule", input: []volunteerForSchedule{{User: "Anybody", Volunteer: 6}}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by not providing a Volunteer", input: []volunteerForSchedule{{User: "Anybody", Schedule: 2}}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by providing an empty/default values VFS struct", input: []volunteerForSchedule{{}}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by providing no input", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateVFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+		})
+	}
+}
+
+func TestRequestVFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input []volunteerForSchedule
+		want  []volunteerForSchedule
+	}{
+		{name: "Request all VFS", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS},
+		{name: "Request a fully specified VFS", input: simulatedCreatedSampleVFS[:1], want: simulatedCreatedSampleVFS[:1]},
+		{name: "Fail by requesting an empty VFS", input: []volunteerForSchedule{{}}, want: []volunteerForSchedule{}},
+		{name: "Request a nonexistent VFS", input: []volunteerForSchedule{{Schedule: 100}}, want: []volunteerForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestVFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestRequestVFSSingle(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input volunteerForSchedule
+		want  volunteerForSchedule
+	}{
+		{name: "Request a fully specified VFS", input: simulatedCreatedSampleVFS[1], want: simulatedCreatedSampleVFS[1]},
+		{name: "Fail by requesting an empty VFS", input: volunteerForSchedule{}, want: volunteerForSchedule{}},
+		{name: "Fail by requesting an multiple VFS", input: volunteerForSchedule{User: env.LoggedInUser}, want: volunteerForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestVFSSingle(env.LoggedInUser, tt.input)
+			checkResults(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestUpdateVFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedUpdatedSampleVFS := simulateUpdatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input []volunteerForSchedule
+		want  []volunteerForSchedule
+	}{
+		{name: "Update 1 VFS", input: []volunteerForSchedule{{VFSID: 1, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Update 1 VFS schedule", input: []volunteerForSchedule{{VFSID: 1, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Update 1 VFS weekday", input: []volunteerForSchedule{{VFSID: 1, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update by only providing one value in VFS", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update by not providing VFSID", input: []volunteerForSchedule{{Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update by providing an empty VFS struct", input: []volunteerForSchedule{{}}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update by providing an empty VFS slice", input: []volunteerForSchedule{}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update because it would create a duplicate VFS (1 existing, 1 proposed)", input: []volunteerForSchedule{{VFSID: 3, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Fail to update because it would create a duplicate VFS (0 existing, 2 proposed)", input: []volunteerForSchedule{{VFSID: 3, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}, {VFSID: 2, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.UpdateVFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+		})
+	}
+}
+
+func TestDeleteVFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input []volunteerForSchedule
+		want  []volunteerForSchedule
+	}{
+		{name: "Delete one VFS by VFSID", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedCreatedSampleVFS[1:]},
+		{name: "Delete one VFS by Schedule and Volunteer", input: []volunteerForSchedule{{Schedule: 2, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID}}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Fail to delete one VFS by VFSID", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Fail to delete one VFS by providing only Schedule", input: []volunteerForSchedule{{Schedule: 3}}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Fail to delete by not providing any VFS structs", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Fail to delete by providing empty VFS struct", input: []volunteerForSchedule{{}}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Fail to delete by not providing Schedule nor Volunteer nor VFSID", input: []volunteerForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleVFS[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteVFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+		})
+	}
+}
+
+func TestCleanOrphanedVFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
+	plusOrphanVFS := append(generatedSampleVFS, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID})
+	err = env.Sample.CreateVFS(env.LoggedInUser, plusOrphanVFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	tests := []struct {
+		name  string
+		input map[schedule][]volunteer
+		want  []volunteerForSchedule
+	}{
+		{name: "Clean Orphaned VFS", input: map[schedule][]volunteer{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {
+				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"})),
+				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})),
+				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Jack"})),
+				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "George"})),
+			},
+		}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]volunteer{
+			{ScheduleName: "test1"}: {Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"}))},
+		}, want: simulatedCreatedSampleVFS},
+		{name: "Fail by not providing a Volunteer with a VolunteerName", input: map[schedule][]volunteer{
+			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{VolunteerID: 5}},
+		}, want: simulatedCreatedSampleVFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedVFS(env.LoggedInUser, tt.input, true, false)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+		})
+	}
+}
+
+func TestCreateUFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input []unavailabilityForSchedule
+		want  []unavailabilityForSchedule
+	}{
+		{name: "Create UFS", input: generatedSampleUFS, want: simulatedCreatedSampleUFS},
+		{name: "Fail by trying to create an existing UFS", input: []unavailabilityForSchedule{generatedSampleUFS[0]}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by providing duplicate inputs", input: []unavailabilityForSchedule{{VolunteerForSchedule: 2, Date: "2023-01-06"}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Date: "2023-01-06"}}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by not providing a VolunteerForSchedule", input: []unavailabilityForSchedule{{User: "Anybody", Date: "2023-01-06"}}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by not providing a Date", input: []unavailabilityForSchedule{{User: "Anybody", VolunteerForSchedule: 2}}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by providing an empty/default values UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by providing no input", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateUFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+		})
+	}
+}
+
+func TestRequestUFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input []unavailabilityForSchedule
+		want  []unavailabilityForSchedule
+	}{
+		{name: "Request all UFS", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS},
+		{name: "Request a fully specified UFS", input: simulatedCreatedSampleUFS[:1], want: simulatedCreatedSampleUFS[:1]},
+		{name: "Fail by requesting an empty UFS", input: []unavailabilityForSchedule{{}}, want: []unavailabilityForSchedule{}},
+		{name: "Request a nonexistent UFS", input: []unavailabilityForSchedule{{VolunteerForSchedule: 100}}, want: []unavailabilityForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestUFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestRequestUFSSingle(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input unavailabilityForSchedule
+		want  unavailabilityForSchedule
+	}{
+		{name: "Request a fully specified UFS", input: simulatedCreatedSampleUFS[1], want: simulatedCreatedSampleUFS[1]},
+		{name: "Fail by requesting an empty UFS", input: unavailabilityForSchedule{}, want: unavailabilityForSchedule{}},
+		{name: "Fail by requesting an multiple UFS", input: unavailabilityForSchedule{User: env.LoggedInUser}, want: unavailabilityForSchedule{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := env.Sample.RequestUFSSingle(env.LoggedInUser, tt.input)
+			checkResults(t, ans, tt.want, tt.input, err)
+		})
+	}
+}
+
+func TestUpdateUFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedUpdatedSampleUFS := simulateUpdatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input []unavailabilityForSchedule
+		want  []unavailabilityForSchedule
+	}{
+		{name: "Update 1 UFS", input: []unavailabilityForSchedule{
+			{
+				UFSID:                1,
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
+			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Update 1 UFS VolunteerForSchedule", input: []unavailabilityForSchedule{
+			{
+				UFSID:                1,
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Update 1 UFS Date", input: []unavailabilityForSchedule{
+			{
+				UFSID: 1,
+				Date:  date{Month: 1, Day: 20, Year: 2024}.ToString(),
+			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update by only providing one value in UFS", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update by not providing UFSID", input: []unavailabilityForSchedule{
+			{
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
+			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update by providing an empty UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update by providing an empty UFS slice", input: []unavailabilityForSchedule{}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update because it would create a duplicate UFS (1 existing, 1 proposed)", input: []unavailabilityForSchedule{
+			{
+				UFSID:                3,
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
+			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Fail to update because it would create a duplicate UFS (0 existing, 2 proposed)", input: []unavailabilityForSchedule{
+			{
+				UFSID:                3,
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+				Date:                 date{Month: 5, Day: 20, Year: 2024}.ToString(),
+			},
+			{
+				UFSID:                4,
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
+				Date:                 date{Month: 5, Day: 20, Year: 2024}.ToString(),
+			}}, want: simulatedUpdatedSampleUFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.UpdateUFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+		})
+	}
+}
+
+func TestDeleteUFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input []unavailabilityForSchedule
+		want  []unavailabilityForSchedule
+	}{
+		{name: "Delete one UFS by UFSID", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedCreatedSampleUFS[1:]},
+		{name: "Delete one UFS by VolunteerForSchedule and Date", input: []unavailabilityForSchedule{
+			{
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{
+					Schedule:  Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID,
+					Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
+				})).VFSID,
+				Date: date{Month: 1, Day: 21, Year: 2024}.ToString(),
+			}}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Fail to delete one UFS by UFSID", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Fail to delete one UFS by providing only VolunteerForSchedule", input: []unavailabilityForSchedule{
+			{
+				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{
+					Schedule:  Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})).ScheduleID,
+					Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bob"})).VolunteerID,
+				})).VFSID,
+			}}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Fail to delete by not providing any UFS structs", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Fail to delete by providing empty UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Fail to delete by not providing Schedule nor Volunteer nor UFSID", input: []unavailabilityForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleUFS[2:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.DeleteUFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+		})
+	}
+}
+
+func TestCleanOrphanedUFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
+	plusOrphanUFS := append(generatedSampleUFS, unavailabilityForSchedule{VolunteerForSchedule: 2, Date: date{Month: 1, Day: 28, Year: 2024}.ToString()})
+	err = env.Sample.CreateUFS(env.LoggedInUser, plusOrphanUFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	tests := []struct {
+		name  string
+		input map[volunteerForSchedule][]date
+		want  []unavailabilityForSchedule
+	}{
+		{name: "Clean Orphaned UFS", input: map[volunteerForSchedule][]date{
+			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
+				date{Month: 1, Day: 21, Year: 2024},
+			},
+		}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]date{
+			{Schedule: 1}: {date{Month: 1, Day: 21, Year: 2024}},
+		}, want: simulatedCreatedSampleUFS},
+		{name: "Fail by not providing a Date with a Day", input: map[volunteerForSchedule][]date{
+			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
+				{Month: 1, Year: 2024},
+			},
+		}, want: simulatedCreatedSampleUFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CleanOrphanedUFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+		})
+	}
+}
+
+func TestCreateURFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleURFS := generateSampleURFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleURFS := simulateCreatedSampleURFS(env.LoggedInUser, generatedSampleURFS)
+	tests := []struct {
+		name  string
+		input []unavailabilityRuleForSchedule
+		want  []unavailabilityRuleForSchedule
+	}{
+		{name: "Create URFS from sampleURFS", input: generatedSampleURFS, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS from duplicate URFS", input: []unavailabilityRuleForSchedule{generatedSampleURFS[0]}, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS by providing one empty URFS struct", input: []unavailabilityRuleForSchedule{{}}, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS by not providing a VolunteerForSchedule", input: []unavailabilityRuleForSchedule{{Rule: "last Sunday"}}, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS by providing an invalid Rule", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: 2, Rule: "sometimes"}}, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS by providing a Rule that is not in ToString form", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: 2, Rule: "last sunday"}}, want: simulatedCreatedSampleURFS},
+		{name: "Fail to create URFS by providing a duplicate input", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: 2, Rule: "last Sunday"}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Rule: "last Sunday"}}, want: simulatedCreatedSampleURFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreateURFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestURFS, env.LoggedInUser, []unavailabilityRuleForSchedule{})
+		})
+	}
+}
+
+func TestRequestURFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleWFS := generateSampleWFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateWFS(env.LoggedInUser, generatedSampleWFS)
+	generatedSampleURFS := generateSampleURFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateURFS(env.LoggedInUser, generatedSampleURFS)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateWFS failed): %v", err)
+		t.Errorf("Error setting up test (CreateURFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleWFS := simulateCreatedSampleWFS(env.LoggedInUser, generatedSampleWFS)
+	simulatedCreatedSampleURFS := simulateCreatedSampleURFS(env.LoggedInUser, generatedSampleURFS)
 	tests := []struct {
 		name  string
-		input []weekdayForSchedule
-		want  []weekdayForSchedule
+		input []unavailabilityRuleForSchedule
+		want  []unavailabilityRuleForSchedule
 	}{
-		{name: "Delete one WFS by WFSID", input: []weekdayForSchedule{{WFSID: 1}}, want: simulatedCreatedSampleWFS[1:]},
-		{name: "Delete one WFS by Schedule and Weekday", input: []weekdayForSchedule{{Schedule: 2, Weekday: Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"})).WeekdayName}}, want: simulatedCreatedSampleWFS[2:]},
-		{name: "Fail to delete one WFS by WFSID", input: []weekdayForSchedule{{WFSID: 1}}, want: simulatedCreatedSampleWFS[2:]},
-		{name: "Fail to delete one WFS by providing only Schedule", input: []weekdayForSchedule{{Schedule: 3}}, want: simulatedCreatedSampleWFS[2:]},
-		{name: "Fail to delete by not providing any WFS structs", input: []weekdayForSchedule{}, want: simulatedCreatedSampleWFS[2:]},
-		{name: "Fail to delete by providing empty WFS struct", input: []weekdayForSchedule{{}}, want: simulatedCreatedSampleWFS[2:]},
-		{name: "Fail to delete by not providing Schedule nor Weekday nor WFSID", input: []weekdayForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleWFS[2:]},
+		{name: "Request all URFS", input: []unavailabilityRuleForSchedule{}, want: simulatedCreatedSampleURFS},
+		{name: "Request the URFS of one VFS", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: generatedSampleURFS[0].VolunteerForSchedule}}, want: simulatedCreatedSampleURFS[:2]},
+		{name: "Request the URFS of one Rule", input: []unavailabilityRuleForSchedule{{Rule: "July"}}, want: simulatedCreatedSampleURFS[1:2]},
+		{name: "Request a fully specified URFS", input: simulatedCreatedSampleURFS[3:], want: simulatedCreatedSampleURFS[3:]},
+		{name: "Fail by requesting an empty URFS", input: []unavailabilityRuleForSchedule{{}}, want: []unavailabilityRuleForSchedule{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.DeleteWFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestWFS, env.LoggedInUser, []weekdayForSchedule{})
+			ans, err := env.Sample.RequestURFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
 		})
 	}
 }
 
-func TestCleanOrphanedWFS(t *testing.T) {
+func TestDeleteURFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1212,39 +3801,42 @@ func TestCleanOrphanedWFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleWFS := generateSampleWFS(env.LoggedInUser, env.Sample)
-	plusOrphanWFS := append(generatedSampleWFS, weekdayForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Weekday: Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Friday"})).WeekdayName})
-	err = env.Sample.CreateWFS(env.LoggedInUser, plusOrphanWFS)
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateWFS failed): %v", err)
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleWFS := simulateCreatedSampleWFS(env.LoggedInUser, generatedSampleWFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleURFS := generateSampleURFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateURFS(env.LoggedInUser, generatedSampleURFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateURFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleURFS := simulateCreatedSampleURFS(env.LoggedInUser, generatedSampleURFS)
 	tests := []struct {
 		name  string
-		input map[schedule][]weekday
-		want  []weekdayForSchedule
+		input []unavailabilityRuleForSchedule
+		want  []unavailabilityRuleForSchedule
 	}{
-		{name: "Clean Orphaned WFS", input: map[schedule][]weekday{
-			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"}))},
-			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Wednesday"}))},
-		}, want: simulatedCreatedSampleWFS},
-		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]weekday{
-			{ScheduleName: "test1"}: {Must(env.Sample.RequestWeekday(weekday{WeekdayName: "Sunday"}))},
-		}, want: simulatedCreatedSampleWFS},
-		{name: "Fail by not providing a weekday with a WeekdayName", input: map[schedule][]weekday{
-			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{WeekdayID: 3}},
-		}, want: simulatedCreatedSampleWFS},
+		{name: "Delete one URFS by URFSID", input: []unavailabilityRuleForSchedule{{URFSID: 1}}, want: simulatedCreatedSampleURFS[1:]},
+		{name: "Delete one URFS by VolunteerForSchedule and Rule", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: generatedSampleURFS[1].VolunteerForSchedule, Rule: generatedSampleURFS[1].Rule}}, want: simulatedCreatedSampleURFS[2:]},
+		{name: "Fail to delete one URFS by providing only VolunteerForSchedule", input: []unavailabilityRuleForSchedule{{VolunteerForSchedule: generatedSampleURFS[2].VolunteerForSchedule}}, want: simulatedCreatedSampleURFS[2:]},
+		{name: "Fail to delete by providing empty URFS struct", input: []unavailabilityRuleForSchedule{{}}, want: simulatedCreatedSampleURFS[2:]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CleanOrphanedWFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestWFS, env.LoggedInUser, []weekdayForSchedule{})
+			err := env.Sample.DeleteURFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestURFS, env.LoggedInUser, []unavailabilityRuleForSchedule{})
 		})
 	}
 }
 
-func TestCreateVFS(t *testing.T) {
+func TestCleanOrphanedURFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1258,30 +3850,44 @@ func TestCreateVFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSampleURFS := generateSampleURFS(env.LoggedInUser, env.Sample)
+	plusOrphanURFS := append(generatedSampleURFS, unavailabilityRuleForSchedule{VolunteerForSchedule: generatedSampleURFS[0].VolunteerForSchedule, Rule: "last Sunday"})
+	err = env.Sample.CreateURFS(env.LoggedInUser, plusOrphanURFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateURFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleURFS := simulateCreatedSampleURFS(env.LoggedInUser, generatedSampleURFS)
+	timVFS := Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{VFSID: generatedSampleURFS[0].VolunteerForSchedule}))
 	tests := []struct {
 		name  string
-		input []volunteerForSchedule
-		want  []volunteerForSchedule
+		input map[volunteerForSchedule][]unavailabilityRuleForSchedule
+		want  []unavailabilityRuleForSchedule
 	}{
-		{name: "Create VFS", input: generatedSampleVFS, want: simulatedCreatedSampleVFS},
-		{name: "Fail by trying to create an existing VFS", input: []volunteerForSchedule{generatedSampleVFS[0]}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by providing duplicate inputs", input: []volunteerForSchedule{{Schedule: 2, Volunteer: 6}, {User: "Doesn'tMatter", Schedule: 2, Volunteer: 6}}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by not providing a Schedule", input: []volunteerForSchedule{{User: "Anybody", Volunteer: 6}}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by not providing a Volunteer", input: []volunteerForSchedule{{User: "Anybody", Schedule: 2}}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by providing an empty/default values VFS struct", input: []volunteerForSchedule{{}}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by providing no input", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS},
+		{name: "Clean Orphaned URFS", input: map[volunteerForSchedule][]unavailabilityRuleForSchedule{
+			timVFS: {{Rule: generatedSampleURFS[0].Rule}, {Rule: generatedSampleURFS[1].Rule}},
+		}, want: simulatedCreatedSampleURFS},
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]unavailabilityRuleForSchedule{
+			{Schedule: 1}: {},
+		}, want: simulatedCreatedSampleURFS},
+		{name: "Fail by not providing a URFS with a Rule", input: map[volunteerForSchedule][]unavailabilityRuleForSchedule{
+			timVFS: {{VolunteerForSchedule: timVFS.VFSID}},
+		}, want: simulatedCreatedSampleURFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CreateVFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+			err := env.Sample.CleanOrphanedURFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestURFS, env.LoggedInUser, []unavailabilityRuleForSchedule{})
 		})
 	}
 }
 
-func TestRequestVFS(t *testing.T) {
+func TestCreatePrFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1295,32 +3901,35 @@ func TestRequestVFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
 	if err != nil {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	generatedSamplePrFS := generateSamplePrFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSamplePrFS := simulateCreatedSamplePrFS(env.LoggedInUser, generatedSamplePrFS)
 	tests := []struct {
 		name  string
-		input []volunteerForSchedule
-		want  []volunteerForSchedule
+		input []preferenceForSchedule
+		want  []preferenceForSchedule
 	}{
-		{name: "Request all VFS", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS},
-		{name: "Request a fully specified VFS", input: simulatedCreatedSampleVFS[:1], want: simulatedCreatedSampleVFS[:1]},
-		{name: "Fail by requesting an empty VFS", input: []volunteerForSchedule{{}}, want: []volunteerForSchedule{}},
-		{name: "Request a nonexistent VFS", input: []volunteerForSchedule{{Schedule: 100}}, want: []volunteerForSchedule{}},
+		{name: "Create PrFS from samplePrFS", input: generatedSamplePrFS, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS from duplicate PrFS", input: []preferenceForSchedule{generatedSamplePrFS[0]}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS by providing one empty PrFS struct", input: []preferenceForSchedule{{}}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS by not providing a VolunteerForSchedule", input: []preferenceForSchedule{{Preference: "Friday"}}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS by providing an invalid Preference", input: []preferenceForSchedule{{VolunteerForSchedule: 2, Preference: "sometimes"}}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS by providing a Preference that is not in ToString form", input: []preferenceForSchedule{{VolunteerForSchedule: 2, Preference: "friday"}}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail to create PrFS by providing a duplicate input", input: []preferenceForSchedule{{VolunteerForSchedule: 2, Preference: "Friday"}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Preference: "Friday"}}, want: simulatedCreatedSamplePrFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestVFS(env.LoggedInUser, tt.input)
-			checkResultsSlice(t, ans, tt.want, tt.input, err)
+			err := env.Sample.CreatePrFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPrFS, env.LoggedInUser, []preferenceForSchedule{})
 		})
 	}
 }
 
-func TestRequestVFSSingle(t *testing.T) {
+func TestRequestPrFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1334,31 +3943,38 @@ func TestRequestVFSSingle(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
 	if err != nil {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	generatedSamplePrFS := generateSamplePrFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreatePrFS(env.LoggedInUser, generatedSamplePrFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreatePrFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSamplePrFS := simulateCreatedSamplePrFS(env.LoggedInUser, generatedSamplePrFS)
 	tests := []struct {
 		name  string
-		input volunteerForSchedule
-		want  volunteerForSchedule
+		input []preferenceForSchedule
+		want  []preferenceForSchedule
 	}{
-		{name: "Request a fully specified VFS", input: simulatedCreatedSampleVFS[1], want: simulatedCreatedSampleVFS[1]},
-		{name: "Fail by requesting an empty VFS", input: volunteerForSchedule{}, want: volunteerForSchedule{}},
-		{name: "Fail by requesting an multiple VFS", input: volunteerForSchedule{User: env.LoggedInUser}, want: volunteerForSchedule{}},
+		{name: "Request all PrFS", input: []preferenceForSchedule{}, want: simulatedCreatedSamplePrFS},
+		{name: "Request the PrFS of one VFS", input: []preferenceForSchedule{{VolunteerForSchedule: generatedSamplePrFS[0].VolunteerForSchedule}}, want: simulatedCreatedSamplePrFS[:2]},
+		{name: "Request the PrFS of one Preference", input: []preferenceForSchedule{{Preference: "at most 2 shifts"}}, want: simulatedCreatedSamplePrFS[1:2]},
+		{name: "Request a fully specified PrFS", input: simulatedCreatedSamplePrFS[3:], want: simulatedCreatedSamplePrFS[3:]},
+		{name: "Fail by requesting an empty PrFS", input: []preferenceForSchedule{{}}, want: []preferenceForSchedule{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestVFSSingle(env.LoggedInUser, tt.input)
-			checkResults(t, ans, tt.want, tt.input, err)
+			ans, err := env.Sample.RequestPrFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
 		})
 	}
 }
 
-func TestUpdateVFS(t *testing.T) {
+func TestDeletePrFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1372,37 +3988,37 @@ func TestUpdateVFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
 	if err != nil {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedUpdatedSampleVFS := simulateUpdatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	generatedSamplePrFS := generateSamplePrFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreatePrFS(env.LoggedInUser, generatedSamplePrFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreatePrFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSamplePrFS := simulateCreatedSamplePrFS(env.LoggedInUser, generatedSamplePrFS)
 	tests := []struct {
 		name  string
-		input []volunteerForSchedule
-		want  []volunteerForSchedule
+		input []preferenceForSchedule
+		want  []preferenceForSchedule
 	}{
-		{name: "Update 1 VFS", input: []volunteerForSchedule{{VFSID: 1, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
-		{name: "Update 1 VFS schedule", input: []volunteerForSchedule{{VFSID: 1, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID}}, want: simulatedUpdatedSampleVFS},
-		{name: "Update 1 VFS weekday", input: []volunteerForSchedule{{VFSID: 1, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update by only providing one value in VFS", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update by not providing VFSID", input: []volunteerForSchedule{{Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update by providing an empty VFS struct", input: []volunteerForSchedule{{}}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update by providing an empty VFS slice", input: []volunteerForSchedule{}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update because it would create a duplicate VFS (1 existing, 1 proposed)", input: []volunteerForSchedule{{VFSID: 3, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test3"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
-		{name: "Fail to update because it would create a duplicate VFS (0 existing, 2 proposed)", input: []volunteerForSchedule{{VFSID: 3, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}, {VFSID: 2, Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID}}, want: simulatedUpdatedSampleVFS},
+		{name: "Delete one PrFS by PrFSID", input: []preferenceForSchedule{{PrFSID: 1}}, want: simulatedCreatedSamplePrFS[1:]},
+		{name: "Delete one PrFS by VolunteerForSchedule and Preference", input: []preferenceForSchedule{{VolunteerForSchedule: generatedSamplePrFS[1].VolunteerForSchedule, Preference: generatedSamplePrFS[1].Preference}}, want: simulatedCreatedSamplePrFS[2:]},
+		{name: "Fail to delete one PrFS by providing only VolunteerForSchedule", input: []preferenceForSchedule{{VolunteerForSchedule: generatedSamplePrFS[2].VolunteerForSchedule}}, want: simulatedCreatedSamplePrFS[2:]},
+		{name: "Fail to delete by providing empty PrFS struct", input: []preferenceForSchedule{{}}, want: simulatedCreatedSamplePrFS[2:]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.UpdateVFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+			err := env.Sample.DeletePrFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPrFS, env.LoggedInUser, []preferenceForSchedule{})
 		})
 	}
 }
 
-func TestDeleteVFS(t *testing.T) {
+func TestCleanOrphanedPrFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1416,35 +4032,44 @@ func TestDeleteVFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateVFS(env.LoggedInUser, generatedSampleVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
 	if err != nil {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	generatedSamplePrFS := generateSamplePrFS(env.LoggedInUser, env.Sample)
+	plusOrphanPrFS := append(generatedSamplePrFS, preferenceForSchedule{VolunteerForSchedule: generatedSamplePrFS[0].VolunteerForSchedule, Preference: "Friday"})
+	err = env.Sample.CreatePrFS(env.LoggedInUser, plusOrphanPrFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreatePrFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSamplePrFS := simulateCreatedSamplePrFS(env.LoggedInUser, generatedSamplePrFS)
+	timVFS := Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{VFSID: generatedSamplePrFS[0].VolunteerForSchedule}))
 	tests := []struct {
 		name  string
-		input []volunteerForSchedule
-		want  []volunteerForSchedule
+		input map[volunteerForSchedule][]preferenceForSchedule
+		want  []preferenceForSchedule
 	}{
-		{name: "Delete one VFS by VFSID", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedCreatedSampleVFS[1:]},
-		{name: "Delete one VFS by Schedule and Volunteer", input: []volunteerForSchedule{{Schedule: 2, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID}}, want: simulatedCreatedSampleVFS[2:]},
-		{name: "Fail to delete one VFS by VFSID", input: []volunteerForSchedule{{VFSID: 1}}, want: simulatedCreatedSampleVFS[2:]},
-		{name: "Fail to delete one VFS by providing only Schedule", input: []volunteerForSchedule{{Schedule: 3}}, want: simulatedCreatedSampleVFS[2:]},
-		{name: "Fail to delete by not providing any VFS structs", input: []volunteerForSchedule{}, want: simulatedCreatedSampleVFS[2:]},
-		{name: "Fail to delete by providing empty VFS struct", input: []volunteerForSchedule{{}}, want: simulatedCreatedSampleVFS[2:]},
-		{name: "Fail to delete by not providing Schedule nor Volunteer nor VFSID", input: []volunteerForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleVFS[2:]},
+		{name: "Clean Orphaned PrFS", input: map[volunteerForSchedule][]preferenceForSchedule{
+			timVFS: {{Preference: generatedSamplePrFS[0].Preference}, {Preference: generatedSamplePrFS[1].Preference}},
+		}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]preferenceForSchedule{
+			{Schedule: 1}: {},
+		}, want: simulatedCreatedSamplePrFS},
+		{name: "Fail by not providing a PrFS with a Preference", input: map[volunteerForSchedule][]preferenceForSchedule{
+			timVFS: {{VolunteerForSchedule: timVFS.VFSID}},
+		}, want: simulatedCreatedSamplePrFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.DeleteVFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+			err := env.Sample.CleanOrphanedPrFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPrFS, env.LoggedInUser, []preferenceForSchedule{})
 		})
 	}
 }
 
-func TestCleanOrphanedVFS(t *testing.T) {
+func TestCreateDRFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1458,43 +4083,35 @@ func TestCleanOrphanedVFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleVFS := generateSampleVFS(env.LoggedInUser, env.Sample)
-	plusOrphanVFS := append(generatedSampleVFS, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Larry"})).VolunteerID})
-	err = env.Sample.CreateVFS(env.LoggedInUser, plusOrphanVFS)
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
 	if err != nil {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleVFS := simulateCreatedSampleVFS(env.LoggedInUser, generatedSampleVFS)
+	generatedSampleDRFS := generateSampleDRFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSampleDRFS := simulateCreatedSampleDRFS(env.LoggedInUser, generatedSampleDRFS)
 	tests := []struct {
 		name  string
-		input map[schedule][]volunteer
-		want  []volunteerForSchedule
+		input []dateRangeForSchedule
+		want  []dateRangeForSchedule
 	}{
-		{name: "Clean Orphaned VFS", input: map[schedule][]volunteer{
-			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})): {
-				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"})),
-				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})),
-				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Jack"})),
-				Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "George"})),
-			},
-		}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by not providing a schedule with a ScheduleID", input: map[schedule][]volunteer{
-			{ScheduleName: "test1"}: {Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"}))},
-		}, want: simulatedCreatedSampleVFS},
-		{name: "Fail by not providing a Volunteer with a VolunteerName", input: map[schedule][]volunteer{
-			Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})): {{VolunteerID: 5}},
-		}, want: simulatedCreatedSampleVFS},
+		{name: "Create DRFS from sampleDRFS", input: generatedSampleDRFS, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS from duplicate DRFS", input: []dateRangeForSchedule{generatedSampleDRFS[0]}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS by providing one empty DRFS struct", input: []dateRangeForSchedule{{}}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS by not providing a VolunteerForSchedule", input: []dateRangeForSchedule{{StartDate: "2023-01-10", EndDate: "2023-01-20"}}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS by not providing an EndDate", input: []dateRangeForSchedule{{VolunteerForSchedule: 2, StartDate: "2023-01-10"}}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS that ends before it starts", input: []dateRangeForSchedule{{VolunteerForSchedule: 2, StartDate: "2023-01-20", EndDate: "2023-01-10"}}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail to create DRFS by providing a duplicate input", input: []dateRangeForSchedule{{VolunteerForSchedule: 2, StartDate: "2023-01-10", EndDate: "2023-01-20"}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, StartDate: "2023-01-10", EndDate: "2023-01-20"}}, want: simulatedCreatedSampleDRFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CleanOrphanedVFS(env.LoggedInUser, tt.input, true, false)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestVFS, env.LoggedInUser, []volunteerForSchedule{})
+			err := env.Sample.CreateDRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestDRFS, env.LoggedInUser, []dateRangeForSchedule{})
 		})
 	}
 }
 
-func TestCreateUFS(t *testing.T) {
+func TestRequestDRFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1513,30 +4130,33 @@ func TestCreateUFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	generatedSampleDRFS := generateSampleDRFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateDRFS(env.LoggedInUser, generatedSampleDRFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateDRFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSampleDRFS := simulateCreatedSampleDRFS(env.LoggedInUser, generatedSampleDRFS)
 	tests := []struct {
 		name  string
-		input []unavailabilityForSchedule
-		want  []unavailabilityForSchedule
+		input []dateRangeForSchedule
+		want  []dateRangeForSchedule
 	}{
-		{name: "Create UFS", input: generatedSampleUFS, want: simulatedCreatedSampleUFS},
-		{name: "Fail by trying to create an existing UFS", input: []unavailabilityForSchedule{generatedSampleUFS[0]}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by providing duplicate inputs", input: []unavailabilityForSchedule{{VolunteerForSchedule: 2, Date: 6}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Date: 6}}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by not providing a VolunteerForSchedule", input: []unavailabilityForSchedule{{User: "Anybody", Date: 6}}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by not providing a Date", input: []unavailabilityForSchedule{{User: "Anybody", VolunteerForSchedule: 2}}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by providing an empty/default values UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by providing no input", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS},
+		{name: "Request all DRFS", input: []dateRangeForSchedule{}, want: simulatedCreatedSampleDRFS},
+		{name: "Request the DRFS of one VFS", input: []dateRangeForSchedule{{VolunteerForSchedule: generatedSampleDRFS[0].VolunteerForSchedule}}, want: simulatedCreatedSampleDRFS[:2]},
+		{name: "Request the DRFS starting on one date", input: []dateRangeForSchedule{{StartDate: generatedSampleDRFS[1].StartDate}}, want: simulatedCreatedSampleDRFS[1:2]},
+		{name: "Request a fully specified DRFS", input: simulatedCreatedSampleDRFS[3:], want: simulatedCreatedSampleDRFS[3:]},
+		{name: "Fail by requesting an empty DRFS", input: []dateRangeForSchedule{{}}, want: []dateRangeForSchedule{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CreateUFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+			ans, err := env.Sample.RequestDRFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
 		})
 	}
 }
 
-func TestRequestUFS(t *testing.T) {
+func TestDeleteDRFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1555,32 +4175,32 @@ func TestRequestUFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	generatedSampleDRFS := generateSampleDRFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreateDRFS(env.LoggedInUser, generatedSampleDRFS)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.Errorf("Error setting up test (CreateDRFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	simulatedCreatedSampleDRFS := simulateCreatedSampleDRFS(env.LoggedInUser, generatedSampleDRFS)
 	tests := []struct {
 		name  string
-		input []unavailabilityForSchedule
-		want  []unavailabilityForSchedule
+		input []dateRangeForSchedule
+		want  []dateRangeForSchedule
 	}{
-		{name: "Request all UFS", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS},
-		{name: "Request a fully specified UFS", input: simulatedCreatedSampleUFS[:1], want: simulatedCreatedSampleUFS[:1]},
-		{name: "Fail by requesting an empty UFS", input: []unavailabilityForSchedule{{}}, want: []unavailabilityForSchedule{}},
-		{name: "Request a nonexistent UFS", input: []unavailabilityForSchedule{{VolunteerForSchedule: 100}}, want: []unavailabilityForSchedule{}},
+		{name: "Delete one DRFS by DRFSID", input: []dateRangeForSchedule{{DRFSID: 1}}, want: simulatedCreatedSampleDRFS[1:]},
+		{name: "Delete one DRFS by VolunteerForSchedule, StartDate, and EndDate", input: []dateRangeForSchedule{{VolunteerForSchedule: generatedSampleDRFS[1].VolunteerForSchedule, StartDate: generatedSampleDRFS[1].StartDate, EndDate: generatedSampleDRFS[1].EndDate}}, want: simulatedCreatedSampleDRFS[2:]},
+		{name: "Fail to delete one DRFS by providing only VolunteerForSchedule", input: []dateRangeForSchedule{{VolunteerForSchedule: generatedSampleDRFS[2].VolunteerForSchedule}}, want: simulatedCreatedSampleDRFS[2:]},
+		{name: "Fail to delete by providing empty DRFS struct", input: []dateRangeForSchedule{{}}, want: simulatedCreatedSampleDRFS[2:]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestUFS(env.LoggedInUser, tt.input)
-			checkResultsSlice(t, ans, tt.want, tt.input, err)
+			err := env.Sample.DeleteDRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestDRFS, env.LoggedInUser, []dateRangeForSchedule{})
 		})
 	}
 }
 
-func TestRequestUFSSingle(t *testing.T) {
+func TestCleanOrphanedDRFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1599,31 +4219,39 @@ func TestRequestUFSSingle(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	generatedSampleDRFS := generateSampleDRFS(env.LoggedInUser, env.Sample)
+	plusOrphanDRFS := append(generatedSampleDRFS, dateRangeForSchedule{VolunteerForSchedule: generatedSampleDRFS[0].VolunteerForSchedule, StartDate: generatedSampleDRFS[2].StartDate, EndDate: generatedSampleDRFS[2].EndDate})
+	err = env.Sample.CreateDRFS(env.LoggedInUser, plusOrphanDRFS)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.Errorf("Error setting up test (CreateDRFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	simulatedCreatedSampleDRFS := simulateCreatedSampleDRFS(env.LoggedInUser, generatedSampleDRFS)
+	timVFS := Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{VFSID: generatedSampleDRFS[0].VolunteerForSchedule}))
 	tests := []struct {
 		name  string
-		input unavailabilityForSchedule
-		want  unavailabilityForSchedule
+		input map[volunteerForSchedule][]dateRangeForSchedule
+		want  []dateRangeForSchedule
 	}{
-		{name: "Request a fully specified UFS", input: simulatedCreatedSampleUFS[1], want: simulatedCreatedSampleUFS[1]},
-		{name: "Fail by requesting an empty UFS", input: unavailabilityForSchedule{}, want: unavailabilityForSchedule{}},
-		{name: "Fail by requesting an multiple UFS", input: unavailabilityForSchedule{User: env.LoggedInUser}, want: unavailabilityForSchedule{}},
+		{name: "Clean Orphaned DRFS", input: map[volunteerForSchedule][]dateRangeForSchedule{
+			timVFS: {{StartDate: generatedSampleDRFS[0].StartDate, EndDate: generatedSampleDRFS[0].EndDate}, {StartDate: generatedSampleDRFS[1].StartDate, EndDate: generatedSampleDRFS[1].EndDate}},
+		}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]dateRangeForSchedule{
+			{Schedule: 1}: {},
+		}, want: simulatedCreatedSampleDRFS},
+		{name: "Fail by not providing a DRFS with an EndDate", input: map[volunteerForSchedule][]dateRangeForSchedule{
+			timVFS: {{StartDate: generatedSampleDRFS[0].StartDate}},
+		}, want: simulatedCreatedSampleDRFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ans, err := env.Sample.RequestUFSSingle(env.LoggedInUser, tt.input)
-			checkResults(t, ans, tt.want, tt.input, err)
+			err := env.Sample.CleanOrphanedDRFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestDRFS, env.LoggedInUser, []dateRangeForSchedule{})
 		})
 	}
 }
 
-func TestUpdateUFS(t *testing.T) {
+func TestCreatePFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1642,69 +4270,75 @@ func TestUpdateUFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
-	if err != nil {
-		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
-		t.FailNow()
-	}
-	simulatedUpdatedSampleUFS := simulateUpdatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	generatedSamplePFS := generateSamplePFS(env.LoggedInUser, env.Sample)
+	simulatedCreatedSamplePFS := simulateCreatedSamplePFS(env.LoggedInUser, generatedSamplePFS)
 	tests := []struct {
 		name  string
-		input []unavailabilityForSchedule
-		want  []unavailabilityForSchedule
+		input []pairingForSchedule
+		want  []pairingForSchedule
 	}{
-		{name: "Update 1 UFS", input: []unavailabilityForSchedule{
-			{
-				UFSID:                1,
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
-			}}, want: simulatedUpdatedSampleUFS},
-		{name: "Update 1 UFS VolunteerForSchedule", input: []unavailabilityForSchedule{
-			{
-				UFSID:                1,
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-			}}, want: simulatedUpdatedSampleUFS},
-		{name: "Update 1 UFS Date", input: []unavailabilityForSchedule{
-			{
-				UFSID: 1,
-				Date:  Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
-			}}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update by only providing one value in UFS", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update by not providing UFSID", input: []unavailabilityForSchedule{
-			{
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
-			}}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update by providing an empty UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update by providing an empty UFS slice", input: []unavailabilityForSchedule{}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update because it would create a duplicate UFS (1 existing, 1 proposed)", input: []unavailabilityForSchedule{
-			{
-				UFSID:                3,
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
-			}}, want: simulatedUpdatedSampleUFS},
-		{name: "Fail to update because it would create a duplicate UFS (0 existing, 2 proposed)", input: []unavailabilityForSchedule{
-			{
-				UFSID:                3,
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 5, Day: 20, Year: 2024})).DateID,
-			},
-			{
-				UFSID:                4,
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 5, Day: 20, Year: 2024})).DateID,
-			}}, want: simulatedUpdatedSampleUFS},
+		{name: "Create PFS from samplePFS", input: generatedSamplePFS, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS from duplicate PFS", input: []pairingForSchedule{generatedSamplePFS[0]}, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS by providing one empty PFS struct", input: []pairingForSchedule{{}}, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS by not providing a PairedVolunteerForSchedule", input: []pairingForSchedule{{VolunteerForSchedule: 3, Pairing: PairingApart}}, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS by pairing a volunteer with themselves", input: []pairingForSchedule{{VolunteerForSchedule: 3, PairedVolunteerForSchedule: 3, Pairing: PairingApart}}, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS by providing an unknown Pairing", input: []pairingForSchedule{{VolunteerForSchedule: 3, PairedVolunteerForSchedule: 1, Pairing: "sometimes"}}, want: simulatedCreatedSamplePFS},
+		{name: "Fail to create PFS by providing a duplicate input", input: []pairingForSchedule{{VolunteerForSchedule: 3, PairedVolunteerForSchedule: 1, Pairing: PairingApart}, {User: "Doesn'tMatter", VolunteerForSchedule: 3, PairedVolunteerForSchedule: 1, Pairing: PairingApart}}, want: simulatedCreatedSamplePFS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := env.Sample.CreatePFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPFS, env.LoggedInUser, []pairingForSchedule{})
+		})
+	}
+}
+
+func TestRequestPFS(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	generatedSampleSchedules := generateSampleSchedules(env.Sample)
+	err := env.Sample.CreateSchedulesExtended(env.LoggedInUser, generatedSampleSchedules, true)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateSchedulesExtended failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVolunteers(env.LoggedInUser, sampleVolunteers)
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVolunteers failed): %v", err)
+		t.FailNow()
+	}
+	err = env.Sample.CreateVFS(env.LoggedInUser, generateSampleVFS(env.LoggedInUser, env.Sample))
+	if err != nil {
+		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
+		t.FailNow()
+	}
+	generatedSamplePFS := generateSamplePFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreatePFS(env.LoggedInUser, generatedSamplePFS)
+	if err != nil {
+		t.Errorf("Error setting up test (CreatePFS failed): %v", err)
+		t.FailNow()
+	}
+	simulatedCreatedSamplePFS := simulateCreatedSamplePFS(env.LoggedInUser, generatedSamplePFS)
+	tests := []struct {
+		name  string
+		input []pairingForSchedule
+		want  []pairingForSchedule
+	}{
+		{name: "Request all PFS", input: []pairingForSchedule{}, want: simulatedCreatedSamplePFS},
+		{name: "Request the PFS owned by one VFS", input: []pairingForSchedule{{VolunteerForSchedule: generatedSamplePFS[0].VolunteerForSchedule}}, want: simulatedCreatedSamplePFS[:1]},
+		{name: "Request the PFS of one Pairing", input: []pairingForSchedule{{Pairing: PairingApart}}, want: simulatedCreatedSamplePFS[1:]},
+		{name: "Request a fully specified PFS", input: simulatedCreatedSamplePFS[2:], want: simulatedCreatedSamplePFS[2:]},
+		{name: "Fail by requesting an empty PFS", input: []pairingForSchedule{{}}, want: []pairingForSchedule{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.UpdateUFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+			ans, err := env.Sample.RequestPFS(env.LoggedInUser, tt.input)
+			checkResultsSlice(t, ans, tt.want, tt.input, err)
 		})
 	}
 }
 
-func TestDeleteUFS(t *testing.T) {
+func TestDeletePFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1723,48 +4357,32 @@ func TestDeleteUFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	err = env.Sample.CreateUFS(env.LoggedInUser, generateSampleUFS(env.LoggedInUser, env.Sample))
+	generatedSamplePFS := generateSamplePFS(env.LoggedInUser, env.Sample)
+	err = env.Sample.CreatePFS(env.LoggedInUser, generatedSamplePFS)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.Errorf("Error setting up test (CreatePFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	simulatedCreatedSamplePFS := simulateCreatedSamplePFS(env.LoggedInUser, generatedSamplePFS)
 	tests := []struct {
 		name  string
-		input []unavailabilityForSchedule
-		want  []unavailabilityForSchedule
+		input []pairingForSchedule
+		want  []pairingForSchedule
 	}{
-		{name: "Delete one UFS by UFSID", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedCreatedSampleUFS[1:]},
-		{name: "Delete one UFS by VolunteerForSchedule and Date", input: []unavailabilityForSchedule{
-			{
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{
-					Schedule:  Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID,
-					Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
-				})).VFSID,
-				Date: Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024})).DateID,
-			}}, want: simulatedCreatedSampleUFS[2:]},
-		{name: "Fail to delete one UFS by UFSID", input: []unavailabilityForSchedule{{UFSID: 1}}, want: simulatedCreatedSampleUFS[2:]},
-		{name: "Fail to delete one UFS by providing only VolunteerForSchedule", input: []unavailabilityForSchedule{
-			{
-				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{
-					Schedule:  Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"})).ScheduleID,
-					Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bob"})).VolunteerID,
-				})).VFSID,
-			}}, want: simulatedCreatedSampleUFS[2:]},
-		{name: "Fail to delete by not providing any UFS structs", input: []unavailabilityForSchedule{}, want: simulatedCreatedSampleUFS[2:]},
-		{name: "Fail to delete by providing empty UFS struct", input: []unavailabilityForSchedule{{}}, want: simulatedCreatedSampleUFS[2:]},
-		{name: "Fail to delete by not providing Schedule nor Volunteer nor UFSID", input: []unavailabilityForSchedule{{User: "Doesn'tMatter"}}, want: simulatedCreatedSampleUFS[2:]},
+		{name: "Delete one PFS by PFSID", input: []pairingForSchedule{{PFSID: 1}}, want: simulatedCreatedSamplePFS[1:]},
+		{name: "Delete one PFS by VolunteerForSchedule and PairedVolunteerForSchedule", input: []pairingForSchedule{{VolunteerForSchedule: generatedSamplePFS[1].VolunteerForSchedule, PairedVolunteerForSchedule: generatedSamplePFS[1].PairedVolunteerForSchedule}}, want: simulatedCreatedSamplePFS[2:]},
+		{name: "Fail to delete one PFS by providing only VolunteerForSchedule", input: []pairingForSchedule{{VolunteerForSchedule: generatedSamplePFS[2].VolunteerForSchedule}}, want: simulatedCreatedSamplePFS[2:]},
+		{name: "Fail to delete by providing empty PFS struct", input: []pairingForSchedule{{}}, want: simulatedCreatedSamplePFS[2:]},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.DeleteUFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+			err := env.Sample.DeletePFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPFS, env.LoggedInUser, []pairingForSchedule{})
 		})
 	}
 }
 
-func TestCleanOrphanedUFS(t *testing.T) {
+func TestCleanOrphanedPFS(t *testing.T) {
 	env, tearDownEnvironment := setUpEnvironment(t)
 	defer tearDownEnvironment(t)
 	generatedSampleSchedules := generateSampleSchedules(env.Sample)
@@ -1783,37 +4401,34 @@ func TestCleanOrphanedUFS(t *testing.T) {
 		t.Errorf("Error setting up test (CreateVFS failed): %v", err)
 		t.FailNow()
 	}
-	generatedSampleUFS := generateSampleUFS(env.LoggedInUser, env.Sample)
-	plusOrphanUFS := append(generatedSampleUFS, unavailabilityForSchedule{VolunteerForSchedule: 2, Date: Must(env.Sample.RequestDate(date{Month: 1, Day: 28, Year: 2024})).DateID})
-	err = env.Sample.CreateUFS(env.LoggedInUser, plusOrphanUFS)
+	generatedSamplePFS := generateSamplePFS(env.LoggedInUser, env.Sample)
+	plusOrphanPFS := append(generatedSamplePFS, pairingForSchedule{VolunteerForSchedule: generatedSamplePFS[0].VolunteerForSchedule, PairedVolunteerForSchedule: generatedSamplePFS[1].VolunteerForSchedule, Pairing: PairingApart})
+	err = env.Sample.CreatePFS(env.LoggedInUser, plusOrphanPFS)
 	if err != nil {
-		t.Errorf("Error setting up test (CreateUFS failed): %v", err)
+		t.Errorf("Error setting up test (CreatePFS failed): %v", err)
 		t.FailNow()
 	}
-	simulatedCreatedSampleUFS := simulateCreatedSampleUFS(env.LoggedInUser, generatedSampleUFS)
+	simulatedCreatedSamplePFS := simulateCreatedSamplePFS(env.LoggedInUser, generatedSamplePFS)
+	billVFS := Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{VFSID: generatedSamplePFS[0].VolunteerForSchedule}))
 	tests := []struct {
 		name  string
-		input map[volunteerForSchedule][]date
-		want  []unavailabilityForSchedule
+		input map[volunteerForSchedule][]pairingForSchedule
+		want  []pairingForSchedule
 	}{
-		{name: "Clean Orphaned UFS", input: map[volunteerForSchedule][]date{
-			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
-				Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024})),
-			},
-		}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]date{
-			{Schedule: 1}: {Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024}))},
-		}, want: simulatedCreatedSampleUFS},
-		{name: "Fail by not providing a Date with a DateID", input: map[volunteerForSchedule][]date{
-			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
-				{Month: 1, Day: 21, Year: 2024},
-			},
-		}, want: simulatedCreatedSampleUFS},
+		{name: "Clean Orphaned PFS", input: map[volunteerForSchedule][]pairingForSchedule{
+			billVFS: {{PairedVolunteerForSchedule: generatedSamplePFS[0].PairedVolunteerForSchedule, Pairing: PairingTogether}},
+		}, want: simulatedCreatedSamplePFS},
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]pairingForSchedule{
+			{Schedule: 1}: {},
+		}, want: simulatedCreatedSamplePFS},
+		{name: "Fail by not providing a PFS with a Pairing", input: map[volunteerForSchedule][]pairingForSchedule{
+			billVFS: {{PairedVolunteerForSchedule: generatedSamplePFS[0].PairedVolunteerForSchedule}},
+		}, want: simulatedCreatedSamplePFS},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := env.Sample.CleanOrphanedUFS(env.LoggedInUser, tt.input)
-			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestUFS, env.LoggedInUser, []unavailabilityForSchedule{})
+			err := env.Sample.CleanOrphanedPFS(env.LoggedInUser, tt.input)
+			checkResultsErrOnly(t, tt.input, err, tt.want, env.Sample.RequestPFS, env.LoggedInUser, []pairingForSchedule{})
 		})
 	}
 }
@@ -1846,8 +4461,8 @@ func TestCreateSVOD(t *testing.T) {
 	}{
 		{name: "Create SVOD", input: generatedSampleSVOD, want: simulatedCreatedSampleSVOD},
 		{name: "Fail by trying to create an existing SVOD", input: []scheduledVolunteerOnDate{generatedSampleSVOD[0]}, want: simulatedCreatedSampleSVOD},
-		{name: "Fail by providing duplicate inputs", input: []scheduledVolunteerOnDate{{VolunteerForSchedule: 2, Date: 6}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Date: 6}}, want: simulatedCreatedSampleSVOD},
-		{name: "Fail by not providing a VolunteerForSchedule", input: []scheduledVolunteerOnDate{{User: "Anybody", Date: 6}}, want: simulatedCreatedSampleSVOD},
+		{name: "Fail by providing duplicate inputs", input: []scheduledVolunteerOnDate{{VolunteerForSchedule: 2, Date: "2023-01-06"}, {User: "Doesn'tMatter", VolunteerForSchedule: 2, Date: "2023-01-06"}}, want: simulatedCreatedSampleSVOD},
+		{name: "Fail by not providing a VolunteerForSchedule", input: []scheduledVolunteerOnDate{{User: "Anybody", Date: "2023-01-06"}}, want: simulatedCreatedSampleSVOD},
 		{name: "Fail by not providing a Date", input: []scheduledVolunteerOnDate{{User: "Anybody", VolunteerForSchedule: 2}}, want: simulatedCreatedSampleSVOD},
 		{name: "Fail by providing an empty/default values SVOD struct", input: []scheduledVolunteerOnDate{{}}, want: simulatedCreatedSampleSVOD},
 		{name: "Fail by providing no input", input: []scheduledVolunteerOnDate{}, want: simulatedCreatedSampleSVOD},
@@ -1982,7 +4597,7 @@ func TestUpdateSVOD(t *testing.T) {
 			{
 				SVODID:               1,
 				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
 			}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Update 1 SVOD VolunteerForSchedule", input: []scheduledVolunteerOnDate{
 			{
@@ -1992,13 +4607,13 @@ func TestUpdateSVOD(t *testing.T) {
 		{name: "Update 1 SVOD Date", input: []scheduledVolunteerOnDate{
 			{
 				SVODID: 1,
-				Date:   Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
+				Date:   date{Month: 1, Day: 20, Year: 2024}.ToString(),
 			}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Fail to update by only providing one value in SVOD", input: []scheduledVolunteerOnDate{{SVODID: 1}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Fail to update by not providing SVODID", input: []scheduledVolunteerOnDate{
 			{
 				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
 			}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Fail to update by providing an empty SVOD struct", input: []scheduledVolunteerOnDate{{}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Fail to update by providing an empty SVOD slice", input: []scheduledVolunteerOnDate{}, want: simulatedUpdatedSampleSVOD},
@@ -2006,18 +4621,18 @@ func TestUpdateSVOD(t *testing.T) {
 			{
 				SVODID:               3,
 				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 1, Day: 20, Year: 2024})).DateID,
+				Date:                 date{Month: 1, Day: 20, Year: 2024}.ToString(),
 			}}, want: simulatedUpdatedSampleSVOD},
 		{name: "Fail to update because it would create a duplicate SVOD (0 existing, 2 proposed)", input: []scheduledVolunteerOnDate{
 			{
 				SVODID:               3,
 				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 5, Day: 20, Year: 2024})).DateID,
+				Date:                 date{Month: 5, Day: 20, Year: 2024}.ToString(),
 			},
 			{
 				SVODID:               4,
 				VolunteerForSchedule: Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})).VFSID,
-				Date:                 Must(env.Sample.RequestDate(date{Month: 5, Day: 20, Year: 2024})).DateID,
+				Date:                 date{Month: 5, Day: 20, Year: 2024}.ToString(),
 			}}, want: simulatedUpdatedSampleSVOD},
 	}
 	for _, tt := range tests {
@@ -2066,7 +4681,7 @@ func TestDeleteSVOD(t *testing.T) {
 					Schedule:  Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID,
 					Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID,
 				})).VFSID,
-				Date: Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024})).DateID,
+				Date: date{Month: 1, Day: 21, Year: 2024}.ToString(),
 			}}, want: simulatedCreatedSampleSVOD[2:]},
 		{name: "Fail to delete one SVOD by SVODID", input: []scheduledVolunteerOnDate{{SVODID: 1}}, want: simulatedCreatedSampleSVOD[2:]},
 		{name: "Fail to delete one SVOD by providing only VolunteerForSchedule", input: []scheduledVolunteerOnDate{
@@ -2108,7 +4723,7 @@ func TestCleanOrphanedSVOD(t *testing.T) {
 		t.FailNow()
 	}
 	generatedSampleSVOD := generateSampleSVOD(env.LoggedInUser, env.Sample)
-	plusOrphanSVOD := append(generatedSampleSVOD, scheduledVolunteerOnDate{VolunteerForSchedule: 2, Date: Must(env.Sample.RequestDate(date{Month: 1, Day: 28, Year: 2024})).DateID})
+	plusOrphanSVOD := append(generatedSampleSVOD, scheduledVolunteerOnDate{VolunteerForSchedule: 2, Date: date{Month: 1, Day: 28, Year: 2024}.ToString()})
 	err = env.Sample.CreateSVOD(env.LoggedInUser, plusOrphanSVOD)
 	if err != nil {
 		t.Errorf("Error setting up test (CreateSVOD failed): %v", err)
@@ -2117,22 +4732,22 @@ func TestCleanOrphanedSVOD(t *testing.T) {
 	simulatedCreatedSampleSVOD := simulateCreatedSampleSVOD(env.LoggedInUser, generatedSampleSVOD)
 	tests := []struct {
 		name  string
-		input map[volunteerForSchedule][]date
+		input map[volunteerForSchedule][]scheduledVolunteerOnDate
 		want  []scheduledVolunteerOnDate
 	}{
-		{name: "Clean Orphaned SVOD", input: map[volunteerForSchedule][]date{
+		{name: "Clean Orphaned SVOD", input: map[volunteerForSchedule][]scheduledVolunteerOnDate{
 			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
-				Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024})),
+				{Date: date{Month: 1, Day: 21, Year: 2024}.ToString()},
 			},
 		}, want: simulatedCreatedSampleSVOD},
-		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]date{
+		{name: "Fail by not providing a VFS with a VFSID", input: map[volunteerForSchedule][]scheduledVolunteerOnDate{
 
-			{Schedule: 1}: {Must(env.Sample.RequestDate(date{Month: 1, Day: 21, Year: 2024}))},
+			{Schedule: 1}: {{Date: date{Month: 1, Day: 21, Year: 2024}.ToString()}},
 		}, want: simulatedCreatedSampleSVOD},
-		{name: "Fail by not providing a Date with a DateID", input: map[volunteerForSchedule][]date{
+		{name: "Fail by not providing a Date", input: map[volunteerForSchedule][]scheduledVolunteerOnDate{
 
 			Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Schedule: Must(env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test1"})).ScheduleID, Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Bill"})).VolunteerID})): {
-				{Month: 1, Day: 21, Year: 2024},
+				{TimeSlot: "8am"},
 			},
 		}, want: simulatedCreatedSampleSVOD},
 	}
@@ -2144,6 +4759,560 @@ func TestCleanOrphanedSVOD(t *testing.T) {
 	}
 }
 
+func TestRecieveAndStoreData(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	parameters := SendReceiveDataStruct{
+		ScheduleName:                "test1",
+		ShiftsOff:                   1,
+		VolunteersPerShift:          1,
+		StartDate:                   "2024-01-01",
+		EndDate:                     "2024-02-01",
+		WeekdaysForSchedule:         []string{"Sunday"},
+		VolunteerUnavailabilityData: map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}, "Jack": {}},
+	}
+	err := env.Sample.RecieveAndStoreData(env.LoggedInUser, parameters, true)
+	if err != nil {
+		t.Errorf("Error setting up test (RecieveAndStoreData failed): %v", err)
+		t.FailNow()
+	}
+	tests := []struct {
+		name      string
+		scheduled map[string][]string
+		want      map[string][]string
+	}{
+		{name: "Save a completed schedule", scheduled: map[string][]string{"Tim": {"2024-01-07", "2024-01-28"}, "Bill": {"2024-01-14"}, "Jack": {"2024-01-21"}}, want: map[string][]string{"Tim": {"2024-01-07", "2024-01-28"}, "Bill": {"2024-01-14"}, "Jack": {"2024-01-21"}}},
+		{name: "Keep the saved schedule when no completed schedule is sent", scheduled: nil, want: map[string][]string{"Tim": {"2024-01-07", "2024-01-28"}, "Bill": {"2024-01-14"}, "Jack": {"2024-01-21"}}},
+		{name: "Move and drop scheduled dates", scheduled: map[string][]string{"Tim": {"2024-01-07"}, "Bill": {"2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}, want: map[string][]string{"Tim": {"2024-01-07"}, "Bill": {"2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}},
+		{name: "Remove a volunteer from the completed schedule", scheduled: map[string][]string{"Tim": {"2024-01-07", "2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}, want: map[string][]string{"Tim": {"2024-01-07", "2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}},
+		{name: "Schedule dates before 2023 and after 2062", scheduled: map[string][]string{"Tim": {"1999-01-03", "2099-01-04"}, "Jack": {"2024-01-14", "2024-01-28"}}, want: map[string][]string{"Tim": {"1999-01-03", "2099-01-04"}, "Jack": {"2024-01-14", "2024-01-28"}}},
+		{name: "Fail to schedule a date that is not YYYY-MM-DD", scheduled: map[string][]string{"Tim": {"2024-1-7"}}, want: map[string][]string{"Tim": {"1999-01-03", "2099-01-04"}, "Jack": {"2024-01-14", "2024-01-28"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := parameters
+			input.VolunteerScheduledData = tt.scheduled
+			err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+			if err != nil {
+				t.Logf("logged error: `%v` for input: `%+v`", err, input)
+			}
+			ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+			if err != nil {
+				t.Errorf("got error while generating check: `%v`", err)
+			}
+			for _, dates := range ans.VolunteerScheduledData {
+				slices.Sort(dates)
+			}
+			if !maps.EqualFunc(ans.VolunteerScheduledData, tt.want, slices.Equal) {
+				t.Errorf("got %+v, want %+v", ans.VolunteerScheduledData, tt.want)
+			}
+		})
+	}
+	t.Run("Save time slots and slot-level assignments", func(t *testing.T) {
+		input := parameters
+		input.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+		input.TimeSlotsForSchedule = map[string][]TimeSlot{"Sunday": {{"8am", 1}, {"11am", 2}}}
+		input.VolunteerScheduledData = map[string][]string{"Tim": {"2024-01-07|8am", "2024-01-21"}, "Jack": {"2024-01-07|11am"}, "Bill": {"2024-01-07|11am"}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		for _, shiftKeys := range ans.VolunteerScheduledData {
+			slices.Sort(shiftKeys)
+		}
+		if !maps.EqualFunc(ans.VolunteerScheduledData, input.VolunteerScheduledData, slices.Equal) {
+			t.Errorf("got %+v, want %+v", ans.VolunteerScheduledData, input.VolunteerScheduledData)
+		}
+		if !maps.EqualFunc(ans.TimeSlotsForSchedule, input.TimeSlotsForSchedule, slices.Equal) {
+			t.Errorf("got %+v, want %+v", ans.TimeSlotsForSchedule, input.TimeSlotsForSchedule)
+		}
+		// reorder and resize the slots, then drop Wednesday so its (empty) slots and the 8am slot go away
+		input.WeekdaysForSchedule = []string{"Sunday"}
+		input.TimeSlotsForSchedule = map[string][]TimeSlot{"Sunday": {{"11am", 3}, {"6pm", 1}}}
+		input.VolunteerScheduledData = nil
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err = env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !maps.EqualFunc(ans.TimeSlotsForSchedule, input.TimeSlotsForSchedule, slices.Equal) {
+			t.Errorf("got %+v, want %+v", ans.TimeSlotsForSchedule, input.TimeSlotsForSchedule)
+		}
+		input.TimeSlotsForSchedule = map[string][]TimeSlot{"Sunday": {{"8am|early", 1}}}
+		if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err == nil {
+			t.Errorf("got no error for a time slot name containing %s", ShiftKeySeparator)
+		}
+		// go back to a single shift on Sundays with the schedule the rest of this test expects
+		input.TimeSlotsForSchedule = map[string][]TimeSlot{}
+		input.VolunteerScheduledData = map[string][]string{"Tim": {"2024-01-07", "2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+	})
+	t.Run("Save roles and role assignments", func(t *testing.T) {
+		input := parameters
+		input.RolesForSchedule = []RoleRequirement{{"Greeter lead", 1}, {"Usher", 2}}
+		input.VolunteerRoleData = map[string][]string{"Tim": {"Greeter lead", "Usher"}, "Jack": {"Usher"}}
+		input.VolunteerScheduledData = map[string][]string{"Tim": {"2024-01-07||Greeter lead", "2024-01-21"}, "Jack": {"2024-01-07||Usher", "2024-01-14"}, "Bill": {"2024-01-07"}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		for _, shiftKeys := range ans.VolunteerScheduledData {
+			slices.Sort(shiftKeys)
+		}
+		if !maps.EqualFunc(ans.VolunteerScheduledData, input.VolunteerScheduledData, slices.Equal) {
+			t.Errorf("got %+v, want %+v", ans.VolunteerScheduledData, input.VolunteerScheduledData)
+		}
+		if !slices.Equal(ans.RolesForSchedule, input.RolesForSchedule) {
+			t.Errorf("got %+v, want %+v", ans.RolesForSchedule, input.RolesForSchedule)
+		}
+		if !maps.EqualFunc(ans.VolunteerRoleData, input.VolunteerRoleData, slices.Equal) {
+			t.Errorf("got %+v, want %+v", ans.VolunteerRoleData, input.VolunteerRoleData)
+		}
+		for _, bad := range []SendReceiveDataStruct{
+			{RolesForSchedule: []RoleRequirement{{"Usher|lead", 1}}},
+			{RolesForSchedule: []RoleRequirement{{"Usher", 0}}},
+			{RolesForSchedule: []RoleRequirement{{"Usher", 1}, {"Usher", 2}}},
+			{VolunteerRoleData: map[string][]string{"George": {"Usher"}}},
+		} {
+			badInput := input
+			badInput.VolunteerScheduledData = nil
+			if bad.RolesForSchedule != nil {
+				badInput.RolesForSchedule = bad.RolesForSchedule
+			}
+			if bad.VolunteerRoleData != nil {
+				badInput.VolunteerRoleData = bad.VolunteerRoleData
+			}
+			if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, badInput, false); err == nil {
+				t.Errorf("got no error for input: `%+v`", badInput)
+			}
+		}
+		// drop the roles again so that the unused roles are cleaned up with them
+		input.RolesForSchedule = []RoleRequirement{}
+		input.VolunteerRoleData = map[string][]string{}
+		input.VolunteerScheduledData = map[string][]string{"Tim": {"2024-01-07", "2024-01-21"}, "Jack": {"2024-01-14", "2024-01-28"}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		roles, err := env.Sample.RequestRoles(env.LoggedInUser, []role{})
+		if err != nil || len(roles) != 0 {
+			t.Errorf("got roles %+v (error: `%v`), want no roles left", roles, err)
+		}
+	})
+	t.Run("Save and drop unavailability rules", func(t *testing.T) {
+		input := parameters
+		input.VolunteerUnavailabilityRuleData = map[string][]string{"Tim": {"first sunday", "July", "1st Sunday"}, "Jack": {"every other week from 2024-01-07"}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		want := map[string][]string{"Tim": {"1st Sunday", "July"}, "Jack": {"every 2 weeks from 2024-01-07"}}
+		if !maps.EqualFunc(ans.VolunteerUnavailabilityRuleData, want, slices.Equal) {
+			t.Errorf("got %v, want %v", ans.VolunteerUnavailabilityRuleData, want)
+		}
+		for _, bad := range []map[string][]string{{"Tim": {"sometimes"}}, {"George": {"July"}}} {
+			badInput := input
+			badInput.VolunteerUnavailabilityRuleData = bad
+			if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, badInput, false); err == nil {
+				t.Errorf("got no error for input: `%+v`", badInput)
+			}
+		}
+		// a nil VolunteerUnavailabilityRuleData keeps the saved rules
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, parameters, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, parameters)
+		}
+		ans, err = env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !maps.EqualFunc(ans.VolunteerUnavailabilityRuleData, want, slices.Equal) {
+			t.Errorf("got %v, want %v", ans.VolunteerUnavailabilityRuleData, want)
+		}
+		// drop one of Tim's rules, and Jack from the schedule along with his rule
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}}
+		input.VolunteerUnavailabilityRuleData = map[string][]string{"Tim": {"July"}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		rules, err := env.Sample.RequestURFS(env.LoggedInUser, []unavailabilityRuleForSchedule{})
+		if err != nil || len(rules) != 1 || rules[0].Rule != "July" {
+			t.Errorf("got rules %+v (error: `%v`), want only July", rules, err)
+		}
+		input = parameters
+		input.VolunteerUnavailabilityRuleData = map[string][]string{}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+	})
+	t.Run("Save and drop preferences", func(t *testing.T) {
+		input := parameters
+		input.VolunteerPreferenceData = map[string][]string{"Tim": {"saturday", "at most 2 shift", "Saturday"}, "Jack": {"2024-03-31 x3"}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		want := map[string][]string{"Tim": {"Saturday", "at most 2 shifts"}, "Jack": {"2024-03-31 x3"}}
+		if !maps.EqualFunc(ans.VolunteerPreferenceData, want, slices.Equal) {
+			t.Errorf("got %v, want %v", ans.VolunteerPreferenceData, want)
+		}
+		for _, bad := range []map[string][]string{{"Tim": {"whenever"}}, {"George": {"Saturday"}}} {
+			badInput := input
+			badInput.VolunteerPreferenceData = bad
+			if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, badInput, false); err == nil {
+				t.Errorf("got no error for input: `%+v`", badInput)
+			}
+		}
+		// drop one of Tim's preferences, and Jack from the schedule along with his preference
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}}
+		input.VolunteerPreferenceData = map[string][]string{"Tim": {"at most 2 shifts"}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		preferences, err := env.Sample.RequestPrFS(env.LoggedInUser, []preferenceForSchedule{})
+		if err != nil || len(preferences) != 1 || preferences[0].Preference != "at most 2 shifts" {
+			t.Errorf("got preferences %+v (error: `%v`), want only at most 2 shifts", preferences, err)
+		}
+		input = parameters
+		input.VolunteerPreferenceData = map[string][]string{}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+	})
+	t.Run("Save and drop unavailable date ranges", func(t *testing.T) {
+		input := parameters
+		input.VolunteerUnavailabilityRangeData = map[string][]DateRange{"Tim": {{"2024-07-01", "2024-07-14"}, {"2024-01-15", "2024-01-20"}, {"2024-07-01", "2024-07-14"}}, "Jack": {{"2024-03-03", "2024-03-03"}}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		want := map[string][]DateRange{"Tim": {{"2024-01-15", "2024-01-20"}, {"2024-07-01", "2024-07-14"}}, "Jack": {{"2024-03-03", "2024-03-03"}}}
+		if !maps.EqualFunc(ans.VolunteerUnavailabilityRangeData, want, slices.Equal) {
+			t.Errorf("got %v, want %v", ans.VolunteerUnavailabilityRangeData, want)
+		}
+		for _, bad := range []map[string][]DateRange{{"Tim": {{"2024-07-14", "2024-07-01"}}}, {"Tim": {{"2024-07-01", "7/14/2024"}}}, {"George": {{"2024-07-01", "2024-07-14"}}}} {
+			badInput := input
+			badInput.VolunteerUnavailabilityRangeData = bad
+			if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, badInput, false); err == nil {
+				t.Errorf("got no error for input: `%+v`", badInput)
+			}
+		}
+		// drop one of Tim's date ranges, and Jack from the schedule along with his date range
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}}
+		input.VolunteerUnavailabilityRangeData = map[string][]DateRange{"Tim": {{"2024-07-01", "2024-07-14"}}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err = env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !maps.EqualFunc(ans.VolunteerUnavailabilityRangeData, input.VolunteerUnavailabilityRangeData, slices.Equal) {
+			t.Errorf("got %v, want %v", ans.VolunteerUnavailabilityRangeData, input.VolunteerUnavailabilityRangeData)
+		}
+		if dateRanges, err := env.Sample.RequestDRFS(env.LoggedInUser, []dateRangeForSchedule{}); err != nil || len(dateRanges) != 1 {
+			t.Errorf("got date ranges %+v (error: `%v`), want one left", dateRanges, err)
+		}
+		input = parameters
+		input.VolunteerUnavailabilityRangeData = map[string][]DateRange{}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+	})
+	t.Run("Save and drop volunteer pairings", func(t *testing.T) {
+		input := parameters
+		input.VolunteerPairingData = []VolunteerPairing{{"Tim", "Bill", PairingTogether}, {"Bill", "Jack", PairingApart}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		want := []VolunteerPairing{{"Bill", "Jack", PairingApart}, {"Bill", "Tim", PairingTogether}}
+		if !slices.Equal(ans.VolunteerPairingData, want) {
+			t.Errorf("got %+v, want %+v", ans.VolunteerPairingData, want)
+		}
+		for _, bad := range [][]VolunteerPairing{
+			{{"Tim", "Tim", PairingApart}},
+			{{"Tim", "George", PairingApart}},
+			{{"Tim", "Bill", "sometimes"}},
+			{{"Tim", "Bill", PairingTogether}, {"Bill", "Tim", PairingApart}},
+		} {
+			badInput := input
+			badInput.VolunteerPairingData = bad
+			if err = env.Sample.RecieveAndStoreData(env.LoggedInUser, badInput, false); err == nil {
+				t.Errorf("got no error for input: `%+v`", badInput)
+			}
+		}
+		// change one pairing and drop the other
+		input.VolunteerPairingData = []VolunteerPairing{{"Bill", "Tim", PairingApart}}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err = env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !slices.Equal(ans.VolunteerPairingData, input.VolunteerPairingData) {
+			t.Errorf("got %+v, want %+v", ans.VolunteerPairingData, input.VolunteerPairingData)
+		}
+		// dropping Bill from the schedule takes his pairings with him
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Jack": {}}
+		input.VolunteerPairingData = nil
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		pairings, err := env.Sample.RequestPFS(env.LoggedInUser, []pairingForSchedule{})
+		if err != nil || len(pairings) != 0 {
+			t.Errorf("got pairings %+v (error: `%v`), want no pairings left", pairings, err)
+		}
+		err = env.Sample.RecieveAndStoreData(env.LoggedInUser, parameters, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, parameters)
+		}
+	})
+	t.Run("Drop a scheduled volunteer from the schedule parameters", func(t *testing.T) {
+		input := parameters
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}}
+		err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false)
+		if err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.RequestSVOD(env.LoggedInUser, []scheduledVolunteerOnDate{})
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		timVFS := Must(env.Sample.RequestVFSSingle(env.LoggedInUser, volunteerForSchedule{Volunteer: Must(env.Sample.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: "Tim"})).VolunteerID}))
+		if len(ans) != 2 || slices.ContainsFunc(ans, func(svod scheduledVolunteerOnDate) bool { return svod.VolunteerForSchedule != timVFS.VFSID }) {
+			t.Errorf("got %+v, want only the two SVOD rows for Tim (VFSID %d)", ans, timVFS.VFSID)
+		}
+	})
+	t.Run("Roll back a save that fails partway", func(t *testing.T) {
+		// the bad date is only caught after the schedule, its weekdays, and its volunteers have been written
+		input := parameters
+		input.ScheduleName = "test2"
+		input.VolunteerUnavailabilityData = map[string][]string{"Zed": {}}
+		input.VolunteerScheduledData = map[string][]string{"Zed": {"2024-1-7"}}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, true); err == nil {
+			t.Errorf("got no error for input: `%+v`", input)
+		}
+		if _, err := env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: "test2"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error `%v`, want ErrNotFound for the schedule of the failed save", err)
+		}
+		if ans, err := env.Sample.RequestVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: "Zed"}}); err != nil || len(ans) != 0 {
+			t.Errorf("got volunteers %+v (error: `%v`), want none left from the failed save", ans, err)
+		}
+		before, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, parameters.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		input = parameters
+		input.WeekdaysForSchedule = []string{"Sunday", "Monday"}
+		input.VolunteerUnavailabilityData = map[string][]string{"Tim": {"2024-01-14"}, "Bill": {}, "Zed": {}}
+		input.VolunteerScheduledData = map[string][]string{"Zed": {"2024-1-7"}}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err == nil {
+			t.Errorf("got no error for input: `%+v`", input)
+		}
+		after, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, parameters.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !reflect.DeepEqual(before, after) {
+			t.Errorf("got %+v after the failed save, want the schedule left as %+v", after, before)
+		}
+	})
+	t.Run("Save, keep, and clear contacts", func(t *testing.T) {
+		input := parameters
+		input.VolunteerContactData = map[string]VolunteerContact{"Tim": {Email: "tim@example.com", Phone: "555-0100"}, "Bill": {Phone: "555-0101"}}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		want := map[string]VolunteerContact{"Tim": {Email: "tim@example.com", Phone: "555-0100"}, "Bill": {Phone: "555-0101"}}
+		// a nil VolunteerContactData, like a save from before contacts, leaves them alone
+		for _, contacts := range []map[string]VolunteerContact{input.VolunteerContactData, nil} {
+			input.VolunteerContactData = contacts
+			if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err != nil {
+				t.Errorf("got error: `%v` for input: `%+v`", err, input)
+			}
+			ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+			if err != nil || !maps.Equal(ans.VolunteerContactData, want) {
+				t.Errorf("got contacts %v (error: `%v`), want %v", ans.VolunteerContactData, err, want)
+			}
+		}
+		input.VolunteerContactData = map[string]VolunteerContact{"Bill": {Email: "bill@example.com"}}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil || !maps.Equal(ans.VolunteerContactData, input.VolunteerContactData) {
+			t.Errorf("got contacts %v (error: `%v`), want %v", ans.VolunteerContactData, err, input.VolunteerContactData)
+		}
+		input.VolunteerContactData = map[string]VolunteerContact{"Zed": {Email: "zed@example.com"}}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, false); err == nil {
+			t.Errorf("got no error for a contact of someone who is not a volunteer on the schedule")
+		}
+	})
+	t.Run("Delete a schedule", func(t *testing.T) {
+		if err := env.Sample.RecieveAndDeleteData(env.LoggedInUser, parameters); err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, parameters)
+		}
+		if _, err := env.Sample.RequestSchedule(env.LoggedInUser, schedule{ScheduleName: parameters.ScheduleName}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("got error `%v`, want ErrNotFound for the deleted schedule", err)
+		}
+		if ans, err := env.Sample.RequestVFS(env.LoggedInUser, []volunteerForSchedule{}); err != nil || len(ans) != 0 {
+			t.Errorf("got VFS rows %+v (error: `%v`), want none left after deleting the schedule", ans, err)
+		}
+	})
+}
+
+func TestInTransaction(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	errRollback := errors.New("roll back")
+	tests := []struct {
+		name    string
+		fnErr   error
+		wantErr error
+		want    int
+	}{
+		{name: "Commit when the callback succeeds", fnErr: nil, wantErr: nil, want: 1},
+		{name: "Roll back when the callback fails", fnErr: errRollback, wantErr: errRollback, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := fmt.Sprintf("Volunteer %d", tt.want)
+			err := env.Sample.inTransaction(func(txModel VSAModel) error {
+				if err := txModel.CreateVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: name}}); err != nil {
+					return err
+				}
+				// a nested call joins the open transaction instead of starting its own
+				if err := txModel.inTransaction(func(nested VSAModel) error {
+					created, err := nested.RequestVolunteer(env.LoggedInUser, volunteer{VolunteerName: name})
+					if err != nil {
+						return err
+					}
+					return nested.UpdateVolunteers(env.LoggedInUser, []volunteer{{VolunteerID: created.VolunteerID, VolunteerName: name + "!"}})
+				}); err != nil {
+					return err
+				}
+				return tt.fnErr
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error `%v`, want `%v`", err, tt.wantErr)
+			}
+			ans, err := env.Sample.RequestVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: name + "!"}})
+			if err != nil {
+				t.Errorf("got error while generating check: `%v`", err)
+			}
+			if len(ans) != tt.want {
+				t.Errorf("got %d volunteers, want %d", len(ans), tt.want)
+			}
+		})
+	}
+}
+
+func TestNamesWithSQLMetacharacters(t *testing.T) {
+	env, tearDownEnvironment := setUpEnvironment(t)
+	defer tearDownEnvironment(t)
+	names := []string{`O"Brien`, `O'Brien`, `Bill" or "1"="1`, `Robert'); drop table Volunteers; --`, `100% _sure_ \ ; *`}
+	t.Run("Volunteers", func(t *testing.T) {
+		toCreate := []volunteer{}
+		for _, name := range names {
+			toCreate = append(toCreate, volunteer{VolunteerName: name})
+		}
+		if err := env.Sample.CreateVolunteers(env.LoggedInUser, toCreate); err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, toCreate)
+			t.FailNow()
+		}
+		for i, name := range names {
+			ans, err := env.Sample.RequestVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: name}})
+			checkResultsSlice(t, ans, []volunteer{{VolunteerID: i + 1, VolunteerName: name, User: env.LoggedInUser}}, []volunteer{{VolunteerName: name}}, err)
+		}
+		// a name that would match every row if it were written into the query matches none
+		ans, err := env.Sample.RequestVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: `x" or "1"="1`}})
+		checkResultsSlice(t, ans, []volunteer{}, []volunteer{{VolunteerName: `x" or "1"="1`}}, err)
+		if err = env.Sample.UpdateVolunteers(env.LoggedInUser, []volunteer{{VolunteerID: 1, VolunteerName: `O"Brien "Jr."`}}); err != nil {
+			t.Errorf("got error: `%v` while updating", err)
+		}
+		if err = env.Sample.DeleteVolunteers(env.LoggedInUser, []volunteer{{VolunteerName: `O'Brien`}}); err != nil {
+			t.Errorf("got error: `%v` while deleting", err)
+		}
+		ans, err = env.Sample.RequestVolunteers(env.LoggedInUser, []volunteer{})
+		want := []volunteer{{1, `O"Brien "Jr."`, env.LoggedInUser, "", ""}, {3, names[2], env.LoggedInUser, "", ""}, {4, names[3], env.LoggedInUser, "", ""}, {5, names[4], env.LoggedInUser, "", ""}}
+		checkResultsSlice(t, ans, want, []volunteer{}, err)
+	})
+	t.Run("Schedule data", func(t *testing.T) {
+		input := SendReceiveDataStruct{
+			ScheduleName:                    `Robert's "Q1" schedule; drop table Schedules; --`,
+			ShiftsOff:                       1,
+			VolunteersPerShift:              1,
+			StartDate:                       "2024-01-01",
+			EndDate:                         "2024-02-01",
+			WeekdaysForSchedule:             []string{"Sunday"},
+			TimeSlotsForSchedule:            map[string][]TimeSlot{"Sunday": {{`8 o'clock "early"`, 1}}},
+			RolesForSchedule:                []RoleRequirement{{`Usher "lead"`, 1}},
+			VolunteerUnavailabilityData:     map[string][]string{names[0]: {"2024-01-14"}, names[3]: {}},
+			VolunteerRoleData:               map[string][]string{names[0]: {`Usher "lead"`}, names[3]: {`Usher "lead"`}},
+			VolunteerUnavailabilityRuleData: map[string][]string{names[3]: {"1st Sunday"}},
+			VolunteerScheduledData:          map[string][]string{names[0]: {`2024-01-07|8 o'clock "early"|Usher "lead"`}},
+		}
+		if err := env.Sample.RecieveAndStoreData(env.LoggedInUser, input, true); err != nil {
+			t.Errorf("got error: `%v` for input: `%+v`", err, input)
+			t.FailNow()
+		}
+		ans, err := env.Sample.FetchAndSendScheduleData(env.LoggedInUser, input.ScheduleName)
+		if err != nil {
+			t.Errorf("got error while generating check: `%v`", err)
+		}
+		if !maps.EqualFunc(ans.VolunteerUnavailabilityData, input.VolunteerUnavailabilityData, slices.Equal) || !maps.EqualFunc(ans.VolunteerRoleData, input.VolunteerRoleData, slices.Equal) ||
+			!maps.EqualFunc(ans.VolunteerScheduledData, input.VolunteerScheduledData, slices.Equal) || !slices.Equal(ans.RolesForSchedule, input.RolesForSchedule) {
+			t.Errorf("got %+v, want %+v", ans, input)
+		}
+		if err = env.Sample.RecieveAndDeleteData(env.LoggedInUser, SendReceiveDataStruct{ScheduleName: input.ScheduleName}); err != nil {
+			t.Errorf("got error: `%v` while deleting", err)
+		}
+		if scheduleNames, err := env.Sample.SendScheduleNames(env.LoggedInUser, true); err != nil || len(scheduleNames) != 0 {
+			t.Errorf("got schedules %v (error: `%v`), want none", scheduleNames, err)
+		}
+	})
+}
+
 func TestMain(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,839 @@
+package vsasched
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// ShiftDates returns every date from data.StartDate through data.EndDate (inclusive) that falls on one of data.WeekdaysForSchedule. Dates are formatted as YYYY-MM-DD.
+func ShiftDates(data vsadb.SendReceiveDataStruct) ([]string, error) {
+	startDate, err := time.Parse(dateLayout, data.StartDate)
+	if err != nil {
+		return []string{}, fmt.Errorf("error in ShiftDates: StartDate \"%s\" is not in a valid date format (YYYY-MM-DD): %w", data.StartDate, err)
+	}
+	endDate, err := time.Parse(dateLayout, data.EndDate)
+	if err != nil {
+		return []string{}, fmt.Errorf("error in ShiftDates: EndDate \"%s\" is not in a valid date format (YYYY-MM-DD): %w", data.EndDate, err)
+	}
+	if endDate.Before(startDate) {
+		return []string{}, fmt.Errorf("error in ShiftDates: EndDate \"%s\" is before StartDate \"%s\"", data.EndDate, data.StartDate)
+	}
+	result := []string{}
+	for workingDate := startDate; !workingDate.After(endDate); workingDate = workingDate.AddDate(0, 0, 1) {
+		if slices.Contains(data.WeekdaysForSchedule, workingDate.Weekday().String()) {
+			result = append(result, workingDate.Format(dateLayout))
+		}
+	}
+	return result, nil
+}
+
+// Load values used in Imbalance
+const (
+	LoadOver  = "over"
+	LoadUnder = "under"
+)
+
+// Shift is one shift of a schedule along with the number of volunteers it needs. Shifts with a Key.Role can only be served by volunteers qualified for that role.
+type Shift struct {
+	Key                vsadb.ShiftKey
+	VolunteersPerShift int
+}
+
+// Shifts returns every shift from data.StartDate through data.EndDate in order. Shift dates whose weekday has time slots in data.TimeSlotsForSchedule get one shift per time slot (in slot order),
+// and the other shift dates get a single unnamed shift of data.VolunteersPerShift volunteers.
+// When data.RolesForSchedule is not empty each of those shifts is split into one shift per role (in role order) followed by a shift without a role for the seats the roles leave over.
+// A shift always gets at least as many seats as its roles add up to.
+func Shifts(data vsadb.SendReceiveDataStruct) ([]Shift, error) {
+	shiftDates, err := ShiftDates(data)
+	if err != nil {
+		return []Shift{}, fmt.Errorf("error in Shifts: %w", err)
+	}
+	result := make([]Shift, 0, len(shiftDates))
+	for _, shiftDate := range shiftDates {
+		parsedDate, _ := time.Parse(dateLayout, shiftDate) // ShiftDates only returns valid dates
+		timeSlots := data.TimeSlotsForSchedule[parsedDate.Weekday().String()]
+		if len(timeSlots) == 0 {
+			result = appendRoleShifts(result, Shift{vsadb.ShiftKey{Date: shiftDate}, data.VolunteersPerShift}, data.RolesForSchedule)
+			continue
+		}
+		for _, timeSlot := range timeSlots {
+			result = appendRoleShifts(result, Shift{vsadb.ShiftKey{Date: shiftDate, TimeSlot: timeSlot.Name}, timeSlot.VolunteersPerShift}, data.RolesForSchedule)
+		}
+	}
+	return result, nil
+}
+
+// appendRoleShifts appends shift to result split into one shift per role and a shift without a role for the remaining seats (which is left out when the roles take every seat).
+func appendRoleShifts(result []Shift, shift Shift, roles []vsadb.RoleRequirement) []Shift {
+	roleSeats := 0
+	for _, role := range roles {
+		result = append(result, Shift{vsadb.ShiftKey{Date: shift.Key.Date, TimeSlot: shift.Key.TimeSlot, Role: role.Name}, role.VolunteersPerShift})
+		roleSeats += role.VolunteersPerShift
+	}
+	if shift.VolunteersPerShift > roleSeats || len(roles) == 0 {
+		result = append(result, Shift{shift.Key, shift.VolunteersPerShift - roleSeats})
+	}
+	return result
+}
+
+// Constraint values used in Shortage
+const (
+	ConstraintPoolSize       = "pool size"
+	ConstraintUnavailability = "unavailability"
+	ConstraintShiftsOff      = "shifts-off spacing"
+	ConstraintQualification  = "role qualification"
+	ConstraintPairing        = "pairing"
+)
+
+// Shortage describes a shift that GenerateSchedule could not fill.
+type Shortage struct {
+	Date        string   `json:"date"`
+	TimeSlot    string   `json:"timeSlot"`    // empty unless the date's weekday has time slots
+	Role        string   `json:"role"`        // empty unless the shift is for one of data.RolesForSchedule
+	Needed      int      `json:"needed"`      // VolunteersPerShift of the shift
+	Available   int      `json:"available"`   // volunteers that could be scheduled for the shift
+	Unavailable []string `json:"unavailable"` // volunteers (qualified for Role, if any) with Date in their VolunteerUnavailabilityData
+	Resting     []string `json:"resting"`     // volunteers (qualified for Role, if any) sitting out the shift because of data.ShiftsOff or because they already serve another shift on Date
+	Paired      []string `json:"paired"`      // volunteers (qualified for Role, if any) left out because of data.VolunteerPairingData: a partner they must serve with cannot take the shift, a partner they must stay apart from serves on Date, or their group does not fit the seats left
+	Constraint  string   `json:"constraint"`  // the constraint that keeps the shift from being filled: ConstraintPoolSize, ConstraintQualification, ConstraintUnavailability, ConstraintShiftsOff, or ConstraintPairing
+}
+
+// InfeasibleError is returned by GenerateSchedule when one or more shifts cannot be filled. The Schedule returned with it has every other shift filled and the short shifts filled as far as possible.
+type InfeasibleError struct {
+	Shortages []Shortage // in shift order
+}
+
+func (e *InfeasibleError) Error() string {
+	details := make([]string, 0, len(e.Shortages))
+	for _, shortage := range e.Shortages {
+		details = append(details, fmt.Sprintf("%s is short %d of %d volunteers (%s)", vsadb.ShiftKey{Date: shortage.Date, TimeSlot: shortage.TimeSlot, Role: shortage.Role}.ToString(), shortage.Needed-shortage.Available, shortage.Needed, shortage.Constraint))
+	}
+	return fmt.Sprintf("error in GenerateSchedule: %d shifts cannot be filled: %s", len(e.Shortages), strings.Join(details, "; "))
+}
+
+// Schedule is what GenerateSchedule returns.
+type Schedule struct {
+	Data         vsadb.SendReceiveDataStruct `json:"schedule"`     // copy of the input data with VolunteerScheduledData filled in with ShiftKey strings
+	ShiftCounts  map[string]int              `json:"shiftCounts"`  // number of shifts each volunteer is scheduled for
+	Imbalances   []Imbalance                 `json:"imbalances"`   // empty when every volunteer is within one shift of every other volunteer
+	Satisfaction map[string]float64          `json:"satisfaction"` // share of each volunteer's preferences the schedule satisfies (see SatisfactionRates), only for volunteers with preferences
+}
+
+// Imbalance explains why a volunteer ended up with more or fewer shifts than their peers.
+type Imbalance struct {
+	Volunteer string `json:"volunteer"`
+	Shifts    int    `json:"shifts"`
+	Load      string `json:"load"` // LoadOver or LoadUnder
+	Reason    string `json:"reason"`
+}
+
+// GenerateSchedule fills in VolunteerScheduledData with enough volunteers for each shift returned by Shifts. A volunteer is never scheduled on a date returned for them by UnavailableDates,
+// serves at most one shift per date, only serves a role listed in their data.VolunteerRoleData, and after serving a shift they sit out data.ShiftsOff shift dates before being scheduled again.
+// Volunteers paired with vsadb.PairingTogether in data.VolunteerPairingData are always scheduled into the same shift and volunteers paired with vsadb.PairingApart never serve on the same date;
+// pairings that can never be satisfied (a group that must serve together but is larger than every shift, or that contains volunteers who must stay apart) are reported as an error.
+// The roles of each date and time slot are filled before its remaining seats, starting with the role that the fewest volunteers are qualified for. If a shift cannot be filled the rest of the
+// schedule is still generated and returned along with an *InfeasibleError.
+// Shifts are spread as evenly as possible: each shift goes to the available volunteers with the fewest shifts so far (then to whoever has gone the longest without serving), and afterwards shifts are
+// moved from the busiest volunteers to the least busy ones until no move can narrow the gap. Whatever imbalance is left is explained in Schedule.Imbalances.
+// data.VolunteerPreferenceData is honored where the rules above allow it: a volunteer who prefers at most a number of shifts only gets more when nobody else can serve, volunteers who prefer a
+// shift's date or weekday get it ahead of volunteers with as many shifts, and afterwards shifts are moved and swapped between volunteers while that raises the weighted score of satisfied
+// preferences without widening the spread of shift counts. How well each volunteer's preferences were met is reported in Schedule.Satisfaction.
+func GenerateSchedule(data vsadb.SendReceiveDataStruct) (Schedule, error) {
+	if data.VolunteersPerShift < 1 && slices.ContainsFunc(data.WeekdaysForSchedule, func(weekday string) bool { return len(data.TimeSlotsForSchedule[weekday]) == 0 }) {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: VolunteersPerShift must be at least 1 when a weekday has no time slots. Value of VolunteersPerShift is %d", data.VolunteersPerShift)
+	}
+	if data.ShiftsOff < 0 {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: ShiftsOff must be at least 0. Value of ShiftsOff is %d", data.ShiftsOff)
+	}
+	if len(data.VolunteerUnavailabilityData) == 0 {
+		return Schedule{}, errors.New("error in GenerateSchedule: the schedule does not have any volunteers")
+	}
+	shifts, err := Shifts(data)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	original := data
+	data.VolunteerUnavailabilityData, err = UnavailableDates(data)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	dateIndexes := make([]int, len(shifts)) // index of each shift's date among the shift dates, which is what data.ShiftsOff counts
+	for i := range shifts {
+		if i > 0 {
+			dateIndexes[i] = dateIndexes[i-1]
+			if shifts[i].Key.Date != shifts[i-1].Key.Date {
+				dateIndexes[i]++
+			}
+		}
+	}
+	volunteerNames := make([]string, 0, len(data.VolunteerUnavailabilityData))
+	for name := range data.VolunteerUnavailabilityData {
+		volunteerNames = append(volunteerNames, name)
+	}
+	slices.Sort(volunteerNames)
+	qualified := func(name string, shift Shift) bool {
+		return shift.Key.Role == "" || slices.Contains(data.VolunteerRoleData[name], shift.Key.Role)
+	}
+	together, apart, err := pairingGroups(data, volunteerNames, shifts)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	preferences, err := volunteerPreferences(data)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	shiftDates, _ := ShiftDates(data) // Shifts already checked the dates
+	maxShifts := map[string]int{}     // the fewest shifts each volunteer with a PreferenceMaxShifts prefers to serve
+	for name, volunteerPreferences := range preferences {
+		for _, preference := range volunteerPreferences {
+			if limit, ok := maxShifts[name]; preference.Kind == vsadb.PreferenceMaxShifts && (!ok || preference.MaxShifts < limit) {
+				maxShifts[name] = preference.MaxShifts
+			}
+		}
+	}
+	roleCounts := make(map[string]int, len(volunteerNames)) // number of data.RolesForSchedule each volunteer is qualified for
+	for _, name := range volunteerNames {
+		for _, role := range data.RolesForSchedule {
+			if slices.Contains(data.VolunteerRoleData[name], role.Name) {
+				roleCounts[name]++
+			}
+		}
+	}
+	// fill the shifts of each date and time slot role by role, scarcest role first, and the seats without a role last
+	fillOrder := make([]int, len(shifts))
+	groupIndexes := make([]int, len(shifts)) // index of each shift's date and time slot, which all of its roles share
+	qualifiedCounts := make([]int, len(shifts))
+	for i, shift := range shifts {
+		fillOrder[i] = i
+		if i > 0 {
+			groupIndexes[i] = groupIndexes[i-1]
+			if shift.Key.Shift() != shifts[i-1].Key.Shift() {
+				groupIndexes[i]++
+			}
+		}
+		qualifiedCounts[i] = len(volunteerNames)
+		if shift.Key.Role != "" {
+			qualifiedCounts[i] = len(slices.DeleteFunc(slices.Clone(volunteerNames), func(name string) bool { return !qualified(name, shift) }))
+		}
+	}
+	slices.SortStableFunc(fillOrder, func(a, b int) int {
+		if c := cmp.Compare(groupIndexes[a], groupIndexes[b]); c != 0 {
+			return c
+		}
+		if (shifts[a].Key.Role == "") != (shifts[b].Key.Role == "") {
+			if shifts[a].Key.Role == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(qualifiedCounts[a], qualifiedCounts[b])
+	})
+	assigned := make(map[string][]int, len(volunteerNames)) // indexes into shifts of the shifts each volunteer serves, in order
+	shortages := map[int]Shortage{}                         // keyed by index into shifts so they can be returned in shift order
+	for _, shiftIndex := range fillOrder {
+		shift := shifts[shiftIndex]
+		candidates, unavailable, resting, paired := []string{}, []string{}, []string{}, []string{}
+		for _, name := range volunteerNames {
+			if !qualified(name, shift) {
+				continue
+			}
+			if slices.Contains(data.VolunteerUnavailabilityData[name], shift.Key.Date) {
+				unavailable = append(unavailable, name)
+				continue
+			}
+			if served := assigned[name]; len(served) > 0 && dateIndexes[shiftIndex]-dateIndexes[served[len(served)-1]] <= data.ShiftsOff {
+				resting = append(resting, name)
+				continue
+			}
+			if slices.ContainsFunc(apart[name], func(other string) bool {
+				return slices.ContainsFunc(assigned[other], func(servedIndex int) bool { return dateIndexes[servedIndex] == dateIndexes[shiftIndex] })
+			}) {
+				paired = append(paired, name)
+				continue
+			}
+			candidates = append(candidates, name)
+		}
+		// a volunteer who must serve with others is only a candidate when all of them are
+		eligible := slices.Clone(candidates)
+		candidates = slices.DeleteFunc(candidates, func(name string) bool {
+			if slices.ContainsFunc(together[name], func(member string) bool { return !slices.Contains(eligible, member) }) {
+				paired = append(paired, name)
+				return true
+			}
+			return false
+		})
+		// volunteers who already have the most shifts they prefer go last, then fewest shifts first, then volunteers who must serve with the most others (their groups are the hardest to
+		// seat), then (for a role) volunteers who are qualified for the fewest other roles, then volunteers whose preferences gain the most from the shift, then volunteers who have never
+		// served or served the longest ago; the stable sort keeps remaining ties in name order
+		gains := make(map[string]int, len(candidates))
+		for _, name := range candidates {
+			if len(preferences[name]) > 0 {
+				served := servedDates(shifts, assigned[name])
+				gains[name] = preferenceScore(preferences[name], shiftDates, append(served, shift.Key.Date)) - preferenceScore(preferences[name], shiftDates, served)
+			}
+		}
+		atMax := func(name string) bool {
+			limit, ok := maxShifts[name]
+			return ok && len(assigned[name]) >= limit
+		}
+		slices.SortStableFunc(candidates, func(a, b string) int {
+			if atMax(a) != atMax(b) {
+				if atMax(a) {
+					return 1
+				}
+				return -1
+			}
+			if c := cmp.Compare(len(assigned[a]), len(assigned[b])); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(len(together[b]), len(together[a])); c != 0 {
+				return c
+			}
+			if shift.Key.Role != "" {
+				if c := cmp.Compare(roleCounts[a], roleCounts[b]); c != 0 {
+					return c
+				}
+			}
+			if c := cmp.Compare(gains[b], gains[a]); c != 0 {
+				return c
+			}
+			lastA, lastB := -1, -1
+			if len(assigned[a]) > 0 {
+				lastA = assigned[a][len(assigned[a])-1]
+			}
+			if len(assigned[b]) > 0 {
+				lastB = assigned[b][len(assigned[b])-1]
+			}
+			return cmp.Compare(lastA, lastB)
+		})
+		chosen, skipped := seatCandidates(candidates, shift.VolunteersPerShift, together, apart)
+		// when pairings leave seats empty, start from each of the other candidates in turn before giving up
+		for start := 1; start < len(candidates) && len(chosen) < shift.VolunteersPerShift; start++ {
+			if rotated, rotatedSkipped := seatCandidates(slices.Concat(candidates[start:], candidates[:start]), shift.VolunteersPerShift, together, apart); len(rotated) == shift.VolunteersPerShift {
+				chosen, skipped = rotated, rotatedSkipped
+			}
+		}
+		if len(chosen) < shift.VolunteersPerShift {
+			paired = append(paired, skipped...)
+			slices.Sort(paired)
+			shortage := Shortage{shift.Key.Date, shift.Key.TimeSlot, shift.Key.Role, shift.VolunteersPerShift, len(chosen), unavailable, resting, paired, ConstraintPairing}
+			if len(volunteerNames) < shift.VolunteersPerShift {
+				shortage.Constraint = ConstraintPoolSize
+			} else if qualifiedCounts[shiftIndex] < shift.VolunteersPerShift {
+				shortage.Constraint = ConstraintQualification
+			} else if qualifiedCounts[shiftIndex]-len(unavailable) < shift.VolunteersPerShift {
+				shortage.Constraint = ConstraintUnavailability
+			} else if qualifiedCounts[shiftIndex]-len(unavailable)-len(resting) < shift.VolunteersPerShift {
+				shortage.Constraint = ConstraintShiftsOff
+			}
+			shortages[shiftIndex] = shortage
+		}
+		for _, name := range chosen {
+			assigned[name] = append(assigned[name], shiftIndex)
+			slices.Sort(assigned[name])
+		}
+	}
+	balanceShifts(data, shifts, dateIndexes, together, apart, volunteerNames, maxShifts, assigned)
+	if len(preferences) > 0 {
+		improvePreferences(data, shifts, dateIndexes, together, apart, volunteerNames, preferences, shiftDates, assigned)
+	}
+	result := Schedule{Data: original}
+	result.Data.VolunteerScheduledData = make(map[string][]string, len(volunteerNames))
+	for _, name := range volunteerNames {
+		result.Data.VolunteerScheduledData[name] = []string{}
+		for _, shiftIndex := range assigned[name] {
+			result.Data.VolunteerScheduledData[name] = append(result.Data.VolunteerScheduledData[name], shifts[shiftIndex].Key.ToString())
+		}
+	}
+	result.ShiftCounts = CountShifts(result.Data)
+	result.Imbalances, err = FindImbalances(result.Data)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	result.Satisfaction, err = SatisfactionRates(result.Data)
+	if err != nil {
+		return Schedule{}, fmt.Errorf("error in GenerateSchedule: %w", err)
+	}
+	if len(shortages) > 0 {
+		infeasible := &InfeasibleError{make([]Shortage, 0, len(shortages))}
+		for shiftIndex := range shifts {
+			if shortage, ok := shortages[shiftIndex]; ok {
+				infeasible.Shortages = append(infeasible.Shortages, shortage)
+			}
+		}
+		return result, infeasible
+	}
+	return result, nil
+}
+
+// seatCandidates takes each candidate in order along with everyone who must serve with them until seats are filled, skipping groups that do not fit the seats left or that include someone who must
+// stay apart from a volunteer already taken. It returns the volunteers taken and the candidates skipped.
+func seatCandidates(candidates []string, seats int, together map[string][]string, apart map[string][]string) (chosen []string, skipped []string) {
+	chosen, skipped = []string{}, []string{}
+	for _, name := range candidates {
+		if slices.Contains(chosen, name) || len(chosen) >= seats {
+			continue
+		}
+		unit := together[name]
+		if len(chosen)+len(unit) > seats || slices.ContainsFunc(unit, func(member string) bool {
+			return slices.ContainsFunc(apart[member], func(other string) bool { return slices.Contains(chosen, other) })
+		}) {
+			skipped = append(skipped, name)
+			continue
+		}
+		chosen = append(chosen, unit...)
+	}
+	return
+}
+
+// pairingGroups checks data.VolunteerPairingData against the volunteers and shifts of the schedule. It returns the sorted names of the volunteers each volunteer must serve with (themselves
+// included) and the names of the volunteers each volunteer must never share a date with.
+func pairingGroups(data vsadb.SendReceiveDataStruct, volunteerNames []string, shifts []Shift) (map[string][]string, map[string][]string, error) {
+	together := make(map[string][]string, len(volunteerNames))
+	for _, name := range volunteerNames {
+		together[name] = []string{name}
+	}
+	apart := map[string][]string{}
+	for _, pairing := range data.VolunteerPairingData {
+		for _, name := range []string{pairing.Volunteer, pairing.PairedVolunteer} {
+			if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+				return nil, nil, fmt.Errorf("error in pairingGroups: %s is paired but is not a volunteer on the schedule", name)
+			}
+		}
+		if pairing.Volunteer == pairing.PairedVolunteer {
+			return nil, nil, fmt.Errorf("error in pairingGroups: %s is paired with themselves", pairing.Volunteer)
+		}
+		switch pairing.Pairing {
+		case vsadb.PairingTogether:
+			if slices.Contains(together[pairing.Volunteer], pairing.PairedVolunteer) {
+				continue
+			}
+			unit := slices.Concat(together[pairing.Volunteer], together[pairing.PairedVolunteer])
+			slices.Sort(unit)
+			for _, member := range unit {
+				together[member] = unit
+			}
+		case vsadb.PairingApart:
+			apart[pairing.Volunteer] = append(apart[pairing.Volunteer], pairing.PairedVolunteer)
+			apart[pairing.PairedVolunteer] = append(apart[pairing.PairedVolunteer], pairing.Volunteer)
+		default:
+			return nil, nil, fmt.Errorf("error in pairingGroups: %s and %s have an unknown Pairing. Value of Pairing is %q", pairing.Volunteer, pairing.PairedVolunteer, pairing.Pairing)
+		}
+	}
+	largestShift := 0
+	for _, shift := range shifts {
+		largestShift = max(largestShift, shift.VolunteersPerShift)
+	}
+	for _, name := range volunteerNames {
+		unit := together[name]
+		if unit[0] != name {
+			continue // check each group once
+		}
+		for _, member := range unit {
+			for _, other := range apart[member] {
+				if slices.Contains(unit, other) {
+					return nil, nil, fmt.Errorf("error in pairingGroups: %s and %s must stay apart but are in the same group that must serve together (%s)", member, other, strings.Join(unit, ", "))
+				}
+			}
+		}
+		if len(unit) > largestShift {
+			return nil, nil, fmt.Errorf("error in pairingGroups: %s must serve together but no shift has more than %d seats (VolunteersPerShift)", strings.Join(unit, ", "), largestShift)
+		}
+	}
+	return together, apart, nil
+}
+
+// UnavailableDates returns data.VolunteerUnavailabilityData with the shift dates matched by each volunteer's data.VolunteerUnavailabilityRuleData and the shift dates inside each volunteer's
+// data.VolunteerUnavailabilityRangeData added, sorted and without repeats. Dates in a range that are not shift dates (because of data.WeekdaysForSchedule) are left out.
+func UnavailableDates(data vsadb.SendReceiveDataStruct) (map[string][]string, error) {
+	result := make(map[string][]string, len(data.VolunteerUnavailabilityData))
+	for name, dates := range data.VolunteerUnavailabilityData {
+		result[name] = slices.Clone(dates)
+	}
+	if len(data.VolunteerUnavailabilityRuleData) == 0 && len(data.VolunteerUnavailabilityRangeData) == 0 {
+		return result, nil
+	}
+	shiftDates, err := ShiftDates(data)
+	if err != nil {
+		return map[string][]string{}, fmt.Errorf("error in UnavailableDates: %w", err)
+	}
+	for name, ruleStrings := range data.VolunteerUnavailabilityRuleData {
+		for _, ruleString := range ruleStrings {
+			rule, err := vsadb.UnavailabilityRule{}.FromString(ruleString)
+			if err != nil {
+				return map[string][]string{}, fmt.Errorf("error in UnavailableDates: %s: %w", name, err)
+			}
+			for _, shiftDate := range shiftDates {
+				day, _ := time.Parse(dateLayout, shiftDate) // ShiftDates only returns valid dates
+				if rule.Matches(day) {
+					result[name] = append(result[name], shiftDate)
+				}
+			}
+		}
+	}
+	for name, dateRanges := range data.VolunteerUnavailabilityRangeData {
+		for _, dateRange := range dateRanges {
+			start, err := time.Parse(dateLayout, dateRange.Start)
+			if err != nil {
+				return map[string][]string{}, fmt.Errorf("error in UnavailableDates: %s: the start of date range %+v is not a valid date (YYYY-MM-DD): %w", name, dateRange, err)
+			}
+			end, err := time.Parse(dateLayout, dateRange.End)
+			if err != nil {
+				return map[string][]string{}, fmt.Errorf("error in UnavailableDates: %s: the end of date range %+v is not a valid date (YYYY-MM-DD): %w", name, dateRange, err)
+			}
+			if end.Before(start) {
+				return map[string][]string{}, fmt.Errorf("error in UnavailableDates: %s: date range %+v ends before it starts", name, dateRange)
+			}
+			for _, shiftDate := range shiftDates {
+				if shiftDate >= dateRange.Start && shiftDate <= dateRange.End {
+					result[name] = append(result[name], shiftDate)
+				}
+			}
+		}
+	}
+	for name := range result {
+		slices.Sort(result[name])
+		result[name] = slices.Compact(result[name])
+	}
+	return result, nil
+}
+
+// canServe reports whether the volunteer can take the shift at shiftIndex given the shifts every volunteer already serves.
+func canServe(data vsadb.SendReceiveDataStruct, shifts []Shift, dateIndexes []int, apart map[string][]string, assigned map[string][]int, name string, shiftIndex int) bool {
+	if role := shifts[shiftIndex].Key.Role; role != "" && !slices.Contains(data.VolunteerRoleData[name], role) {
+		return false
+	}
+	if slices.Contains(data.VolunteerUnavailabilityData[name], shifts[shiftIndex].Key.Date) {
+		return false
+	}
+	for _, other := range apart[name] {
+		if slices.ContainsFunc(assigned[other], func(servedIndex int) bool { return dateIndexes[servedIndex] == dateIndexes[shiftIndex] }) {
+			return false
+		}
+	}
+	for _, servedIndex := range assigned[name] {
+		if max(dateIndexes[servedIndex]-dateIndexes[shiftIndex], dateIndexes[shiftIndex]-dateIndexes[servedIndex]) <= data.ShiftsOff {
+			return false
+		}
+	}
+	return true
+}
+
+// balanceShifts moves single shifts from volunteers with more shifts to volunteers with at least two fewer. Every move lowers the sum of the squared shift counts, so the loop always ends.
+// Volunteers who must serve with others keep the shifts GenerateSchedule gave their group, and volunteers never get more shifts than they prefer in maxShifts.
+func balanceShifts(data vsadb.SendReceiveDataStruct, shifts []Shift, dateIndexes []int, together map[string][]string, apart map[string][]string, volunteerNames []string, maxShifts map[string]int, assigned map[string][]int) {
+	for moved := true; moved; {
+		moved = false
+		byLoad := slices.DeleteFunc(slices.Clone(volunteerNames), func(name string) bool { return len(together[name]) > 1 })
+		slices.SortStableFunc(byLoad, func(a, b string) int { return cmp.Compare(len(assigned[b]), len(assigned[a])) })
+	search:
+		for _, over := range byLoad {
+			for i := len(byLoad) - 1; i >= 0; i-- {
+				under := byLoad[i]
+				if len(assigned[over])-len(assigned[under]) < 2 {
+					break
+				}
+				if limit, ok := maxShifts[under]; ok && len(assigned[under]) >= limit {
+					continue
+				}
+				for j, shiftIndex := range assigned[over] {
+					if canServe(data, shifts, dateIndexes, apart, assigned, under, shiftIndex) {
+						assigned[over] = slices.Delete(assigned[over], j, j+1)
+						assigned[under] = append(assigned[under], shiftIndex)
+						slices.Sort(assigned[under])
+						moved = true
+						break search
+					}
+				}
+			}
+		}
+	}
+}
+
+// improvePreferences moves and swaps shifts between volunteers for as long as that raises the sum of their preferenceScores. A shift only moves to a volunteer with fewer shifts and a swap keeps
+// both volunteers' shift counts, so the spread left by balanceShifts never widens. Every change raises the score, so the loop always ends. Volunteers who must serve with others keep the shifts
+// GenerateSchedule gave their group.
+func improvePreferences(data vsadb.SendReceiveDataStruct, shifts []Shift, dateIndexes []int, together map[string][]string, apart map[string][]string, volunteerNames []string,
+	preferences map[string][]vsadb.VolunteerPreference, shiftDates []string, assigned map[string][]int) {
+	score := func(a, b string) int {
+		return preferenceScore(preferences[a], shiftDates, servedDates(shifts, assigned[a])) + preferenceScore(preferences[b], shiftDates, servedDates(shifts, assigned[b]))
+	}
+	movable := slices.DeleteFunc(slices.Clone(volunteerNames), func(name string) bool { return len(together[name]) > 1 })
+	for improved := true; improved; {
+		improved = false
+	search:
+		for _, a := range movable {
+			for _, b := range movable {
+				if a == b || (len(preferences[a]) == 0 && len(preferences[b]) == 0) {
+					continue
+				}
+				before, savedA, savedB := score(a, b), slices.Clone(assigned[a]), slices.Clone(assigned[b])
+				for i, shiftIndex := range savedA {
+					if len(savedA) > len(savedB) { // give the shift to b
+						assigned[a] = slices.Delete(slices.Clone(savedA), i, i+1)
+						if canServe(data, shifts, dateIndexes, apart, assigned, b, shiftIndex) {
+							assigned[b] = append(slices.Clone(savedB), shiftIndex)
+							slices.Sort(assigned[b])
+							if score(a, b) > before {
+								improved = true
+								break search
+							}
+						}
+						assigned[a], assigned[b] = savedA, savedB
+					}
+					for j, otherIndex := range savedB { // trade the shift for one of b's
+						assigned[a] = slices.Delete(slices.Clone(savedA), i, i+1)
+						assigned[b] = slices.Delete(slices.Clone(savedB), j, j+1)
+						if canServe(data, shifts, dateIndexes, apart, assigned, a, otherIndex) {
+							assigned[a] = append(assigned[a], otherIndex)
+							slices.Sort(assigned[a])
+							if canServe(data, shifts, dateIndexes, apart, assigned, b, shiftIndex) {
+								assigned[b] = append(assigned[b], shiftIndex)
+								slices.Sort(assigned[b])
+								if score(a, b) > before {
+									improved = true
+									break search
+								}
+							}
+						}
+						assigned[a], assigned[b] = savedA, savedB
+					}
+				}
+			}
+		}
+	}
+}
+
+// volunteerPreferences parses data.VolunteerPreferenceData.
+func volunteerPreferences(data vsadb.SendReceiveDataStruct) (map[string][]vsadb.VolunteerPreference, error) {
+	result := make(map[string][]vsadb.VolunteerPreference, len(data.VolunteerPreferenceData))
+	for name, preferenceStrings := range data.VolunteerPreferenceData {
+		if _, ok := data.VolunteerUnavailabilityData[name]; !ok {
+			return nil, fmt.Errorf("error in volunteerPreferences: %s has preferences but is not a volunteer on the schedule", name)
+		}
+		for _, preferenceString := range preferenceStrings {
+			preference, err := vsadb.VolunteerPreference{}.FromString(preferenceString)
+			if err != nil {
+				return nil, fmt.Errorf("error in volunteerPreferences: %s: %w", name, err)
+			}
+			result[name] = append(result[name], preference)
+		}
+	}
+	return result, nil
+}
+
+// servedDates returns the date of each shift in shiftIndexes.
+func servedDates(shifts []Shift, shiftIndexes []int) []string {
+	result := make([]string, 0, len(shiftIndexes))
+	for _, shiftIndex := range shiftIndexes {
+		result = append(result, shifts[shiftIndex].Key.Date)
+	}
+	return result
+}
+
+// preferenceWeights adds up the Weight of the preferences that serving on served (one date per shift) satisfies and the Weight of all of the preferences that apply. A vsadb.PreferenceWeekday
+// applies to every shift served, weighing the most of the volunteer's weekday preferences when the shift is on none of their weekdays; a vsadb.PreferenceDate applies when it is one of
+// shiftDates; and a vsadb.PreferenceMaxShifts always applies.
+func preferenceWeights(preferences []vsadb.VolunteerPreference, shiftDates []string, served []string) (satisfied int, total int) {
+	weekdayWeights := map[string]int{}
+	mostWeekdayWeight := 0
+	for _, preference := range preferences {
+		switch preference.Kind {
+		case vsadb.PreferenceWeekday:
+			weekdayWeights[preference.Weekday] = max(weekdayWeights[preference.Weekday], preference.Weight)
+			mostWeekdayWeight = max(mostWeekdayWeight, preference.Weight)
+		case vsadb.PreferenceDate:
+			if slices.Contains(shiftDates, preference.Date) {
+				total += preference.Weight
+				if slices.Contains(served, preference.Date) {
+					satisfied += preference.Weight
+				}
+			}
+		case vsadb.PreferenceMaxShifts:
+			total += preference.Weight
+			if len(served) <= preference.MaxShifts {
+				satisfied += preference.Weight
+			}
+		}
+	}
+	if len(weekdayWeights) > 0 {
+		for _, servedDate := range served {
+			day, _ := time.Parse(dateLayout, servedDate)
+			if weight, ok := weekdayWeights[day.Weekday().String()]; ok {
+				satisfied += weight
+				total += weight
+			} else {
+				total += mostWeekdayWeight
+			}
+		}
+	}
+	return
+}
+
+// preferenceScore is the weight of the preferences that serving on served satisfies minus the weight of those it does not.
+func preferenceScore(preferences []vsadb.VolunteerPreference, shiftDates []string, served []string) int {
+	satisfied, total := preferenceWeights(preferences, shiftDates, served)
+	return satisfied - (total - satisfied)
+}
+
+// SatisfactionRates returns the share (0 through 1) of the weight of each volunteer's data.VolunteerPreferenceData that data.VolunteerScheduledData satisfies, as added up by preferenceWeights.
+// Volunteers without preferences are left out, and volunteers none of whose preferences apply (e.g. they prefer a weekday but serve no shifts) are fully satisfied.
+func SatisfactionRates(data vsadb.SendReceiveDataStruct) (map[string]float64, error) {
+	preferences, err := volunteerPreferences(data)
+	if err != nil {
+		return map[string]float64{}, fmt.Errorf("error in SatisfactionRates: %w", err)
+	}
+	result := make(map[string]float64, len(preferences))
+	if len(preferences) == 0 {
+		return result, nil
+	}
+	shiftDates, err := ShiftDates(data)
+	if err != nil {
+		return map[string]float64{}, fmt.Errorf("error in SatisfactionRates: %w", err)
+	}
+	for name, volunteerPreferences := range preferences {
+		served, err := scheduledDates(data.VolunteerScheduledData[name])
+		if err != nil {
+			return map[string]float64{}, fmt.Errorf("error in SatisfactionRates: %w", err)
+		}
+		result[name] = 1
+		if satisfied, total := preferenceWeights(volunteerPreferences, shiftDates, served); total > 0 {
+			result[name] = float64(satisfied) / float64(total)
+		}
+	}
+	return result, nil
+}
+
+// CountShifts returns the number of shifts each volunteer in data.VolunteerUnavailabilityData (or data.VolunteerScheduledData) is scheduled for. Volunteers without shifts are counted as 0.
+func CountShifts(data vsadb.SendReceiveDataStruct) map[string]int {
+	result := make(map[string]int, len(data.VolunteerUnavailabilityData))
+	for name := range data.VolunteerUnavailabilityData {
+		result[name] = 0
+	}
+	for name, dates := range data.VolunteerScheduledData {
+		result[name] = len(dates)
+	}
+	return result
+}
+
+// FindImbalances checks whether every volunteer is within one shift of every other volunteer. When they are not, the volunteers outside the fair range (the average shift count rounded down
+// and rounded up) are returned along with the reason the schedule could not give them a fair share, sorted by volunteer name.
+func FindImbalances(data vsadb.SendReceiveDataStruct) ([]Imbalance, error) {
+	shiftCounts := CountShifts(data)
+	if len(shiftCounts) == 0 {
+		return []Imbalance{}, nil
+	}
+	fewest, most, total := -1, 0, 0
+	for _, count := range shiftCounts {
+		if fewest == -1 || count < fewest {
+			fewest = count
+		}
+		most = max(most, count)
+		total += count
+	}
+	if most-fewest <= 1 {
+		return []Imbalance{}, nil
+	}
+	shiftDates, err := ShiftDates(data)
+	if err != nil {
+		return []Imbalance{}, fmt.Errorf("error in FindImbalances: %w", err)
+	}
+	unavailableDates, err := UnavailableDates(data)
+	if err != nil {
+		return []Imbalance{}, fmt.Errorf("error in FindImbalances: %w", err)
+	}
+	preferences, err := volunteerPreferences(data)
+	if err != nil {
+		return []Imbalance{}, fmt.Errorf("error in FindImbalances: %w", err)
+	}
+	fairLow, fairHigh := total/len(shiftCounts), (total+len(shiftCounts)-1)/len(shiftCounts)
+	atPreferredMax := func(name string, count int) (vsadb.VolunteerPreference, bool) {
+		for _, preference := range preferences[name] {
+			if preference.Kind == vsadb.PreferenceMaxShifts && count >= preference.MaxShifts {
+				return preference, true
+			}
+		}
+		return vsadb.VolunteerPreference{}, false
+	}
+	underloaded := []string{} // under-loaded volunteers who could have taken more shifts, so not those who already have the most they prefer
+	for name, count := range shiftCounts {
+		if _, ok := atPreferredMax(name, count); count < fairLow && !ok {
+			underloaded = append(underloaded, name)
+		}
+	}
+	result := []Imbalance{}
+	for name, count := range shiftCounts {
+		if count < fairLow {
+			unavailable := 0
+			for _, shiftDate := range shiftDates {
+				if slices.Contains(unavailableDates[name], shiftDate) {
+					unavailable++
+				}
+			}
+			reason := fmt.Sprintf("unavailable on %d of %d shift dates", unavailable, len(shiftDates))
+			if data.ShiftsOff > 0 {
+				reason = fmt.Sprintf("%s and must have %d shifts off between shifts", reason, data.ShiftsOff)
+			}
+			if preference, ok := atPreferredMax(name, count); ok {
+				reason = fmt.Sprintf("%s; prefers %s", reason, vsadb.VolunteerPreference{Kind: vsadb.PreferenceMaxShifts, MaxShifts: preference.MaxShifts}.ToString()) // without its weight
+			}
+			result = append(result, Imbalance{name, count, LoadUnder, reason})
+		} else if count > fairHigh {
+			// count the shifts this volunteer serves that none of the under-loaded volunteers were available for
+			servedDates, err := scheduledDates(data.VolunteerScheduledData[name])
+			if err != nil {
+				return []Imbalance{}, fmt.Errorf("error in FindImbalances: %w", err)
+			}
+			noneAvailable := 0
+			for _, servedDate := range servedDates {
+				if !slices.ContainsFunc(underloaded, func(under string) bool {
+					underDates, _ := scheduledDates(data.VolunteerScheduledData[under])
+					return !slices.Contains(unavailableDates[under], servedDate) && !slices.Contains(underDates, servedDate)
+				}) {
+					noneAvailable++
+				}
+			}
+			reason := fmt.Sprintf("no under-loaded volunteer was available on %d of their %d shifts", noneAvailable, count)
+			if noneAvailable < count && data.ShiftsOff > 0 {
+				reason = fmt.Sprintf("%s; the rest would break the %d shifts off rule", reason, data.ShiftsOff)
+			}
+			result = append(result, Imbalance{name, count, LoadOver, reason})
+		}
+	}
+	slices.SortFunc(result, func(a, b Imbalance) int { return cmp.Compare(a.Volunteer, b.Volunteer) })
+	return result, nil
+}
+
+// scheduledDates returns the date of each ShiftKey string in shiftKeys.
+func scheduledDates(shiftKeys []string) ([]string, error) {
+	result := make([]string, 0, len(shiftKeys))
+	for _, shiftKeyString := range shiftKeys {
+		shiftKey, err := vsadb.ShiftKey{}.FromString(shiftKeyString)
+		if err != nil {
+			return []string{}, fmt.Errorf("error in scheduledDates: %w", err)
+		}
+		result = append(result, shiftKey.Date)
+	}
+	return result, nil
+}
+
+// ScheduledVolunteersByShift turns a VolunteerScheduledData map (volunteer name to ShiftKey strings) into a map of ShiftKey strings to the sorted names of the volunteers scheduled for that shift.
+func ScheduledVolunteersByShift(volunteerScheduledData map[string][]string) map[string][]string {
+	result := map[string][]string{}
+	for name, shiftKeys := range volunteerScheduledData {
+		for _, shiftKey := range shiftKeys {
+			result[shiftKey] = append(result[shiftKey], name)
+		}
+	}
+	for shiftKey := range result {
+		slices.Sort(result[shiftKey])
+	}
+	return result
+}
@@ -0,0 +1,554 @@
+package vsasched
+
+import (
+	"VolunteerSchedulerApp/vsadb"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func sampleData() vsadb.SendReceiveDataStruct {
+	return vsadb.SendReceiveDataStruct{
+		ScheduleName:        "test1",
+		ShiftsOff:           1,
+		VolunteersPerShift:  2,
+		StartDate:           "2024-01-01",
+		EndDate:             "2024-02-01",
+		WeekdaysForSchedule: []string{"Sunday"},
+		VolunteerUnavailabilityData: map[string][]string{
+			"Bill":   {"2024-01-21"},
+			"George": {},
+			"Jack":   {},
+			"Lance":  {},
+			"Tim":    {"2024-01-14"},
+		},
+	}
+}
+
+func TestShiftDates(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   vsadb.SendReceiveDataStruct
+		want    []string
+		wantErr bool
+	}{
+		{name: "Sundays in January 2024", input: sampleData(), want: []string{"2024-01-07", "2024-01-14", "2024-01-21", "2024-01-28"}},
+		{name: "Sundays and Wednesdays in one week", input: vsadb.SendReceiveDataStruct{StartDate: "2024-01-07", EndDate: "2024-01-13", WeekdaysForSchedule: []string{"Sunday", "Wednesday"}}, want: []string{"2024-01-07", "2024-01-10"}},
+		{name: "Start and end on the same shift date", input: vsadb.SendReceiveDataStruct{StartDate: "2024-01-07", EndDate: "2024-01-07", WeekdaysForSchedule: []string{"Sunday"}}, want: []string{"2024-01-07"}},
+		{name: "No weekdays selected", input: vsadb.SendReceiveDataStruct{StartDate: "2024-01-01", EndDate: "2024-02-01"}, want: []string{}},
+		{name: "Fail with end date before start date", input: vsadb.SendReceiveDataStruct{StartDate: "2024-02-01", EndDate: "2024-01-01", WeekdaysForSchedule: []string{"Sunday"}}, want: []string{}, wantErr: true},
+		{name: "Fail with malformed start date", input: vsadb.SendReceiveDataStruct{StartDate: "1/1/2024", EndDate: "2024-01-01"}, want: []string{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := ShiftDates(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if !slices.Equal(ans, tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnavailableDates(t *testing.T) {
+	withRules := sampleData()
+	withRules.VolunteerUnavailabilityRuleData = map[string][]string{"Bill": {"last Sunday", "2024-01-20 to 2024-01-22"}, "Jack": {"every other week from 2024-01-01"}}
+	badRule := sampleData()
+	badRule.VolunteerUnavailabilityRuleData = map[string][]string{"Bill": {"sometimes"}}
+	withRanges := sampleData()
+	withRanges.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	withRanges.VolunteerUnavailabilityRangeData = map[string][]vsadb.DateRange{"George": {{Start: "2024-01-08", End: "2024-01-20"}}, "Tim": {{Start: "2024-01-13", End: "2024-01-14"}}}
+	backwardsRange := sampleData()
+	backwardsRange.VolunteerUnavailabilityRangeData = map[string][]vsadb.DateRange{"Tim": {{Start: "2024-01-20", End: "2024-01-13"}}}
+	tests := []struct {
+		name    string
+		input   vsadb.SendReceiveDataStruct
+		want    map[string][]string
+		wantErr bool
+	}{
+		{name: "Dates only", input: sampleData(), want: sampleData().VolunteerUnavailabilityData},
+		{name: "Rules add the shift dates they match", input: withRules, want: map[string][]string{
+			"Bill":   {"2024-01-21", "2024-01-28"},
+			"George": {},
+			"Jack":   {"2024-01-07", "2024-01-21"},
+			"Lance":  {},
+			"Tim":    {"2024-01-14"},
+		}},
+		{name: "Date ranges add the shift dates inside them", input: withRanges, want: map[string][]string{
+			"Bill":   {"2024-01-21"},
+			"George": {"2024-01-10", "2024-01-14", "2024-01-17"},
+			"Jack":   {},
+			"Lance":  {},
+			"Tim":    {"2024-01-14"},
+		}},
+		{name: "Fail with an invalid rule", input: badRule, want: map[string][]string{}, wantErr: true},
+		{name: "Fail with a date range that ends before it starts", input: backwardsRange, want: map[string][]string{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := UnavailableDates(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if !maps.EqualFunc(ans, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestShifts(t *testing.T) {
+	withTimeSlots := sampleData()
+	withTimeSlots.EndDate = "2024-01-10"
+	withTimeSlots.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	withTimeSlots.TimeSlotsForSchedule = map[string][]vsadb.TimeSlot{"Sunday": {{Name: "8am", VolunteersPerShift: 2}, {Name: "11am", VolunteersPerShift: 3}}}
+	withRoles := withTimeSlots
+	withRoles.RolesForSchedule = []vsadb.RoleRequirement{{Name: "Lead", VolunteersPerShift: 1}, {Name: "Usher", VolunteersPerShift: 1}}
+	withRoles.WeekdaysForSchedule = []string{"Sunday"}
+	tests := []struct {
+		name    string
+		input   vsadb.SendReceiveDataStruct
+		want    []Shift
+		wantErr bool
+	}{
+		{name: "One shift per date without time slots", input: sampleData(), want: []Shift{{vsadb.ShiftKey{Date: "2024-01-07"}, 2}, {vsadb.ShiftKey{Date: "2024-01-14"}, 2}, {vsadb.ShiftKey{Date: "2024-01-21"}, 2}, {vsadb.ShiftKey{Date: "2024-01-28"}, 2}}},
+		{name: "Time slots on Sundays only", input: withTimeSlots, want: []Shift{{vsadb.ShiftKey{Date: "2024-01-03"}, 2}, {vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "8am"}, 2}, {vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "11am"}, 3}, {vsadb.ShiftKey{Date: "2024-01-10"}, 2}}},
+		{name: "Roles in every time slot", input: withRoles, want: []Shift{
+			{vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "8am", Role: "Lead"}, 1}, {vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "8am", Role: "Usher"}, 1},
+			{vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "11am", Role: "Lead"}, 1}, {vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "11am", Role: "Usher"}, 1}, {vsadb.ShiftKey{Date: "2024-01-07", TimeSlot: "11am"}, 1},
+		}},
+		{name: "Fail with malformed end date", input: vsadb.SendReceiveDataStruct{StartDate: "2024-01-01", EndDate: "2024-13-01"}, want: []Shift{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := Shifts(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if !slices.Equal(ans, tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSchedule(t *testing.T) {
+	noPerShift := sampleData()
+	noPerShift.VolunteersPerShift = 0
+	slotsOnly := noPerShift
+	slotsOnly.TimeSlotsForSchedule = map[string][]vsadb.TimeSlot{"Sunday": {{Name: "9am", VolunteersPerShift: 2}}}
+	someSlots := slotsOnly
+	someSlots.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	noVolunteers := sampleData()
+	noVolunteers.VolunteerUnavailabilityData = map[string][]string{}
+	tests := []struct {
+		name    string
+		input   vsadb.SendReceiveDataStruct
+		want    map[string][]string
+		wantErr bool
+	}{
+		{name: "Generate rotating schedule", input: sampleData(), want: map[string][]string{
+			"Bill":   {"2024-01-07", "2024-01-28"},
+			"George": {"2024-01-07", "2024-01-21"},
+			"Jack":   {"2024-01-14", "2024-01-28"},
+			"Lance":  {"2024-01-14"},
+			"Tim":    {"2024-01-21"},
+		}},
+		{name: "Fail without volunteers per shift", input: noPerShift, wantErr: true},
+		{name: "Staff every weekday from its time slots", input: slotsOnly, want: map[string][]string{
+			"Bill":   {"2024-01-07|9am", "2024-01-28|9am"},
+			"George": {"2024-01-07|9am", "2024-01-21|9am"},
+			"Jack":   {"2024-01-14|9am", "2024-01-28|9am"},
+			"Lance":  {"2024-01-14|9am"},
+			"Tim":    {"2024-01-21|9am"},
+		}},
+		{name: "Fail without volunteers per shift for a weekday without time slots", input: someSlots, wantErr: true},
+		{name: "Fail without volunteers", input: noVolunteers, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := GenerateSchedule(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if !maps.EqualFunc(ans.Data.VolunteerScheduledData, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", ans.Data.VolunteerScheduledData, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleWithTimeSlots(t *testing.T) {
+	data := sampleData()
+	data.ShiftsOff = 0
+	data.EndDate = "2024-01-10"
+	data.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	data.TimeSlotsForSchedule = map[string][]vsadb.TimeSlot{"Sunday": {{Name: "8am", VolunteersPerShift: 2}, {Name: "11am", VolunteersPerShift: 3}}}
+	want := map[string][]string{
+		"Bill":   {"2024-01-03", "2024-01-07|11am"},
+		"George": {"2024-01-03", "2024-01-07|11am"},
+		"Jack":   {"2024-01-07|8am", "2024-01-10"},
+		"Lance":  {"2024-01-07|8am", "2024-01-10"},
+		"Tim":    {"2024-01-07|11am"},
+	}
+	ans, err := GenerateSchedule(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if !maps.EqualFunc(ans.Data.VolunteerScheduledData, want, slices.Equal) {
+		t.Errorf("got %v, want %v", ans.Data.VolunteerScheduledData, want)
+	}
+}
+
+func TestGenerateScheduleWithRoles(t *testing.T) {
+	data := sampleData()
+	data.ShiftsOff = 0
+	data.VolunteersPerShift = 4
+	data.RolesForSchedule = []vsadb.RoleRequirement{{Name: "Usher", VolunteersPerShift: 2}, {Name: "Lead", VolunteersPerShift: 1}}
+	data.VolunteerRoleData = map[string][]string{"Bill": {"Usher"}, "George": {"Lead"}, "Jack": {"Lead", "Usher"}, "Tim": {"Usher"}}
+	tooFewLeads := data
+	tooFewLeads.EndDate = "2024-01-07"
+	tooFewLeads.RolesForSchedule = []vsadb.RoleRequirement{{Name: "Lead", VolunteersPerShift: 3}}
+	tests := []struct {
+		name          string
+		input         vsadb.SendReceiveDataStruct
+		want          map[string][]string
+		wantShortages []Shortage
+	}{
+		{name: "Fill roles with qualified volunteers", input: data, want: map[string][]string{
+			"Bill":   {"2024-01-07||Usher", "2024-01-14||Usher", "2024-01-28||Usher"},
+			"George": {"2024-01-07||Lead", "2024-01-14||Lead", "2024-01-21||Lead", "2024-01-28||Lead"},
+			"Jack":   {"2024-01-07", "2024-01-14||Usher", "2024-01-21||Usher"},
+			"Lance":  {"2024-01-14", "2024-01-21", "2024-01-28"},
+			"Tim":    {"2024-01-07||Usher", "2024-01-21||Usher", "2024-01-28||Usher"},
+		}, wantShortages: []Shortage{}},
+		{name: "Report a role too few volunteers are qualified for", input: tooFewLeads, want: map[string][]string{
+			"Bill":   {"2024-01-07"},
+			"George": {"2024-01-07||Lead"},
+			"Jack":   {"2024-01-07||Lead"},
+			"Lance":  {},
+			"Tim":    {},
+		}, wantShortages: []Shortage{{"2024-01-07", "", "Lead", 3, 2, []string{}, []string{}, []string{}, ConstraintQualification}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := GenerateSchedule(tt.input)
+			shortages := []Shortage{}
+			var infeasible *InfeasibleError
+			if errors.As(err, &infeasible) {
+				shortages = infeasible.Shortages
+			} else if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if !maps.EqualFunc(ans.Data.VolunteerScheduledData, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", ans.Data.VolunteerScheduledData, tt.want)
+			}
+			if !slices.EqualFunc(shortages, tt.wantShortages, func(a, b Shortage) bool {
+				return a.Date == b.Date && a.Role == b.Role && a.Needed == b.Needed && a.Available == b.Available && a.Constraint == b.Constraint
+			}) {
+				t.Errorf("got %v, want %v", shortages, tt.wantShortages)
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleWithPairings(t *testing.T) {
+	data := sampleData()
+	data.ShiftsOff = 0
+	data.VolunteerPairingData = []vsadb.VolunteerPairing{{Volunteer: "Jack", PairedVolunteer: "Lance", Pairing: vsadb.PairingTogether}, {Volunteer: "Bill", PairedVolunteer: "George", Pairing: vsadb.PairingApart}}
+	ans, err := GenerateSchedule(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	if !slices.Equal(ans.Data.VolunteerScheduledData["Jack"], ans.Data.VolunteerScheduledData["Lance"]) {
+		t.Errorf("got Jack %v and Lance %v, want the same shifts", ans.Data.VolunteerScheduledData["Jack"], ans.Data.VolunteerScheduledData["Lance"])
+	}
+	for _, shiftKey := range ans.Data.VolunteerScheduledData["Bill"] {
+		if slices.Contains(ans.Data.VolunteerScheduledData["George"], shiftKey) {
+			t.Errorf("got Bill and George both serving %s", shiftKey)
+		}
+	}
+	splitGroup := sampleData()
+	splitGroup.ShiftsOff = 0
+	splitGroup.VolunteersPerShift = 3
+	splitGroup.EndDate = "2024-01-14"
+	splitGroup.VolunteerUnavailabilityData = map[string][]string{"Bill": {}, "George": {}, "Jack": {}}
+	splitGroup.VolunteerPairingData = []vsadb.VolunteerPairing{{Volunteer: "George", PairedVolunteer: "Jack", Pairing: vsadb.PairingTogether}, {Volunteer: "Bill", PairedVolunteer: "George", Pairing: vsadb.PairingApart}}
+	ans, err = GenerateSchedule(splitGroup)
+	var infeasible *InfeasibleError
+	if !errors.As(err, &infeasible) {
+		t.Fatalf("got error `%v`, want *InfeasibleError", err)
+	}
+	wantScheduled := map[string][]string{"Bill": {"2024-01-14"}, "George": {"2024-01-07"}, "Jack": {"2024-01-07"}}
+	if !maps.EqualFunc(ans.Data.VolunteerScheduledData, wantScheduled, slices.Equal) {
+		t.Errorf("got %v, want %v", ans.Data.VolunteerScheduledData, wantScheduled)
+	}
+	wantShortages := []Shortage{
+		{"2024-01-07", "", "", 3, 2, []string{}, []string{}, []string{"Bill"}, ConstraintPairing},
+		{"2024-01-14", "", "", 3, 1, []string{}, []string{}, []string{"George", "Jack"}, ConstraintPairing},
+	}
+	if !slices.EqualFunc(infeasible.Shortages, wantShortages, func(a, b Shortage) bool {
+		return a.Date == b.Date && a.Needed == b.Needed && a.Available == b.Available && slices.Equal(a.Paired, b.Paired) && a.Constraint == b.Constraint
+	}) {
+		t.Errorf("got %v, want %v", infeasible.Shortages, wantShortages)
+	}
+	tests := []struct {
+		name     string
+		pairings []vsadb.VolunteerPairing
+	}{
+		{name: "Fail when a group that must serve together is larger than every shift", pairings: []vsadb.VolunteerPairing{
+			{Volunteer: "Jack", PairedVolunteer: "Lance", Pairing: vsadb.PairingTogether}, {Volunteer: "Lance", PairedVolunteer: "Tim", Pairing: vsadb.PairingTogether},
+		}},
+		{name: "Fail when volunteers must serve together and apart", pairings: []vsadb.VolunteerPairing{
+			{Volunteer: "Jack", PairedVolunteer: "Lance", Pairing: vsadb.PairingTogether}, {Volunteer: "Jack", PairedVolunteer: "Lance", Pairing: vsadb.PairingApart},
+		}},
+		{name: "Fail when a pairing names someone who is not a volunteer", pairings: []vsadb.VolunteerPairing{{Volunteer: "Jack", PairedVolunteer: "Larry", Pairing: vsadb.PairingApart}}},
+		{name: "Fail when a volunteer is paired with themselves", pairings: []vsadb.VolunteerPairing{{Volunteer: "Jack", PairedVolunteer: "Jack", Pairing: vsadb.PairingApart}}},
+		{name: "Fail on an unknown Pairing", pairings: []vsadb.VolunteerPairing{{Volunteer: "Jack", PairedVolunteer: "Lance", Pairing: "sometimes"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := sampleData()
+			input.VolunteerPairingData = tt.pairings
+			_, err := GenerateSchedule(input)
+			if err == nil || errors.As(err, &infeasible) {
+				t.Errorf("got error `%v`, want a non-InfeasibleError error", err)
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleWithPreferences(t *testing.T) {
+	data := vsadb.SendReceiveDataStruct{
+		StartDate:           "2024-01-01",
+		EndDate:             "2024-01-31",
+		WeekdaysForSchedule: []string{"Saturday", "Sunday"},
+		VolunteersPerShift:  1,
+		VolunteerUnavailabilityData: map[string][]string{
+			"Bill": {},
+			"Jack": {},
+			"Tim":  {},
+		},
+		VolunteerPreferenceData: map[string][]string{
+			"Bill": {"Saturday"},
+			"Jack": {"Sunday x2"},
+			"Tim":  {"at most 2 shifts", "2024-01-20 x3"},
+		},
+	}
+	ans, err := GenerateSchedule(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	wantCounts := map[string]int{"Bill": 3, "Jack": 3, "Tim": 2}
+	if !maps.Equal(ans.ShiftCounts, wantCounts) {
+		t.Errorf("got counts %v, want %v", ans.ShiftCounts, wantCounts)
+	}
+	if !slices.Contains(ans.Data.VolunteerScheduledData["Tim"], "2024-01-20") {
+		t.Errorf("got Tim %v, want 2024-01-20 among his shifts", ans.Data.VolunteerScheduledData["Tim"])
+	}
+	wantSatisfaction := map[string]float64{"Bill": 1, "Jack": 1, "Tim": 1}
+	if !maps.Equal(ans.Satisfaction, wantSatisfaction) {
+		t.Errorf("got satisfaction %v (schedule %v), want %v", ans.Satisfaction, ans.Data.VolunteerScheduledData, wantSatisfaction)
+	}
+	// Tim preferring fewer shifts than his fair share leaves him under-loaded
+	data.VolunteerPreferenceData = map[string][]string{"Tim": {"at most 1 shift"}}
+	ans, err = GenerateSchedule(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	wantImbalances := []Imbalance{
+		{"Bill", 4, LoadOver, "no under-loaded volunteer was available on 4 of their 4 shifts"},
+		{"Tim", 1, LoadUnder, "unavailable on 0 of 8 shift dates; prefers at most 1 shift"},
+	}
+	if !slices.Equal(ans.Imbalances, wantImbalances) {
+		t.Errorf("got imbalances %v (counts %v), want %v", ans.Imbalances, ans.ShiftCounts, wantImbalances)
+	}
+	data.VolunteerPreferenceData = map[string][]string{"Tim": {"whenever"}}
+	if _, err = GenerateSchedule(data); err == nil {
+		t.Errorf("got no error for preferences %v", data.VolunteerPreferenceData)
+	}
+}
+
+func TestSatisfactionRates(t *testing.T) {
+	data := sampleData()
+	data.VolunteerScheduledData = map[string][]string{
+		"Bill": {"2024-01-06", "2024-01-07"},
+		"Jack": {"2024-01-07"},
+		"Tim":  {"2024-01-07", "2024-01-28"},
+	}
+	data.WeekdaysForSchedule = []string{"Saturday", "Sunday"}
+	tests := []struct {
+		name        string
+		preferences map[string][]string
+		want        map[string]float64
+		wantErr     bool
+	}{
+		{name: "No preferences", preferences: nil, want: map[string]float64{}},
+		{name: "Weekday served half the time", preferences: map[string][]string{"Bill": {"Saturday"}}, want: map[string]float64{"Bill": 0.5}},
+		{name: "Weighted weekday and missed date", preferences: map[string][]string{"Jack": {"Sunday x3", "2024-01-06"}}, want: map[string]float64{"Jack": 0.75}},
+		{name: "Too many shifts", preferences: map[string][]string{"Tim": {"at most 1 shift"}}, want: map[string]float64{"Tim": 0}},
+		{name: "Date outside the schedule does not count", preferences: map[string][]string{"Tim": {"2024-03-01"}}, want: map[string]float64{"Tim": 1}},
+		{name: "No shifts to weigh a weekday", preferences: map[string][]string{"George": {"Saturday"}}, want: map[string]float64{"George": 1}},
+		{name: "Fail on a preference for someone who is not a volunteer", preferences: map[string][]string{"Larry": {"Saturday"}}, want: map[string]float64{}, wantErr: true},
+		{name: "Fail on an invalid preference", preferences: map[string][]string{"Tim": {"whenever"}}, want: map[string]float64{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := data
+			input.VolunteerPreferenceData = tt.preferences
+			ans, err := SatisfactionRates(input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error `%v`, want error: %t", err, tt.wantErr)
+			}
+			if !maps.Equal(ans, tt.want) {
+				t.Errorf("got %v, want %v", ans, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleInfeasible(t *testing.T) {
+	tooFewVolunteers := sampleData()
+	tooFewVolunteers.VolunteersPerShift = 6
+	mostlyUnavailable := sampleData()
+	mostlyUnavailable.VolunteerUnavailabilityData = map[string][]string{"Bill": {"2024-01-07", "2024-01-21"}, "Tim": {"2024-01-07", "2024-01-14"}}
+	tests := []struct {
+		name          string
+		input         vsadb.SendReceiveDataStruct
+		wantScheduled map[string][]string
+		wantShortages []Shortage
+	}{
+		{name: "VolunteersPerShift exceeds the pool", input: tooFewVolunteers,
+			wantScheduled: map[string][]string{
+				"Bill":   {"2024-01-07", "2024-01-28"},
+				"George": {"2024-01-07", "2024-01-21"},
+				"Jack":   {"2024-01-07", "2024-01-21"},
+				"Lance":  {"2024-01-07", "2024-01-21"},
+				"Tim":    {"2024-01-07", "2024-01-21"},
+			},
+			wantShortages: []Shortage{
+				{"2024-01-07", "", "", 6, 5, []string{}, []string{}, []string{}, ConstraintPoolSize},
+				{"2024-01-14", "", "", 6, 0, []string{"Tim"}, []string{"Bill", "George", "Jack", "Lance"}, []string{}, ConstraintPoolSize},
+				{"2024-01-21", "", "", 6, 4, []string{"Bill"}, []string{}, []string{}, ConstraintPoolSize},
+				{"2024-01-28", "", "", 6, 1, []string{}, []string{"George", "Jack", "Lance", "Tim"}, []string{}, ConstraintPoolSize},
+			}},
+		{name: "Unavailability and spacing leave dates short", input: mostlyUnavailable,
+			wantScheduled: map[string][]string{
+				"Bill": {"2024-01-14", "2024-01-28"},
+				"Tim":  {"2024-01-21"},
+			},
+			wantShortages: []Shortage{
+				{"2024-01-07", "", "", 2, 0, []string{"Bill", "Tim"}, []string{}, []string{}, ConstraintUnavailability},
+				{"2024-01-14", "", "", 2, 1, []string{"Tim"}, []string{}, []string{}, ConstraintUnavailability},
+				{"2024-01-21", "", "", 2, 1, []string{"Bill"}, []string{}, []string{}, ConstraintUnavailability},
+				{"2024-01-28", "", "", 2, 1, []string{}, []string{"Tim"}, []string{}, ConstraintShiftsOff},
+			}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := GenerateSchedule(tt.input)
+			var infeasible *InfeasibleError
+			if !errors.As(err, &infeasible) {
+				t.Fatalf("got error `%v`, want *InfeasibleError", err)
+			}
+			if !maps.EqualFunc(ans.Data.VolunteerScheduledData, tt.wantScheduled, slices.Equal) {
+				t.Errorf("got %v, want %v", ans.Data.VolunteerScheduledData, tt.wantScheduled)
+			}
+			if !slices.EqualFunc(infeasible.Shortages, tt.wantShortages, func(a, b Shortage) bool {
+				return a.Date == b.Date && a.Needed == b.Needed && a.Available == b.Available && slices.Equal(a.Unavailable, b.Unavailable) && slices.Equal(a.Resting, b.Resting) && a.Constraint == b.Constraint
+			}) {
+				t.Errorf("got %v, want %v", infeasible.Shortages, tt.wantShortages)
+			}
+		})
+	}
+}
+
+func TestGenerateScheduleHonorsConstraints(t *testing.T) {
+	data := sampleData()
+	data.EndDate = "2024-06-30"
+	data.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	data.VolunteerUnavailabilityRuleData = map[string][]string{"George": {"1st Wednesday"}, "Jack": {"2024-05-01 to 2024-05-05"}}
+	ans, err := GenerateSchedule(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	unavailableDates, err := UnavailableDates(data)
+	if err != nil {
+		t.Fatalf("got error: %v", err)
+	}
+	shiftDates, _ := ShiftDates(data)
+	byDate := ScheduledVolunteersByShift(ans.Data.VolunteerScheduledData)
+	for _, shiftDate := range shiftDates {
+		if len(byDate[shiftDate]) != data.VolunteersPerShift {
+			t.Errorf("%s has %d volunteers, want %d", shiftDate, len(byDate[shiftDate]), data.VolunteersPerShift)
+		}
+	}
+	for name, dates := range ans.Data.VolunteerScheduledData {
+		for _, scheduledDate := range dates {
+			if slices.Contains(unavailableDates[name], scheduledDate) {
+				t.Errorf("%s was scheduled on %s despite being unavailable", name, scheduledDate)
+			}
+		}
+		for i := 1; i < len(dates); i++ {
+			if slices.Index(shiftDates, dates[i])-slices.Index(shiftDates, dates[i-1]) <= data.ShiftsOff {
+				t.Errorf("%s was scheduled on %s and %s without %d shifts off", name, dates[i-1], dates[i], data.ShiftsOff)
+			}
+		}
+	}
+}
+
+func TestGenerateScheduleBalancesShifts(t *testing.T) {
+	mostlyUnavailable := vsadb.SendReceiveDataStruct{
+		StartDate:           "2024-01-01",
+		EndDate:             "2024-02-15",
+		WeekdaysForSchedule: []string{"Sunday"},
+		VolunteersPerShift:  1,
+		VolunteerUnavailabilityData: map[string][]string{
+			"Bill": {},
+			"Jack": {},
+			"Tim":  {"2024-01-14", "2024-01-21", "2024-01-28", "2024-02-04", "2024-02-11"},
+		},
+	}
+	longRange := sampleData()
+	longRange.EndDate = "2024-12-31"
+	longRange.WeekdaysForSchedule = []string{"Sunday", "Wednesday"}
+	tests := []struct {
+		name           string
+		input          vsadb.SendReceiveDataStruct
+		wantCounts     map[string]int
+		wantImbalances []Imbalance
+	}{
+		{name: "Balanced sample schedule", input: sampleData(), wantCounts: map[string]int{"Bill": 2, "George": 2, "Jack": 2, "Lance": 1, "Tim": 1}, wantImbalances: []Imbalance{}},
+		{name: "Balanced over a year", input: longRange, wantCounts: map[string]int{"Bill": 42, "George": 42, "Jack": 42, "Lance": 41, "Tim": 41}, wantImbalances: []Imbalance{}},
+		{name: "Report volunteers unavailability keeps apart", input: mostlyUnavailable, wantCounts: map[string]int{"Bill": 2, "Jack": 3, "Tim": 1}, wantImbalances: []Imbalance{
+			{"Jack", 3, LoadOver, "no under-loaded volunteer was available on 3 of their 3 shifts"},
+			{"Tim", 1, LoadUnder, "unavailable on 5 of 6 shift dates"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ans, err := GenerateSchedule(tt.input)
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if !maps.Equal(ans.ShiftCounts, tt.wantCounts) {
+				t.Errorf("got counts %v, want %v", ans.ShiftCounts, tt.wantCounts)
+			}
+			if !slices.Equal(ans.Imbalances, tt.wantImbalances) {
+				t.Errorf("got imbalances %v, want %v", ans.Imbalances, tt.wantImbalances)
+			}
+		})
+	}
+}
+
+func TestScheduledVolunteersByShift(t *testing.T) {
+	input := map[string][]string{"Tim": {"2024-01-07"}, "Bill": {"2024-01-07", "2024-01-14"}, "Jack": {}}
+	want := map[string][]string{"2024-01-07": {"Bill", "Tim"}, "2024-01-14": {"Bill"}}
+	ans := ScheduledVolunteersByShift(input)
+	if !maps.EqualFunc(ans, want, slices.Equal) {
+		t.Errorf("got %v, want %v", ans, want)
+	}
+}